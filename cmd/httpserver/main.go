@@ -2,22 +2,38 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sort"
 	"syscall"
 	"time"
 
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	fieldengineersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/service"
 	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	slasvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/sla/service"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	basicusersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/user/basic_user_service"
 	externalusersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/user/external_user_service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	webhooksvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/grpcserver"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest"
+	"github.com/crywolf/itsm-ticket-management-service/internal/outbox"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/boltdb"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository/memory"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/postgres"
+	_ "github.com/lib/pq"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
@@ -42,60 +58,150 @@ func main() {
 
 	loadEnvConfiguration()
 
-	basicUserRepository := &memory.BasicUserRepositoryMemory{}
-	// add test user - just for playing and testing
-	basicUser := user.BasicUser{
-		ExternalUserUUID: "83b231f2-5898-2658-70f4-5db03d1ccbc1",
-		Name:             "Jan",
-		Surname:          "Novák",
-		OrgDisplayName:   "KompiTech",
-		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
-	}
-	basicUserUUID, err := basicUserRepository.AddBasicUser(context.Background(), "", basicUser)
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = basicUser.SetUUID(basicUserUUID)
-	if err != nil {
-		log.Fatal(err)
+	// cursors signed with random key are not valid after restart or on other server instances,
+	// it is acceptable only for memory storage that does not survive the restart either
+	if viper.GetString("CursorSecret") == "" {
+		if viper.GetString("RepositoryType") != "memory" {
+			logger.Fatal("CURSOR_SECRET must be set when persistent storage is used")
+		}
+		logger.Warn("CURSOR_SECRET is not set, pagination cursors are signed with random key and are valid only until restart")
 	}
 
 	clock := realClock{}
-	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
-	fieldEngineer := fieldengineer.FieldEngineer{
-		BasicUser: basicUser,
-	}
-	err = fieldEngineer.CreatedUpdated.SetCreatedBy(basicUser)
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = fieldEngineer.CreatedUpdated.SetUpdatedBy(basicUser)
-	if err != nil {
-		log.Fatal(err)
+
+	var basicUserRepository repository.BasicUserRepository
+	var fieldEngineerRepository repository.FieldEngineerRepository
+	var incidentRepository repository.IncidentRepository
+	var unitOfWork repository.UnitOfWork
+	var outboxRepository repository.OutboxRepository
+	var webhookRepository repository.WebhookRepository
+	var slaRepository repository.SLARepository
+	var priorityMatrixRepository repository.PriorityMatrixRepository
+
+	switch repositoryType := viper.GetString("RepositoryType"); repositoryType {
+	case "memory":
+		basicUserRepo := &memory.BasicUserRepositoryMemory{}
+		fieldEngineerRepo := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepo)
+		incidentRepo := memory.NewIncidentRepositoryMemory(clock, basicUserRepo, fieldEngineerRepo)
+
+		basicUserRepository = basicUserRepo
+		fieldEngineerRepository = fieldEngineerRepo
+		incidentRepository = incidentRepo
+		unitOfWork = memory.NewUnitOfWorkMemory(fieldEngineerRepo, incidentRepo)
+		outboxRepository = memory.NewOutboxRepositoryMemory(fieldEngineerRepo, incidentRepo)
+		webhookRepository = memory.NewWebhookRepositoryMemory(clock, basicUserRepo)
+		slaRepository = memory.NewSLARepositoryMemory(clock, basicUserRepo)
+		priorityMatrixRepository = memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepo)
+
+		addTestFieldEngineer(ref.ChannelID(viper.GetString("TestDataChannelID")), basicUserRepository, fieldEngineerRepository)
+	case "postgres":
+		db, err := sql.Open("postgres", viper.GetString("PostgresDSN"))
+		if err != nil {
+			logger.Fatalw("could not open database", "error", err)
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		if err := postgres.Migrate(context.Background(), db); err != nil {
+			logger.Fatalw("could not migrate database", "error", err)
+		}
+
+		basicUserRepo := postgres.NewBasicUserRepositoryPostgres(db)
+		fieldEngineerRepo := postgres.NewFieldEngineerRepositoryPostgres(db, clock, basicUserRepo)
+		incidentRepo := postgres.NewIncidentRepositoryPostgres(db, clock, basicUserRepo, fieldEngineerRepo)
+
+		basicUserRepository = basicUserRepo
+		fieldEngineerRepository = fieldEngineerRepo
+		incidentRepository = incidentRepo
+		unitOfWork = postgres.NewUnitOfWorkPostgres(db, basicUserRepo, fieldEngineerRepo, incidentRepo)
+		outboxRepository = postgres.NewOutboxRepositoryPostgres(db)
+		webhookRepository = postgres.NewWebhookRepositoryPostgres(db, clock, basicUserRepo)
+		slaRepository = postgres.NewSLARepositoryPostgres(db, clock, basicUserRepo)
+		priorityMatrixRepository = postgres.NewPriorityMatrixRepositoryPostgres(db, clock, basicUserRepo)
+	case "bolt":
+		store, err := boltdb.Open(viper.GetString("BoltDBPath"))
+		if err != nil {
+			logger.Fatalw("could not open bolt database", "error", err)
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		if restoreFrom := viper.GetString("BoltRestoreFrom"); restoreFrom != "" {
+			restoreBoltSnapshot(store, restoreFrom, logger)
+		}
+
+		snapshotInterval := time.Duration(viper.GetInt("BoltSnapshotIntervalInMinutes")) * time.Minute
+		if snapshotInterval > 0 {
+			go runBoltSnapshots(store, viper.GetString("BoltSnapshotDir"), snapshotInterval, viper.GetInt("BoltSnapshotRetention"), logger)
+		}
+
+		basicUserRepo := boltdb.NewBasicUserRepositoryBolt(store)
+		fieldEngineerRepo := boltdb.NewFieldEngineerRepositoryBolt(store, clock, basicUserRepo)
+		incidentRepo := boltdb.NewIncidentRepositoryBolt(store, clock, basicUserRepo, fieldEngineerRepo)
+
+		basicUserRepository = basicUserRepo
+		fieldEngineerRepository = fieldEngineerRepo
+		incidentRepository = incidentRepo
+		unitOfWork = boltdb.NewUnitOfWorkBolt(store, basicUserRepo, fieldEngineerRepo, incidentRepo)
+		outboxRepository = boltdb.NewOutboxRepositoryBolt(store)
+		webhookRepository = boltdb.NewWebhookRepositoryBolt(store, clock, basicUserRepo)
+		slaRepository = boltdb.NewSLARepositoryBolt(store, clock, basicUserRepo)
+		priorityMatrixRepository = boltdb.NewPriorityMatrixRepositoryBolt(store, clock, basicUserRepo)
+	default:
+		logger.Fatalf("unknown repository type '%s'", repositoryType)
 	}
 
-	fieldEngID, err := fieldEngineerRepository.AddFieldEngineer(context.Background(), "", fieldEngineer)
-	if err != nil {
-		log.Fatal(err)
+	// Domain events are sent to the URLs subscribed by the channels
+	webhookRetryPolicy := webhook.RetryPolicy{
+		MaxAttempts:    viper.GetUint("WebhookMaxAttempts"),
+		InitialBackoff: time.Duration(viper.GetInt("WebhookInitialBackoffInSeconds")) * time.Second,
+		MaxBackoff:     time.Duration(viper.GetInt("WebhookMaxBackoffInSeconds")) * time.Second,
 	}
-	err = fieldEngineer.SetUUID(fieldEngID)
-	if err != nil {
-		log.Fatal(err)
+	webhookClient := &http.Client{
+		Timeout: time.Duration(viper.GetInt("WebhookRequestTimeoutInSeconds")) * time.Second,
 	}
+	webhookBatchSize := viper.GetUint("WebhookDeliveryBatchSize")
+	webhookService := webhooksvc.NewWebhookService(webhookRepository, clock, webhookClient, webhookRetryPolicy, webhookBatchSize)
 
-	fmt.Println("\n===> field eng UUID:", fieldEngID)
+	// Domain events stored in the outbox are published in the background
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
 
-	fieldEngineerService := fieldengineersvc.NewFieldEngineerService(fieldEngineerRepository)
+	dispatchInterval := time.Duration(viper.GetInt("OutboxDispatchIntervalInSeconds")) * time.Second
+	if dispatchInterval > 0 {
+		dispatcher := outbox.NewDispatcher(outboxRepository, webhookService, logger, dispatchInterval, viper.GetUint("OutboxBatchSize"))
+		go dispatcher.Run(dispatcherCtx)
+	}
 
-	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
-	incidentService := incidentsvc.NewIncidentService(incidentRepository, fieldEngineerRepository)
+	webhookDeliveryInterval := time.Duration(viper.GetInt("WebhookDeliveryIntervalInSeconds")) * time.Second
+	if webhookDeliveryInterval > 0 {
+		go runWebhookDeliveries(dispatcherCtx, webhookService, webhookDeliveryInterval, webhookBatchSize, logger)
+	}
 
 	// External user service fetches user data from external service
-	externalUserService, err := externalusersvc.NewService(basicUserRepository)
+	externalUserService, err := externalusersvc.NewService(basicUserRepository, fieldEngineerRepository)
 	if err != nil {
 		logger.Fatalw("could not create external user service", "error", err)
 	}
 
+	fieldEngineerService := fieldengineersvc.NewFieldEngineerService(fieldEngineerRepository, basicUserRepository, externalUserService)
+
+	autoClosePeriod := time.Duration(viper.GetInt("IncidentAutoClosePeriodInHours")) * time.Hour
+	incidentService := incidentsvc.NewIncidentService(incidentRepository, fieldEngineerRepository, slaRepository, priorityMatrixRepository,
+		unitOfWork, clock, autoClosePeriod)
+
+	// Resolved incidents are closed automatically in the background
+	autoCloseInterval := time.Duration(viper.GetInt("IncidentAutoCloseIntervalInSeconds")) * time.Second
+	if autoClosePeriod > 0 && autoCloseInterval > 0 {
+		go runIncidentAutoClose(dispatcherCtx, incidentService, autoCloseInterval, viper.GetUint("IncidentAutoCloseBatchSize"), logger)
+	}
+
+	basicUserService := basicusersvc.NewBasicUserService(basicUserRepository)
+
+	slaService := slasvc.NewSLAService(slaRepository)
+
 	// HTTP server
 	server := rest.NewServer(rest.Config{
 		Addr:                    viper.GetString("HTTPBindAddress"),
@@ -105,7 +211,12 @@ func main() {
 		ExternalUserService:     externalUserService,
 		IncidentService:         incidentService,
 		FieldEngineerService:    fieldEngineerService,
+		BasicUserService:        basicUserService,
+		WebhookService:          webhookService,
+		SLAService:              slaService,
 		ExternalLocationAddress: viper.GetString("ExternalLocationAddress"),
+		RequireIfMatch:          viper.GetBool("RequireIfMatch"),
+		CursorSecret:            []byte(viper.GetString("CursorSecret")),
 	})
 
 	srv := &http.Server{
@@ -113,6 +224,30 @@ func main() {
 		Handler: server,
 	}
 
+	// gRPC server
+	var grpcServer *grpcserver.Server
+	if grpcAddr := viper.GetString("GRPCBindAddress"); grpcAddr != "" {
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			logger.Fatalw("could not listen on gRPC address", "address", grpcAddr, "error", err)
+		}
+
+		grpcServer = grpcserver.NewServer(grpcserver.Config{
+			Clock:               realClock{},
+			Logger:              logger,
+			ExternalUserService: externalUserService,
+			IncidentService:     incidentService,
+			RequireVersion:      viper.GetBool("RequireIfMatch"),
+		})
+
+		go func() {
+			logger.Infof("Starting gRPC server at %s", grpcAddr)
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Fatalw("gRPC server Serve", "error", err)
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	idleConnsClosed := make(chan struct{})
 	go func() {
@@ -135,6 +270,14 @@ func main() {
 		}
 		logger.Info("HTTP server shutdown finished successfully")
 
+		if grpcServer != nil {
+			logger.Info("Shutting down gRPC server...")
+			grpcServer.GracefulStop()
+			logger.Info("gRPC server shutdown finished successfully")
+		}
+
+		stopDispatcher()
+
 		close(idleConnsClosed)
 	}()
 
@@ -151,3 +294,159 @@ func main() {
 	logger.Info("Exiting")
 	_ = logger.Sync()
 }
+
+// addTestFieldEngineer adds test user and field engineer - just for playing and testing
+func addTestFieldEngineer(channelID ref.ChannelID, basicUserRepository repository.BasicUserRepository, fieldEngineerRepository repository.FieldEngineerRepository) {
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "83b231f2-5898-2658-70f4-5db03d1ccbc1",
+		Name:             "Jan",
+		Surname:          "Novák",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	basicUserUUID, err := basicUserRepository.AddBasicUser(context.Background(), channelID, basicUser)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = basicUser.SetUUID(basicUserUUID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fieldEngineer := fieldengineer.FieldEngineer{
+		BasicUser: basicUser,
+	}
+	err = fieldEngineer.CreatedUpdated.SetCreatedBy(basicUser)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = fieldEngineer.CreatedUpdated.SetUpdatedBy(basicUser)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fieldEngID, err := fieldEngineerRepository.AddFieldEngineer(context.Background(), channelID, fieldEngineer)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("\n===> field eng UUID:", fieldEngID)
+}
+
+// restoreBoltSnapshot replaces the content of the bolt database with the snapshot file
+func restoreBoltSnapshot(store *boltdb.Store, snapshotPath string, logger *zap.SugaredLogger) {
+	snapshot, err := os.Open(snapshotPath)
+	if err != nil {
+		logger.Fatalw("could not open bolt database snapshot", "error", err)
+	}
+	defer func() {
+		_ = snapshot.Close()
+	}()
+
+	if err := store.Restore(snapshot); err != nil {
+		logger.Fatalw("could not restore bolt database snapshot", "error", err)
+	}
+
+	logger.Infof("Bolt database restored from snapshot %s", snapshotPath)
+}
+
+// runBoltSnapshots periodically writes snapshots of the bolt database to the directory,
+// only the given number of the newest snapshots is kept (zero retention keeps all snapshots)
+func runBoltSnapshots(store *boltdb.Store, dir string, interval time.Duration, retention int, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		snapshotPath := filepath.Join(dir, fmt.Sprintf("itsm-snapshot-%s.db", time.Now().UTC().Format("20060102T150405Z")))
+		if err := store.SnapshotToFile(snapshotPath); err != nil {
+			logger.Errorw("could not create bolt database snapshot", "error", err)
+			continue
+		}
+
+		logger.Infof("Bolt database snapshot written to %s", snapshotPath)
+
+		if retention > 0 {
+			pruneBoltSnapshots(dir, retention, logger)
+		}
+	}
+}
+
+// pruneBoltSnapshots deletes the oldest snapshots in the directory so that only the given number of the newest ones is kept
+func pruneBoltSnapshots(dir string, retention int, logger *zap.SugaredLogger) {
+	snapshots, err := filepath.Glob(filepath.Join(dir, "itsm-snapshot-*.db"))
+	if err != nil {
+		logger.Errorw("could not list bolt database snapshots", "error", err)
+		return
+	}
+
+	if len(snapshots) <= retention {
+		return
+	}
+
+	// snapshot names contain UTC timestamps, so the oldest snapshots are sorted first
+	sort.Strings(snapshots)
+	for _, snapshotPath := range snapshots[:len(snapshots)-retention] {
+		if err := os.Remove(snapshotPath); err != nil {
+			logger.Errorw("could not delete old bolt database snapshot", "path", snapshotPath, "error", err)
+			continue
+		}
+
+		logger.Infof("Old bolt database snapshot %s deleted", snapshotPath)
+	}
+}
+
+// runWebhookDeliveries sends the due webhook deliveries periodically until the context is cancelled
+func runWebhookDeliveries(ctx context.Context, webhookService webhooksvc.WebhookService, interval time.Duration, batchSize uint, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := webhookService.DeliverDue(ctx)
+				if err != nil {
+					logger.Errorw("could not deliver webhooks", "error", err)
+					break
+				}
+				if uint(n) < batchSize { // no more due deliveries
+					break
+				}
+			}
+		}
+	}
+}
+
+// runIncidentAutoClose closes the incidents resolved for too long periodically until the context is cancelled
+func runIncidentAutoClose(ctx context.Context, incidentService incidentsvc.IncidentService, interval time.Duration, batchSize uint, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := incidentService.AutoCloseResolved(ctx, batchSize)
+				var failed int
+				var autoCloseErr *incidentsvc.AutoCloseError
+				if errors.As(err, &autoCloseErr) {
+					for _, f := range autoCloseErr.Failures {
+						logger.Errorw("could not close resolved incident", "channel_id", f.ChannelID, "incident_id", f.IncidentID, "error", f.Err)
+					}
+					failed = len(autoCloseErr.Failures)
+				} else if err != nil {
+					logger.Errorw("could not close resolved incidents", "error", err)
+					break
+				}
+				// skipped incidents stay in the next batch, continue only if some incidents were closed
+				if n == 0 || uint(n+failed) < batchSize { // no more incidents to close
+					break
+				}
+			}
+		}
+	}
+}
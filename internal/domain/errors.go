@@ -19,6 +19,7 @@ const (
 	ErrorCodeInvalidArgument
 	ErrorCodeActionForbidden
 	ErrorCodeUserNotAuthorized
+	ErrorCodeVersionMismatch
 )
 
 // WrapErrorf returns a wrapped error
@@ -0,0 +1,240 @@
+// Package event contains domain events recorded by the aggregates when their state changes.
+// Events are stored in the outbox together with the aggregate and delivered to other systems asynchronously.
+package event
+
+import (
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+)
+
+// Type of the domain event
+type Type string
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// Type values
+const (
+	TypeIncidentCancelled             Type = "IncidentCancelled"
+	TypeIncidentStateChanged          Type = "IncidentStateChanged"
+	TypeIncidentFieldEngineerAssigned Type = "IncidentFieldEngineerAssigned"
+	TypeIncidentFieldEngineerAccepted Type = "IncidentFieldEngineerAccepted"
+	TypeIncidentFieldEngineerRejected Type = "IncidentFieldEngineerRejected"
+	TypeIncidentWorkStarted           Type = "IncidentWorkStarted"
+	TypeIncidentWorkStopped           Type = "IncidentWorkStopped"
+	TypeTimeSessionStateChanged       Type = "TimeSessionStateChanged"
+)
+
+// Types returns all event types
+func Types() []Type {
+	return []Type{
+		TypeIncidentCancelled,
+		TypeIncidentStateChanged,
+		TypeIncidentFieldEngineerAssigned,
+		TypeIncidentFieldEngineerAccepted,
+		TypeIncidentFieldEngineerRejected,
+		TypeIncidentWorkStarted,
+		TypeIncidentWorkStopped,
+		TypeTimeSessionStateChanged,
+	}
+}
+
+// NewTypeFromString returns the event type with the given name or error if the type does not exist
+func NewTypeFromString(typeStr string) (Type, error) {
+	for _, t := range Types() {
+		if t.String() == typeStr {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown event type '%s'", typeStr)
+}
+
+// Event is a domain event. Events are JSON encoded when they are stored in the outbox.
+type Event interface {
+	// EventType returns the type of the event
+	EventType() Type
+
+	// AggregateID returns ID of the aggregate that recorded the event
+	AggregateID() ref.UUID
+}
+
+// IncidentCancelled is recorded when the incident is cancelled
+type IncidentCancelled struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	// ID of the basic user who cancelled the incident
+	ActorID ref.UUID `json:"actor_id"`
+}
+
+// EventType returns the type of the event
+func (e IncidentCancelled) EventType() Type {
+	return TypeIncidentCancelled
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentCancelled) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// IncidentStateChanged is recorded when the incident moves to other state
+type IncidentStateChanged struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	// ID of the basic user who changed the state, it is missing if the state was changed automatically (ie. AutoClose)
+	ActorID ref.UUID `json:"actor_id,omitempty"`
+
+	From string `json:"from"`
+
+	To string `json:"to"`
+}
+
+// EventType returns the type of the event
+func (e IncidentStateChanged) EventType() Type {
+	return TypeIncidentStateChanged
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentStateChanged) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// IncidentFieldEngineerAssigned is recorded when other field engineer is assigned to the incident
+type IncidentFieldEngineerAssigned struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	FieldEngineerID ref.UUID `json:"field_engineer_id"`
+
+	// ID of the basic user who assigned the field engineer
+	ActorID ref.UUID `json:"actor_id"`
+}
+
+// EventType returns the type of the event
+func (e IncidentFieldEngineerAssigned) EventType() Type {
+	return TypeIncidentFieldEngineerAssigned
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentFieldEngineerAssigned) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// IncidentFieldEngineerAccepted is recorded when the assigned field engineer accepts the incident
+type IncidentFieldEngineerAccepted struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	FieldEngineerID ref.UUID `json:"field_engineer_id"`
+
+	// ID of the basic user who accepted the incident
+	ActorID ref.UUID `json:"actor_id"`
+}
+
+// EventType returns the type of the event
+func (e IncidentFieldEngineerAccepted) EventType() Type {
+	return TypeIncidentFieldEngineerAccepted
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentFieldEngineerAccepted) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// IncidentFieldEngineerRejected is recorded when the assigned field engineer rejects the incident, the incident is left
+// without field engineer so that it can be assigned to other one
+type IncidentFieldEngineerRejected struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	// ID of the field engineer who rejected the incident
+	FieldEngineerID ref.UUID `json:"field_engineer_id"`
+
+	// ID of the basic user who rejected the incident
+	ActorID ref.UUID `json:"actor_id"`
+
+	Reason string `json:"reason"`
+}
+
+// EventType returns the type of the event
+func (e IncidentFieldEngineerRejected) EventType() Type {
+	return TypeIncidentFieldEngineerRejected
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentFieldEngineerRejected) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// IncidentWorkStarted is recorded when the field engineer starts working on the incident
+type IncidentWorkStarted struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	FieldEngineerID ref.UUID `json:"field_engineer_id"`
+
+	// ID of the basic user who started working
+	ActorID ref.UUID `json:"actor_id"`
+
+	Remote bool `json:"remote"`
+}
+
+// EventType returns the type of the event
+func (e IncidentWorkStarted) EventType() Type {
+	return TypeIncidentWorkStarted
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentWorkStarted) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// IncidentWorkStopped is recorded when the field engineer stops working on the incident and the open timelog is closed
+type IncidentWorkStopped struct {
+	IncidentID ref.UUID `json:"incident_id"`
+
+	FieldEngineerID ref.UUID `json:"field_engineer_id"`
+
+	// ID of the basic user who stopped working
+	ActorID ref.UUID `json:"actor_id"`
+
+	// ID of the closed timelog
+	TimelogID ref.UUID `json:"timelog_id"`
+
+	// Time spent working in seconds
+	Work uint `json:"work"`
+
+	VisitSummary string `json:"visit_summary"`
+}
+
+// EventType returns the type of the event
+func (e IncidentWorkStopped) EventType() Type {
+	return TypeIncidentWorkStopped
+}
+
+// AggregateID returns ID of the incident
+func (e IncidentWorkStopped) AggregateID() ref.UUID {
+	return e.IncidentID
+}
+
+// TimeSessionStateChanged is recorded when the state of the field engineer's open time session changes
+type TimeSessionStateChanged struct {
+	FieldEngineerID ref.UUID `json:"field_engineer_id"`
+
+	// ID of the time session
+	TimeSessionID ref.UUID `json:"time_session_id"`
+
+	// New state of the time session
+	State string `json:"state"`
+
+	// ID of the incident the field engineer started working on, it is set only if the state was changed by starting the work
+	IncidentID ref.UUID `json:"incident_id,omitempty"`
+}
+
+// EventType returns the type of the event
+func (e TimeSessionStateChanged) EventType() Type {
+	return TypeTimeSessionStateChanged
+}
+
+// AggregateID returns ID of the field engineer
+func (e TimeSessionStateChanged) AggregateID() ref.UUID {
+	return e.FieldEngineerID
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/embedded"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
 	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
@@ -19,9 +20,15 @@ type FieldEngineer struct {
 
 	openTimeSession *tsession.TimeSession
 
+	// Deactivated field engineer cannot work on tickets anymore
+	deactivated bool
+
 	TimeSessions []ref.UUID
 
 	CreatedUpdated types.CreatedUpdated
+
+	// events recorded since the field engineer was loaded, they are stored in the outbox by the repository
+	events []event.Event
 }
 
 // UUID getter
@@ -53,6 +60,51 @@ func (e FieldEngineer) HasOpenTimeSession() bool {
 	return e.openTimeSession != nil
 }
 
+// Events returns domain events recorded since the field engineer was loaded from the repository
+func (e FieldEngineer) Events() []event.Event {
+	return e.events
+}
+
+// EventsWithOpenTimeSessionID returns domain events recorded since the field engineer was loaded from the repository
+// with the ID of the newly opened time session filled in (do not use in the domain, method is used by repository)
+func (e FieldEngineer) EventsWithOpenTimeSessionID(tSessionID ref.UUID) []event.Event {
+	events := make([]event.Event, 0, len(e.events))
+	for _, ev := range e.events {
+		if changed, ok := ev.(event.TimeSessionStateChanged); ok && changed.TimeSessionID.IsZero() {
+			changed.TimeSessionID = tSessionID
+			ev = changed
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// ClearEvents removes the recorded events (ie. after they were stored in the outbox)
+func (e *FieldEngineer) ClearEvents() {
+	e.events = nil
+}
+
+// recordTimeSessionStateChanged records the event with the current state of the open time session.
+// incID is the ID of the incident the field engineer started working on (zero if the state was not changed by starting the work).
+func (e *FieldEngineer) recordTimeSessionStateChanged(incID ref.UUID) {
+	e.events = append(e.events, event.TimeSessionStateChanged{
+		FieldEngineerID: e.uuid,
+		TimeSessionID:   e.openTimeSession.UUID(),
+		State:           e.openTimeSession.State().String(),
+		IncidentID:      incID,
+	})
+}
+
+// IsDeactivated returns true if the field engineer was deactivated
+func (e FieldEngineer) IsDeactivated() bool {
+	return e.deactivated
+}
+
+// SetDeactivated sets deactivated flag (do not use in the domain, method is used by repository)
+func (e *FieldEngineer) SetDeactivated(deactivated bool) {
+	e.deactivated = deactivated
+}
+
 // EmbeddedResources returns list of other objects that are 'embedded' in the ticket
 func (e FieldEngineer) EmbeddedResources(actor actor.Actor) []embedded.Resource {
 	var resources []embedded.Resource
@@ -18,7 +18,10 @@ func (a AllowedAction) String() string {
 const (
 	ActionStartTravelling     AllowedAction = "StartTravelling"
 	ActionStartTravellingBack AllowedAction = "StartTravellingBack"
+	ActionStartBreak          AllowedAction = "StartBreak"
+	ActionEndBreak            AllowedAction = "EndBreak"
 	ActionCloseTimeSession    AllowedAction = "CloseTimeSession"
+	ActionDeactivate          AllowedAction = "Deactivate"
 	//	ActionStartWorking    AllowedAction = "StartWorking"
 	//  ActionStopWorking AllowedAction = "StopWorking"
 )
@@ -31,50 +34,216 @@ func (e FieldEngineer) AllowedActions(actor actor.Actor) []string {
 		acts = append(acts, ActionStartTravelling.String())
 	}
 
+	if err := e.canChangeTimeSessionState(actor, tsession.StateTravelBack); err == nil {
+		acts = append(acts, ActionStartTravellingBack.String())
+	}
+
+	if err := e.canChangeTimeSessionState(actor, tsession.StateBreak); err == nil {
+		acts = append(acts, ActionStartBreak.String())
+	}
+
+	if err := e.canEndBreak(actor); err == nil {
+		acts = append(acts, ActionEndBreak.String())
+	}
+
+	if err := e.canChangeTimeSessionState(actor, tsession.StateClosed); err == nil {
+		acts = append(acts, ActionCloseTimeSession.String())
+	}
+
+	if err := e.canBeDeactivated(actor); err == nil {
+		acts = append(acts, ActionDeactivate.String())
+	}
+
 	return acts
 }
 
 // StartWorking can be used by assigned field engineer to start working on the ticket
-func (e *FieldEngineer) StartWorking(actor actor.Actor, inc incident.Incident) error {
+func (e *FieldEngineer) StartWorking(actor actor.Actor, clock domain.Clock, inc incident.Incident) error {
 	if err := e.canStartWorking(actor); err != nil {
 		return err
 	}
 
 	if !e.HasOpenTimeSession() {
 		// open new time session
-		newTimeSession := &tsession.TimeSession{}
-		if err := newTimeSession.SetState(tsession.StateWork); err != nil {
-			return err
-		}
-		if err := newTimeSession.CreatedUpdated.SetCreatedBy(actor.BasicUser); err != nil {
+		if err := e.openNewTimeSession(actor); err != nil {
 			return err
 		}
-		if err := newTimeSession.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
-			return err
-		}
-		e.openTimeSession = newTimeSession
 	}
 
-	if err := e.openTimeSession.StartWorking(inc); err != nil {
+	if err := e.openTimeSession.StartWorking(clock, inc); err != nil {
 		return err
 	}
 
+	e.recordTimeSessionStateChanged(inc.UUID())
+
 	return nil
 }
 
 func (e *FieldEngineer) canStartWorking(actor actor.Actor) error {
-	if !actor.IsFieldEngineer() {
-		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not field engineer")
+	if err := e.actorIsThisFieldEngineer(actor); err != nil {
+		return err
 	}
 
-	if actor.IsFieldEngineer() && *actor.FieldEngineerID() != e.uuid {
-		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not this field engineer")
+	return e.isActive()
+}
+
+// StartTravelling opens new time session and starts travelling to the customer
+func (e *FieldEngineer) StartTravelling(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canStartTravelling(actor); err != nil {
+		return err
+	}
+
+	if err := e.openNewTimeSession(actor); err != nil {
+		return err
+	}
+
+	if err := e.openTimeSession.StartTravelling(clock); err != nil {
+		return err
 	}
 
+	e.recordTimeSessionStateChanged("")
+
 	return nil
 }
 
 func (e *FieldEngineer) canStartTravelling(actor actor.Actor) error {
+	if err := e.actorIsThisFieldEngineer(actor); err != nil {
+		return err
+	}
+
+	if err := e.isActive(); err != nil {
+		return err
+	}
+
+	if e.HasOpenTimeSession() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor already has an open time session")
+	}
+
+	return nil
+}
+
+// StartTravellingBack starts travelling from the customer
+func (e *FieldEngineer) StartTravellingBack(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canChangeTimeSessionState(actor, tsession.StateTravelBack); err != nil {
+		return err
+	}
+
+	if err := e.openTimeSession.StartTravellingBack(clock); err != nil {
+		return err
+	}
+
+	e.recordTimeSessionStateChanged("")
+
+	return e.openTimeSession.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+// StartBreak pauses the work in the open time session
+func (e *FieldEngineer) StartBreak(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canChangeTimeSessionState(actor, tsession.StateBreak); err != nil {
+		return err
+	}
+
+	if err := e.openTimeSession.StartBreak(clock); err != nil {
+		return err
+	}
+
+	e.recordTimeSessionStateChanged("")
+
+	return e.openTimeSession.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+// EndBreak resumes the work in the open time session
+func (e *FieldEngineer) EndBreak(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canEndBreak(actor); err != nil {
+		return err
+	}
+
+	if err := e.openTimeSession.EndBreak(clock); err != nil {
+		return err
+	}
+
+	e.recordTimeSessionStateChanged("")
+
+	return e.openTimeSession.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+func (e *FieldEngineer) canEndBreak(actor actor.Actor) error {
+	if err := e.canChangeTimeSessionState(actor, tsession.StateWork); err != nil {
+		return err
+	}
+
+	if e.openTimeSession.State() != tsession.StateBreak {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session is not in Break state")
+	}
+
+	return nil
+}
+
+// CloseTimeSession closes the open time session
+func (e *FieldEngineer) CloseTimeSession(actor actor.Actor, clock domain.Clock, travelDistanceInTravelUnits uint) error {
+	if err := e.canChangeTimeSessionState(actor, tsession.StateClosed); err != nil {
+		return err
+	}
+
+	if err := e.openTimeSession.Close(clock, travelDistanceInTravelUnits); err != nil {
+		return err
+	}
+
+	e.recordTimeSessionStateChanged("")
+
+	return e.openTimeSession.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+// Deactivate deactivates the field engineer so that he cannot work on tickets anymore
+func (e *FieldEngineer) Deactivate(actor actor.Actor) error {
+	if err := e.canBeDeactivated(actor); err != nil {
+		return err
+	}
+
+	e.deactivated = true
+
+	return nil
+}
+
+func (e FieldEngineer) canBeDeactivated(actor actor.Actor) error {
+	if actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer cannot deactivate field engineers")
+	}
+
+	if err := e.isActive(); err != nil {
+		return err
+	}
+
+	if e.HasOpenTimeSession() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer has an open time session")
+	}
+
+	return nil
+}
+
+// isActive returns error if the field engineer was deactivated
+func (e FieldEngineer) isActive() error {
+	if e.deactivated {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer is deactivated")
+	}
+
+	return nil
+}
+
+// canChangeTimeSessionState returns error if the actor cannot move the open time session to the given state
+func (e *FieldEngineer) canChangeTimeSessionState(actor actor.Actor, s tsession.State) error {
+	if err := e.actorIsThisFieldEngineer(actor); err != nil {
+		return err
+	}
+
+	if !e.HasOpenTimeSession() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor does not have an open time session")
+	}
+
+	return e.openTimeSession.CanChangeState(s)
+}
+
+func (e *FieldEngineer) actorIsThisFieldEngineer(actor actor.Actor) error {
 	if !actor.IsFieldEngineer() {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not field engineer")
 	}
@@ -83,9 +252,21 @@ func (e *FieldEngineer) canStartTravelling(actor actor.Actor) error {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not this field engineer")
 	}
 
-	if e.HasOpenTimeSession() {
-		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor already has an open time session")
+	return nil
+}
+
+// openNewTimeSession sets new empty time session as the open one
+func (e *FieldEngineer) openNewTimeSession(actor actor.Actor) error {
+	newTimeSession := &tsession.TimeSession{}
+
+	if err := newTimeSession.CreatedUpdated.SetCreatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+	if err := newTimeSession.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
 	}
 
+	e.openTimeSession = newTimeSession
+
 	return nil
 }
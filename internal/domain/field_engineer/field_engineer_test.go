@@ -1,14 +1,19 @@
 package fieldengineer_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
 	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -23,6 +28,7 @@ var _ = Describe("Field Engineer behavior", func() {
 	var basicUser user.BasicUser
 	var fieldEngineer FieldEngineer
 	var actorUser actor.Actor
+	var clock *mocks.FixedClock
 
 	BeforeEach(func() {
 		basicUser = user.BasicUser{
@@ -42,6 +48,8 @@ var _ = Describe("Field Engineer behavior", func() {
 		actorUser = actor.Actor{
 			BasicUser: basicUser,
 		}
+
+		clock = mocks.NewFixedClock()
 	})
 
 	Describe("StartWorking()", func() {
@@ -52,7 +60,7 @@ var _ = Describe("Field Engineer behavior", func() {
 
 		When("called by actor that is not field engineer", func() {
 			It("should return error", func() {
-				err := fieldEngineer.StartWorking(actorUser, inc)
+				err := fieldEngineer.StartWorking(actorUser, clock, inc)
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(Equal("actor is not field engineer"))
 			})
@@ -65,7 +73,7 @@ var _ = Describe("Field Engineer behavior", func() {
 			})
 
 			It("should return error", func() {
-				err := fieldEngineer.StartWorking(actorUser, inc)
+				err := fieldEngineer.StartWorking(actorUser, clock, inc)
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(Equal("actor is not this field engineer"))
 			})
@@ -80,7 +88,7 @@ var _ = Describe("Field Engineer behavior", func() {
 			Context("and the field engineer has not an open time session", func() {
 				JustBeforeEach(func() {
 					Expect(fieldEngineer.HasOpenTimeSession()).To(BeFalse())
-					err := fieldEngineer.StartWorking(actorUser, inc)
+					err := fieldEngineer.StartWorking(actorUser, clock, inc)
 					Expect(err).To(BeNil())
 				})
 
@@ -88,6 +96,14 @@ var _ = Describe("Field Engineer behavior", func() {
 					Expect(fieldEngineer.HasOpenTimeSession()).To(BeTrue())
 				})
 
+				It("should record TimeSessionStateChanged event", func() {
+					Expect(fieldEngineer.Events()).To(ConsistOf(event.TimeSessionStateChanged{
+						FieldEngineerID: fieldEngineer.UUID(),
+						State:           tsession.StateWork.String(),
+						IncidentID:      inc.UUID(),
+					}))
+				})
+
 				Describe("open time session", func() {
 					var ts *tsession.TimeSession
 
@@ -118,7 +134,7 @@ var _ = Describe("Field Engineer behavior", func() {
 							HasSupplierProduct: true,
 						}},
 					}
-					err := ts.SetState(tsession.StateTravel)
+					err := ts.RestoreState(tsession.StateTravel)
 					Expect(err).To(BeNil())
 
 					Expect(ts.Incidents).To(HaveLen(1))
@@ -131,7 +147,7 @@ var _ = Describe("Field Engineer behavior", func() {
 				When("time session is in Travel or Work state", func() {
 					JustBeforeEach(func() {
 						Expect(fieldEngineer.HasOpenTimeSession()).To(BeTrue())
-						err := fieldEngineer.StartWorking(actorUser, inc)
+						err := fieldEngineer.StartWorking(actorUser, clock, inc)
 						Expect(err).To(BeNil())
 					})
 
@@ -152,13 +168,13 @@ var _ = Describe("Field Engineer behavior", func() {
 
 				When("time session is in TravelBack state", func() {
 					JustBeforeEach(func() {
-						err := ts.SetState(tsession.StateTravelBack)
+						err := ts.RestoreState(tsession.StateTravelBack)
 						Expect(err).To(BeNil())
 					})
 
 					It("should return error", func() {
 						Expect(fieldEngineer.HasOpenTimeSession()).To(BeTrue())
-						err := fieldEngineer.StartWorking(actorUser, inc)
+						err := fieldEngineer.StartWorking(actorUser, clock, inc)
 						Expect(err).NotTo(BeNil())
 
 						Expect(err.Error()).To(Equal("time session is not in New, Travel nor Work state"))
@@ -166,28 +182,32 @@ var _ = Describe("Field Engineer behavior", func() {
 
 					When("time session is in Brake state", func() {
 						JustBeforeEach(func() {
-							err := ts.SetState(tsession.StateBreak)
+							err := ts.RestoreState(tsession.StateBreak)
 							Expect(err).To(BeNil())
 						})
 
-						It("should return error", func() {
+						It("should return ActionForbidden error", func() {
 							Expect(fieldEngineer.HasOpenTimeSession()).To(BeTrue())
-							err := fieldEngineer.StartWorking(actorUser, inc)
+							err := fieldEngineer.StartWorking(actorUser, clock, inc)
 							Expect(err).NotTo(BeNil())
 
 							Expect(err.Error()).To(Equal("time session is not in New, Travel nor Work state"))
+
+							var dErr *domain.Error
+							Expect(errors.As(err, &dErr)).To(BeTrue())
+							Expect(dErr.Code()).To(Equal(domain.ErrorCodeActionForbidden))
 						})
 					})
 
 					When("time session is in Closed state", func() {
 						JustBeforeEach(func() {
-							err := ts.SetState(tsession.StateClosed)
+							err := ts.RestoreState(tsession.StateClosed)
 							Expect(err).To(BeNil())
 						})
 
 						It("should return error", func() {
 							Expect(fieldEngineer.HasOpenTimeSession()).To(BeTrue())
-							err := fieldEngineer.StartWorking(actorUser, inc)
+							err := fieldEngineer.StartWorking(actorUser, clock, inc)
 							Expect(err).NotTo(BeNil())
 
 							Expect(err.Error()).To(Equal("time session is not in New, Travel nor Work state"))
@@ -197,4 +217,157 @@ var _ = Describe("Field Engineer behavior", func() {
 			})
 		})
 	})
+
+	Describe("time session actions", func() {
+		When("called by actor that is not this field engineer", func() {
+			BeforeEach(func() {
+				feUUID := ref.UUID("b8d49f19-5e54-44cf-b547-f16bacb69294")
+				actorUser.SetFieldEngineerID(&feUUID)
+			})
+
+			It("should not allow any action", func() {
+				Expect(fieldEngineer.AllowedActions(actorUser)).To(BeEmpty())
+
+				err := fieldEngineer.StartTravelling(actorUser, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("actor is not this field engineer"))
+			})
+		})
+
+		When("called by this field engineer actor", func() {
+			BeforeEach(func() {
+				feUUID := fieldEngineer.UUID()
+				actorUser.SetFieldEngineerID(&feUUID)
+			})
+
+			Context("and the field engineer has not an open time session", func() {
+				It("should allow only StartTravelling action", func() {
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(Equal([]string{ActionStartTravelling.String()}))
+				})
+
+				It("should return error when trying to change time session state", func() {
+					err := fieldEngineer.StartBreak(actorUser, clock)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("actor does not have an open time session"))
+
+					err = fieldEngineer.CloseTimeSession(actorUser, clock, 10)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("actor does not have an open time session"))
+				})
+
+				It("should go through the whole time session lifecycle", func() {
+					err := fieldEngineer.StartTravelling(actorUser, clock)
+					Expect(err).To(BeNil())
+					Expect(fieldEngineer.HasOpenTimeSession()).To(BeTrue())
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(BeEmpty())
+
+					err = fieldEngineer.StartTravelling(actorUser, clock)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("actor already has an open time session"))
+
+					inc := incident.Incident{Number: "INC123"}
+					err = inc.SetUUID("3c032e34-b1a2-43a9-b1d2-eb3b241b4a78")
+					Expect(err).To(BeNil())
+
+					clock.AddTime(20 * time.Minute)
+					err = fieldEngineer.StartWorking(actorUser, clock, inc)
+					Expect(err).To(BeNil())
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(Equal([]string{
+						ActionStartTravellingBack.String(),
+						ActionStartBreak.String(),
+						ActionCloseTimeSession.String(),
+					}))
+
+					clock.AddTime(1 * time.Hour)
+					err = fieldEngineer.StartBreak(actorUser, clock)
+					Expect(err).To(BeNil())
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(Equal([]string{
+						ActionStartTravellingBack.String(),
+						ActionEndBreak.String(),
+						ActionCloseTimeSession.String(),
+					}))
+
+					clock.AddTime(30 * time.Minute)
+					err = fieldEngineer.EndBreak(actorUser, clock)
+					Expect(err).To(BeNil())
+
+					clock.AddTime(1 * time.Hour)
+					err = fieldEngineer.StartTravellingBack(actorUser, clock)
+					Expect(err).To(BeNil())
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(Equal([]string{ActionCloseTimeSession.String()}))
+
+					clock.AddTime(25 * time.Minute)
+					err = fieldEngineer.CloseTimeSession(actorUser, clock, 48)
+					Expect(err).To(BeNil())
+
+					ts := fieldEngineer.OpenTimeSession()
+					Expect(ts.State()).To(Equal(tsession.StateClosed))
+					Expect(ts.Travel).To(Equal(uint(1200)))
+					Expect(ts.Work).To(Equal(uint(7200)))
+					Expect(ts.TravelBack).To(Equal(uint(1500)))
+					Expect(ts.TravelDistanceInTravelUnits).To(Equal(uint(48)))
+					Expect(ts.Incidents).To(HaveLen(1))
+				})
+			})
+		})
+	})
+
+	Describe("Deactivate()", func() {
+		When("called by field engineer actor", func() {
+			BeforeEach(func() {
+				feUUID := fieldEngineer.UUID()
+				actorUser.SetFieldEngineerID(&feUUID)
+			})
+
+			It("should return error", func() {
+				Expect(fieldEngineer.AllowedActions(actorUser)).NotTo(ContainElement(ActionDeactivate.String()))
+
+				err := fieldEngineer.Deactivate(actorUser)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("field engineer cannot deactivate field engineers"))
+				Expect(fieldEngineer.IsDeactivated()).To(BeFalse())
+			})
+		})
+
+		When("called by actor that is not field engineer", func() {
+			Context("and the field engineer has an open time session", func() {
+				It("should return error", func() {
+					fieldEngineer.SetOpenTimeSession(&tsession.TimeSession{})
+					Expect(fieldEngineer.AllowedActions(actorUser)).NotTo(ContainElement(ActionDeactivate.String()))
+
+					err := fieldEngineer.Deactivate(actorUser)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("field engineer has an open time session"))
+				})
+			})
+
+			Context("and the field engineer has not an open time session", func() {
+				It("should deactivate the field engineer", func() {
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(Equal([]string{ActionDeactivate.String()}))
+
+					err := fieldEngineer.Deactivate(actorUser)
+					Expect(err).To(BeNil())
+					Expect(fieldEngineer.IsDeactivated()).To(BeTrue())
+					Expect(fieldEngineer.AllowedActions(actorUser)).To(BeEmpty())
+
+					err = fieldEngineer.Deactivate(actorUser)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("field engineer is deactivated"))
+				})
+
+				It("should not allow deactivated field engineer to start travelling", func() {
+					err := fieldEngineer.Deactivate(actorUser)
+					Expect(err).To(BeNil())
+
+					feUUID := fieldEngineer.UUID()
+					feActor := actor.Actor{BasicUser: basicUser}
+					feActor.SetFieldEngineerID(&feUUID)
+
+					err = fieldEngineer.StartTravelling(feActor, clock)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("field engineer is deactivated"))
+				})
+			})
+		})
+	})
 })
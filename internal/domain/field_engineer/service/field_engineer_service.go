@@ -3,21 +3,135 @@ package fieldengineersvc
 import (
 	"context"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
-// NewFieldEngineerService creates the field engineer service
-func NewFieldEngineerService(repo repository.FieldEngineerRepository) FieldEngineerService {
-	return &fieldEngineerService{repo}
+// NewFieldEngineerService creates the field engineer service; identityCache is notified when the basic user becomes
+// or stops being the field engineer, it can be nil if field engineer identities are not cached
+func NewFieldEngineerService(repo repository.FieldEngineerRepository, basicUserRepository repository.BasicUserRepository, identityCache IdentityCache) FieldEngineerService {
+	return &fieldEngineerService{
+		repo:                repo,
+		basicUserRepository: basicUserRepository,
+		identityCache:       identityCache,
+	}
 }
 
 type fieldEngineerService struct {
-	repo repository.FieldEngineerRepository
+	repo                repository.FieldEngineerRepository
+	basicUserRepository repository.BasicUserRepository
+	identityCache       IdentityCache
 }
 
-func (s *fieldEngineerService) GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
+func (s *fieldEngineerService) CreateFieldEngineer(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateFieldEngineerParams) (ref.UUID, error) {
+	basicUser, err := s.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(params.BasicUserID))
+	if err != nil {
+		return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeNotFound, "cannot create field engineer")
+	}
+
+	newFieldEngineer := fieldengineer.FieldEngineer{
+		BasicUser: basicUser,
+	}
+
+	if err := newFieldEngineer.CreatedUpdated.SetCreatedBy(actor.BasicUser); err != nil {
+		return ref.UUID(""), err
+	}
+	if err := newFieldEngineer.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return ref.UUID(""), err
+	}
+
+	ID, err := s.repo.AddFieldEngineer(ctx, channelID, newFieldEngineer)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	s.invalidateIdentity(channelID, basicUser.UUID())
+
+	return ID, nil
+}
+
+func (s *fieldEngineerService) GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
 	return s.repo.GetFieldEngineer(ctx, channelID, ID)
 }
+
+func (s *fieldEngineerService) ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, params PaginationParams) (repository.FieldEngineerList, error) {
+	return s.repo.ListFieldEngineers(ctx, channelID, params.Page(), params.ItemsPerPage())
+}
+
+func (s *fieldEngineerService) Deactivate(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) error {
+	var basicUserID ref.UUID
+	err := s.updateFieldEngineer(ctx, channelID, actor, ID, func(fe *fieldengineer.FieldEngineer) error {
+		basicUserID = fe.BasicUser.UUID()
+		return fe.Deactivate(actor)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateIdentity(channelID, basicUserID)
+
+	return nil
+}
+
+func (s *fieldEngineerService) StartTravelling(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error {
+	return s.updateFieldEngineer(ctx, channelID, actor, ID, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.StartTravelling(actor, clock)
+	})
+}
+
+func (s *fieldEngineerService) StartTravellingBack(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error {
+	return s.updateFieldEngineer(ctx, channelID, actor, ID, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.StartTravellingBack(actor, clock)
+	})
+}
+
+func (s *fieldEngineerService) StartBreak(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error {
+	return s.updateFieldEngineer(ctx, channelID, actor, ID, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.StartBreak(actor, clock)
+	})
+}
+
+func (s *fieldEngineerService) EndBreak(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error {
+	return s.updateFieldEngineer(ctx, channelID, actor, ID, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.EndBreak(actor, clock)
+	})
+}
+
+func (s *fieldEngineerService) CloseTimeSession(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, params api.FieldEngineerCloseTimeSessionParams, clock domain.Clock) error {
+	return s.updateFieldEngineer(ctx, channelID, actor, ID, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.CloseTimeSession(actor, clock, params.TravelDistanceInTravelUnits)
+	})
+}
+
+// invalidateIdentity removes the cached field engineer identity of the basic user
+func (s *fieldEngineerService) invalidateIdentity(channelID ref.ChannelID, basicUserID ref.UUID) {
+	if s.identityCache != nil {
+		s.identityCache.InvalidateFieldEngineer(channelID, basicUserID)
+	}
+}
+
+// updateFieldEngineer loads the field engineer from the repository, performs the action and saves the changes
+func (s *fieldEngineerService) updateFieldEngineer(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, action func(fe *fieldengineer.FieldEngineer) error) error {
+	fe, err := s.repo.GetFieldEngineer(ctx, channelID, ID)
+	if err != nil {
+		return err
+	}
+
+	if err := action(&fe); err != nil {
+		return err
+	}
+
+	if err := fe.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.UpdateFieldEngineer(ctx, channelID, fe); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,14 +3,56 @@ package fieldengineersvc
 import (
 	"context"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
-// FieldEngineerService provides incident operations
+// IdentityCache caches field engineer identities of the basic users
+type IdentityCache interface {
+	// InvalidateFieldEngineer removes the cached field engineer identity of the basic user
+	InvalidateFieldEngineer(channelID ref.ChannelID, basicUserID ref.UUID)
+}
+
+// FieldEngineerService provides field engineer operations
 type FieldEngineerService interface {
+	// CreateFieldEngineer creates new field engineer from the existing basic user and adds it to the repository
+	CreateFieldEngineer(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateFieldEngineerParams) (ref.UUID, error)
 
 	// GetFieldEngineer returns the field engineer with the given ID from the repository
 	GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (fieldengineer.FieldEngineer, error)
+
+	// ListFieldEngineers returns the list of field engineers from the repository
+	ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams PaginationParams) (repository.FieldEngineerList, error)
+
+	// Deactivate deactivates the field engineer so that he cannot work on tickets anymore
+	Deactivate(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) error
+
+	// StartTravelling opens new time session of the field engineer and starts travelling to the customer
+	StartTravelling(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error
+
+	// StartTravellingBack starts travelling from the customer in the open time session
+	StartTravellingBack(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error
+
+	// StartBreak pauses the work in the open time session
+	StartBreak(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error
+
+	// EndBreak resumes the work in the open time session
+	EndBreak(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, clock domain.Clock) error
+
+	// CloseTimeSession closes the open time session of the field engineer
+	CloseTimeSession(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, params api.FieldEngineerCloseTimeSessionParams, clock domain.Clock) error
+}
+
+// PaginationParams provides information about current requested page number and a number of items per page to be displayed.
+// It has the same method set as converters.PaginationParams (which cannot be imported here because of the import cycle).
+type PaginationParams interface {
+	// Page is the requested page number to be returned
+	Page() uint
+
+	// ItemsPerPage returns how many items per page should be displayed
+	ItemsPerPage() uint
 }
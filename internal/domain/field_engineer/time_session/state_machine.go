@@ -0,0 +1,46 @@
+package tsession
+
+import (
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+)
+
+// stateTransitions is the time session state machine definition.
+// It maps current state to the states the time session can be moved to.
+var stateTransitions = map[State][]State{
+	State{}: { // zero state => newly opened time session
+		StateTravel,
+		StateWork,
+	},
+	StateTravel: {
+		StateWork,
+	},
+	StateWork: {
+		StateBreak,
+		StateTravelBack,
+		StateClosed,
+	},
+	StateBreak: {
+		StateWork,
+		StateTravelBack,
+		StateClosed,
+	},
+	StateTravelBack: {
+		StateClosed,
+	},
+	StateClosed: {},
+}
+
+// CanChangeState returns error if the time session cannot be moved to the given state
+func (e TimeSession) CanChangeState(s State) error {
+	if s == e.state {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session is already in '%s' state", s)
+	}
+
+	for _, allowed := range stateTransitions[e.state] {
+		if allowed == s {
+			return nil
+		}
+	}
+
+	return domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session cannot be moved from '%s' to '%s' state", e.state, s)
+}
@@ -16,6 +16,9 @@ type TimeSession struct {
 	// State of the time session
 	state State
 
+	// Time when the time session was moved to the current state
+	stateChangedAt types.DateTime
+
 	Incidents []IncidentInfo
 
 	// Time spent working (in seconds) counted from all timespans
@@ -58,23 +61,111 @@ func (e TimeSession) State() State {
 	return e.state
 }
 
-// SetState ...
-func (e *TimeSession) SetState(s State) error {
-	// TODO add state machine and checks
+// SetState moves the time session to the given state. It returns error if the transition is not allowed by the state machine.
+// Time spent in the previous state is added to the corresponding counter (Travel, Work or TravelBack).
+func (e *TimeSession) SetState(clock domain.Clock, s State) error {
+	if err := e.CanChangeState(s); err != nil {
+		return err
+	}
+
+	now := clock.Now()
+
+	if !e.stateChangedAt.IsZero() {
+		changedAt, err := e.stateChangedAt.ToTime()
+		if err != nil {
+			return err
+		}
+
+		var elapsed uint
+		if now.After(changedAt) {
+			elapsed = uint(now.Sub(changedAt).Seconds())
+		}
+
+		switch e.state {
+		case StateTravel:
+			e.Travel += elapsed
+		case StateWork:
+			e.Work += elapsed
+		case StateTravelBack:
+			e.TravelBack += elapsed
+		}
+	}
+
+	e.state = s
+	e.stateChangedAt = clock.NowFormatted()
+
+	return nil
+}
+
+// RestoreState sets the state without any checks (do not use in the domain, method is used by repository)
+func (e *TimeSession) RestoreState(s State) error {
+	if s.IsZero() {
+		return fmt.Errorf("time session: cannot restore zero state")
+	}
 	e.state = s
 	return nil
 }
 
+// StateChangedAt returns time when the time session was moved to the current state
+func (e TimeSession) StateChangedAt() types.DateTime {
+	return e.stateChangedAt
+}
+
+// SetStateChangedAt sets time of the last state change (do not use in the domain, method is used by repository)
+func (e *TimeSession) SetStateChangedAt(t types.DateTime) {
+	e.stateChangedAt = t
+}
+
+// StartTravelling sets newly opened time session to Travel state
+func (e *TimeSession) StartTravelling(clock domain.Clock) error {
+	return e.SetState(clock, StateTravel)
+}
+
 // StartWorking add incident to time session a sets it to Work state
-func (e *TimeSession) StartWorking(inc incident.Incident) error {
+func (e *TimeSession) StartWorking(clock domain.Clock, inc incident.Incident) error {
 	if !e.state.IsZero() && e.state != StateTravel && e.state != StateWork {
-		return domain.NewErrorf(domain.ErrorCodeUnknown, "time session is not in New, Travel nor Work state")
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session is not in New, Travel nor Work state")
+	}
+
+	if e.state != StateWork {
+		if err := e.SetState(clock, StateWork); err != nil {
+			return err
+		}
 	}
 
-	e.state = StateWork
 	return e.AddIncident(inc)
 }
 
+// StartBreak sets the time session to Break state, time spent in Break state is not counted as work
+func (e *TimeSession) StartBreak(clock domain.Clock) error {
+	return e.SetState(clock, StateBreak)
+}
+
+// EndBreak sets the time session back to Work state
+func (e *TimeSession) EndBreak(clock domain.Clock) error {
+	if e.state != StateBreak {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session is not in Break state")
+	}
+
+	return e.SetState(clock, StateWork)
+}
+
+// StartTravellingBack sets the time session to TravelBack state
+func (e *TimeSession) StartTravellingBack(clock domain.Clock) error {
+	return e.SetState(clock, StateTravelBack)
+}
+
+// Close closes the time session and sets distance travelled to the customer and back
+func (e *TimeSession) Close(clock domain.Clock, travelDistanceInTravelUnits uint) error {
+	if err := e.SetState(clock, StateClosed); err != nil {
+		return err
+	}
+
+	e.TravelDistanceInTravelUnits = travelDistanceInTravelUnits
+
+	return nil
+}
+
 // AddIncident adds incident to the time session (skips adding the same incident multiple times)
 func (e *TimeSession) AddIncident(inc incident.Incident) error {
 	//hasSp := inc.SupplierProduct != nil // TODO supplier product not implemented yet
@@ -93,7 +184,7 @@ func (e *TimeSession) AddIncident(inc incident.Incident) error {
 	}
 
 	if e.state != StateWork {
-		return domain.NewErrorf(domain.ErrorCodeUnknown, "time session is not in Work state")
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session is not in Work state")
 	}
 
 	incInfo := IncidentInfo{
@@ -2,9 +2,11 @@ package tsession_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -26,7 +28,7 @@ var _ = Describe("Time Session behavior", func() {
 			Expect(err).To(BeNil())
 
 			ts = TimeSession{}
-			err = ts.SetState(StateWork)
+			err = ts.RestoreState(StateWork)
 			Expect(err).To(BeNil())
 		})
 
@@ -67,7 +69,7 @@ var _ = Describe("Time Session behavior", func() {
 
 		When("time session is not in Work state", func() {
 			BeforeEach(func() {
-				err := ts.SetState(StateTravel)
+				err := ts.RestoreState(StateTravel)
 				Expect(err).To(BeNil())
 			})
 
@@ -79,4 +81,115 @@ var _ = Describe("Time Session behavior", func() {
 			})
 		})
 	})
+
+	Describe("time session lifecycle", func() {
+		var inc incident.Incident
+		var ts TimeSession
+		var clock *mocks.FixedClock
+
+		BeforeEach(func() {
+			inc = incident.Incident{}
+			err := inc.SetUUID("40018f49-e7dd-4afa-86f5-021b44ad33ad")
+			Expect(err).To(BeNil())
+
+			ts = TimeSession{}
+			clock = mocks.NewFixedClock()
+		})
+
+		It("should accumulate travel, work and travel back time", func() {
+			err := ts.StartTravelling(clock)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateTravel))
+			Expect(ts.StateChangedAt()).To(Equal(clock.NowFormatted()))
+
+			clock.AddTime(30 * time.Minute)
+			err = ts.StartWorking(clock, inc)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateWork))
+			Expect(ts.Travel).To(Equal(uint(1800)))
+
+			clock.AddTime(1 * time.Hour)
+			err = ts.StartBreak(clock)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateBreak))
+			Expect(ts.Work).To(Equal(uint(3600)))
+
+			// break is not counted as work
+			clock.AddTime(45 * time.Minute)
+			err = ts.EndBreak(clock)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateWork))
+			Expect(ts.Work).To(Equal(uint(3600)))
+
+			clock.AddTime(10 * time.Minute)
+			err = ts.StartTravellingBack(clock)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateTravelBack))
+			Expect(ts.Work).To(Equal(uint(4200)))
+
+			clock.AddTime(20 * time.Minute)
+			err = ts.Close(clock, 35)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateClosed))
+			Expect(ts.TravelBack).To(Equal(uint(1200)))
+			Expect(ts.TravelDistanceInTravelUnits).To(Equal(uint(35)))
+
+			Expect(ts.Travel).To(Equal(uint(1800)))
+			Expect(ts.Work).To(Equal(uint(4200)))
+		})
+
+		It("should allow to start working without travelling", func() {
+			err := ts.StartWorking(clock, inc)
+			Expect(err).To(BeNil())
+			Expect(ts.State()).To(Equal(StateWork))
+
+			clock.AddTime(15 * time.Minute)
+			err = ts.Close(clock, 0)
+			Expect(err).To(BeNil())
+			Expect(ts.Work).To(Equal(uint(900)))
+			Expect(ts.Travel).To(BeZero())
+		})
+
+		When("transition is not allowed", func() {
+			It("should return error", func() {
+				err := ts.StartTravellingBack(clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("time session cannot be moved from '' to 'travel back' state"))
+
+				err = ts.StartTravelling(clock)
+				Expect(err).To(BeNil())
+
+				err = ts.StartBreak(clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("time session cannot be moved from 'travel' to 'break' state"))
+
+				err = ts.StartTravelling(clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("time session is already in 'travel' state"))
+
+				err = ts.EndBreak(clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("time session is not in Break state"))
+			})
+		})
+
+		When("time session is closed", func() {
+			BeforeEach(func() {
+				err := ts.RestoreState(StateClosed)
+				Expect(err).To(BeNil())
+			})
+
+			It("should not allow any transition", func() {
+				err := ts.StartWorking(clock, inc)
+				Expect(err).NotTo(BeNil())
+
+				err = ts.StartTravellingBack(clock)
+				Expect(err).NotTo(BeNil())
+
+				err = ts.Close(clock, 10)
+				Expect(err).NotTo(BeNil())
+				Expect(ts.TravelDistanceInTravelUnits).To(BeZero())
+			})
+		})
+	})
 })
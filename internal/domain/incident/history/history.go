@@ -0,0 +1,95 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+)
+
+// Action values
+const (
+	ActionCreateIncident           = "CreateIncident"
+	ActionUpdateIncident           = "UpdateIncident"
+	ActionAccept                   = "Accept"
+	ActionReject                   = "Reject"
+	ActionStartWorking             = "StartWorking"
+	ActionStopWorking              = "StopWorking"
+	ActionPauseWorking             = "PauseWorking"
+	ActionResumeWorking            = "ResumeWorking"
+	ActionPutOnHold                = "PutOnHold"
+	ActionResume                   = "Resume"
+	ActionResolve                  = "Resolve"
+	ActionClose                    = "Close"
+	ActionAutoClose                = "AutoClose"
+	ActionReopen                   = "Reopen"
+	ActionProposeTimelogCorrection = "ProposeTimelogCorrection"
+	ActionApproveTimelogCorrection = "ApproveTimelogCorrection"
+	ActionRejectTimelogCorrection  = "RejectTimelogCorrection"
+)
+
+// Entry is an immutable record of one change of the incident
+type Entry struct {
+	uuid ref.UUID
+
+	// ID of the changed incident
+	IncidentID ref.UUID
+
+	// Action that changed the incident
+	Action string
+
+	// ID of the basic user who performed the action (ie. the user the request was made on behalf of),
+	// it is zero if the action was performed automatically by the system
+	ActorID ref.UUID
+
+	// ID of the basic user who sent the request on behalf of the actor, it is zero if the request was not made on behalf of other user
+	RequestedByID ref.UUID
+
+	// Time when the action was performed
+	Time types.DateTime
+
+	// Fields changed by the action
+	Changes []Change
+}
+
+// Change is a change of one field of the incident, empty value means the field was not set
+type Change struct {
+	Field string
+
+	Before string
+
+	After string
+}
+
+// NewEntry creates history entry of the action performed by the actor
+func NewEntry(actor actor.Actor, clock domain.Clock, incID ref.UUID, action string, changes []Change) Entry {
+	entry := Entry{
+		IncidentID: incID,
+		Action:     action,
+		ActorID:    actor.BasicUser.UUID(),
+		Time:       clock.NowFormatted(),
+		Changes:    changes,
+	}
+
+	if requestedBy := actor.RequestedBy(); requestedBy != nil {
+		entry.RequestedByID = requestedBy.UUID()
+	}
+
+	return entry
+}
+
+// UUID getter
+func (e Entry) UUID() ref.UUID {
+	return e.uuid
+}
+
+// SetUUID returns error if UUID was already set
+func (e *Entry) SetUUID(v ref.UUID) error {
+	if !e.uuid.IsZero() {
+		return fmt.Errorf("history entry: cannot set UUID, it was already set (%s)", e.uuid)
+	}
+	e.uuid = v
+	return nil
+}
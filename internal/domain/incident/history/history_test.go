@@ -0,0 +1,124 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// TestInit initializes test suite
+func TestInit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "History tests")
+}
+
+var _ = Describe("Incident history", func() {
+	var clock *mocks.FixedClock
+
+	BeforeEach(func() {
+		clock = mocks.NewFixedClock()
+	})
+
+	Describe("NewEntry()", func() {
+		var basicUser user.BasicUser
+
+		BeforeEach(func() {
+			basicUser = user.BasicUser{
+				ExternalUserUUID: "3d334abe-f289-42a5-9742-72c3133768c2",
+				Name:             "Test",
+				Surname:          "User",
+			}
+			err := basicUser.SetUUID("8540d943-8ccd-4ff1-8a08-0c3aa338c58e")
+			Expect(err).To(BeNil())
+		})
+
+		It("should record the actor and the time of the action", func() {
+			actorUser := actor.Actor{BasicUser: basicUser}
+
+			entry := NewEntry(actorUser, clock, "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", ActionResume, nil)
+			Expect(entry.IncidentID).To(Equal(ref.UUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")))
+			Expect(entry.Action).To(Equal(ActionResume))
+			Expect(entry.ActorID).To(Equal(basicUser.UUID()))
+			Expect(entry.RequestedByID.IsZero()).To(BeTrue())
+			Expect(entry.Time).To(Equal(clock.NowFormatted()))
+		})
+
+		When("the action was requested on behalf of the actor", func() {
+			It("should record both identities", func() {
+				requestingUser := user.BasicUser{
+					ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+					Name:             "Alfred",
+					Surname:          "Koletschko",
+				}
+				err := requestingUser.SetUUID("a1e5c0c2-49f1-4c8e-a9d4-6d1b9e8a3f7e")
+				Expect(err).To(BeNil())
+
+				actorUser := actor.Actor{BasicUser: basicUser}
+				actorUser.SetRequestedBy(&requestingUser)
+
+				entry := NewEntry(actorUser, clock, "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", ActionResume, nil)
+				Expect(entry.ActorID).To(Equal(basicUser.UUID()))
+				Expect(entry.RequestedByID).To(Equal(requestingUser.UUID()))
+			})
+		})
+	})
+
+	Describe("Changes()", func() {
+		It("should return changed fields of the incident sorted by the field name", func() {
+			before := incident.Incident{
+				Number:           "INC123",
+				ShortDescription: "Printer is broken",
+			}
+			err := before.RestoreState(incident.StateNew)
+			Expect(err).To(BeNil())
+
+			after := before
+			after.ShortDescription = "Printer is on fire"
+			after.Description = "Smoke everywhere"
+			err = after.RestoreState(incident.StateInProgress)
+			Expect(err).To(BeNil())
+
+			changes := Changes(IncidentSnapshot(before), IncidentSnapshot(after))
+			Expect(changes).To(Equal([]Change{
+				{Field: "description", Before: "", After: "Smoke everywhere"},
+				{Field: "short_description", Before: "Printer is broken", After: "Printer is on fire"},
+				{Field: "state", Before: "new", After: "in progress"},
+			}))
+		})
+
+		It("should return no changes if the incident did not change", func() {
+			inc := incident.Incident{Number: "INC123"}
+			Expect(Changes(IncidentSnapshot(inc), IncidentSnapshot(inc))).To(BeEmpty())
+		})
+
+		It("should return changed fields of the timelog prefixed with the timelog ID", func() {
+			before := timelog.Timelog{
+				Start: "2021-04-01T12:00:00Z",
+				End:   "2021-04-01T13:00:00Z",
+				Work:  3600,
+			}
+			err := before.SetUUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+			Expect(err).To(BeNil())
+
+			after := before
+			after.Start = "2021-04-01T12:30:00Z"
+			after.Work = 1800
+			after.Corrections = []timelog.Correction{{Status: timelog.CorrectionStatusApproved}}
+
+			changes := Changes(TimelogSnapshot(before), TimelogSnapshot(after))
+			Expect(changes).To(Equal([]Change{
+				{Field: "timelogs.0ac5ebce-17e7-4edc-9552-fefe16e127fb.correction", Before: "", After: "approved"},
+				{Field: "timelogs.0ac5ebce-17e7-4edc-9552-fefe16e127fb.start", Before: "2021-04-01T12:00:00Z", After: "2021-04-01T12:30:00Z"},
+				{Field: "timelogs.0ac5ebce-17e7-4edc-9552-fefe16e127fb.work", Before: "3600", After: "1800"},
+			}))
+		})
+	})
+})
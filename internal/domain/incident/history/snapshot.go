@@ -0,0 +1,115 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+)
+
+// Snapshot contains values of the recorded fields of the object at some moment
+type Snapshot map[string]string
+
+// IncidentSnapshot returns values of the recorded fields of the incident
+func IncidentSnapshot(inc incident.Incident) Snapshot {
+	snapshot := Snapshot{
+		"number":            inc.Number,
+		"external_id":       inc.ExternalID,
+		"short_description": inc.ShortDescription,
+		"description":       inc.Description,
+		"state":             inc.State().String(),
+		"impact":            inc.Impact().String(),
+		"urgency":           inc.Urgency().String(),
+		"priority":          inc.Priority().String(),
+	}
+
+	if inc.PriorityOverridden() {
+		snapshot["priority_overridden"] = strconv.FormatBool(true)
+	}
+
+	if inc.FieldEngineerID != nil {
+		snapshot["field_engineer_id"] = inc.FieldEngineerID.String()
+	}
+
+	if inc.FieldEngineerAccepted() {
+		snapshot["field_engineer_accepted"] = strconv.FormatBool(true)
+	}
+
+	if onHold := inc.OnHold(); onHold != nil {
+		snapshot["on_hold_reason"] = onHold.Reason.String()
+		snapshot["remind_at"] = onHold.RemindAt.String()
+	}
+
+	if resolution := inc.Resolution(); resolution != nil {
+		snapshot["resolution_code"] = resolution.Code.String()
+		snapshot["resolution_notes"] = resolution.Notes
+		snapshot["resolved_at"] = resolution.ResolvedAt.String()
+	}
+
+	if openTimelog := inc.OpenTimelog(); openTimelog != nil {
+		snapshot["open_timelog"] = openTimelog.UUID().String()
+		snapshot["open_timelog.remote"] = strconv.FormatBool(openTimelog.Remote)
+		if openTimespan := openTimelog.OpenTimespan(); openTimespan != nil {
+			snapshot["open_timelog.timespan_type"] = openTimespan.Type.String()
+		}
+	}
+
+	var timelogIDs []string
+	for _, timelogID := range inc.Timelogs {
+		timelogIDs = append(timelogIDs, timelogID.String())
+	}
+	snapshot["timelogs"] = strings.Join(timelogIDs, ",")
+
+	return snapshot
+}
+
+// TimelogSnapshot returns values of the recorded fields of the incident's timelog.
+// Field names are prefixed with the timelog ID (ie. 'timelogs.<uuid>.start').
+func TimelogSnapshot(tmlg timelog.Timelog) Snapshot {
+	prefix := fmt.Sprintf("timelogs.%s.", tmlg.UUID())
+
+	snapshot := Snapshot{
+		prefix + "remote":        strconv.FormatBool(tmlg.Remote),
+		prefix + "start":         tmlg.Start.String(),
+		prefix + "end":           tmlg.End.String(),
+		prefix + "work":          strconv.FormatUint(uint64(tmlg.Work), 10),
+		prefix + "visit_summary": tmlg.VisitSummary,
+	}
+
+	if len(tmlg.Corrections) > 0 {
+		snapshot[prefix+"correction"] = tmlg.Corrections[len(tmlg.Corrections)-1].Status.String()
+	}
+
+	return snapshot
+}
+
+// Changes returns the fields that have different values in the snapshots, sorted by the field name
+func Changes(before, after Snapshot) []Change {
+	fields := map[string]struct{}{}
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	var changes []Change
+	for field := range fields {
+		if before[field] != after[field] {
+			changes = append(changes, Change{
+				Field:  field,
+				Before: before[field],
+				After:  after[field],
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
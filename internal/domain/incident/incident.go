@@ -5,8 +5,10 @@ import (
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/embedded"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
@@ -27,14 +29,39 @@ type Incident struct {
 
 	FieldEngineerID *ref.UUID
 
+	// fieldEngineerAccepted is true if the assigned field engineer accepted the incident
+	fieldEngineerAccepted bool
+
 	state State
 
+	onHold *OnHoldInfo
+
+	resolution *Resolution
+
+	impact Impact
+
+	urgency Urgency
+
+	priority sla.Priority
+
+	priorityOverridden bool
+
+	auditTrail []AuditRecord
+
 	openTimelog *timelog.Timelog
 
+	sla *sla.SLA
+
 	// TODO make it private - timelogIDs
 	Timelogs []ref.UUID
 
 	CreatedUpdated types.CreatedUpdated
+
+	// version is incremented by the repository on each update, it is used for optimistic locking
+	version uint
+
+	// events recorded since the incident was loaded, they are stored in the outbox by the repository
+	events []event.Event
 }
 
 // New creates initialized Incident
@@ -73,13 +100,135 @@ func (e Incident) State() State {
 	return e.state
 }
 
-// SetState ...
-func (e *Incident) SetState(s State) error {
-	// TODO add state machine and checks
+// SetState moves the incident to the given state. It returns error if the transition is not allowed by the state machine
+// or if the actor is not allowed to perform it.
+func (e *Incident) SetState(actor actor.Actor, s State) error {
+	if err := e.canChangeState(actor, s); err != nil {
+		return err
+	}
+
+	if from := e.state; !from.IsZero() && from != s {
+		e.recordEvent(event.IncidentStateChanged{
+			IncidentID: e.uuid,
+			ActorID:    actor.BasicUser.UUID(),
+			From:       from.String(),
+			To:         s.String(),
+		})
+	}
+	e.state = s
+
+	if s != StatePreOnHold && s != StateOnHold {
+		e.onHold = nil
+	}
+
+	return nil
+}
+
+// AssignFieldEngineer assigns the field engineer to the incident, the event is recorded only if the assigned field engineer changes.
+// Newly assigned field engineer must accept the incident before starting to work on it.
+func (e *Incident) AssignFieldEngineer(actor actor.Actor, fieldEngineerID ref.UUID) {
+	if e.FieldEngineerID != nil && *e.FieldEngineerID == fieldEngineerID {
+		return
+	}
+
+	e.FieldEngineerID = &fieldEngineerID
+	e.fieldEngineerAccepted = false
+
+	e.recordEvent(event.IncidentFieldEngineerAssigned{
+		IncidentID:      e.uuid,
+		FieldEngineerID: fieldEngineerID,
+		ActorID:         actor.BasicUser.UUID(),
+	})
+}
+
+// FieldEngineerAccepted returns true if the assigned field engineer accepted the incident
+func (e Incident) FieldEngineerAccepted() bool {
+	return e.fieldEngineerAccepted
+}
+
+// SetFieldEngineerAccepted sets whether the assigned field engineer accepted the incident (do not use in the domain, method is used by repository)
+func (e *Incident) SetFieldEngineerAccepted(accepted bool) {
+	e.fieldEngineerAccepted = accepted
+}
+
+// RestoreState sets the state without any checks (do not use in the domain, method is used by repository)
+func (e *Incident) RestoreState(s State) error {
+	if s.IsZero() {
+		return fmt.Errorf("incident: cannot restore zero state")
+	}
 	e.state = s
 	return nil
 }
 
+// Version returns the version of the incident as it was loaded from the repository (zero if the incident was not stored yet)
+func (e Incident) Version() uint {
+	return e.version
+}
+
+// SetVersion sets the version (do not use in the domain, method is used by repository)
+func (e *Incident) SetVersion(v uint) {
+	e.version = v
+}
+
+// Events returns domain events recorded since the incident was loaded from the repository
+func (e Incident) Events() []event.Event {
+	return e.events
+}
+
+// EventsWithIncidentID returns domain events recorded before the incident was stored for the first time
+// with the ID of the newly added incident filled in (do not use in the domain, method is used by repository)
+func (e Incident) EventsWithIncidentID(incID ref.UUID) []event.Event {
+	events := make([]event.Event, 0, len(e.events))
+	for _, ev := range e.events {
+		if assigned, ok := ev.(event.IncidentFieldEngineerAssigned); ok && assigned.IncidentID.IsZero() {
+			assigned.IncidentID = incID
+			ev = assigned
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// ClearEvents removes the recorded events (ie. after they were stored in the outbox)
+func (e *Incident) ClearEvents() {
+	e.events = nil
+}
+
+// recordEvent appends the event to the recorded events
+func (e *Incident) recordEvent(ev event.Event) {
+	e.events = append(e.events, ev)
+}
+
+// OnHold returns info about why the ticket is on hold or nil pointer if it is not on hold
+func (e Incident) OnHold() *OnHoldInfo {
+	return e.onHold
+}
+
+// SetOnHold sets on hold info (do not use in the domain, method is used by repository)
+func (e *Incident) SetOnHold(onHold *OnHoldInfo) {
+	e.onHold = onHold
+}
+
+// Resolution returns info about the ticket resolution or nil pointer if the ticket is not resolved
+func (e Incident) Resolution() *Resolution {
+	return e.resolution
+}
+
+// SetResolution sets resolution info (do not use in the domain, method is used by repository)
+func (e *Incident) SetResolution(resolution *Resolution) {
+	e.resolution = resolution
+}
+
+// AuditTrail returns records of the actions that changed the resolution status of the ticket
+func (e Incident) AuditTrail() []AuditRecord {
+	return e.auditTrail
+}
+
+// SetAuditTrail sets audit trail records (do not use in the domain, method is used by repository)
+func (e *Incident) SetAuditTrail(records []AuditRecord) {
+	e.auditTrail = records
+}
+
 // OpenTimelog returns open timelog if any or nil pointer
 func (e Incident) OpenTimelog() *timelog.Timelog {
 	return e.openTimelog
@@ -1,8 +1,13 @@
 package incident
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
 )
 
@@ -15,18 +20,42 @@ func (a AllowedAction) String() string {
 
 // AllowedActions values
 const (
-	ActionCancel       AllowedAction = "Cancel"
-	ActionStartWorking AllowedAction = "StartWorking"
-	ActionStopWorking  AllowedAction = "StopWorking"
+	ActionCancel        AllowedAction = "Cancel"
+	ActionAccept        AllowedAction = "Accept"
+	ActionReject        AllowedAction = "Reject"
+	ActionStartWorking  AllowedAction = "StartWorking"
+	ActionStopWorking   AllowedAction = "StopWorking"
+	ActionPauseWorking  AllowedAction = "PauseWorking"
+	ActionResumeWorking AllowedAction = "ResumeWorking"
+	ActionPutOnHold     AllowedAction = "PutOnHold"
+	ActionResume        AllowedAction = "Resume"
+	ActionResolve       AllowedAction = "Resolve"
+	ActionClose         AllowedAction = "Close"
+	ActionReopen        AllowedAction = "Reopen"
+)
+
+// Actions that are not offered by AllowedActions, they are only recorded in the audit trail
+const (
+	ActionOverridePriority AllowedAction = "OverridePriority"
+	ActionResetPriority    AllowedAction = "ResetPriority"
 )
 
-// AllowedActions returns list of actions that can be performed with the incident according to its state and other conditions
+// AllowedActions returns list of actions that can be performed with the incident according to its state and other conditions.
+// Actions that change the state are allowed only if the transition is allowed by the state machine.
 func (e Incident) AllowedActions(actor actor.Actor) []string {
 	var acts []string
 	if err := e.canBeCancelled(actor); err == nil {
 		acts = append(acts, ActionCancel.String())
 	}
 
+	if err := e.canAccept(actor); err == nil {
+		acts = append(acts, ActionAccept.String())
+	}
+
+	if err := e.canReject(actor); err == nil {
+		acts = append(acts, ActionReject.String())
+	}
+
 	if err := e.canStartWorking(actor); err == nil {
 		acts = append(acts, ActionStartWorking.String())
 	}
@@ -35,6 +64,34 @@ func (e Incident) AllowedActions(actor actor.Actor) []string {
 		acts = append(acts, ActionStopWorking.String())
 	}
 
+	if err := e.canPauseWorking(actor); err == nil {
+		acts = append(acts, ActionPauseWorking.String())
+	}
+
+	if err := e.canResumeWorking(actor); err == nil {
+		acts = append(acts, ActionResumeWorking.String())
+	}
+
+	if err := e.canPutOnHold(actor); err == nil {
+		acts = append(acts, ActionPutOnHold.String())
+	}
+
+	if err := e.canResume(actor); err == nil {
+		acts = append(acts, ActionResume.String())
+	}
+
+	if err := e.canResolve(actor); err == nil {
+		acts = append(acts, ActionResolve.String())
+	}
+
+	if err := e.canClose(actor); err == nil {
+		acts = append(acts, ActionClose.String())
+	}
+
+	if err := e.canReopen(actor); err == nil {
+		acts = append(acts, ActionReopen.String())
+	}
+
 	return acts
 }
 
@@ -44,16 +101,108 @@ func (e *Incident) Cancel(actor actor.Actor) error {
 		return err
 	}
 
-	if err := e.SetState(StateCancelled); err != nil {
+	if err := e.SetState(actor, StateCancelled); err != nil {
 		return err
 	}
 
+	e.recordEvent(event.IncidentCancelled{
+		IncidentID: e.uuid,
+		ActorID:    actor.BasicUser.UUID(),
+	})
+
 	return nil
 }
 
-func (e *Incident) canBeCancelled(_ actor.Actor) error {
+func (e *Incident) canBeCancelled(actor actor.Actor) error {
 	if e.state != StateNew {
-		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "ticket can be cancelled only in New state")
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket can be cancelled only in New state")
+	}
+
+	return e.canChangeState(actor, StateCancelled)
+}
+
+// Accept can be used by assigned field engineer to accept the ticket, the work on the ticket cannot be started until it is accepted
+func (e *Incident) Accept(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canAccept(actor); err != nil {
+		return err
+	}
+
+	e.fieldEngineerAccepted = true
+
+	e.addAuditRecord(ActionAccept, actor, clock, "")
+
+	e.recordEvent(event.IncidentFieldEngineerAccepted{
+		IncidentID:      e.uuid,
+		FieldEngineerID: *e.FieldEngineerID,
+		ActorID:         actor.BasicUser.UUID(),
+	})
+
+	return nil
+}
+
+func (e *Incident) canAccept(actor actor.Actor) error {
+	if err := e.canAcceptOrReject(actor); err != nil {
+		return err
+	}
+
+	if e.fieldEngineerAccepted {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket was already accepted")
+	}
+
+	return nil
+}
+
+// Reject can be used by assigned field engineer to reject the ticket he has not accepted yet.
+// The field engineer is unassigned from the ticket, so that it can be assigned to other one.
+func (e *Incident) Reject(actor actor.Actor, clock domain.Clock, reason string) error {
+	if err := e.canReject(actor); err != nil {
+		return err
+	}
+
+	if reason == "" {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "rejection reason is required")
+	}
+
+	fieldEngineerID := *e.FieldEngineerID
+	e.FieldEngineerID = nil
+	e.fieldEngineerAccepted = false
+
+	e.addAuditRecord(ActionReject, actor, clock, reason)
+
+	e.recordEvent(event.IncidentFieldEngineerRejected{
+		IncidentID:      e.uuid,
+		FieldEngineerID: fieldEngineerID,
+		ActorID:         actor.BasicUser.UUID(),
+		Reason:          reason,
+	})
+
+	return nil
+}
+
+func (e *Incident) canReject(actor actor.Actor) error {
+	if err := e.canAcceptOrReject(actor); err != nil {
+		return err
+	}
+
+	if e.fieldEngineerAccepted {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket was already accepted, it cannot be rejected")
+	}
+
+	return nil
+}
+
+// canAcceptOrReject returns error if the actor is not assigned field engineer or the ticket is not open
+func (e *Incident) canAcceptOrReject(actor actor.Actor) error {
+	if !actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not field engineer, only assigned field engineer can accept or reject the ticket")
+	}
+
+	if e.FieldEngineerID == nil || *actor.FieldEngineerID() != *e.FieldEngineerID {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not assigned as field engineer, only assigned field engineer can accept or reject the ticket")
+	}
+
+	if e.state != StateNew && e.state != StateInProgress && e.state != StateOnHold {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket is not in New, InProgress nor OnHold state")
 	}
 
 	return nil
@@ -76,13 +225,23 @@ func (e *Incident) StartWorking(actor actor.Actor, clock domain.Clock, remote bo
 	if err := newTimelog.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
 		return err
 	}
+	if err := newTimelog.StartTimespan(clock, timelog.TimespanTypeWork); err != nil {
+		return err
+	}
 
 	e.openTimelog = newTimelog
 
-	if err := e.SetState(StateInProgress); err != nil {
+	if err := e.SetState(actor, StateInProgress); err != nil {
 		return err
 	}
 
+	e.recordEvent(event.IncidentWorkStarted{
+		IncidentID:      e.uuid,
+		FieldEngineerID: *e.FieldEngineerID,
+		ActorID:         actor.BasicUser.UUID(),
+		Remote:          remote,
+	})
+
 	return nil
 }
 
@@ -99,7 +258,9 @@ func (e *Incident) canStartWorking(actor actor.Actor) error {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not assigned as field engineer, only assigned field engineer can start working")
 	}
 
-	// TODO disallow if FE did not accepted the incident
+	if !e.fieldEngineerAccepted {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket was not accepted by the assigned field engineer")
+	}
 
 	if e.HasOpenTimelog() {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket already has an open timelog")
@@ -109,7 +270,7 @@ func (e *Incident) canStartWorking(actor actor.Actor) error {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket is not in New, InProgress nor OnHold state")
 	}
 
-	return nil
+	return e.canChangeState(actor, StateInProgress)
 }
 
 // StopWorking can be used by assigned field engineer to stop working on the ticket
@@ -118,27 +279,27 @@ func (e *Incident) StopWorking(actor actor.Actor, clock domain.Clock, visitSumma
 		return err
 	}
 
-	start, err := e.openTimelog.Start.ToTime()
-	if err != nil {
+	if err := e.closeOpenTimelog(clock, visitSummary); err != nil {
 		return err
 	}
 
-	end := clock.Now()
-
-	if end.Before(start) {
-		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "end time cannot be before start time")
-	}
-
-	e.openTimelog.End = clock.NowFormatted()
-
-	// TODO change when timespans are implemented
-	e.openTimelog.Work = uint(end.Sub(start).Seconds())
-
-	e.openTimelog.VisitSummary = visitSummary
+	e.recordEvent(event.IncidentWorkStopped{
+		IncidentID:      e.uuid,
+		FieldEngineerID: *e.FieldEngineerID,
+		ActorID:         actor.BasicUser.UUID(),
+		TimelogID:       e.openTimelog.UUID(),
+		Work:            e.openTimelog.Work,
+		VisitSummary:    e.openTimelog.VisitSummary,
+	})
 
 	return nil
 }
 
+// closeOpenTimelog sets end time and calculates work in the open timelog
+func (e *Incident) closeOpenTimelog(clock domain.Clock, visitSummary string) error {
+	return e.openTimelog.Close(clock, visitSummary)
+}
+
 func (e *Incident) canStopWorking(actor actor.Actor) error {
 	if !actor.IsFieldEngineer() {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not field engineer, only assigned field engineer can stop working")
@@ -158,3 +319,304 @@ func (e *Incident) canStopWorking(actor actor.Actor) error {
 
 	return nil
 }
+
+// PauseWorking can be used by assigned field engineer to pause the work on the ticket (ie. lunch break or travelling
+// to another site) without closing the open timelog. The given timespan type must be Break or Travel.
+func (e *Incident) PauseWorking(actor actor.Actor, clock domain.Clock, timespanType timelog.TimespanType) error {
+	if err := e.canPauseWorking(actor); err != nil {
+		return err
+	}
+
+	if timespanType != timelog.TimespanTypeBreak && timespanType != timelog.TimespanTypeTravel {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "work can be paused only by break or travel timespan")
+	}
+
+	if err := e.openTimelog.StartTimespan(clock, timespanType); err != nil {
+		return err
+	}
+
+	return e.openTimelog.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+func (e *Incident) canPauseWorking(actor actor.Actor) error {
+	if err := e.canChangeOpenTimelog(actor); err != nil {
+		return err
+	}
+
+	if span := e.openTimelog.OpenTimespan(); span != nil && span.Type != timelog.TimespanTypeWork {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "work on the ticket is already paused")
+	}
+
+	return nil
+}
+
+// ResumeWorking can be used by assigned field engineer to resume the paused work on the ticket
+func (e *Incident) ResumeWorking(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canResumeWorking(actor); err != nil {
+		return err
+	}
+
+	if err := e.openTimelog.StartTimespan(clock, timelog.TimespanTypeWork); err != nil {
+		return err
+	}
+
+	return e.openTimelog.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+func (e *Incident) canResumeWorking(actor actor.Actor) error {
+	if err := e.canChangeOpenTimelog(actor); err != nil {
+		return err
+	}
+
+	if span := e.openTimelog.OpenTimespan(); span == nil || span.Type == timelog.TimespanTypeWork {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "work on the ticket is not paused")
+	}
+
+	return nil
+}
+
+// canChangeOpenTimelog returns error if the actor is not assigned field engineer or the ticket does not have an open timelog
+func (e *Incident) canChangeOpenTimelog(actor actor.Actor) error {
+	if !actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not field engineer, only assigned field engineer can change the timelog")
+	}
+
+	if e.FieldEngineerID == nil || *actor.FieldEngineerID() != *e.FieldEngineerID {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not assigned as field engineer, only assigned field engineer can change the timelog")
+	}
+
+	if !e.HasOpenTimelog() || !e.openTimelog.End.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket does not have an open timelog")
+	}
+
+	return nil
+}
+
+// PutOnHold puts the ticket on hold. If it is called by assigned field engineer, the ticket is moved to PreOnHold state
+// and it must be confirmed by other user (ie. by calling PutOnHold again) to be moved to OnHold state.
+// Open timelog (if any) is closed.
+func (e *Incident) PutOnHold(actor actor.Actor, clock domain.Clock, reason OnHoldReason, remindAt types.DateTime) error {
+	if err := e.canPutOnHold(actor); err != nil {
+		return err
+	}
+
+	if reason.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "on hold reason is required")
+	}
+
+	if !remindAt.IsZero() {
+		remindAtTime, err := remindAt.ToTime()
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid remind at time")
+		}
+
+		if !remindAtTime.After(clock.Now()) {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "remind at time must be in the future")
+		}
+	}
+
+	if e.HasOpenTimelog() && e.openTimelog.End.IsZero() {
+		if err := e.closeOpenTimelog(clock, fmt.Sprintf("ticket put on hold (%s)", reason)); err != nil {
+			return err
+		}
+	}
+
+	if err := e.SetState(actor, e.onHoldTargetState(actor)); err != nil {
+		return err
+	}
+
+	e.onHold = &OnHoldInfo{
+		Reason:   reason,
+		RemindAt: remindAt,
+	}
+
+	return nil
+}
+
+func (e *Incident) canPutOnHold(actor actor.Actor) error {
+	if e.state == StateOnHold || (e.state == StatePreOnHold && actor.IsFieldEngineer()) {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket is already on hold")
+	}
+
+	return e.canChangeState(actor, e.onHoldTargetState(actor))
+}
+
+// onHoldTargetState returns the state the ticket is moved to when the actor puts it on hold
+func (e Incident) onHoldTargetState(actor actor.Actor) State {
+	if actor.IsFieldEngineer() {
+		return StatePreOnHold
+	}
+	return StateOnHold
+}
+
+// Resume moves the ticket that is on hold (or waiting to be put on hold) back to InProgress state
+func (e *Incident) Resume(actor actor.Actor) error {
+	if err := e.canResume(actor); err != nil {
+		return err
+	}
+
+	if err := e.SetState(actor, StateInProgress); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *Incident) canResume(actor actor.Actor) error {
+	if e.state != StateOnHold && e.state != StatePreOnHold {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket is not in OnHold nor PreOnHold state")
+	}
+
+	return e.canChangeState(actor, StateInProgress)
+}
+
+// Resolve resolves the ticket. The ticket must have at least one closed timelog and no open timelog.
+func (e *Incident) Resolve(actor actor.Actor, clock domain.Clock, code ResolutionCode, notes string) error {
+	if err := e.canResolve(actor); err != nil {
+		return err
+	}
+
+	if code.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "resolution code is required")
+	}
+
+	if notes == "" {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "resolution notes are required")
+	}
+
+	if err := e.SetState(actor, StateResolved); err != nil {
+		return err
+	}
+
+	e.resolution = &Resolution{
+		Code:       code,
+		Notes:      notes,
+		ResolvedAt: clock.NowFormatted(),
+	}
+
+	e.addAuditRecord(ActionResolve, actor, clock, "")
+
+	return nil
+}
+
+func (e *Incident) canResolve(actor actor.Actor) error {
+	if e.state != StateInProgress {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket can be resolved only in InProgress state")
+	}
+
+	if e.HasOpenTimelog() && e.openTimelog.End.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket has an open timelog")
+	}
+
+	if len(e.Timelogs) == 0 {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket does not have any closed timelog")
+	}
+
+	return e.canChangeState(actor, StateResolved)
+}
+
+// Close closes the resolved ticket
+func (e *Incident) Close(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canClose(actor); err != nil {
+		return err
+	}
+
+	if err := e.SetState(actor, StateClosed); err != nil {
+		return err
+	}
+
+	e.addAuditRecord(ActionClose, actor, clock, "")
+
+	return nil
+}
+
+func (e *Incident) canClose(actor actor.Actor) error {
+	if e.state != StateResolved {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket can be closed only in Resolved state")
+	}
+
+	return e.canChangeState(actor, StateClosed)
+}
+
+// AutoClose closes the ticket if it has been in Resolved state for at least the given period.
+// It returns true if the ticket was closed. Zero period disables automatic closing.
+func (e *Incident) AutoClose(clock domain.Clock, period time.Duration) (bool, error) {
+	if period == 0 || e.state != StateResolved || e.resolution == nil {
+		return false, nil
+	}
+
+	expired, err := e.resolutionPeriodExpired(clock, period)
+	if err != nil || !expired {
+		return false, err
+	}
+
+	// closed by the system, not by any user
+	if err := e.SetState(actor.Actor{}, StateClosed); err != nil {
+		return false, err
+	}
+
+	e.addAuditRecord(ActionClose, actor.Actor{}, clock, fmt.Sprintf("closed automatically after %s in resolved state", period))
+
+	return true, nil
+}
+
+// Reopen moves the resolved ticket back to InProgress state. The ticket can be reopened only within the reopen window
+// (ie. before it is closed automatically). Zero reopenWindow means that the window is not limited.
+func (e *Incident) Reopen(actor actor.Actor, clock domain.Clock, reason string, reopenWindow time.Duration) error {
+	if err := e.canReopen(actor); err != nil {
+		return err
+	}
+
+	if reason == "" {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "reopen reason is required")
+	}
+
+	if reopenWindow != 0 && e.resolution != nil {
+		expired, err := e.resolutionPeriodExpired(clock, reopenWindow)
+		if err != nil {
+			return err
+		}
+
+		if expired {
+			return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket cannot be reopened, reopen window has expired")
+		}
+	}
+
+	if err := e.SetState(actor, StateInProgress); err != nil {
+		return err
+	}
+
+	e.resolution = nil
+
+	e.addAuditRecord(ActionReopen, actor, clock, reason)
+
+	return nil
+}
+
+func (e *Incident) canReopen(actor actor.Actor) error {
+	if e.state != StateResolved {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket can be reopened only in Resolved state")
+	}
+
+	return e.canChangeState(actor, StateInProgress)
+}
+
+// resolutionPeriodExpired returns true if the ticket has been resolved for at least the given period
+func (e Incident) resolutionPeriodExpired(clock domain.Clock, period time.Duration) (bool, error) {
+	resolvedAt, err := e.resolution.ResolvedAt.ToTime()
+	if err != nil {
+		return false, err
+	}
+
+	return !clock.Now().Before(resolvedAt.Add(period)), nil
+}
+
+// addAuditRecord appends new record to the audit trail
+func (e *Incident) addAuditRecord(action AllowedAction, actor actor.Actor, clock domain.Clock, note string) {
+	e.auditTrail = append(e.auditTrail, AuditRecord{
+		Action:  action,
+		ActorID: actor.BasicUser.UUID(),
+		Time:    clock.NowFormatted(),
+		Note:    note,
+	})
+}
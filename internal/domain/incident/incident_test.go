@@ -1,13 +1,18 @@
 package incident_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
 	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
@@ -103,6 +108,27 @@ var _ = Describe("Incident behavior", func() {
 				})
 
 				Context("and the actor is assigned as field engineer", func() {
+					Context("but the incident was not accepted by the field engineer", func() {
+						var inc Incident
+
+						BeforeEach(func() {
+							feUUID := fieldEngineer.UUID()
+							inc = Incident{
+								FieldEngineerID: &feUUID,
+							}
+							err := inc.SetState(actorUser, StateNew)
+							Expect(err).To(BeNil())
+						})
+
+						It("should return error", func() {
+							Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionStartWorking.String()))
+
+							err := inc.StartWorking(actorUser, clock, false)
+							Expect(err).NotTo(BeNil())
+							Expect(err.Error()).To(Equal("ticket was not accepted by the assigned field engineer"))
+						})
+					})
+
 					Context("but the incident has an open timelog", func() {
 						var inc Incident
 
@@ -111,6 +137,7 @@ var _ = Describe("Incident behavior", func() {
 							inc = Incident{
 								FieldEngineerID: &feUUID,
 							}
+							inc.SetFieldEngineerAccepted(true)
 							inc.SetOpenTimelog(&timelog.Timelog{})
 						})
 
@@ -129,7 +156,8 @@ var _ = Describe("Incident behavior", func() {
 							inc = Incident{
 								FieldEngineerID: &feUUID,
 							}
-							err := inc.SetState(StateNew)
+							inc.SetFieldEngineerAccepted(true)
+							err := inc.SetState(actorUser, StateNew)
 							Expect(err).To(BeNil())
 						})
 
@@ -150,6 +178,23 @@ var _ = Describe("Incident behavior", func() {
 						It("should set state to InProgress", func() {
 							Expect(inc.State()).To(Equal(StateInProgress))
 						})
+
+						It("should record IncidentStateChanged and IncidentWorkStarted events", func() {
+							Expect(inc.Events()).To(Equal([]event.Event{
+								event.IncidentStateChanged{
+									IncidentID: inc.UUID(),
+									ActorID:    basicUser.UUID(),
+									From:       StateNew.String(),
+									To:         StateInProgress.String(),
+								},
+								event.IncidentWorkStarted{
+									IncidentID:      inc.UUID(),
+									FieldEngineerID: fieldEngineer.UUID(),
+									ActorID:         basicUser.UUID(),
+									Remote:          true,
+								},
+							}))
+						})
 					})
 
 					Context("but the incident is not in New, InProgress or OnHold state", func() {
@@ -160,7 +205,8 @@ var _ = Describe("Incident behavior", func() {
 							inc = Incident{
 								FieldEngineerID: &feUUID,
 							}
-							err := inc.SetState(StatePreOnHold)
+							inc.SetFieldEngineerAccepted(true)
+							err := inc.RestoreState(StatePreOnHold)
 							Expect(err).To(BeNil())
 						})
 
@@ -175,6 +221,114 @@ var _ = Describe("Incident behavior", func() {
 		})
 	})
 
+	Describe("Accept() and Reject()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+			}
+			err := inc.RestoreState(StateNew)
+			Expect(err).To(BeNil())
+		})
+
+		When("called by actor that is not field engineer", func() {
+			It("should return error", func() {
+				err := inc.Accept(actorUser, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("user is not field engineer, only assigned field engineer can accept or reject the ticket"))
+
+				err = inc.Reject(actorUser, clock, "too far away")
+				Expect(err).NotTo(BeNil())
+			})
+		})
+
+		When("called by field engineer that is not assigned to the incident", func() {
+			It("should return error", func() {
+				otherFeUUID := ref.UUID("63fcafcb-e0ac-490b-b67c-b6f60afeccfd")
+				actorUser.SetFieldEngineerID(&otherFeUUID)
+
+				err := inc.Accept(actorUser, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("user is not assigned as field engineer, only assigned field engineer can accept or reject the ticket"))
+			})
+		})
+
+		When("called by assigned field engineer", func() {
+			BeforeEach(func() {
+				feUUID := fieldEngineer.UUID()
+				actorUser.SetFieldEngineerID(&feUUID)
+			})
+
+			It("should allow to accept or reject the incident", func() {
+				Expect(inc.AllowedActions(actorUser)).To(ContainElements(ActionAccept.String(), ActionReject.String()))
+			})
+
+			It("should accept the incident and allow to start working", func() {
+				err := inc.Accept(actorUser, clock)
+				Expect(err).To(BeNil())
+				Expect(inc.FieldEngineerAccepted()).To(BeTrue())
+				Expect(inc.AuditTrail()).To(HaveLen(1))
+				Expect(inc.AuditTrail()[0].Action).To(Equal(ActionAccept))
+				Expect(inc.Events()).To(Equal([]event.Event{
+					event.IncidentFieldEngineerAccepted{
+						IncidentID:      inc.UUID(),
+						FieldEngineerID: fieldEngineer.UUID(),
+						ActorID:         basicUser.UUID(),
+					},
+				}))
+
+				Expect(inc.AllowedActions(actorUser)).To(ContainElement(ActionStartWorking.String()))
+				Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionAccept.String()))
+				Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionReject.String()))
+
+				err = inc.Accept(actorUser, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket was already accepted"))
+
+				err = inc.Reject(actorUser, clock, "too far away")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket was already accepted, it cannot be rejected"))
+			})
+
+			It("should require rejection reason", func() {
+				err := inc.Reject(actorUser, clock, "")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("rejection reason is required"))
+				Expect(inc.FieldEngineerID).NotTo(BeNil())
+			})
+
+			It("should reject the incident and unassign the field engineer", func() {
+				err := inc.Reject(actorUser, clock, "too far away")
+				Expect(err).To(BeNil())
+				Expect(inc.FieldEngineerID).To(BeNil())
+				Expect(inc.FieldEngineerAccepted()).To(BeFalse())
+				Expect(inc.AuditTrail()).To(HaveLen(1))
+				Expect(inc.AuditTrail()[0].Action).To(Equal(ActionReject))
+				Expect(inc.AuditTrail()[0].Note).To(Equal("too far away"))
+				Expect(inc.Events()).To(Equal([]event.Event{
+					event.IncidentFieldEngineerRejected{
+						IncidentID:      inc.UUID(),
+						FieldEngineerID: fieldEngineer.UUID(),
+						ActorID:         basicUser.UUID(),
+						Reason:          "too far away",
+					},
+				}))
+
+				Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionStartWorking.String()))
+			})
+
+			It("should require new acceptance when other field engineer is assigned", func() {
+				err := inc.Accept(actorUser, clock)
+				Expect(err).To(BeNil())
+
+				inc.AssignFieldEngineer(actorUser, ref.UUID("63fcafcb-e0ac-490b-b67c-b6f60afeccfd"))
+				Expect(inc.FieldEngineerAccepted()).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("StopWorking()", func() {
 		When("called by actor that is not field engineer", func() {
 			var inc Incident
@@ -237,7 +391,7 @@ var _ = Describe("Incident behavior", func() {
 							inc = Incident{
 								FieldEngineerID: &feUUID,
 							}
-							err := inc.SetState(StateInProgress)
+							err := inc.RestoreState(StateInProgress)
 							Expect(err).To(BeNil())
 						})
 
@@ -256,7 +410,7 @@ var _ = Describe("Incident behavior", func() {
 							inc = Incident{
 								FieldEngineerID: &feUUID,
 							}
-							err := inc.SetState(StateInProgress)
+							err := inc.RestoreState(StateInProgress)
 							Expect(err).To(BeNil())
 
 							inc.SetOpenTimelog(&timelog.Timelog{
@@ -283,19 +437,138 @@ var _ = Describe("Incident behavior", func() {
 						It("should not change state", func() {
 							Expect(inc.State()).To(Equal(StateInProgress))
 						})
+
+						It("should record IncidentWorkStopped event", func() {
+							Expect(inc.Events()).To(ConsistOf(event.IncidentWorkStopped{
+								IncidentID:      inc.UUID(),
+								FieldEngineerID: fieldEngineer.UUID(),
+								ActorID:         basicUser.UUID(),
+								Work:            3600,
+								VisitSummary:    "summary",
+							}))
+						})
 					})
 				})
 			})
 		})
 	})
 
+	Describe("PauseWorking() and ResumeWorking()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+			}
+		})
+
+		When("called by actor that is not field engineer", func() {
+			It("should return error", func() {
+				err := inc.PauseWorking(actorUser, clock, timelog.TimespanTypeBreak)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("user is not field engineer, only assigned field engineer can change the timelog"))
+			})
+		})
+
+		When("called by assigned field engineer", func() {
+			BeforeEach(func() {
+				feUUID := fieldEngineer.UUID()
+				actorUser.SetFieldEngineerID(&feUUID)
+			})
+
+			Context("but the incident has no open timelog", func() {
+				It("should return error", func() {
+					err := inc.PauseWorking(actorUser, clock, timelog.TimespanTypeBreak)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("ticket does not have an open timelog"))
+				})
+			})
+
+			Context("and the incident has an open timelog", func() {
+				BeforeEach(func() {
+					err := inc.RestoreState(StateNew)
+					Expect(err).To(BeNil())
+
+					err = inc.Accept(actorUser, clock)
+					Expect(err).To(BeNil())
+
+					err = inc.StartWorking(actorUser, clock, false)
+					Expect(err).To(BeNil())
+					Expect(inc.OpenTimelog().Timespans).To(HaveLen(1))
+				})
+
+				It("should allow only pausing", func() {
+					Expect(inc.AllowedActions(actorUser)).To(ContainElement(ActionPauseWorking.String()))
+					Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionResumeWorking.String()))
+
+					err := inc.ResumeWorking(actorUser, clock)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("work on the ticket is not paused"))
+				})
+
+				It("should not allow to pause work by work timespan", func() {
+					err := inc.PauseWorking(actorUser, clock, timelog.TimespanTypeWork)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("work can be paused only by break or travel timespan"))
+				})
+
+				It("should count only work timespans as work when the timelog is closed", func() {
+					clock.AddTime(2 * time.Hour)
+					err := inc.PauseWorking(actorUser, clock, timelog.TimespanTypeBreak)
+					Expect(err).To(BeNil())
+					Expect(inc.AllowedActions(actorUser)).To(ContainElement(ActionResumeWorking.String()))
+					Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionPauseWorking.String()))
+
+					err = inc.PauseWorking(actorUser, clock, timelog.TimespanTypeTravel)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("work on the ticket is already paused"))
+
+					clock.AddTime(30 * time.Minute)
+					err = inc.ResumeWorking(actorUser, clock)
+					Expect(err).To(BeNil())
+
+					clock.AddTime(1 * time.Hour)
+					err = inc.PauseWorking(actorUser, clock, timelog.TimespanTypeTravel)
+					Expect(err).To(BeNil())
+
+					clock.AddTime(15 * time.Minute)
+					err = inc.ResumeWorking(actorUser, clock)
+					Expect(err).To(BeNil())
+
+					clock.AddTime(20 * time.Minute)
+					err = inc.StopWorking(actorUser, clock, "summary")
+					Expect(err).To(BeNil())
+
+					tmlg := inc.OpenTimelog()
+					Expect(tmlg.End).To(Equal(clock.NowFormatted()))
+					Expect(tmlg.Work).To(Equal(uint(2*3600 + 3600 + 20*60)))
+
+					Expect(tmlg.Timespans).To(HaveLen(5))
+					expectedTypes := []timelog.TimespanType{
+						timelog.TimespanTypeWork,
+						timelog.TimespanTypeBreak,
+						timelog.TimespanTypeWork,
+						timelog.TimespanTypeTravel,
+						timelog.TimespanTypeWork,
+					}
+					for i, span := range tmlg.Timespans {
+						Expect(span.Type).To(Equal(expectedTypes[i]))
+						Expect(span.IsOpen()).To(BeFalse())
+					}
+					Expect(tmlg.OpenTimespan()).To(BeNil())
+				})
+			})
+		})
+	})
+
 	Describe("Cancel()", func() {
 		When("incident is in New' state", func() {
 			var inc Incident
 
 			BeforeEach(func() {
 				inc = Incident{}
-				err := inc.SetState(StateNew)
+				err := inc.SetState(actorUser, StateNew)
 				Expect(err).To(BeNil())
 			})
 
@@ -307,6 +580,26 @@ var _ = Describe("Incident behavior", func() {
 			It("should set state to Cancelled", func() {
 				Expect(inc.State()).To(Equal(StateCancelled))
 			})
+
+			It("should record IncidentStateChanged and IncidentCancelled events", func() {
+				Expect(inc.Events()).To(Equal([]event.Event{
+					event.IncidentStateChanged{
+						IncidentID: inc.UUID(),
+						ActorID:    basicUser.UUID(),
+						From:       StateNew.String(),
+						To:         StateCancelled.String(),
+					},
+					event.IncidentCancelled{
+						IncidentID: inc.UUID(),
+						ActorID:    basicUser.UUID(),
+					},
+				}))
+			})
+
+			It("should not return any event after the events are cleared", func() {
+				inc.ClearEvents()
+				Expect(inc.Events()).To(BeEmpty())
+			})
 		})
 
 		When("incident is NOT in New state", func() {
@@ -314,7 +607,7 @@ var _ = Describe("Incident behavior", func() {
 
 			BeforeEach(func() {
 				inc = Incident{}
-				err := inc.SetState(StateInProgress)
+				err := inc.RestoreState(StateInProgress)
 				Expect(err).To(BeNil())
 			})
 
@@ -325,4 +618,625 @@ var _ = Describe("Incident behavior", func() {
 			})
 		})
 	})
+	Describe("AssignFieldEngineer()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+			}
+		})
+
+		When("other field engineer is assigned", func() {
+			otherFEUUID := ref.UUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+
+			JustBeforeEach(func() {
+				inc.AssignFieldEngineer(actorUser, otherFEUUID)
+			})
+
+			It("should set the field engineer", func() {
+				Expect(*inc.FieldEngineerID).To(Equal(otherFEUUID))
+			})
+
+			It("should record IncidentFieldEngineerAssigned event", func() {
+				Expect(inc.Events()).To(ConsistOf(event.IncidentFieldEngineerAssigned{
+					IncidentID:      inc.UUID(),
+					FieldEngineerID: otherFEUUID,
+					ActorID:         basicUser.UUID(),
+				}))
+			})
+		})
+
+		When("the same field engineer is assigned", func() {
+			It("should not record any event", func() {
+				inc.AssignFieldEngineer(actorUser, fieldEngineer.UUID())
+				Expect(inc.Events()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SetState()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+			}
+		})
+
+		When("transition is not allowed by the state machine", func() {
+			BeforeEach(func() {
+				err := inc.RestoreState(StateCancelled)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return ActionForbidden error and keep the state", func() {
+				err := inc.SetState(actorUser, StateInProgress)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket cannot be moved from 'cancelled' to 'in progress' state"))
+
+				var dErr *domain.Error
+				Expect(errors.As(err, &dErr)).To(BeTrue())
+				Expect(dErr.Code()).To(Equal(domain.ErrorCodeActionForbidden))
+
+				Expect(inc.State()).To(Equal(StateCancelled))
+			})
+		})
+
+		When("transition is allowed by the state machine", func() {
+			BeforeEach(func() {
+				err := inc.RestoreState(StateNew)
+				Expect(err).To(BeNil())
+			})
+
+			Context("but the actor is field engineer not assigned to the ticket", func() {
+				BeforeEach(func() {
+					otherFeUUID := ref.UUID("63fcafcb-e0ac-490b-b67c-b6f60afeccfd")
+					actorUser.SetFieldEngineerID(&otherFeUUID)
+				})
+
+				It("should return error", func() {
+					err := inc.SetState(actorUser, StateCancelled)
+					Expect(err).NotTo(BeNil())
+					Expect(err.Error()).To(Equal("user is not assigned as field engineer, only assigned field engineer can change ticket state"))
+					Expect(inc.State()).To(Equal(StateNew))
+					Expect(inc.Events()).To(BeEmpty())
+				})
+			})
+
+			Context("and the actor is allowed to perform it", func() {
+				It("should change the state", func() {
+					err := inc.SetState(actorUser, StateCancelled)
+					Expect(err).To(BeNil())
+					Expect(inc.State()).To(Equal(StateCancelled))
+				})
+
+				It("should record IncidentStateChanged event", func() {
+					err := inc.SetState(actorUser, StateCancelled)
+					Expect(err).To(BeNil())
+					Expect(inc.Events()).To(ConsistOf(event.IncidentStateChanged{
+						IncidentID: inc.UUID(),
+						ActorID:    basicUser.UUID(),
+						From:       StateNew.String(),
+						To:         StateCancelled.String(),
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("AllowedActions()", func() {
+		When("incident is in final state", func() {
+			var inc Incident
+
+			BeforeEach(func() {
+				feUUID := fieldEngineer.UUID()
+				actorUser.SetFieldEngineerID(&feUUID)
+				inc = Incident{
+					FieldEngineerID: &feUUID,
+				}
+				err := inc.RestoreState(StateClosed)
+				Expect(err).To(BeNil())
+			})
+
+			It("should not return any action", func() {
+				Expect(inc.AllowedActions(actorUser)).To(BeEmpty())
+			})
+		})
+	})
+	Describe("PutOnHold()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+			}
+			err := inc.RestoreState(StateInProgress)
+			Expect(err).To(BeNil())
+		})
+
+		When("reason is missing", func() {
+			It("should return error", func() {
+				err := inc.PutOnHold(actorUser, clock, OnHoldReason{}, "")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("on hold reason is required"))
+				Expect(inc.State()).To(Equal(StateInProgress))
+			})
+		})
+
+		When("remind at time is in the past", func() {
+			It("should return error", func() {
+				remindAt := types.DateTime(clock.Now().Add(-time.Hour).Format(time.RFC3339))
+				err := inc.PutOnHold(actorUser, clock, OnHoldReasonAwaitingCaller, remindAt)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("remind at time must be in the future"))
+			})
+		})
+
+		When("called by actor that is not field engineer", func() {
+			var remindAt types.DateTime
+
+			JustBeforeEach(func() {
+				remindAt = types.DateTime(clock.Now().Add(24 * time.Hour).Format(time.RFC3339))
+				err := inc.PutOnHold(actorUser, clock, OnHoldReasonAwaitingSupplier, remindAt)
+				Expect(err).To(BeNil())
+			})
+
+			It("should set state to OnHold", func() {
+				Expect(inc.State()).To(Equal(StateOnHold))
+			})
+
+			It("should set on hold info", func() {
+				Expect(inc.OnHold()).NotTo(BeNil())
+				Expect(inc.OnHold().Reason).To(Equal(OnHoldReasonAwaitingSupplier))
+				Expect(inc.OnHold().RemindAt).To(Equal(remindAt))
+			})
+
+			It("should allow to resume the ticket", func() {
+				Expect(inc.AllowedActions(actorUser)).To(ContainElement(ActionResume.String()))
+				Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionPutOnHold.String()))
+			})
+		})
+
+		When("called by assigned field engineer", func() {
+			BeforeEach(func() {
+				feUUID := fieldEngineer.UUID()
+				actorUser.SetFieldEngineerID(&feUUID)
+
+				inc.SetOpenTimelog(&timelog.Timelog{
+					Start: clock.NowFormatted(),
+				})
+			})
+
+			JustBeforeEach(func() {
+				clock.AddTime(time.Hour)
+				err := inc.PutOnHold(actorUser, clock, OnHoldReasonAwaitingCaller, "")
+				Expect(err).To(BeNil())
+			})
+
+			It("should set state to PreOnHold", func() {
+				Expect(inc.State()).To(Equal(StatePreOnHold))
+			})
+
+			It("should close the open timelog", func() {
+				Expect(inc.OpenTimelog().End).To(Equal(clock.NowFormatted()))
+				Expect(inc.OpenTimelog().Work).To(Equal(uint(3600)))
+			})
+
+			It("should not allow field engineer to put it on hold again", func() {
+				err := inc.PutOnHold(actorUser, clock, OnHoldReasonAwaitingCaller, "")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket is already on hold"))
+			})
+
+			Context("and then confirmed by other user", func() {
+				It("should set state to OnHold", func() {
+					otherUser := actor.Actor{BasicUser: basicUser}
+					err := inc.PutOnHold(otherUser, clock, OnHoldReasonAwaitingChange, "")
+					Expect(err).To(BeNil())
+					Expect(inc.State()).To(Equal(StateOnHold))
+					Expect(inc.OnHold().Reason).To(Equal(OnHoldReasonAwaitingChange))
+				})
+			})
+		})
+	})
+
+	Describe("Resume()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+			}
+		})
+
+		When("incident is on hold", func() {
+			BeforeEach(func() {
+				err := inc.RestoreState(StateOnHold)
+				Expect(err).To(BeNil())
+				inc.SetOnHold(&OnHoldInfo{Reason: OnHoldReasonAwaitingCaller})
+			})
+
+			It("should set state to InProgress and clear on hold info", func() {
+				err := inc.Resume(actorUser)
+				Expect(err).To(BeNil())
+				Expect(inc.State()).To(Equal(StateInProgress))
+				Expect(inc.OnHold()).To(BeNil())
+			})
+		})
+
+		When("incident is not on hold", func() {
+			BeforeEach(func() {
+				err := inc.RestoreState(StateInProgress)
+				Expect(err).To(BeNil())
+			})
+
+			It("should return error", func() {
+				err := inc.Resume(actorUser)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket is not in OnHold nor PreOnHold state"))
+			})
+		})
+	})
+
+	Describe("Resolve()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			feUUID := fieldEngineer.UUID()
+			inc = Incident{
+				FieldEngineerID: &feUUID,
+				Timelogs:        []ref.UUID{"0ac5ebce-17e7-4edc-9552-fefe16e127fb"},
+			}
+			err := inc.RestoreState(StateInProgress)
+			Expect(err).To(BeNil())
+		})
+
+		When("resolution code is missing", func() {
+			It("should return error", func() {
+				err := inc.Resolve(actorUser, clock, ResolutionCode{}, "some notes")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("resolution code is required"))
+				Expect(inc.State()).To(Equal(StateInProgress))
+			})
+		})
+
+		When("resolution notes are missing", func() {
+			It("should return error", func() {
+				err := inc.Resolve(actorUser, clock, ResolutionCodeSolvedPermanently, "")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("resolution notes are required"))
+			})
+		})
+
+		When("incident does not have any timelog", func() {
+			BeforeEach(func() {
+				inc.Timelogs = nil
+			})
+
+			It("should return error", func() {
+				err := inc.Resolve(actorUser, clock, ResolutionCodeSolvedPermanently, "some notes")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket does not have any closed timelog"))
+				Expect(inc.AllowedActions(actorUser)).NotTo(ContainElement(ActionResolve.String()))
+			})
+		})
+
+		When("incident has an open timelog", func() {
+			BeforeEach(func() {
+				inc.SetOpenTimelog(&timelog.Timelog{
+					Start: clock.NowFormatted(),
+				})
+			})
+
+			It("should return error", func() {
+				err := inc.Resolve(actorUser, clock, ResolutionCodeSolvedPermanently, "some notes")
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket has an open timelog"))
+			})
+		})
+
+		When("incident has closed timelog", func() {
+			It("should set state to Resolved and record the resolution", func() {
+				Expect(inc.AllowedActions(actorUser)).To(ContainElement(ActionResolve.String()))
+
+				err := inc.Resolve(actorUser, clock, ResolutionCodeSolvedWorkaround, "some notes")
+				Expect(err).To(BeNil())
+				Expect(inc.State()).To(Equal(StateResolved))
+				Expect(inc.Resolution()).To(Equal(&Resolution{
+					Code:       ResolutionCodeSolvedWorkaround,
+					Notes:      "some notes",
+					ResolvedAt: clock.NowFormatted(),
+				}))
+				Expect(inc.AuditTrail()).To(HaveLen(1))
+				Expect(inc.AuditTrail()[0].Action).To(Equal(ActionResolve))
+				Expect(inc.AllowedActions(actorUser)).To(ConsistOf(ActionClose.String(), ActionReopen.String()))
+			})
+		})
+	})
+
+	Describe("Close()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			inc = Incident{}
+		})
+
+		When("incident is resolved", func() {
+			It("should set state to Closed", func() {
+				err := inc.RestoreState(StateResolved)
+				Expect(err).To(BeNil())
+
+				err = inc.Close(actorUser, clock)
+				Expect(err).To(BeNil())
+				Expect(inc.State()).To(Equal(StateClosed))
+				Expect(inc.AuditTrail()).To(HaveLen(1))
+				Expect(inc.AuditTrail()[0].Action).To(Equal(ActionClose))
+				Expect(inc.AllowedActions(actorUser)).To(BeEmpty())
+			})
+		})
+
+		When("incident is not resolved", func() {
+			It("should return error", func() {
+				err := inc.RestoreState(StateInProgress)
+				Expect(err).To(BeNil())
+
+				err = inc.Close(actorUser, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket can be closed only in Resolved state"))
+			})
+		})
+	})
+
+	Describe("AutoClose() and Reopen()", func() {
+		var inc Incident
+		period := 72 * time.Hour
+
+		BeforeEach(func() {
+			inc = Incident{}
+			err := inc.RestoreState(StateResolved)
+			Expect(err).To(BeNil())
+			inc.SetResolution(&Resolution{
+				Code:       ResolutionCodeSolvedPermanently,
+				Notes:      "some notes",
+				ResolvedAt: clock.NowFormatted(),
+			})
+		})
+
+		When("auto close period has not expired yet", func() {
+			BeforeEach(func() {
+				clock.AddTime(period - time.Second)
+			})
+
+			It("should not close the incident", func() {
+				closed, err := inc.AutoClose(clock, period)
+				Expect(err).To(BeNil())
+				Expect(closed).To(BeFalse())
+				Expect(inc.State()).To(Equal(StateResolved))
+			})
+
+			It("should allow to reopen the incident", func() {
+				err := inc.Reopen(actorUser, clock, "problem persists", period)
+				Expect(err).To(BeNil())
+				Expect(inc.State()).To(Equal(StateInProgress))
+				Expect(inc.Resolution()).To(BeNil())
+				Expect(inc.AuditTrail()).To(HaveLen(1))
+				Expect(inc.AuditTrail()[0].Action).To(Equal(ActionReopen))
+				Expect(inc.AuditTrail()[0].Note).To(Equal("problem persists"))
+			})
+
+			It("should require reopen reason", func() {
+				err := inc.Reopen(actorUser, clock, "", period)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("reopen reason is required"))
+			})
+		})
+
+		When("auto close period has expired", func() {
+			BeforeEach(func() {
+				clock.AddTime(period)
+			})
+
+			It("should close the incident", func() {
+				closed, err := inc.AutoClose(clock, period)
+				Expect(err).To(BeNil())
+				Expect(closed).To(BeTrue())
+				Expect(inc.State()).To(Equal(StateClosed))
+				Expect(inc.AuditTrail()).To(HaveLen(1))
+				Expect(inc.AuditTrail()[0].Action).To(Equal(ActionClose))
+				Expect(inc.AuditTrail()[0].ActorID.IsZero()).To(BeTrue())
+			})
+
+			It("should not allow to reopen the incident", func() {
+				err := inc.Reopen(actorUser, clock, "problem persists", period)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("ticket cannot be reopened, reopen window has expired"))
+				Expect(inc.State()).To(Equal(StateResolved))
+			})
+		})
+
+		When("auto close period is zero", func() {
+			It("should never close the incident", func() {
+				clock.AddTime(10 * period)
+				closed, err := inc.AutoClose(clock, 0)
+				Expect(err).To(BeNil())
+				Expect(closed).To(BeFalse())
+				Expect(inc.State()).To(Equal(StateResolved))
+			})
+		})
+	})
+
+	Describe("StartSLA() and UpdateSLA()", func() {
+		var inc Incident
+
+		BeforeEach(func() {
+			inc = Incident{}
+			err := inc.RestoreState(StateNew)
+			Expect(err).To(BeNil())
+		})
+
+		It("should not do anything if the incident does not have SLA", func() {
+			err := inc.UpdateSLA(clock)
+			Expect(err).To(BeNil())
+			Expect(inc.SLA()).To(BeNil())
+		})
+
+		It("should start, pause and stop the timers according to the incident state", func() {
+			def := sla.Definition{
+				Targets: map[sla.Priority]sla.Target{
+					sla.PriorityModerate: {Response: time.Hour, Resolution: 8 * time.Hour},
+				},
+				DefaultPriority: sla.PriorityModerate,
+			}
+
+			err := inc.StartSLA(clock, def)
+			Expect(err).To(BeNil())
+			Expect(inc.SLA().Priority).To(Equal(sla.PriorityModerate))
+			Expect(inc.SLA().Response.State()).To(Equal(sla.TimerStateRunning))
+			Expect(inc.SLA().Resolution.State()).To(Equal(sla.TimerStateRunning))
+
+			updateState := func(s State, after time.Duration) {
+				clock.AddTime(after)
+				err := inc.RestoreState(s)
+				Expect(err).To(BeNil())
+				err = inc.UpdateSLA(clock)
+				Expect(err).To(BeNil())
+			}
+
+			updateState(StateOnHold, 10*time.Minute)
+			Expect(inc.SLA().Response.State()).To(Equal(sla.TimerStatePaused))
+			Expect(inc.SLA().Resolution.State()).To(Equal(sla.TimerStatePaused))
+			Expect(inc.SLA().Response.Elapsed).To(Equal(10 * time.Minute))
+
+			updateState(StateInProgress, time.Hour)
+			Expect(inc.SLA().Response.State()).To(Equal(sla.TimerStateStopped))
+			Expect(inc.SLA().Response.Elapsed).To(Equal(10 * time.Minute))
+			Expect(inc.SLA().Resolution.State()).To(Equal(sla.TimerStateRunning))
+
+			updateState(StateOnHold, 20*time.Minute)
+			Expect(inc.SLA().Response.State()).To(Equal(sla.TimerStateStopped))
+			Expect(inc.SLA().Resolution.State()).To(Equal(sla.TimerStatePaused))
+
+			updateState(StateInProgress, time.Hour)
+			updateState(StateResolved, 10*time.Minute)
+			Expect(inc.SLA().Resolution.State()).To(Equal(sla.TimerStateStopped))
+			Expect(inc.SLA().Resolution.Elapsed).To(Equal(40 * time.Minute))
+
+			// reopened
+			updateState(StateInProgress, 2*time.Hour)
+			clock.AddTime(5 * time.Minute)
+
+			status, err := inc.SLA().Status(clock.Now())
+			Expect(err).To(BeNil())
+			Expect(status.Response.Elapsed).To(Equal(10 * time.Minute))
+			Expect(status.Response.Breached).To(BeFalse())
+			Expect(status.Resolution.State).To(Equal(sla.TimerStateRunning))
+			Expect(status.Resolution.Elapsed).To(Equal(45 * time.Minute))
+			Expect(status.Resolution.Remaining).To(Equal(8*time.Hour - 45*time.Minute))
+		})
+	})
+
+	Describe("Prioritize(), OverridePriority() and ResetPriority()", func() {
+		var inc Incident
+		var matrix PriorityMatrix
+
+		BeforeEach(func() {
+			inc = Incident{}
+			matrix = DefaultPriorityMatrix()
+		})
+
+		It("should require impact and urgency", func() {
+			err := inc.Prioritize(matrix, Impact{}, UrgencyHigh)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("impact is required"))
+
+			err = inc.Prioritize(matrix, ImpactHigh, Urgency{})
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("urgency is required"))
+		})
+
+		It("should derive the priority from the matrix", func() {
+			err := inc.Prioritize(matrix, ImpactMedium, UrgencyHigh)
+			Expect(err).To(BeNil())
+			Expect(inc.Impact()).To(Equal(ImpactMedium))
+			Expect(inc.Urgency()).To(Equal(UrgencyHigh))
+			Expect(inc.Priority()).To(Equal(sla.PriorityHigh))
+			Expect(inc.PriorityOverridden()).To(BeFalse())
+		})
+
+		It("should not allow field engineer to override or reset the priority", func() {
+			feUUID := fieldEngineer.UUID()
+			actorUser.SetFieldEngineerID(&feUUID)
+
+			err := inc.OverridePriority(actorUser, clock, sla.PriorityCritical, "VIP customer")
+			Expect(err).NotTo(BeNil())
+			var dErr *domain.Error
+			Expect(errors.As(err, &dErr)).To(BeTrue())
+			Expect(dErr.Code()).To(Equal(domain.ErrorCodeActionForbidden))
+
+			err = inc.ResetPriority(actorUser, clock, matrix)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should require override reason", func() {
+			err := inc.OverridePriority(actorUser, clock, sla.PriorityCritical, "")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("priority override reason is required"))
+		})
+
+		It("should keep the overridden priority until it is reset", func() {
+			err := inc.Prioritize(matrix, ImpactLow, UrgencyLow)
+			Expect(err).To(BeNil())
+
+			err = inc.OverridePriority(actorUser, clock, sla.PriorityCritical, "VIP customer")
+			Expect(err).To(BeNil())
+			Expect(inc.Priority()).To(Equal(sla.PriorityCritical))
+			Expect(inc.PriorityOverridden()).To(BeTrue())
+			Expect(inc.AuditTrail()).To(HaveLen(1))
+			Expect(inc.AuditTrail()[0].Action).To(Equal(ActionOverridePriority))
+			Expect(inc.AuditTrail()[0].Note).To(Equal("low -> critical: VIP customer"))
+
+			// the same override is not recorded again
+			err = inc.OverridePriority(actorUser, clock, sla.PriorityCritical, "VIP customer")
+			Expect(err).To(BeNil())
+			Expect(inc.AuditTrail()).To(HaveLen(1))
+
+			err = inc.Prioritize(matrix, ImpactMedium, UrgencyLow)
+			Expect(err).To(BeNil())
+			Expect(inc.Impact()).To(Equal(ImpactMedium))
+			Expect(inc.Priority()).To(Equal(sla.PriorityCritical))
+
+			err = inc.ResetPriority(actorUser, clock, matrix)
+			Expect(err).To(BeNil())
+			Expect(inc.Priority()).To(Equal(sla.PriorityLow))
+			Expect(inc.PriorityOverridden()).To(BeFalse())
+			Expect(inc.AuditTrail()).To(HaveLen(2))
+			Expect(inc.AuditTrail()[1].Action).To(Equal(ActionResetPriority))
+			Expect(inc.AuditTrail()[1].Note).To(Equal("critical -> low"))
+
+			// reset of the priority that is not overridden is not recorded
+			err = inc.ResetPriority(actorUser, clock, matrix)
+			Expect(err).To(BeNil())
+			Expect(inc.AuditTrail()).To(HaveLen(2))
+		})
+	})
+
+	Describe("PriorityMatrix", func() {
+		It("should have valid default matrix", func() {
+			Expect(DefaultPriorityMatrix().Validate()).To(BeNil())
+		})
+
+		It("should require priority for every combination of impact and urgency", func() {
+			matrix := DefaultPriorityMatrix()
+			delete(matrix.Priorities, ImpactUrgency{Impact: ImpactLow, Urgency: UrgencyMedium})
+
+			err := matrix.Validate()
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("priority matrix does not have priority for 'low' impact and 'medium' urgency"))
+		})
+	})
 })
@@ -0,0 +1,62 @@
+package incident
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// OnHoldReason values
+var (
+	OnHoldReasonAwaitingCaller   = OnHoldReason{"awaiting caller"}
+	OnHoldReasonAwaitingChange   = OnHoldReason{"awaiting change"}
+	OnHoldReasonAwaitingProblem  = OnHoldReason{"awaiting problem"}
+	OnHoldReasonAwaitingSupplier = OnHoldReason{"awaiting supplier"}
+)
+
+var onHoldReasonValues = []OnHoldReason{
+	OnHoldReasonAwaitingCaller,
+	OnHoldReasonAwaitingChange,
+	OnHoldReasonAwaitingProblem,
+	OnHoldReasonAwaitingSupplier,
+}
+
+// OnHoldReason is a reason why the ticket was put on hold. It is enum.
+// swagger:strfmt string
+type OnHoldReason struct {
+	v string
+}
+
+// NewOnHoldReasonFromString creates new instance from string value
+func NewOnHoldReasonFromString(reasonStr string) (OnHoldReason, error) {
+	for _, reason := range onHoldReasonValues {
+		if reason.String() == reasonStr {
+			return reason, nil
+		}
+	}
+	return OnHoldReason{}, fmt.Errorf("unknown '%s' on hold reason", reasonStr)
+}
+
+// IsZero returns true if OnHoldReason has zero value
+func (r OnHoldReason) IsZero() bool {
+	return r == OnHoldReason{}
+}
+
+func (r OnHoldReason) String() string {
+	return r.v
+}
+
+// MarshalJSON returns JSON encoded OnHoldReason
+func (r OnHoldReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// OnHoldInfo contains information about why the ticket is on hold
+type OnHoldInfo struct {
+	// Reason why the ticket was put on hold
+	Reason OnHoldReason
+
+	// Optional time when the assignee wants to be reminded of the ticket
+	RemindAt types.DateTime
+}
@@ -0,0 +1,259 @@
+package incident
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+)
+
+// Impact values
+var (
+	ImpactHigh   = Impact{"high"}
+	ImpactMedium = Impact{"medium"}
+	ImpactLow    = Impact{"low"}
+)
+
+var impactValues = []Impact{
+	ImpactHigh,
+	ImpactMedium,
+	ImpactLow,
+}
+
+// Impact describes how much the incident affects the business (ie. number of affected users or services). It is enum.
+// swagger:strfmt string
+type Impact struct {
+	v string
+}
+
+// Impacts returns all impacts, the highest impact is the first one
+func Impacts() []Impact {
+	return append([]Impact(nil), impactValues...)
+}
+
+// NewImpactFromString creates new instance from string value
+func NewImpactFromString(impactStr string) (Impact, error) {
+	for _, impact := range impactValues {
+		if impact.String() == impactStr {
+			return impact, nil
+		}
+	}
+	return Impact{}, fmt.Errorf("unknown '%s' impact", impactStr)
+}
+
+// IsZero returns true if Impact has zero value
+func (i Impact) IsZero() bool {
+	return i == Impact{}
+}
+
+func (i Impact) String() string {
+	return i.v
+}
+
+// MarshalJSON returns JSON encoded Impact
+func (i Impact) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// Urgency values
+var (
+	UrgencyHigh   = Urgency{"high"}
+	UrgencyMedium = Urgency{"medium"}
+	UrgencyLow    = Urgency{"low"}
+)
+
+var urgencyValues = []Urgency{
+	UrgencyHigh,
+	UrgencyMedium,
+	UrgencyLow,
+}
+
+// Urgency describes how quickly the incident must be resolved. It is enum.
+// swagger:strfmt string
+type Urgency struct {
+	v string
+}
+
+// Urgencies returns all urgencies, the highest urgency is the first one
+func Urgencies() []Urgency {
+	return append([]Urgency(nil), urgencyValues...)
+}
+
+// NewUrgencyFromString creates new instance from string value
+func NewUrgencyFromString(urgencyStr string) (Urgency, error) {
+	for _, urgency := range urgencyValues {
+		if urgency.String() == urgencyStr {
+			return urgency, nil
+		}
+	}
+	return Urgency{}, fmt.Errorf("unknown '%s' urgency", urgencyStr)
+}
+
+// IsZero returns true if Urgency has zero value
+func (u Urgency) IsZero() bool {
+	return u == Urgency{}
+}
+
+func (u Urgency) String() string {
+	return u.v
+}
+
+// MarshalJSON returns JSON encoded Urgency
+func (u Urgency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+// ImpactUrgency is the key of the priority matrix
+type ImpactUrgency struct {
+	Impact Impact
+
+	Urgency Urgency
+}
+
+// PriorityMatrix of the channel derives the priority of the incident from its impact and urgency
+type PriorityMatrix struct {
+	// Priorities by impact and urgency of the incident
+	Priorities map[ImpactUrgency]sla.Priority
+
+	CreatedUpdated types.CreatedUpdated
+}
+
+// DefaultPriorityMatrix returns the matrix used in the channels that do not have their own priority matrix
+func DefaultPriorityMatrix() PriorityMatrix {
+	return PriorityMatrix{
+		Priorities: map[ImpactUrgency]sla.Priority{
+			{ImpactHigh, UrgencyHigh}:     sla.PriorityCritical,
+			{ImpactHigh, UrgencyMedium}:   sla.PriorityHigh,
+			{ImpactHigh, UrgencyLow}:      sla.PriorityModerate,
+			{ImpactMedium, UrgencyHigh}:   sla.PriorityHigh,
+			{ImpactMedium, UrgencyMedium}: sla.PriorityModerate,
+			{ImpactMedium, UrgencyLow}:    sla.PriorityLow,
+			{ImpactLow, UrgencyHigh}:      sla.PriorityModerate,
+			{ImpactLow, UrgencyMedium}:    sla.PriorityLow,
+			{ImpactLow, UrgencyLow}:       sla.PriorityLow,
+		},
+	}
+}
+
+// Validate returns error if the matrix does not have priority for every combination of impact and urgency
+func (m PriorityMatrix) Validate() error {
+	for _, impact := range impactValues {
+		for _, urgency := range urgencyValues {
+			if m.PriorityFor(impact, urgency).IsZero() {
+				return domain.NewErrorf(domain.ErrorCodeInvalidArgument,
+					"priority matrix does not have priority for '%s' impact and '%s' urgency", impact, urgency)
+			}
+		}
+	}
+
+	return nil
+}
+
+// PriorityFor returns the priority of the given impact and urgency, zero value is returned if the matrix does not have it
+func (m PriorityMatrix) PriorityFor(impact Impact, urgency Urgency) sla.Priority {
+	return m.Priorities[ImpactUrgency{Impact: impact, Urgency: urgency}]
+}
+
+// Impact getter
+func (e Incident) Impact() Impact {
+	return e.impact
+}
+
+// Urgency getter
+func (e Incident) Urgency() Urgency {
+	return e.urgency
+}
+
+// Priority returns the priority derived from the impact and urgency or the manually overridden priority
+func (e Incident) Priority() sla.Priority {
+	return e.priority
+}
+
+// PriorityOverridden returns true if the priority was set manually, ie. it is not derived from the impact and urgency
+func (e Incident) PriorityOverridden() bool {
+	return e.priorityOverridden
+}
+
+// RestorePriority sets the impact, urgency and priority without any checks (do not use in the domain, method is used by repository)
+func (e *Incident) RestorePriority(impact Impact, urgency Urgency, priority sla.Priority, overridden bool) {
+	e.impact = impact
+	e.urgency = urgency
+	e.priority = priority
+	e.priorityOverridden = overridden
+}
+
+// Prioritize sets the impact and urgency of the incident and derives its priority from the matrix.
+// Manually overridden priority is kept.
+func (e *Incident) Prioritize(matrix PriorityMatrix, impact Impact, urgency Urgency) error {
+	if impact.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "impact is required")
+	}
+
+	if urgency.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "urgency is required")
+	}
+
+	priority := matrix.PriorityFor(impact, urgency)
+	if priority.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeUnknown, "priority matrix does not have priority for '%s' impact and '%s' urgency", impact, urgency)
+	}
+
+	e.impact = impact
+	e.urgency = urgency
+	if !e.priorityOverridden {
+		e.priority = priority
+	}
+
+	return nil
+}
+
+// OverridePriority sets the priority manually, it is not derived from the impact and urgency until it is reset.
+// The override is recorded in the audit trail together with the reason.
+func (e *Incident) OverridePriority(actor actor.Actor, clock domain.Clock, priority sla.Priority, reason string) error {
+	if actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer cannot override ticket priority")
+	}
+
+	if priority.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "priority is required")
+	}
+
+	if reason == "" {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "priority override reason is required")
+	}
+
+	if e.priorityOverridden && e.priority == priority {
+		return nil
+	}
+
+	e.addAuditRecord(ActionOverridePriority, actor, clock, fmt.Sprintf("%s -> %s: %s", e.priority, priority, reason))
+
+	e.priority = priority
+	e.priorityOverridden = true
+
+	return nil
+}
+
+// ResetPriority removes the manual override and derives the priority from the impact and urgency again.
+// The reset is recorded in the audit trail.
+func (e *Incident) ResetPriority(actor actor.Actor, clock domain.Clock, matrix PriorityMatrix) error {
+	if actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer cannot reset ticket priority")
+	}
+
+	if !e.priorityOverridden {
+		return nil
+	}
+
+	priority := matrix.PriorityFor(e.impact, e.urgency)
+
+	e.addAuditRecord(ActionResetPriority, actor, clock, fmt.Sprintf("%s -> %s", e.priority, priority))
+
+	e.priority = priority
+	e.priorityOverridden = false
+
+	return nil
+}
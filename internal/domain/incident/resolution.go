@@ -0,0 +1,86 @@
+package incident
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// ResolutionCode values
+var (
+	ResolutionCodeSolvedPermanently        = ResolutionCode{"solved (permanently)"}
+	ResolutionCodeSolvedWorkaround         = ResolutionCode{"solved (work around)"}
+	ResolutionCodeSolvedRemotely           = ResolutionCode{"solved remotely"}
+	ResolutionCodeNotSolvedNotReproducible = ResolutionCode{"not solved (not reproducible)"}
+	ResolutionCodeNotSolvedTooCostly       = ResolutionCode{"not solved (too costly)"}
+	ResolutionCodeClosedByCaller           = ResolutionCode{"closed/resolved by caller"}
+)
+
+var resolutionCodeValues = []ResolutionCode{
+	ResolutionCodeSolvedPermanently,
+	ResolutionCodeSolvedWorkaround,
+	ResolutionCodeSolvedRemotely,
+	ResolutionCodeNotSolvedNotReproducible,
+	ResolutionCodeNotSolvedTooCostly,
+	ResolutionCodeClosedByCaller,
+}
+
+// ResolutionCode describes how the ticket was resolved. It is enum.
+// swagger:strfmt string
+type ResolutionCode struct {
+	v string
+}
+
+// NewResolutionCodeFromString creates new instance from string value
+func NewResolutionCodeFromString(codeStr string) (ResolutionCode, error) {
+	for _, code := range resolutionCodeValues {
+		if code.String() == codeStr {
+			return code, nil
+		}
+	}
+	return ResolutionCode{}, fmt.Errorf("unknown '%s' resolution code", codeStr)
+}
+
+// IsZero returns true if ResolutionCode has zero value
+func (c ResolutionCode) IsZero() bool {
+	return c == ResolutionCode{}
+}
+
+func (c ResolutionCode) String() string {
+	return c.v
+}
+
+// MarshalJSON returns JSON encoded ResolutionCode
+func (c ResolutionCode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// Resolution contains information about how and when the ticket was resolved
+type Resolution struct {
+	// Code describing how the ticket was resolved
+	Code ResolutionCode
+
+	// Notes describing the resolution
+	Notes string
+
+	// Time when the ticket was resolved
+	ResolvedAt types.DateTime
+}
+
+// AuditRecord is a record of the action that changed the resolution status of the ticket (ie. resolve, close and reopen)
+// or that overrode its priority or that was performed by the assigned field engineer (ie. accept and reject)
+type AuditRecord struct {
+	// Action that was performed
+	Action AllowedAction
+
+	// ID of the basic user who performed the action, it is zero if the action was performed automatically by the system
+	ActorID ref.UUID
+
+	// Time when the action was performed
+	Time types.DateTime
+
+	// Optional note (ie. reason of the reopening or of the priority override)
+	Note string
+}
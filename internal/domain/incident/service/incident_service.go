@@ -2,49 +2,163 @@ package incidentsvc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
 	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
-// NewIncidentService creates the incident service
-func NewIncidentService(incidentRepository repository.IncidentRepository, fieldEngineerRepository repository.FieldEngineerRepository) IncidentService {
+// NewIncidentService creates the incident service.
+// Operations changing both the incident and the field engineer are run in the unitOfWork,
+// every change of the incident is saved together with its history entry.
+// Resolved incidents are closed automatically after autoClosePeriod (zero value disables automatic closing).
+// SLA timers of the new incident are started if the channel has SLA defined in slaRepository.
+// Priorities of the incidents are derived by the channel's matrix from priorityMatrixRepository (or by the default matrix).
+func NewIncidentService(incidentRepository repository.IncidentRepository, fieldEngineerRepository repository.FieldEngineerRepository,
+	slaRepository repository.SLARepository, priorityMatrixRepository repository.PriorityMatrixRepository,
+	unitOfWork repository.UnitOfWork, clock domain.Clock, autoClosePeriod time.Duration) IncidentService {
 	return &incidentService{
-		incidentRepository:      incidentRepository,
-		fieldEngineerRepository: fieldEngineerRepository,
+		incidentRepository:       incidentRepository,
+		fieldEngineerRepository:  fieldEngineerRepository,
+		slaRepository:            slaRepository,
+		priorityMatrixRepository: priorityMatrixRepository,
+		unitOfWork:               unitOfWork,
+		clock:                    clock,
+		autoClosePeriod:          autoClosePeriod,
 	}
 }
 
 type incidentService struct {
-	incidentRepository      repository.IncidentRepository
-	fieldEngineerRepository repository.FieldEngineerRepository
+	incidentRepository       repository.IncidentRepository
+	fieldEngineerRepository  repository.FieldEngineerRepository
+	slaRepository            repository.SLARepository
+	priorityMatrixRepository repository.PriorityMatrixRepository
+	unitOfWork               repository.UnitOfWork
+	clock                    domain.Clock
+	autoClosePeriod          time.Duration
+	inUnitOfWork             bool
 }
 
-func (s *incidentService) CreateIncident(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateIncidentParams) (ref.UUID, error) {
-	// TODO validate that Caller or FE is not trying to set other fields then he is allowed to set, only SD agent can set everything (also in Update)
+// withRepositories returns the copy of the service using the repositories bound to the unit of work
+func (s *incidentService) withRepositories(repos repository.Repositories) *incidentService {
+	txService := *s
+	txService.incidentRepository = repos.Incident
+	txService.fieldEngineerRepository = repos.FieldEngineer
+	txService.inUnitOfWork = true
+	return &txService
+}
 
-	var feUUID ref.UUID
-	if params.FieldEngineerID != nil {
-		feUUID = ref.UUID(*params.FieldEngineerID)
-		if _, err := s.fieldEngineerRepository.GetFieldEngineer(ctx, channelID, feUUID); err != nil {
-			return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeNotFound, "cannot assign field engineer")
+// atomically calls fn with the service bound to the unit of work, fn is called directly if the service is already bound to it
+func (s *incidentService) atomically(ctx context.Context, fn func(ctx context.Context, txService *incidentService) error) error {
+	if s.inUnitOfWork {
+		return fn(ctx, s)
+	}
+
+	return s.unitOfWork.Do(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		return fn(ctx, s.withRepositories(repos))
+	})
+}
+
+// updateIncident saves the incident to the repository together with the history entry of the changes made since the before snapshot
+func (s *incidentService) updateIncident(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, clock domain.Clock,
+	action string, before history.Snapshot, inc incident.Incident) (ref.UUID, error) {
+	if err := inc.UpdateSLA(clock); err != nil {
+		return ref.UUID(""), err
+	}
+
+	var incID ref.UUID
+	err := s.atomically(ctx, func(ctx context.Context, txService *incidentService) error {
+		var err error
+		if incID, err = txService.incidentRepository.UpdateIncident(ctx, channelID, inc); err != nil {
+			return err
+		}
+
+		return txService.recordHistory(ctx, channelID, actor, clock, inc.UUID(), action, history.Changes(before, history.IncidentSnapshot(inc)))
+	})
+
+	return incID, err
+}
+
+// updateIncidentTimelog saves the incident's timelog to the repository together with the history entry of the changes made since the before snapshot
+func (s *incidentService) updateIncidentTimelog(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, clock domain.Clock,
+	action string, incID ref.UUID, before history.Snapshot, tmlg timelog.Timelog) error {
+	return s.atomically(ctx, func(ctx context.Context, txService *incidentService) error {
+		if _, err := txService.incidentRepository.UpdateIncidentTimelog(ctx, channelID, incID, tmlg); err != nil {
+			return err
 		}
+
+		return txService.recordHistory(ctx, channelID, actor, clock, incID, action, history.Changes(before, history.TimelogSnapshot(tmlg)))
+	})
+}
+
+// recordHistory adds the history entry of the action performed by the actor to the repository
+func (s *incidentService) recordHistory(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, clock domain.Clock,
+	incID ref.UUID, action string, changes []history.Change) error {
+	entry := history.NewEntry(actor, clock, incID, action, changes)
+	if _, err := s.incidentRepository.AddIncidentHistoryEntry(ctx, channelID, entry); err != nil {
+		return err
 	}
 
+	return nil
+}
+
+func (s *incidentService) CreateIncident(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateIncidentParams) (ref.UUID, error) {
+	// TODO validate that Caller or FE is not trying to set other fields then he is allowed to set, only SD agent can set everything (also in Update)
+
 	newIncident := incident.Incident{
 		Number:           params.Number,
 		ExternalID:       params.ExternalID,
 		ShortDescription: params.ShortDescription,
 		Description:      params.Description,
-		FieldEngineerID:  &feUUID,
 	}
-	if err := newIncident.SetState(incident.StateNew); err != nil {
+	if err := newIncident.SetState(actor, incident.StateNew); err != nil {
+		return ref.UUID(""), err
+	}
+
+	if params.FieldEngineerID != nil {
+		feUUID := ref.UUID(*params.FieldEngineerID)
+		if _, err := s.fieldEngineerRepository.GetFieldEngineer(ctx, channelID, feUUID); err != nil {
+			return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeNotFound, "cannot assign field engineer")
+		}
+
+		newIncident.AssignFieldEngineer(actor, feUUID)
+	}
+
+	impact, urgency := incident.ImpactMedium, incident.UrgencyMedium
+	if params.Impact != "" {
+		var err error
+		if impact, err = incident.NewImpactFromString(params.Impact); err != nil {
+			return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid impact")
+		}
+	}
+	if params.Urgency != "" {
+		var err error
+		if urgency, err = incident.NewUrgencyFromString(params.Urgency); err != nil {
+			return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid urgency")
+		}
+	}
+
+	matrix, err := s.GetPriorityMatrix(ctx, channelID, actor)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	// priority must be known before the SLA is started, it selects the SLA targets
+	if err := newIncident.Prioritize(matrix, impact, urgency); err != nil {
 		return ref.UUID(""), err
 	}
 
@@ -55,15 +169,39 @@ func (s *incidentService) CreateIncident(ctx context.Context, channelID ref.Chan
 		return ref.UUID(""), err
 	}
 
-	return s.incidentRepository.AddIncident(ctx, channelID, newIncident)
+	slaDefinition, err := s.slaRepository.GetSLADefinition(ctx, channelID)
+	if err == nil {
+		if err := newIncident.StartSLA(s.clock, slaDefinition); err != nil {
+			return ref.UUID(""), err
+		}
+	} else if !isNotFound(err) { // channel without SLA definition does not measure SLA
+		return ref.UUID(""), err
+	}
+
+	var incID ref.UUID
+	err = s.atomically(ctx, func(ctx context.Context, txService *incidentService) error {
+		var err error
+		if incID, err = txService.incidentRepository.AddIncident(ctx, channelID, newIncident); err != nil {
+			return err
+		}
+
+		changes := history.Changes(history.Snapshot{}, history.IncidentSnapshot(newIncident))
+		return txService.recordHistory(ctx, channelID, actor, s.clock, incID, history.ActionCreateIncident, changes)
+	})
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	return incID, nil
 }
 
 // UpdateIncident updates the given incident in the repository
 func (s *incidentService) UpdateIncident(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, params api.UpdateIncidentParams) (ref.UUID, error) {
-	inc, err := s.incidentRepository.GetIncident(ctx, channelID, ID)
+	inc, err := s.getIncidentForUpdate(ctx, channelID, ID)
 	if err != nil {
 		return ref.UUID(""), err
 	}
+	before := history.IncidentSnapshot(inc)
 
 	inc.ShortDescription = params.ShortDescription
 	inc.Description = params.Description
@@ -74,36 +212,311 @@ func (s *incidentService) UpdateIncident(ctx context.Context, channelID ref.Chan
 			return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeNotFound, "cannot assign field engineer")
 		}
 
-		inc.FieldEngineerID = &feUUID
+		inc.AssignFieldEngineer(actor, feUUID)
+	}
+
+	priority := inc.Priority()
+	if err := s.prioritize(ctx, channelID, actor, &inc, params); err != nil {
+		return ref.UUID(""), err
+	}
+
+	if inc.Priority() != priority {
+		if err := s.retargetSLA(ctx, channelID, &inc); err != nil {
+			return ref.UUID(""), err
+		}
 	}
 
 	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
 		return ref.UUID(""), err
 	}
 
-	return s.incidentRepository.UpdateIncident(ctx, channelID, inc)
+	return s.updateIncident(ctx, channelID, actor, s.clock, history.ActionUpdateIncident, before, inc)
+}
+
+// prioritize changes the impact, urgency and priority of the incident according to the update params
+func (s *incidentService) prioritize(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, inc *incident.Incident, params api.UpdateIncidentParams) error {
+	if params.Impact == "" && params.Urgency == "" && params.Priority == nil {
+		return nil
+	}
+
+	matrix, err := s.GetPriorityMatrix(ctx, channelID, actor)
+	if err != nil {
+		return err
+	}
+
+	if params.Impact != "" || params.Urgency != "" {
+		impact, urgency := inc.Impact(), inc.Urgency()
+		if params.Impact != "" {
+			if impact, err = incident.NewImpactFromString(params.Impact); err != nil {
+				return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid impact")
+			}
+		}
+		if params.Urgency != "" {
+			if urgency, err = incident.NewUrgencyFromString(params.Urgency); err != nil {
+				return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid urgency")
+			}
+		}
+
+		if err := inc.Prioritize(matrix, impact, urgency); err != nil {
+			return err
+		}
+	}
+
+	if params.Priority == nil {
+		return nil
+	}
+
+	if *params.Priority == "" {
+		return inc.ResetPriority(actor, s.clock, matrix)
+	}
+
+	priority, err := sla.NewPriorityFromString(*params.Priority)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid priority")
+	}
+
+	return inc.OverridePriority(actor, s.clock, priority, params.PriorityOverrideReason)
+}
+
+// retargetSLA selects the SLA targets of the incident by its changed priority from the channel's SLA definition,
+// the targets are kept if the channel does not have SLA defined anymore
+func (s *incidentService) retargetSLA(ctx context.Context, channelID ref.ChannelID, inc *incident.Incident) error {
+	if inc.SLA() == nil {
+		return nil
+	}
+
+	slaDefinition, err := s.slaRepository.GetSLADefinition(ctx, channelID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	inc.RetargetSLA(slaDefinition)
+
+	return nil
+}
+
+func (s *incidentService) GetPriorityMatrix(ctx context.Context, channelID ref.ChannelID, _ actor.Actor) (incident.PriorityMatrix, error) {
+	matrix, err := s.priorityMatrixRepository.GetPriorityMatrix(ctx, channelID)
+	if err != nil {
+		if isNotFound(err) {
+			return incident.DefaultPriorityMatrix(), nil
+		}
+		return incident.PriorityMatrix{}, err
+	}
+
+	return matrix, nil
+}
+
+func (s *incidentService) SetPriorityMatrix(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.SetPriorityMatrixParams) error {
+	matrix := incident.PriorityMatrix{
+		Priorities: make(map[incident.ImpactUrgency]sla.Priority),
+	}
+
+	for _, entry := range params.Priorities {
+		impact, err := incident.NewImpactFromString(entry.Impact)
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid impact")
+		}
+
+		urgency, err := incident.NewUrgencyFromString(entry.Urgency)
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid urgency")
+		}
+
+		priority, err := sla.NewPriorityFromString(entry.Priority)
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid priority")
+		}
+
+		key := incident.ImpactUrgency{Impact: impact, Urgency: urgency}
+		if _, ok := matrix.Priorities[key]; ok {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "duplicate priority for '%s' impact and '%s' urgency", impact, urgency)
+		}
+
+		matrix.Priorities[key] = priority
+	}
+
+	if err := matrix.Validate(); err != nil {
+		return err
+	}
+
+	if err := matrix.CreatedUpdated.SetCreatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+	if err := matrix.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	return s.priorityMatrixRepository.SetPriorityMatrix(ctx, channelID, matrix)
+}
+
+// isNotFound returns true if err is the domain error with NotFound code
+func isNotFound(err error) bool {
+	var domainErr *domain.Error
+	return errors.As(err, &domainErr) && domainErr.Code() == domain.ErrorCodeNotFound
 }
 
 func (s *incidentService) GetIncident(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, ID ref.UUID) (incident.Incident, error) {
 	return s.incidentRepository.GetIncident(ctx, channelID, ID)
 }
 
-func (s *incidentService) ListIncidents(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, params converters.PaginationParams) (repository.IncidentList, error) {
-	return s.incidentRepository.ListIncidents(ctx, channelID, params.Page(), params.ItemsPerPage())
+func (s *incidentService) ListIncidents(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, filter repository.IncidentFilter, params converters.PaginationParams) (repository.IncidentList, error) {
+	if cursorPage := params.CursorPage(); cursorPage != nil {
+		return s.incidentRepository.ListIncidentsByCursor(ctx, channelID, filter, *cursorPage)
+	}
+
+	return s.incidentRepository.ListIncidents(ctx, channelID, filter, params.Page(), params.ItemsPerPage())
+}
+
+// getIncidentForUpdate loads the incident from the repository and checks that it has the version expected by the client, if any
+func (s *incidentService) getIncidentForUpdate(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error) {
+	inc, err := s.incidentRepository.GetIncident(ctx, channelID, ID)
+	if err != nil {
+		return incident.Incident{}, err
+	}
+
+	if err := checkExpectedVersion(ctx, inc); err != nil {
+		return incident.Incident{}, err
+	}
+
+	return inc, nil
+}
+
+// AutoCloseFailure is the incident that could not be closed automatically
+type AutoCloseFailure struct {
+	ChannelID  ref.ChannelID
+	IncidentID ref.UUID
+	Err        error
+}
+
+// AutoCloseError is returned by AutoCloseResolved if some of the resolved incidents could not be closed, other incidents are closed anyway
+type AutoCloseError struct {
+	Failures []AutoCloseFailure
+}
+
+func (e *AutoCloseError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("incident '%s': %s", f.IncidentID, f.Err))
+	}
+	return fmt.Sprintf("could not close %d resolved incident(s): %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+func (s *incidentService) AutoCloseResolved(ctx context.Context, limit uint) (int, error) {
+	if s.autoClosePeriod == 0 {
+		return 0, nil
+	}
+
+	resolved, err := s.incidentRepository.ListResolvedIncidents(ctx, s.clock.Now().Add(-s.autoClosePeriod), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	closedCount := 0
+	var failures []AutoCloseFailure
+	for _, ri := range resolved {
+		closed, err := s.autoClose(ctx, ri)
+		if err != nil { // one failing incident must not block closing of the others
+			failures = append(failures, AutoCloseFailure{ChannelID: ri.ChannelID, IncidentID: ri.Incident.UUID(), Err: err})
+			continue
+		}
+
+		if closed {
+			closedCount++
+		}
+	}
+
+	if len(failures) > 0 {
+		return closedCount, &AutoCloseError{Failures: failures}
+	}
+
+	return closedCount, nil
+}
+
+// autoClose closes the resolved incident if the auto close period has expired, it returns true if the incident was closed
+func (s *incidentService) autoClose(ctx context.Context, ri repository.ChannelIncident) (bool, error) {
+	inc := ri.Incident
+	before := history.IncidentSnapshot(inc)
+
+	closed, err := inc.AutoClose(s.clock, s.autoClosePeriod)
+	if err != nil || !closed {
+		return false, err
+	}
+
+	// incident is closed by the system, not by any user
+	if _, err := s.updateIncident(ctx, ri.ChannelID, actor.Actor{}, s.clock, history.ActionAutoClose, before, inc); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *incidentService) Accept(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.Accept(actor, clock); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionAccept, before, inc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) Reject(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentRejectParams, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.Reject(actor, clock, params.Reason); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionReject, before, inc); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (s *incidentService) StartWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentStartWorkingParams, clock domain.Clock) error {
 	if !actor.IsFieldEngineer() {
 		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not field engineer")
 	}
-	feID := actor.FieldEngineerID()
 
-	inc, err := s.incidentRepository.GetIncident(ctx, channelID, incID)
+	// incident and field engineer are updated together or not at all
+	return s.unitOfWork.Do(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		return s.withRepositories(repos).startWorking(ctx, channelID, actor, incID, params, clock)
+	})
+}
+
+func (s *incidentService) startWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentStartWorkingParams, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
 	if err != nil {
 		return err
 	}
+	before := history.IncidentSnapshot(inc)
 
-	fe, err := s.fieldEngineerRepository.GetFieldEngineer(ctx, channelID, *feID)
+	fe, err := s.fieldEngineerRepository.GetFieldEngineer(ctx, channelID, *actor.FieldEngineerID())
 	if err != nil {
 		return err
 	}
@@ -112,11 +525,11 @@ func (s *incidentService) StartWorking(ctx context.Context, channelID ref.Channe
 		return err
 	}
 
-	if err := fe.StartWorking(actor, inc); err != nil {
+	if err := fe.StartWorking(actor, clock, inc); err != nil {
 		return err
 	}
 
-	if _, err := s.incidentRepository.UpdateIncident(ctx, channelID, inc); err != nil {
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionStartWorking, before, inc); err != nil {
 		return err
 	}
 
@@ -128,16 +541,234 @@ func (s *incidentService) StartWorking(ctx context.Context, channelID ref.Channe
 }
 
 func (s *incidentService) StopWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentStopWorkingParams, clock domain.Clock) error {
-	inc, err := s.incidentRepository.GetIncident(ctx, channelID, incID)
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
 	if err != nil {
 		return err
 	}
+	before := history.IncidentSnapshot(inc)
 
 	if err := inc.StopWorking(actor, clock, params.VisitSummary); err != nil {
 		return err
 	}
 
-	if _, err := s.incidentRepository.UpdateIncident(ctx, channelID, inc); err != nil {
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionStopWorking, before, inc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) PauseWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentPauseWorkingParams, clock domain.Clock) error {
+	if !actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not field engineer")
+	}
+
+	timespanType, err := timelog.NewTimespanTypeFromString(params.TimespanType)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid timespan type")
+	}
+
+	// incident and field engineer are updated together or not at all
+	return s.unitOfWork.Do(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		return s.withRepositories(repos).pauseWorking(ctx, channelID, actor, incID, timespanType, clock)
+	})
+}
+
+// pauseWorking pauses the work on the incident, the break is started also in the open time session of the field engineer
+func (s *incidentService) pauseWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timespanType timelog.TimespanType, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.PauseWorking(actor, clock, timespanType); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionPauseWorking, before, inc); err != nil {
+		return err
+	}
+
+	if timespanType != timelog.TimespanTypeBreak {
+		return nil
+	}
+
+	return s.updateTimeSessionState(ctx, channelID, actor, tsession.StateWork, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.StartBreak(actor, clock)
+	})
+}
+
+func (s *incidentService) ResumeWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error {
+	if !actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "actor is not field engineer")
+	}
+
+	// incident and field engineer are updated together or not at all
+	return s.unitOfWork.Do(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		return s.withRepositories(repos).resumeWorking(ctx, channelID, actor, incID, clock)
+	})
+}
+
+// resumeWorking resumes the paused work on the incident, the break in the open time session of the field engineer is ended too
+func (s *incidentService) resumeWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.ResumeWorking(actor, clock); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionResumeWorking, before, inc); err != nil {
+		return err
+	}
+
+	return s.updateTimeSessionState(ctx, channelID, actor, tsession.StateBreak, func(fe *fieldengineer.FieldEngineer) error {
+		return fe.EndBreak(actor, clock)
+	})
+}
+
+// updateTimeSessionState performs the action on the actor's (field engineer's) open time session if it is in the given state,
+// the time session in other state (ie. the break was already started or ended by the field engineer) is left untouched
+func (s *incidentService) updateTimeSessionState(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, state tsession.State,
+	action func(fe *fieldengineer.FieldEngineer) error) error {
+	fe, err := s.fieldEngineerRepository.GetFieldEngineer(ctx, channelID, *actor.FieldEngineerID())
+	if err != nil {
+		return err
+	}
+
+	if !fe.HasOpenTimeSession() || fe.OpenTimeSession().State() != state {
+		return nil
+	}
+
+	if err := action(&fe); err != nil {
+		return err
+	}
+
+	if _, err := s.fieldEngineerRepository.UpdateFieldEngineer(ctx, channelID, fe); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) PutOnHold(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentPutOnHoldParams, clock domain.Clock) error {
+	reason, err := incident.NewOnHoldReasonFromString(params.Reason)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid on hold reason")
+	}
+
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.PutOnHold(actor, clock, reason, types.DateTime(params.RemindAt)); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionPutOnHold, before, inc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) Resume(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.Resume(actor); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionResume, before, inc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) Resolve(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentResolveParams, clock domain.Clock) error {
+	code, err := incident.NewResolutionCodeFromString(params.ResolutionCode)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid resolution code")
+	}
+
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.Resolve(actor, clock, code, params.ResolutionNotes); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionResolve, before, inc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) Close(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.Close(actor, clock); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionClose, before, inc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *incidentService) Reopen(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentReopenParams, clock domain.Clock) error {
+	inc, err := s.getIncidentForUpdate(ctx, channelID, incID)
+	if err != nil {
+		return err
+	}
+	before := history.IncidentSnapshot(inc)
+
+	if err := inc.Reopen(actor, clock, params.Reason, s.autoClosePeriod); err != nil {
+		return err
+	}
+
+	if err := inc.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	if _, err := s.updateIncident(ctx, channelID, actor, clock, history.ActionReopen, before, inc); err != nil {
 		return err
 	}
 
@@ -148,3 +779,63 @@ func (s *incidentService) StopWorking(ctx context.Context, channelID ref.Channel
 func (s *incidentService) GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
 	return s.incidentRepository.GetIncidentTimelog(ctx, channelID, incID, timelogID)
 }
+
+// ListIncidentTimelogs returns the list of the incident's timelogs from the repository
+func (s *incidentService) ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, incID ref.UUID, params converters.PaginationParams) (repository.TimelogList, error) {
+	return s.incidentRepository.ListIncidentTimelogs(ctx, channelID, incID, params.Page(), params.ItemsPerPage())
+}
+
+func (s *incidentService) ProposeTimelogCorrection(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, params api.ProposeTimelogCorrectionParams, clock domain.Clock) error {
+	tmlg, err := s.incidentRepository.GetIncidentTimelog(ctx, channelID, incID, timelogID)
+	if err != nil {
+		return err
+	}
+	before := history.TimelogSnapshot(tmlg)
+
+	correction := timelog.Correction{
+		Remote:       params.Remote,
+		Start:        types.DateTime(params.Start),
+		End:          types.DateTime(params.End),
+		VisitSummary: params.VisitSummary,
+		Reason:       params.Reason,
+	}
+
+	if err := tmlg.ProposeCorrection(actor, clock, correction); err != nil {
+		return err
+	}
+
+	return s.updateIncidentTimelog(ctx, channelID, actor, clock, history.ActionProposeTimelogCorrection, incID, before, tmlg)
+}
+
+func (s *incidentService) ApproveTimelogCorrection(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, clock domain.Clock) error {
+	tmlg, err := s.incidentRepository.GetIncidentTimelog(ctx, channelID, incID, timelogID)
+	if err != nil {
+		return err
+	}
+	before := history.TimelogSnapshot(tmlg)
+
+	if err := tmlg.ApproveCorrection(actor, clock); err != nil {
+		return err
+	}
+
+	return s.updateIncidentTimelog(ctx, channelID, actor, clock, history.ActionApproveTimelogCorrection, incID, before, tmlg)
+}
+
+func (s *incidentService) RejectTimelogCorrection(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, params api.RejectTimelogCorrectionParams, clock domain.Clock) error {
+	tmlg, err := s.incidentRepository.GetIncidentTimelog(ctx, channelID, incID, timelogID)
+	if err != nil {
+		return err
+	}
+	before := history.TimelogSnapshot(tmlg)
+
+	if err := tmlg.RejectCorrection(actor, clock, params.Note); err != nil {
+		return err
+	}
+
+	return s.updateIncidentTimelog(ctx, channelID, actor, clock, history.ActionRejectTimelogCorrection, incID, before, tmlg)
+}
+
+// ListIncidentHistory returns the list of the incident's history entries from the repository
+func (s *incidentService) ListIncidentHistory(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, incID ref.UUID, params converters.PaginationParams) (repository.HistoryList, error) {
+	return s.incidentRepository.ListIncidentHistory(ctx, channelID, incID, params.Page(), params.ItemsPerPage())
+}
@@ -2,18 +2,26 @@ package incidentsvc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	fieldengineersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/service"
 	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
 	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,7 +52,9 @@ func Test_incidentService_CreateAndRetrieveIncidents(t *testing.T) {
 	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
 	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
 
-	svc := NewIncidentService(incidentRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
 
 	// CreateIncident
 	params1 := api.CreateIncidentParams{
@@ -66,8 +76,9 @@ func Test_incidentService_CreateAndRetrieveIncidents(t *testing.T) {
 	paginationParams := new(mocks.PaginationParamsMock)
 	paginationParams.On("Page").Return(uint(1))
 	paginationParams.On("ItemsPerPage").Return(uint(10))
+	paginationParams.On("CursorPage").Return((*repository.CursorPage)(nil))
 
-	list, err := svc.ListIncidents(ctx, channelID, actorUser, paginationParams)
+	list, err := svc.ListIncidents(ctx, channelID, actorUser, repository.IncidentFilter{}, paginationParams)
 	require.NoError(t, err)
 
 	incidents := list.Result
@@ -76,6 +87,26 @@ func Test_incidentService_CreateAndRetrieveIncidents(t *testing.T) {
 	assert.Equal(t, inc1ID, incidents[0].UUID())
 	assert.Equal(t, inc2ID, incidents[1].UUID())
 
+	// ListIncidents by cursor
+	cursorParams := new(mocks.PaginationParamsMock)
+	cursorParams.On("CursorPage").Return(&repository.CursorPage{Limit: 1})
+
+	list, err = svc.ListIncidents(ctx, channelID, actorUser, repository.IncidentFilter{}, cursorParams)
+	require.NoError(t, err)
+	require.Len(t, list.Result, 1)
+	assert.Equal(t, inc1ID, list.Result[0].UUID())
+	require.NotNil(t, list.Cursors)
+	require.NotNil(t, list.Cursors.Next)
+
+	cursorParams = new(mocks.PaginationParamsMock)
+	cursorParams.On("CursorPage").Return(&repository.CursorPage{After: list.Cursors.Next, Limit: 1})
+
+	list, err = svc.ListIncidents(ctx, channelID, actorUser, repository.IncidentFilter{}, cursorParams)
+	require.NoError(t, err)
+	require.Len(t, list.Result, 1)
+	assert.Equal(t, inc2ID, list.Result[0].UUID())
+	assert.Nil(t, list.Cursors.Next)
+
 	// GetIncident
 	retInc1, err := svc.GetIncident(ctx, channelID, actorUser, inc1ID)
 	require.NoError(t, err)
@@ -127,7 +158,9 @@ func Test_incidentService_UpdateIncident(t *testing.T) {
 
 	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
 
-	svc := NewIncidentService(incidentRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
 
 	feUUID := api.UUID(fieldEngineer.UUID().String())
 	// CreateIncident
@@ -184,6 +217,25 @@ func Test_incidentService_UpdateIncident(t *testing.T) {
 	// timestamp updatedAt should change
 	assert.NotEqual(t, origInc.CreatedUpdated.UpdatedAt(), updatedInc.CreatedUpdated.UpdatedAt())
 	assert.Equal(t, updatedInc.UUID(), incID)
+
+	// update of the incident version the client has seen before the last update is rejected
+	assert.Equal(t, origInc.Version()+1, updatedInc.Version())
+	_, err = svc.UpdateIncident(WithExpectedVersions(ctx, origInc.Version()), channelID, actorUser, incID, updateParams)
+	require.Error(t, err)
+	var dErr *domain.Error
+	require.ErrorAs(t, err, &dErr)
+	assert.Equal(t, domain.ErrorCodeVersionMismatch, dErr.Code())
+	assert.Equal(t, fmt.Sprintf("incident '%s' was modified (version %d, expected version %d)", incID, updatedInc.Version(), origInc.Version()), err.Error())
+
+	// update of the current incident version succeeds
+	_, err = svc.UpdateIncident(WithExpectedVersions(ctx, updatedInc.Version()), channelID, actorUser, incID, updateParams)
+	require.NoError(t, err)
+
+	// update succeeds if the current version is any of the expected versions
+	updatedInc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	_, err = svc.UpdateIncident(WithExpectedVersions(ctx, origInc.Version(), updatedInc.Version()), channelID, actorUser, incID, updateParams)
+	require.NoError(t, err)
 }
 
 func Test_incidentService_StartWorking_and_StopWorking(t *testing.T) {
@@ -209,10 +261,12 @@ func Test_incidentService_StartWorking_and_StopWorking(t *testing.T) {
 
 	clock := mocks.NewFixedClock()
 	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
-	feSvc := fieldengineersvc.NewFieldEngineerService(fieldEngineerRepository)
+	feSvc := fieldengineersvc.NewFieldEngineerService(fieldEngineerRepository, basicUserRepository, nil)
 
 	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
-	svc := NewIncidentService(incidentRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
 
 	// create field engineer
 	fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
@@ -237,6 +291,23 @@ func Test_incidentService_StartWorking_and_StopWorking(t *testing.T) {
 	incID, err := svc.CreateIncident(ctx, channelID, actorUser, incParams)
 	require.NoError(t, err)
 
+	// assignment of the field engineer is published
+	outboxRepository := memory.NewOutboxRepositoryMemory(fieldEngineerRepository, incidentRepository)
+	messages, err := outboxRepository.ListPendingMessages(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, event.TypeIncidentFieldEngineerAssigned, messages[0].EventType)
+	assert.Equal(t, incID, messages[0].AggregateID)
+
+	// StartWorking is not allowed until the field engineer accepts the incident
+	err = svc.StartWorking(ctx, channelID, actorUser, incID, api.IncidentStartWorkingParams{}, clock)
+	require.Error(t, err)
+	assert.EqualError(t, err, "ticket was not accepted by the assigned field engineer")
+
+	// Accept
+	err = svc.Accept(ctx, channelID, actorUser, incID, clock)
+	require.NoError(t, err)
+
 	// StartWorking
 	remote := true
 	err = svc.StartWorking(ctx, channelID, actorUser, incID, api.IncidentStartWorkingParams{Remote: remote}, clock)
@@ -268,6 +339,33 @@ func Test_incidentService_StartWorking_and_StopWorking(t *testing.T) {
 	assert.Len(t, openTS.Incidents, 1)
 	assert.Equal(t, incID, openTS.Incidents[0].IncidentID)
 
+	// PauseWorking
+	clock.AddTime(1 * time.Hour)
+	err = svc.PauseWorking(ctx, channelID, actorUser, incID, api.IncidentPauseWorkingParams{TimespanType: "work"}, clock)
+	require.Error(t, err)
+	err = svc.PauseWorking(ctx, channelID, actorUser, incID, api.IncidentPauseWorkingParams{TimespanType: "break"}, clock)
+	require.NoError(t, err)
+
+	// break is started in the time session too
+	updatedFe, err = feSvc.GetFieldEngineer(ctx, channelID, actorUser, feID)
+	require.NoError(t, err)
+	assert.Equal(t, tsession.StateBreak, updatedFe.OpenTimeSession().State())
+
+	// ResumeWorking
+	clock.AddTime(30 * time.Minute)
+	err = svc.ResumeWorking(ctx, channelID, actorUser, incID, clock)
+	require.NoError(t, err)
+
+	updatedFe, err = feSvc.GetFieldEngineer(ctx, channelID, actorUser, feID)
+	require.NoError(t, err)
+	assert.Equal(t, tsession.StateWork, updatedFe.OpenTimeSession().State())
+	assert.Equal(t, uint(3600), updatedFe.OpenTimeSession().Work, "break is not counted as work")
+
+	updatedInc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	require.True(t, updatedInc.HasOpenTimelog())
+	assert.Len(t, updatedInc.OpenTimelog().Timespans, 3)
+
 	clock.AddTime(2 * time.Hour)
 	err = svc.StopWorking(ctx, channelID, actorUser, incID, api.IncidentStopWorkingParams{VisitSummary: "some message"}, clock)
 	require.NoError(t, err)
@@ -290,5 +388,1033 @@ func Test_incidentService_StartWorking_and_StopWorking(t *testing.T) {
 
 	assert.NotEmpty(t, timelog.End)
 	assert.Equal(t, clock.NowFormatted(), timelog.End)
-	assert.NotEmpty(t, timelog.Work)
+	assert.Equal(t, uint(3*3600), timelog.Work, "break is not counted as work")
+	assert.Len(t, timelog.Timespans, 3)
+}
+
+// failingFieldEngineerRepository fails to update the field engineer
+type failingFieldEngineerRepository struct {
+	repository.FieldEngineerRepository
+}
+
+func (failingFieldEngineerRepository) UpdateFieldEngineer(context.Context, ref.ChannelID, fieldengineer.FieldEngineer) (ref.UUID, error) {
+	return ref.UUID(""), errors.New("field engineer update failed")
+}
+
+// failingUnitOfWork runs the unit of work with the field engineer repository failing to update
+type failingUnitOfWork struct {
+	repository.UnitOfWork
+}
+
+func (u failingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	return u.UnitOfWork.Do(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		repos.FieldEngineer = failingFieldEngineerRepository{repos.FieldEngineer}
+		return fn(ctx, repos)
+	})
+}
+
+func Test_incidentService_Reject(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	// create field engineer
+	fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+	err = fe.CreatedUpdated.SetCreatedBy(basicUser)
+	require.NoError(t, err)
+	err = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+	require.NoError(t, err)
+	feID, err := fieldEngineerRepository.AddFieldEngineer(ctx, channelID, fe)
+	require.NoError(t, err)
+
+	feActor := actor.Actor{BasicUser: basicUser}
+	feActor.SetFieldEngineerID(&feID)
+
+	feUUID := api.UUID(feID)
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+		FieldEngineerID:  &feUUID,
+	})
+	require.NoError(t, err)
+
+	// only assigned field engineer can reject the incident
+	err = svc.Reject(ctx, channelID, actorUser, incID, api.IncidentRejectParams{Reason: "too far away"}, clock)
+	require.Error(t, err)
+
+	err = svc.Reject(ctx, channelID, feActor, incID, api.IncidentRejectParams{Reason: "too far away"}, clock)
+	require.NoError(t, err)
+
+	inc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Nil(t, inc.FieldEngineerID)
+	assert.False(t, inc.FieldEngineerAccepted())
+	require.Len(t, inc.AuditTrail(), 1)
+	assert.Equal(t, incident.ActionReject, inc.AuditTrail()[0].Action)
+	assert.Equal(t, "too far away", inc.AuditTrail()[0].Note)
+
+	paginationParams := new(mocks.PaginationParamsMock)
+	paginationParams.On("Page").Return(uint(1))
+	paginationParams.On("ItemsPerPage").Return(uint(10))
+
+	historyList, err := svc.ListIncidentHistory(ctx, channelID, actorUser, incID, paginationParams)
+	require.NoError(t, err)
+	require.Len(t, historyList.Result, 2)
+	assert.Equal(t, history.ActionReject, historyList.Result[1].Action)
+	assert.Equal(t, []history.Change{{Field: "field_engineer_id", Before: feID.String()}}, historyList.Result[1].Changes)
+
+	// rejected field engineer is not assigned anymore
+	err = svc.Accept(ctx, channelID, feActor, incID, clock)
+	require.Error(t, err)
+
+	// the incident can be assigned again, it must be accepted by the field engineer
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription: "Some incident 1",
+		FieldEngineerID:  &feUUID,
+	})
+	require.NoError(t, err)
+
+	err = svc.Accept(ctx, channelID, feActor, incID, clock)
+	require.NoError(t, err)
+
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	require.NotNil(t, inc.FieldEngineerID)
+	assert.Equal(t, feID, *inc.FieldEngineerID)
+	assert.True(t, inc.FieldEngineerAccepted())
+}
+
+func Test_incidentService_StartWorking_RollsBackIncident(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	unitOfWork := failingUnitOfWork{memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)}
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	// create field engineer
+	fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+	err = fe.CreatedUpdated.SetCreatedBy(basicUser)
+	require.NoError(t, err)
+	err = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+	require.NoError(t, err)
+	feID, err := fieldEngineerRepository.AddFieldEngineer(ctx, channelID, fe)
+	require.NoError(t, err)
+
+	actorUser.SetFieldEngineerID(&feID)
+
+	feUUID := api.UUID(feID)
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+		FieldEngineerID:  &feUUID,
+	})
+	require.NoError(t, err)
+
+	err = svc.Accept(ctx, channelID, actorUser, incID, clock)
+	require.NoError(t, err)
+
+	// field engineer update fails after the incident was updated
+	err = svc.StartWorking(ctx, channelID, actorUser, incID, api.IncidentStartWorkingParams{}, clock)
+	require.EqualError(t, err, "field engineer update failed")
+
+	// incident update is rolled back
+	inc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+
+	assert.Equal(t, incident.StateNew, inc.State())
+	assert.Empty(t, inc.Timelogs)
+	assert.False(t, inc.HasOpenTimelog())
+	assert.Equal(t, uint(2), inc.Version())
+	assert.True(t, inc.FieldEngineerAccepted())
+
+	retFE, err := fieldEngineerRepository.GetFieldEngineer(ctx, channelID, feID)
+	require.NoError(t, err)
+	assert.Empty(t, retFE.TimeSessions)
+	assert.False(t, retFE.HasOpenTimeSession())
+
+	// history entry is rolled back as well
+	historyList, err := incidentRepository.ListIncidentHistory(ctx, channelID, incID, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, historyList.Result, 2)
+	assert.Equal(t, history.ActionCreateIncident, historyList.Result[0].Action)
+	assert.Equal(t, history.ActionAccept, historyList.Result[1].Action)
+}
+
+func Test_incidentService_History(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	dispatcherUser := user.BasicUser{
+		ExternalUserUUID: "ee824cad-d7a6-4f48-87dc-e8461a9201c4",
+		Name:             "Jan",
+		Surname:          "Novak",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	dispatcherUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, dispatcherUser)
+	require.NoError(t, err)
+	err = dispatcherUser.SetUUID(dispatcherUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	// request sent by the dispatcher on behalf of the actor user
+	onBehalfActor := actor.Actor{BasicUser: basicUser}
+	onBehalfActor.SetRequestedBy(&dispatcherUser)
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+	})
+	require.NoError(t, err)
+
+	clock.AddTime(time.Hour)
+	_, err = svc.UpdateIncident(ctx, channelID, onBehalfActor, incID, api.UpdateIncidentParams{
+		ShortDescription: "Some incident 1",
+		Description:      "Updated description",
+	})
+	require.NoError(t, err)
+
+	// failed action is not recorded
+	err = svc.PutOnHold(ctx, channelID, actorUser, incID, api.IncidentPutOnHoldParams{Reason: "some reason"}, clock)
+	require.Error(t, err)
+
+	clock.AddTime(time.Hour)
+	remindAt := clock.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	err = svc.PutOnHold(ctx, channelID, actorUser, incID, api.IncidentPutOnHoldParams{Reason: "awaiting caller", RemindAt: remindAt}, clock)
+	require.NoError(t, err)
+
+	paginationParams := new(mocks.PaginationParamsMock)
+	paginationParams.On("Page").Return(uint(1))
+	paginationParams.On("ItemsPerPage").Return(uint(10))
+
+	list, err := svc.ListIncidentHistory(ctx, channelID, actorUser, incID, paginationParams)
+	require.NoError(t, err)
+	require.Len(t, list.Result, 3)
+	assert.Equal(t, 3, list.Total)
+
+	created := list.Result[0]
+	assert.Equal(t, history.ActionCreateIncident, created.Action)
+	assert.Equal(t, incID, created.IncidentID)
+	assert.Equal(t, basicUserID, created.ActorID)
+	assert.True(t, created.RequestedByID.IsZero())
+	assert.Contains(t, created.Changes, history.Change{Field: "number", After: "ABC123"})
+	assert.Contains(t, created.Changes, history.Change{Field: "state", After: incident.StateNew.String()})
+
+	updated := list.Result[1]
+	assert.Equal(t, history.ActionUpdateIncident, updated.Action)
+	assert.Equal(t, basicUserID, updated.ActorID)
+	assert.Equal(t, dispatcherUserID, updated.RequestedByID)
+	assert.Equal(t, types.DateTime("2021-04-01T13:34:56+02:00"), updated.Time)
+	assert.Equal(t, []history.Change{{Field: "description", After: "Updated description"}}, updated.Changes)
+
+	onHold := list.Result[2]
+	assert.Equal(t, history.ActionPutOnHold, onHold.Action)
+	assert.Equal(t, clock.NowFormatted(), onHold.Time)
+	assert.Equal(t, []history.Change{
+		{Field: "on_hold_reason", After: incident.OnHoldReasonAwaitingCaller.String()},
+		{Field: "remind_at", After: remindAt},
+		{Field: "state", Before: incident.StateNew.String(), After: incident.StateOnHold.String()},
+	}, onHold.Changes)
+}
+
+func Test_incidentService_PutOnHold_and_Resume(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+	})
+	require.NoError(t, err)
+
+	// unknown reason
+	err = svc.PutOnHold(ctx, channelID, actorUser, incID, api.IncidentPutOnHoldParams{Reason: "some reason"}, clock)
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid on hold reason: unknown 'some reason' on hold reason")
+
+	// PutOnHold
+	remindAt := clock.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	err = svc.PutOnHold(ctx, channelID, actorUser, incID, api.IncidentPutOnHoldParams{Reason: "awaiting caller", RemindAt: remindAt}, clock)
+	require.NoError(t, err)
+
+	onHoldInc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.StateOnHold, onHoldInc.State())
+	require.NotNil(t, onHoldInc.OnHold())
+	assert.Equal(t, incident.OnHoldReasonAwaitingCaller, onHoldInc.OnHold().Reason)
+	assert.Equal(t, remindAt, onHoldInc.OnHold().RemindAt.String())
+
+	// Resume
+	requestClock := mocks.NewFixedClock()
+	requestClock.AddTime(time.Hour)
+	err = svc.Resume(ctx, channelID, actorUser, incID, requestClock)
+	require.NoError(t, err)
+
+	resumedInc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.StateInProgress, resumedInc.State())
+	assert.Nil(t, resumedInc.OnHold())
+
+	// the action is recorded at the time of the request
+	historyList, err := incidentRepository.ListIncidentHistory(ctx, channelID, incID, 1, 10)
+	require.NoError(t, err)
+	lastEntry := historyList.Result[len(historyList.Result)-1]
+	assert.Equal(t, history.ActionResume, lastEntry.Action)
+	assert.Equal(t, requestClock.NowFormatted(), lastEntry.Time)
+}
+
+func Test_incidentService_Resolve_Reopen_and_AutoClose(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+
+	autoClosePeriod := 72 * time.Hour
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, autoClosePeriod)
+
+	// create field engineer
+	fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+	err = fe.CreatedUpdated.SetCreatedBy(basicUser)
+	require.NoError(t, err)
+	err = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+	require.NoError(t, err)
+	feID, err := fieldEngineerRepository.AddFieldEngineer(ctx, channelID, fe)
+	require.NoError(t, err)
+
+	feActor := actor.Actor{BasicUser: basicUser}
+	feActor.SetFieldEngineerID(&feID)
+
+	feUUID := api.UUID(feID)
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+		FieldEngineerID:  &feUUID,
+	})
+	require.NoError(t, err)
+
+	resolveParams := api.IncidentResolveParams{
+		ResolutionCode:  "solved (permanently)",
+		ResolutionNotes: "disk replaced",
+	}
+
+	// incident without closed timelog cannot be resolved
+	err = svc.Resolve(ctx, channelID, actorUser, incID, resolveParams, clock)
+	require.Error(t, err)
+
+	err = svc.Accept(ctx, channelID, feActor, incID, clock)
+	require.NoError(t, err)
+
+	err = svc.StartWorking(ctx, channelID, feActor, incID, api.IncidentStartWorkingParams{}, clock)
+	require.NoError(t, err)
+
+	clock.AddTime(time.Hour)
+	err = svc.StopWorking(ctx, channelID, feActor, incID, api.IncidentStopWorkingParams{VisitSummary: "disk replaced"}, clock)
+	require.NoError(t, err)
+
+	// unknown resolution code
+	err = svc.Resolve(ctx, channelID, actorUser, incID, api.IncidentResolveParams{ResolutionCode: "fixed", ResolutionNotes: "notes"}, clock)
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid resolution code: unknown 'fixed' resolution code")
+
+	// Resolve
+	err = svc.Resolve(ctx, channelID, actorUser, incID, resolveParams, clock)
+	require.NoError(t, err)
+
+	resolvedInc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.StateResolved, resolvedInc.State())
+	require.NotNil(t, resolvedInc.Resolution())
+	assert.Equal(t, incident.ResolutionCodeSolvedPermanently, resolvedInc.Resolution().Code)
+	assert.Equal(t, "disk replaced", resolvedInc.Resolution().Notes)
+	assert.Equal(t, clock.NowFormatted(), resolvedInc.Resolution().ResolvedAt)
+
+	// Reopen within the window
+	clock.AddTime(24 * time.Hour)
+	err = svc.Reopen(ctx, channelID, actorUser, incID, api.IncidentReopenParams{Reason: "disk failed again"}, clock)
+	require.NoError(t, err)
+
+	reopenedInc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.StateInProgress, reopenedInc.State())
+	assert.Nil(t, reopenedInc.Resolution())
+	require.Len(t, reopenedInc.AuditTrail(), 3)
+	assert.Equal(t, incident.ActionAccept, reopenedInc.AuditTrail()[0].Action)
+	assert.Equal(t, incident.ActionReopen, reopenedInc.AuditTrail()[2].Action)
+	assert.Equal(t, basicUserID, reopenedInc.AuditTrail()[2].ActorID)
+	assert.Equal(t, "disk failed again", reopenedInc.AuditTrail()[2].Note)
+
+	// Resolve again and let the auto close period expire
+	err = svc.Resolve(ctx, channelID, actorUser, incID, resolveParams, clock)
+	require.NoError(t, err)
+
+	n, err := svc.AutoCloseResolved(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "auto close period has not expired yet")
+
+	clock.AddTime(autoClosePeriod)
+
+	// reading the incident does not close it
+	resolvedAgainInc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.StateResolved, resolvedAgainInc.State())
+
+	n, err = svc.AutoCloseResolved(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	closedInc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.StateClosed, closedInc.State())
+	assert.Equal(t, resolvedAgainInc.Version()+1, closedInc.Version())
+	require.Len(t, closedInc.AuditTrail(), 5)
+	assert.Equal(t, incident.ActionClose, closedInc.AuditTrail()[4].Action)
+	assert.True(t, closedInc.AuditTrail()[4].ActorID.IsZero())
+
+	historyList, err := incidentRepository.ListIncidentHistory(ctx, channelID, incID, 1, 100)
+	require.NoError(t, err)
+	lastEntry := historyList.Result[len(historyList.Result)-1]
+	assert.Equal(t, history.ActionAutoClose, lastEntry.Action)
+
+	// closed incident is not closed again
+	n, err = svc.AutoCloseResolved(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// closed incident cannot be reopened
+	err = svc.Reopen(ctx, channelID, actorUser, incID, api.IncidentReopenParams{Reason: "disk failed again"}, clock)
+	require.Error(t, err)
+	assert.EqualError(t, err, "ticket can be reopened only in Resolved state")
+}
+
+// staleIncidentRepository lists the resolved incident with the given ID in its outdated version
+type staleIncidentRepository struct {
+	repository.IncidentRepository
+	staleID ref.UUID
+}
+
+func (r staleIncidentRepository) ListResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]repository.ChannelIncident, error) {
+	resolved, err := r.IncidentRepository.ListResolvedIncidents(ctx, resolvedBefore, limit)
+	for i := range resolved {
+		if resolved[i].Incident.UUID() == r.staleID {
+			resolved[i].Incident.SetVersion(resolved[i].Incident.Version() - 1)
+		}
+	}
+	return resolved, err
+}
+
+func Test_incidentService_AutoCloseResolved_SkipsFailingIncident(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+
+	autoClosePeriod := 72 * time.Hour
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+
+	// add resolved incidents
+	var incIDs []ref.UUID
+	for _, number := range []string{"ABC1", "ABC2", "ABC3"} {
+		inc := incident.Incident{Number: number}
+		require.NoError(t, inc.RestoreState(incident.StateResolved))
+		inc.SetResolution(&incident.Resolution{Code: incident.ResolutionCodeSolvedPermanently, ResolvedAt: clock.NowFormatted()})
+		require.NoError(t, inc.CreatedUpdated.SetCreatedBy(basicUser))
+		require.NoError(t, inc.CreatedUpdated.SetUpdatedBy(basicUser))
+
+		incID, err := incidentRepository.AddIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		storedInc, err := incidentRepository.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		storedInc.SetResolution(inc.Resolution())
+		_, err = incidentRepository.UpdateIncident(ctx, channelID, storedInc)
+		require.NoError(t, err)
+
+		incIDs = append(incIDs, incID)
+	}
+
+	// the second incident was modified concurrently
+	staleRepository := staleIncidentRepository{IncidentRepository: incidentRepository, staleID: incIDs[1]}
+	svc := NewIncidentService(staleRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, autoClosePeriod)
+
+	clock.AddTime(autoClosePeriod)
+
+	n, err := svc.AutoCloseResolved(ctx, 10)
+	assert.Equal(t, 2, n)
+	require.Error(t, err)
+
+	var autoCloseErr *AutoCloseError
+	require.ErrorAs(t, err, &autoCloseErr)
+	require.Len(t, autoCloseErr.Failures, 1)
+	assert.Equal(t, channelID, autoCloseErr.Failures[0].ChannelID)
+	assert.Equal(t, incIDs[1], autoCloseErr.Failures[0].IncidentID)
+	var dErr *domain.Error
+	require.ErrorAs(t, autoCloseErr.Failures[0].Err, &dErr)
+	assert.Equal(t, domain.ErrorCodeVersionMismatch, dErr.Code())
+
+	for i, expectedState := range []incident.State{incident.StateClosed, incident.StateResolved, incident.StateClosed} {
+		inc, err := svc.GetIncident(ctx, channelID, actorUser, incIDs[i])
+		require.NoError(t, err)
+		assert.Equal(t, expectedState, inc.State(), "state of incident %d", i+1)
+	}
+}
+
+func Test_incidentService_TimelogCorrection(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	dispatcherUser := user.BasicUser{
+		ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+		Name:             "Alois",
+		Surname:          "Vomacka",
+		OrgDisplayName:   "CGI",
+		OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	dispatcherUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, dispatcherUser)
+	require.NoError(t, err)
+	err = dispatcherUser.SetUUID(dispatcherUserID)
+	require.NoError(t, err)
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+	err = fe.CreatedUpdated.SetCreatedBy(basicUser)
+	require.NoError(t, err)
+	err = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+	require.NoError(t, err)
+	feID, err := fieldEngineerRepository.AddFieldEngineer(ctx, channelID, fe)
+	require.NoError(t, err)
+
+	feActor := actor.Actor{BasicUser: basicUser}
+	feActor.SetFieldEngineerID(&feID)
+
+	dispatcherActor := actor.Actor{BasicUser: dispatcherUser}
+	dispatcherActor.SetDispatcher(true)
+
+	feUUID := api.UUID(feID)
+	incID, err := svc.CreateIncident(ctx, channelID, feActor, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+		FieldEngineerID:  &feUUID,
+	})
+	require.NoError(t, err)
+
+	err = svc.Accept(ctx, channelID, feActor, incID, clock)
+	require.NoError(t, err)
+
+	// work for one hour
+	start := clock.NowFormatted()
+	err = svc.StartWorking(ctx, channelID, feActor, incID, api.IncidentStartWorkingParams{}, clock)
+	require.NoError(t, err)
+	clock.AddTime(1 * time.Hour)
+	end := clock.NowFormatted()
+	err = svc.StopWorking(ctx, channelID, feActor, incID, api.IncidentStopWorkingParams{VisitSummary: "disk replaced"}, clock)
+	require.NoError(t, err)
+
+	inc, err := svc.GetIncident(ctx, channelID, feActor, incID)
+	require.NoError(t, err)
+	timelogID := inc.Timelogs[0]
+
+	// field engineer actually started working 30 minutes later
+	startTime, err := start.ToTime()
+	require.NoError(t, err)
+	correctionParams := api.ProposeTimelogCorrectionParams{
+		Start:        startTime.Add(30 * time.Minute).Format(time.RFC3339),
+		End:          end.String(),
+		VisitSummary: "disk replaced",
+		Reason:       "started working later",
+	}
+
+	// only field engineer can propose correction
+	err = svc.ProposeTimelogCorrection(ctx, channelID, dispatcherActor, incID, timelogID, correctionParams, clock)
+	require.Error(t, err)
+
+	err = svc.ProposeTimelogCorrection(ctx, channelID, feActor, incID, timelogID, correctionParams, clock)
+	require.NoError(t, err)
+
+	tmlg, err := svc.GetIncidentTimelog(ctx, channelID, feActor, incID, timelogID)
+	require.NoError(t, err)
+	require.NotNil(t, tmlg.PendingCorrection())
+	assert.Equal(t, uint(3600), tmlg.Work, "proposed correction is not applied")
+
+	// only dispatcher can approve correction
+	err = svc.ApproveTimelogCorrection(ctx, channelID, feActor, incID, timelogID, clock)
+	require.Error(t, err)
+
+	err = svc.ApproveTimelogCorrection(ctx, channelID, dispatcherActor, incID, timelogID, clock)
+	require.NoError(t, err)
+
+	tmlg, err = svc.GetIncidentTimelog(ctx, channelID, feActor, incID, timelogID)
+	require.NoError(t, err)
+	assert.Nil(t, tmlg.PendingCorrection())
+	assert.Equal(t, uint(1800), tmlg.Work)
+	assert.Equal(t, types.DateTime(correctionParams.Start), tmlg.Start)
+	require.NotNil(t, tmlg.Original)
+	assert.Equal(t, uint(3600), tmlg.Original.Work)
+	assert.Equal(t, start, tmlg.Original.Start)
+
+	// rejected correction does not change the timelog
+	correctionParams.Remote = true
+	err = svc.ProposeTimelogCorrection(ctx, channelID, feActor, incID, timelogID, correctionParams, clock)
+	require.NoError(t, err)
+
+	err = svc.RejectTimelogCorrection(ctx, channelID, dispatcherActor, incID, timelogID, api.RejectTimelogCorrectionParams{Note: "visit was not remote"}, clock)
+	require.NoError(t, err)
+
+	tmlg, err = svc.GetIncidentTimelog(ctx, channelID, feActor, incID, timelogID)
+	require.NoError(t, err)
+	assert.False(t, tmlg.Remote)
+	require.Len(t, tmlg.Corrections, 2)
+	assert.Equal(t, "visit was not remote", tmlg.Corrections[1].DecisionNote)
+}
+
+func Test_incidentService_SLA(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	slaRepository := memory.NewSLARepositoryMemory(clock, basicUserRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, slaRepository,
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	def := sla.Definition{
+		Targets: map[sla.Priority]sla.Target{
+			sla.PriorityModerate: {Response: time.Hour, Resolution: 8 * time.Hour},
+			sla.PriorityCritical: {Response: 15 * time.Minute, Resolution: 12 * time.Hour},
+		},
+		DefaultPriority: sla.PriorityModerate,
+	}
+	require.NoError(t, def.CreatedUpdated.SetCreatedBy(basicUser))
+	require.NoError(t, def.CreatedUpdated.SetUpdatedBy(basicUser))
+	require.NoError(t, slaRepository.SetSLADefinition(ctx, channelID, def))
+
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+	})
+	require.NoError(t, err)
+
+	inc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	require.NotNil(t, inc.SLA())
+	assert.Equal(t, sla.PriorityModerate, inc.SLA().Priority)
+	assert.Equal(t, sla.TimerStateRunning, inc.SLA().Response.State())
+	assert.Equal(t, sla.TimerStateRunning, inc.SLA().Resolution.State())
+
+	// timers are paused while the incident is on hold
+	clock.AddTime(20 * time.Minute)
+	err = svc.PutOnHold(ctx, channelID, actorUser, incID, api.IncidentPutOnHoldParams{Reason: "awaiting caller"}, clock)
+	require.NoError(t, err)
+
+	clock.AddTime(2 * time.Hour)
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	status, err := inc.SLA().Status(clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, sla.TimerStatePaused, status.Response.State)
+	assert.Equal(t, 20*time.Minute, status.Response.Elapsed)
+	assert.Equal(t, sla.TimerStatePaused, status.Resolution.State)
+	assert.Equal(t, 20*time.Minute, status.Resolution.Elapsed)
+	assert.True(t, status.Resolution.DueAt.IsZero())
+
+	// response timer is stopped when the incident is taken over, resolution timer is resumed
+	err = svc.Resume(ctx, channelID, actorUser, incID, clock)
+	require.NoError(t, err)
+
+	clock.AddTime(30 * time.Minute)
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	status, err = inc.SLA().Status(clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, sla.TimerStateStopped, status.Response.State)
+	assert.Equal(t, 40*time.Minute, status.Response.Remaining)
+	assert.False(t, status.Response.Breached)
+	assert.Equal(t, sla.TimerStateRunning, status.Resolution.State)
+	assert.Equal(t, 50*time.Minute, status.Resolution.Elapsed)
+	assert.Equal(t, 7*time.Hour+10*time.Minute, status.Resolution.Remaining)
+	assert.Equal(t, types.DateTime(clock.Now().Add(7*time.Hour+10*time.Minute).Format(time.RFC3339)), status.Resolution.DueAt)
+
+	// resolution target is breached
+	clock.AddTime(8 * time.Hour)
+	status, err = inc.SLA().Status(clock.Now())
+	require.NoError(t, err)
+	assert.True(t, status.Resolution.Breached)
+	assert.Equal(t, -(time.Hour - 10*time.Minute), status.Resolution.Remaining)
+	assert.True(t, status.Resolution.DueAt.IsZero())
+
+	// targets are selected again when the priority changes, measured time is kept
+	critical := "critical"
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription:       inc.ShortDescription,
+		Priority:               &critical,
+		PriorityOverrideReason: "customer escalation",
+	})
+	require.NoError(t, err)
+
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, sla.PriorityCritical, inc.SLA().Priority)
+	status, err = inc.SLA().Status(clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, status.Response.Target)
+	assert.Equal(t, 20*time.Minute, status.Response.Elapsed)
+	assert.True(t, status.Response.Breached)
+	assert.Equal(t, 12*time.Hour, status.Resolution.Target)
+	assert.Equal(t, 8*time.Hour+50*time.Minute, status.Resolution.Elapsed)
+	assert.False(t, status.Resolution.Breached)
+
+	// resetting the priority selects the targets of the derived priority
+	reset := ""
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription: inc.ShortDescription,
+		Priority:         &reset,
+	})
+	require.NoError(t, err)
+
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, sla.PriorityModerate, inc.SLA().Priority)
+	assert.Equal(t, 8*time.Hour, inc.SLA().Resolution.Target)
+
+	// channel without SLA definition does not measure SLA
+	svcWithoutSLA := NewIncidentService(incidentRepository, fieldEngineerRepository, memory.NewSLARepositoryMemory(clock, basicUserRepository),
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+	otherIncID, err := svcWithoutSLA.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "DEF456",
+		ShortDescription: "Some incident 2",
+	})
+	require.NoError(t, err)
+
+	otherInc, err := svcWithoutSLA.GetIncident(ctx, channelID, actorUser, otherIncID)
+	require.NoError(t, err)
+	assert.Nil(t, otherInc.SLA())
+}
+
+func Test_incidentService_Priority(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := memory.NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := memory.NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	slaRepository := memory.NewSLARepositoryMemory(clock, basicUserRepository)
+	unitOfWork := memory.NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+	svc := NewIncidentService(incidentRepository, fieldEngineerRepository, slaRepository,
+		memory.NewPriorityMatrixRepositoryMemory(clock, basicUserRepository), unitOfWork, clock, 0)
+
+	// channel without its own priority matrix uses the default one
+	matrix, err := svc.GetPriorityMatrix(ctx, channelID, actorUser)
+	require.NoError(t, err)
+	assert.Equal(t, incident.DefaultPriorityMatrix(), matrix)
+
+	incID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "ABC123",
+		ShortDescription: "Some incident 1",
+	})
+	require.NoError(t, err)
+
+	inc, err := svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.ImpactMedium, inc.Impact())
+	assert.Equal(t, incident.UrgencyMedium, inc.Urgency())
+	assert.Equal(t, sla.PriorityModerate, inc.Priority())
+
+	_, err = svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "DEF456",
+		ShortDescription: "Some incident 2",
+		Impact:           "huge",
+	})
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid impact: unknown 'huge' impact")
+
+	// incomplete matrix is rejected
+	err = svc.SetPriorityMatrix(ctx, channelID, actorUser, api.SetPriorityMatrixParams{
+		Priorities: []api.PriorityMatrixEntry{{Impact: "high", Urgency: "high", Priority: "critical"}},
+	})
+	require.Error(t, err)
+	assert.EqualError(t, err, "priority matrix does not have priority for 'high' impact and 'medium' urgency")
+
+	var entries []api.PriorityMatrixEntry
+	for _, impact := range incident.Impacts() {
+		for _, urgency := range incident.Urgencies() {
+			entries = append(entries, api.PriorityMatrixEntry{Impact: impact.String(), Urgency: urgency.String(), Priority: "low"})
+		}
+	}
+	entries[0].Priority = "critical" // high impact and high urgency
+
+	err = svc.SetPriorityMatrix(ctx, channelID, actorUser, api.SetPriorityMatrixParams{
+		Priorities: append(entries, api.PriorityMatrixEntry{Impact: "low", Urgency: "low", Priority: "high"}),
+	})
+	require.Error(t, err)
+	assert.EqualError(t, err, "duplicate priority for 'low' impact and 'low' urgency")
+
+	err = svc.SetPriorityMatrix(ctx, channelID, actorUser, api.SetPriorityMatrixParams{Priorities: entries})
+	require.NoError(t, err)
+
+	matrix, err = svc.GetPriorityMatrix(ctx, channelID, actorUser)
+	require.NoError(t, err)
+	assert.Equal(t, sla.PriorityCritical, matrix.PriorityFor(incident.ImpactHigh, incident.UrgencyHigh))
+	assert.Equal(t, sla.PriorityLow, matrix.PriorityFor(incident.ImpactMedium, incident.UrgencyMedium))
+	assert.Equal(t, basicUser.UUID(), matrix.CreatedUpdated.CreatedByID())
+
+	// priority of the existing incident is not changed by the new matrix until its impact or urgency is changed
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, sla.PriorityModerate, inc.Priority())
+
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription: "Some incident 1",
+		Impact:           "high",
+		Urgency:          "high",
+	})
+	require.NoError(t, err)
+
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, incident.ImpactHigh, inc.Impact())
+	assert.Equal(t, incident.UrgencyHigh, inc.Urgency())
+	assert.Equal(t, sla.PriorityCritical, inc.Priority())
+
+	// override
+	priority := "low"
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription: "Some incident 1",
+		Priority:         &priority,
+	})
+	require.Error(t, err)
+	assert.EqualError(t, err, "priority override reason is required")
+
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription:       "Some incident 1",
+		Priority:               &priority,
+		PriorityOverrideReason: "false alarm",
+	})
+	require.NoError(t, err)
+
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, sla.PriorityLow, inc.Priority())
+	assert.True(t, inc.PriorityOverridden())
+	require.Len(t, inc.AuditTrail(), 1)
+	assert.Equal(t, incident.ActionOverridePriority, inc.AuditTrail()[0].Action)
+	assert.Equal(t, "critical -> low: false alarm", inc.AuditTrail()[0].Note)
+
+	// reset
+	priority = ""
+	_, err = svc.UpdateIncident(ctx, channelID, actorUser, incID, api.UpdateIncidentParams{
+		ShortDescription: "Some incident 1",
+		Priority:         &priority,
+	})
+	require.NoError(t, err)
+
+	inc, err = svc.GetIncident(ctx, channelID, actorUser, incID)
+	require.NoError(t, err)
+	assert.Equal(t, sla.PriorityCritical, inc.Priority())
+	assert.False(t, inc.PriorityOverridden())
+	require.Len(t, inc.AuditTrail(), 2)
+	assert.Equal(t, incident.ActionResetPriority, inc.AuditTrail()[1].Action)
+
+	// new incident selects the SLA targets by its priority
+	def := sla.Definition{
+		Targets: map[sla.Priority]sla.Target{
+			sla.PriorityCritical: {Response: 15 * time.Minute, Resolution: 4 * time.Hour},
+			sla.PriorityLow:      {Response: 4 * time.Hour, Resolution: 40 * time.Hour},
+		},
+		DefaultPriority: sla.PriorityLow,
+	}
+	require.NoError(t, def.CreatedUpdated.SetCreatedBy(basicUser))
+	require.NoError(t, def.CreatedUpdated.SetUpdatedBy(basicUser))
+	require.NoError(t, slaRepository.SetSLADefinition(ctx, channelID, def))
+
+	otherIncID, err := svc.CreateIncident(ctx, channelID, actorUser, api.CreateIncidentParams{
+		Number:           "GHI789",
+		ShortDescription: "Some incident 3",
+		Impact:           "high",
+		Urgency:          "high",
+	})
+	require.NoError(t, err)
+
+	otherInc, err := svc.GetIncident(ctx, channelID, actorUser, otherIncID)
+	require.NoError(t, err)
+	require.NotNil(t, otherInc.SLA())
+	assert.Equal(t, sla.PriorityCritical, otherInc.SLA().Priority)
 }
@@ -24,8 +24,14 @@ type IncidentService interface {
 	// GetIncident returns the incident with the given ID from the repository
 	GetIncident(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (incident.Incident, error)
 
-	// ListIncidents returns the list of incidents from the repository
-	ListIncidents(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams converters.PaginationParams) (repository.IncidentList, error)
+	// ListIncidents returns the list of incidents matching the filter from the repository, the page is selected by the page number or by the cursor
+	ListIncidents(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, filter repository.IncidentFilter, paginationParams converters.PaginationParams) (repository.IncidentList, error)
+
+	// Accept is used by actor (assigned field engineer) to accept the incident, the work on the incident cannot be started until it is accepted
+	Accept(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error
+
+	// Reject is used by actor (assigned field engineer) to reject the incident, the field engineer is unassigned from the incident
+	Reject(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentRejectParams, clock domain.Clock) error
 
 	// StartWorking is used by actor (field engineer) to start working on the incident
 	StartWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentStartWorkingParams, clock domain.Clock) error
@@ -33,6 +39,54 @@ type IncidentService interface {
 	// StopWorking is used by actor (field engineer) to stop working on the incident
 	StopWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentStopWorkingParams, clock domain.Clock) error
 
+	// PauseWorking is used by actor (field engineer) to pause the work on the incident without closing the open timelog,
+	// the break is started also in the open time session of the field engineer
+	PauseWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentPauseWorkingParams, clock domain.Clock) error
+
+	// ResumeWorking is used by actor (field engineer) to resume the paused work on the incident,
+	// the break in the open time session of the field engineer is ended too
+	ResumeWorking(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error
+
+	// PutOnHold puts the incident on hold (or to PreOnHold state if actor is field engineer)
+	PutOnHold(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentPutOnHoldParams, clock domain.Clock) error
+
+	// Resume moves the incident that is on hold back to InProgress state
+	Resume(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error
+
+	// Resolve resolves the incident
+	Resolve(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentResolveParams, clock domain.Clock) error
+
+	// Close closes the resolved incident
+	Close(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, clock domain.Clock) error
+
+	// Reopen moves the resolved incident back to InProgress state
+	Reopen(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentReopenParams, clock domain.Clock) error
+
+	// AutoCloseResolved closes up to limit incidents of all channels that have been in Resolved state for the auto close period
+	// and returns the number of closed incidents. Incidents that could not be closed are skipped and reported by *AutoCloseError.
+	AutoCloseResolved(ctx context.Context, limit uint) (int, error)
+
 	// GetIncidentTimelog returns the incident's timelog with the given ID from the repository
 	GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error)
+
+	// ListIncidentTimelogs returns the list of the incident's timelogs from the repository
+	ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, paginationParams converters.PaginationParams) (repository.TimelogList, error)
+
+	// ProposeTimelogCorrection is used by actor (field engineer) to propose correction of the closed timelog he created
+	ProposeTimelogCorrection(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, params api.ProposeTimelogCorrectionParams, clock domain.Clock) error
+
+	// ApproveTimelogCorrection is used by actor (dispatcher) to approve the proposed timelog correction
+	ApproveTimelogCorrection(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, clock domain.Clock) error
+
+	// RejectTimelogCorrection is used by actor (dispatcher) to reject the proposed timelog correction
+	RejectTimelogCorrection(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, params api.RejectTimelogCorrectionParams, clock domain.Clock) error
+
+	// ListIncidentHistory returns the list of the incident's history entries (changes made to the incident) from the repository
+	ListIncidentHistory(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, paginationParams converters.PaginationParams) (repository.HistoryList, error)
+
+	// GetPriorityMatrix returns the priority matrix of the channel, the default matrix is returned if the channel does not have its own
+	GetPriorityMatrix(ctx context.Context, channelID ref.ChannelID, actor actor.Actor) (incident.PriorityMatrix, error)
+
+	// SetPriorityMatrix sets the priority matrix of the channel, priorities of the existing incidents are not changed
+	SetPriorityMatrix(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.SetPriorityMatrixParams) error
 }
@@ -0,0 +1,45 @@
+package incidentsvc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+)
+
+type expectedVersionKeyType int
+
+var expectedVersionKey expectedVersionKeyType
+
+// WithExpectedVersions returns a copy of ctx carrying the incident versions the client expects to modify.
+// Incident modifications made with such context fail with domain.ErrorCodeVersionMismatch if the incident has none of the versions.
+func WithExpectedVersions(ctx context.Context, versions ...uint) context.Context {
+	return context.WithValue(ctx, expectedVersionKey, versions)
+}
+
+// ExpectedVersionsFromContext returns the expected incident versions stored in ctx, if any
+func ExpectedVersionsFromContext(ctx context.Context) ([]uint, bool) {
+	versions, ok := ctx.Value(expectedVersionKey).([]uint)
+	return versions, ok
+}
+
+// checkExpectedVersion returns error if ctx carries expected versions and the incident has none of them
+func checkExpectedVersion(ctx context.Context, inc incident.Incident) error {
+	expectedVersions, ok := ExpectedVersionsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	expected := make([]string, 0, len(expectedVersions))
+	for _, version := range expectedVersions {
+		if version == inc.Version() {
+			return nil
+		}
+		expected = append(expected, strconv.FormatUint(uint64(version), 10))
+	}
+
+	return domain.NewErrorf(domain.ErrorCodeVersionMismatch,
+		"incident '%s' was modified (version %d, expected version %s)", inc.UUID(), inc.Version(), strings.Join(expected, ", "))
+}
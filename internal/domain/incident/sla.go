@@ -0,0 +1,66 @@
+package incident
+
+import (
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+)
+
+// SLA returns the SLA timers of the incident or nil pointer if the channel did not have SLA defined when the incident was created
+func (e Incident) SLA() *sla.SLA {
+	return e.sla
+}
+
+// SetSLA sets the SLA timers (do not use in the domain, method is used by repository)
+func (e *Incident) SetSLA(s *sla.SLA) {
+	e.sla = s
+}
+
+// StartSLA starts the SLA timers with the targets of the channel's SLA definition selected by the incident's priority
+// (the targets of the definition's default priority are used if the incident does not have priority or the definition
+// does not have targets for it). The targets must be selected again by RetargetSLA if the priority changes later.
+func (e *Incident) StartSLA(clock domain.Clock, def sla.Definition) error {
+	s, err := sla.New(def, e.priority, clock.Now())
+	if err != nil {
+		return err
+	}
+
+	e.sla = s
+	return e.UpdateSLA(clock)
+}
+
+// RetargetSLA selects the SLA targets by the current priority of the incident from the channel's SLA definition,
+// the time measured so far is kept. It must be called whenever the priority changes.
+func (e *Incident) RetargetSLA(def sla.Definition) {
+	if e.sla == nil {
+		return
+	}
+
+	e.sla.Retarget(def, e.priority)
+}
+
+// UpdateSLA starts, pauses or stops the SLA timers according to the current state of the incident.
+// It must be called whenever the state changes (ie. before the incident is saved).
+//
+// Response timer runs in New state, it is paused in OnHold state and it is stopped when the incident is taken over
+// (ie. moved to any other state). Resolution timer is paused in OnHold state and it is stopped when the incident
+// is resolved, closed or cancelled; it is resumed if the incident is reopened.
+func (e *Incident) UpdateSLA(clock domain.Clock) error {
+	if e.sla == nil {
+		return nil
+	}
+
+	response, resolution := sla.TimerStateStopped, sla.TimerStateRunning
+	switch e.state {
+	case StateNew:
+		response = sla.TimerStateRunning
+	case StateOnHold:
+		if e.sla.Response.State() != sla.TimerStateStopped {
+			response = sla.TimerStatePaused
+		}
+		resolution = sla.TimerStatePaused
+	case StateResolved, StateClosed, StateCancelled:
+		resolution = sla.TimerStateStopped
+	}
+
+	return e.sla.Update(clock.Now(), response, resolution)
+}
@@ -0,0 +1,87 @@
+package incident
+
+import (
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+)
+
+// transitionGuard checks whether the actor is allowed to perform the state transition
+type transitionGuard func(e Incident, actor actor.Actor) error
+
+// stateTransitions is the incident state machine definition.
+// It maps current state to the states the incident can be moved to and to the guard that checks the acting user.
+var stateTransitions = map[State]map[State]transitionGuard{
+	State{}: { // zero state => newly created incident
+		StateNew: anyActor,
+	},
+	StateNew: {
+		StatePreOnHold:  actorIsAssignedFieldEngineer,
+		StateInProgress: actorIsAssignedFieldEngineer,
+		StateOnHold:     actorIsNotFieldEngineer,
+		StateCancelled:  actorIsNotOtherFieldEngineer,
+	},
+	StatePreOnHold: {
+		StateInProgress: actorIsNotOtherFieldEngineer,
+		StateOnHold:     actorIsNotFieldEngineer,
+	},
+	StateInProgress: {
+		StatePreOnHold: actorIsAssignedFieldEngineer,
+		StateOnHold:    actorIsNotFieldEngineer,
+		StateResolved:  actorIsNotOtherFieldEngineer,
+	},
+	StateOnHold: {
+		StateInProgress: actorIsNotOtherFieldEngineer,
+	},
+	StateResolved: {
+		StateInProgress: actorIsNotOtherFieldEngineer,
+		StateClosed:     actorIsNotOtherFieldEngineer,
+	},
+	StateClosed:    {},
+	StateCancelled: {},
+}
+
+// canChangeState returns error if the incident cannot be moved to the given state by the actor
+func (e Incident) canChangeState(actor actor.Actor, s State) error {
+	if s == e.state {
+		return nil
+	}
+
+	guard, ok := stateTransitions[e.state][s]
+	if !ok {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket cannot be moved from '%s' to '%s' state", e.state, s)
+	}
+
+	return guard(e, actor)
+}
+
+func anyActor(_ Incident, _ actor.Actor) error {
+	return nil
+}
+
+func actorIsAssignedFieldEngineer(e Incident, actor actor.Actor) error {
+	if !actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not field engineer, only assigned field engineer can change ticket state")
+	}
+
+	if e.FieldEngineerID == nil || *actor.FieldEngineerID() != *e.FieldEngineerID {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not assigned as field engineer, only assigned field engineer can change ticket state")
+	}
+
+	return nil
+}
+
+func actorIsNotFieldEngineer(_ Incident, actor actor.Actor) error {
+	if actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer cannot change ticket state")
+	}
+
+	return nil
+}
+
+func actorIsNotOtherFieldEngineer(e Incident, actor actor.Actor) error {
+	if !actor.IsFieldEngineer() {
+		return nil
+	}
+
+	return actorIsAssignedFieldEngineer(e, actor)
+}
@@ -0,0 +1,97 @@
+package timelog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// CorrectionStatus values
+var (
+	CorrectionStatusProposed = CorrectionStatus{"proposed"}
+	CorrectionStatusApproved = CorrectionStatus{"approved"}
+	CorrectionStatusRejected = CorrectionStatus{"rejected"}
+)
+
+var correctionStatusValues = []CorrectionStatus{
+	CorrectionStatusProposed,
+	CorrectionStatusApproved,
+	CorrectionStatusRejected,
+}
+
+// CorrectionStatus is a status of the timelog correction. It is enum.
+// swagger:strfmt string
+type CorrectionStatus struct {
+	v string
+}
+
+// NewCorrectionStatusFromString creates new instance from string value
+func NewCorrectionStatusFromString(statusStr string) (CorrectionStatus, error) {
+	for _, s := range correctionStatusValues {
+		if s.String() == statusStr {
+			return s, nil
+		}
+	}
+	return CorrectionStatus{}, fmt.Errorf("unknown '%s' correction status", statusStr)
+}
+
+// IsZero returns true if CorrectionStatus has zero value
+func (s CorrectionStatus) IsZero() bool {
+	return s == CorrectionStatus{}
+}
+
+func (s CorrectionStatus) String() string {
+	return s.v
+}
+
+// MarshalJSON returns JSON encoded CorrectionStatus
+func (s CorrectionStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Correction is a change of the closed timelog proposed by the field engineer.
+// Proposed values are applied to the timelog only after the correction is approved by the dispatcher.
+type Correction struct {
+	Remote bool
+
+	Start types.DateTime
+
+	End types.DateTime
+
+	VisitSummary string
+
+	// Reason why the correction was proposed
+	Reason string
+
+	Status CorrectionStatus
+
+	// ID of the basic user who proposed the correction
+	ProposedBy ref.UUID
+
+	ProposedAt types.DateTime
+
+	// ID of the basic user who approved or rejected the correction, it is zero while the correction is proposed
+	DecidedBy ref.UUID
+
+	DecidedAt types.DateTime
+
+	// Note of the dispatcher (ie. why the correction was rejected)
+	DecisionNote string
+}
+
+// OriginalValues are the values of the timelog as it was closed by the field engineer, before any correction was applied
+type OriginalValues struct {
+	Remote bool
+
+	Start types.DateTime
+
+	End types.DateTime
+
+	Work uint
+
+	Timespans []Timespan
+
+	VisitSummary string
+}
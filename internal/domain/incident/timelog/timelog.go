@@ -3,6 +3,10 @@ package timelog
 import (
 	"fmt"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/embedded"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 )
@@ -17,13 +21,30 @@ type Timelog struct {
 
 	End types.DateTime
 
+	// Time spent working in seconds (sum of all work timespans)
 	Work uint
 
+	// Timespans the timelog consists of (ie. work, break, work)
+	Timespans []Timespan
+
 	VisitSummary string
 
+	// Corrections proposed by the field engineer, approved ones are already applied to the timelog
+	Corrections []Correction
+
+	// Original values of the timelog, it is nil if no correction was approved
+	Original *OriginalValues
+
 	CreatedUpdated types.CreatedUpdated
 }
 
+// EmbeddedResources returns list of other objects that are 'embedded' in the timelog
+func (e Timelog) EmbeddedResources(actor actor.Actor) []embedded.Resource {
+	var resources []embedded.Resource
+	resources = append(resources, e.CreatedUpdated.EmbeddedResources()...)
+	return resources
+}
+
 // UUID getter
 func (e Timelog) UUID() ref.UUID {
 	return e.uuid
@@ -37,3 +58,96 @@ func (e *Timelog) SetUUID(v ref.UUID) error {
 	e.uuid = v
 	return nil
 }
+
+// OpenTimespan returns the timespan that was not ended yet or nil
+func (e *Timelog) OpenTimespan() *Timespan {
+	if len(e.Timespans) == 0 {
+		return nil
+	}
+
+	last := &e.Timespans[len(e.Timespans)-1]
+	if !last.IsOpen() {
+		return nil
+	}
+
+	return last
+}
+
+// StartTimespan ends the open timespan (if any) and starts new timespan of the given type
+func (e *Timelog) StartTimespan(clock domain.Clock, t TimespanType) error {
+	if t.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "timespan type cannot be empty")
+	}
+
+	if !e.End.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "timelog is already closed")
+	}
+
+	if open := e.OpenTimespan(); open != nil {
+		if open.Type == t {
+			return domain.NewErrorf(domain.ErrorCodeActionForbidden, "timelog already has an open '%s' timespan", t)
+		}
+		open.End = clock.NowFormatted()
+	}
+
+	e.Timespans = append(e.Timespans, Timespan{
+		Type:  t,
+		Start: clock.NowFormatted(),
+	})
+
+	return nil
+}
+
+// Close ends the open timespan, sets end time of the timelog and calculates the work
+func (e *Timelog) Close(clock domain.Clock, visitSummary string) error {
+	start, err := e.Start.ToTime()
+	if err != nil {
+		return err
+	}
+
+	if clock.Now().Before(start) {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "end time cannot be before start time")
+	}
+
+	// timelog without timespans is considered to be one work timespan
+	if len(e.Timespans) == 0 {
+		e.Timespans = append(e.Timespans, Timespan{
+			Type:  TimespanTypeWork,
+			Start: e.Start,
+		})
+	}
+
+	if open := e.OpenTimespan(); open != nil {
+		open.End = clock.NowFormatted()
+	}
+
+	e.End = clock.NowFormatted()
+
+	work, err := e.calculateWork()
+	if err != nil {
+		return err
+	}
+	e.Work = work
+
+	e.VisitSummary = visitSummary
+
+	return nil
+}
+
+// calculateWork returns sum of durations of all work timespans
+func (e Timelog) calculateWork() (uint, error) {
+	var work uint
+	for _, span := range e.Timespans {
+		if span.Type != TimespanTypeWork {
+			continue
+		}
+
+		d, err := span.Duration()
+		if err != nil {
+			return 0, err
+		}
+		work += d
+	}
+
+	return work, nil
+}
@@ -0,0 +1,247 @@
+package timelog
+
+import (
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+)
+
+// AllowedAction represents action that can be performed with the timelog
+type AllowedAction string
+
+func (a AllowedAction) String() string {
+	return string(a)
+}
+
+// AllowedActions values
+const (
+	ActionProposeCorrection AllowedAction = "ProposeCorrection"
+	ActionApproveCorrection AllowedAction = "ApproveCorrection"
+	ActionRejectCorrection  AllowedAction = "RejectCorrection"
+)
+
+// AllowedActions returns list of actions that can be performed with the timelog according to its state and the actor's role
+func (e Timelog) AllowedActions(actor actor.Actor) []string {
+	var acts []string
+	if err := e.canProposeCorrection(actor); err == nil {
+		acts = append(acts, ActionProposeCorrection.String())
+	}
+
+	if err := e.canDecideCorrection(actor); err == nil {
+		acts = append(acts, ActionApproveCorrection.String())
+		acts = append(acts, ActionRejectCorrection.String())
+	}
+
+	return acts
+}
+
+// PendingCorrection returns the correction waiting for approval or nil pointer
+func (e Timelog) PendingCorrection() *Correction {
+	for i := range e.Corrections {
+		if e.Corrections[i].Status == CorrectionStatusProposed {
+			return &e.Corrections[i]
+		}
+	}
+
+	return nil
+}
+
+// ProposeCorrection can be used by the field engineer who created the closed timelog to propose new values of the timelog.
+// Proposed values are not applied until the correction is approved by the dispatcher.
+func (e *Timelog) ProposeCorrection(actor actor.Actor, clock domain.Clock, correction Correction) error {
+	if err := e.canProposeCorrection(actor); err != nil {
+		return err
+	}
+
+	start, err := correction.Start.ToTime()
+	if err != nil {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "incorrect start time '%s'", correction.Start)
+	}
+
+	end, err := correction.End.ToTime()
+	if err != nil {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "incorrect end time '%s'", correction.End)
+	}
+
+	if !start.Before(end) {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "end time must be after start time")
+	}
+
+	if end.After(clock.Now()) {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "end time cannot be in the future")
+	}
+
+	if e.Remote == correction.Remote && e.VisitSummary == correction.VisitSummary && e.sameStartEnd(start, end) {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "correction does not change the timelog")
+	}
+
+	e.Corrections = append(e.Corrections, Correction{
+		Remote:       correction.Remote,
+		Start:        correction.Start,
+		End:          correction.End,
+		VisitSummary: correction.VisitSummary,
+		Reason:       correction.Reason,
+		Status:       CorrectionStatusProposed,
+		ProposedBy:   actor.BasicUser.UUID(),
+		ProposedAt:   clock.NowFormatted(),
+	})
+
+	return e.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+func (e Timelog) canProposeCorrection(actor actor.Actor) error {
+	if !actor.IsFieldEngineer() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not field engineer, only field engineer who created the timelog can propose its correction")
+	}
+
+	if e.CreatedUpdated.CreatedByID() != actor.BasicUser.UUID() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "only field engineer who created the timelog can propose its correction")
+	}
+
+	if e.End.IsZero() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "timelog is not closed yet")
+	}
+
+	if e.PendingCorrection() != nil {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "timelog already has a correction waiting for approval")
+	}
+
+	return nil
+}
+
+// ApproveCorrection can be used by the dispatcher to approve the pending correction. Corrected values replace the values
+// of the timelog (the original values are preserved), timespans are fitted to the corrected start and end time and the work is recalculated.
+func (e *Timelog) ApproveCorrection(actor actor.Actor, clock domain.Clock) error {
+	if err := e.canDecideCorrection(actor); err != nil {
+		return err
+	}
+
+	correction := e.PendingCorrection()
+
+	start, err := correction.Start.ToTime()
+	if err != nil {
+		return err
+	}
+
+	end, err := correction.End.ToTime()
+	if err != nil {
+		return err
+	}
+
+	timespans, err := fitTimespans(e.Timespans, correction.Start, start, correction.End, end)
+	if err != nil {
+		return err
+	}
+
+	if e.Original == nil {
+		e.Original = &OriginalValues{
+			Remote:       e.Remote,
+			Start:        e.Start,
+			End:          e.End,
+			Work:         e.Work,
+			Timespans:    e.Timespans,
+			VisitSummary: e.VisitSummary,
+		}
+	}
+
+	e.Remote = correction.Remote
+	e.Start = correction.Start
+	e.End = correction.End
+	e.VisitSummary = correction.VisitSummary
+	e.Timespans = timespans
+
+	work, err := e.calculateWork()
+	if err != nil {
+		return err
+	}
+	e.Work = work
+
+	correction.Status = CorrectionStatusApproved
+	correction.DecidedBy = actor.BasicUser.UUID()
+	correction.DecidedAt = clock.NowFormatted()
+
+	return e.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+// RejectCorrection can be used by the dispatcher to reject the pending correction, the timelog is not changed
+func (e *Timelog) RejectCorrection(actor actor.Actor, clock domain.Clock, note string) error {
+	if err := e.canDecideCorrection(actor); err != nil {
+		return err
+	}
+
+	correction := e.PendingCorrection()
+	correction.Status = CorrectionStatusRejected
+	correction.DecidedBy = actor.BasicUser.UUID()
+	correction.DecidedAt = clock.NowFormatted()
+	correction.DecisionNote = note
+
+	return e.CreatedUpdated.SetUpdatedBy(actor.BasicUser)
+}
+
+func (e Timelog) canDecideCorrection(actor actor.Actor) error {
+	if !actor.IsDispatcher() {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not dispatcher, only dispatcher can approve or reject timelog correction")
+	}
+
+	if e.PendingCorrection() == nil {
+		return domain.NewErrorf(domain.ErrorCodeActionForbidden, "timelog does not have a correction waiting for approval")
+	}
+
+	return nil
+}
+
+// sameStartEnd returns true if the timelog starts and ends at the given times
+func (e Timelog) sameStartEnd(start, end time.Time) bool {
+	currentStart, err := e.Start.ToTime()
+	if err != nil {
+		return false
+	}
+
+	currentEnd, err := e.End.ToTime()
+	if err != nil {
+		return false
+	}
+
+	return currentStart.Equal(start) && currentEnd.Equal(end)
+}
+
+// fitTimespans returns copy of the timespans cut to the new start and end time. Timespans outside of the new period are dropped,
+// the first and the last timespan are stretched to the new start and end time. If no timespan remains, the whole period is work.
+func fitTimespans(timespans []Timespan, startStr types.DateTime, start time.Time, endStr types.DateTime, end time.Time) ([]Timespan, error) {
+	var fitted []Timespan
+	for _, span := range timespans {
+		spanStart, err := span.Start.ToTime()
+		if err != nil {
+			return nil, err
+		}
+
+		spanEnd, err := span.End.ToTime()
+		if err != nil {
+			return nil, err
+		}
+
+		if !spanEnd.After(start) || !spanStart.Before(end) {
+			continue
+		}
+
+		if spanStart.Before(start) {
+			span.Start = startStr
+		}
+		if spanEnd.After(end) {
+			span.End = endStr
+		}
+
+		fitted = append(fitted, span)
+	}
+
+	if len(fitted) == 0 {
+		return []Timespan{{Type: TimespanTypeWork, Start: startStr, End: endStr}}, nil
+	}
+
+	fitted[0].Start = startStr
+	fitted[len(fitted)-1].End = endStr
+
+	return fitted, nil
+}
@@ -0,0 +1,342 @@
+package timelog_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// TestInit initializes test suite
+func TestInit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Timelog tests")
+}
+
+var _ = Describe("Timelog behavior", func() {
+	var tmlg Timelog
+	var clock *mocks.FixedClock
+
+	BeforeEach(func() {
+		clock = mocks.NewFixedClock()
+		tmlg = Timelog{
+			Start: clock.NowFormatted(),
+		}
+	})
+
+	Describe("StartTimespan()", func() {
+		It("should end the open timespan and start the new one", func() {
+			err := tmlg.StartTimespan(clock, TimespanTypeWork)
+			Expect(err).To(BeNil())
+			Expect(tmlg.OpenTimespan().Type).To(Equal(TimespanTypeWork))
+
+			clock.AddTime(10 * time.Minute)
+			err = tmlg.StartTimespan(clock, TimespanTypeBreak)
+			Expect(err).To(BeNil())
+
+			Expect(tmlg.Timespans).To(HaveLen(2))
+			Expect(tmlg.Timespans[0].End).To(Equal(clock.NowFormatted()))
+			Expect(tmlg.Timespans[0].Duration()).To(Equal(uint(600)))
+			Expect(tmlg.OpenTimespan().Type).To(Equal(TimespanTypeBreak))
+			Expect(tmlg.OpenTimespan().Duration()).To(BeZero())
+		})
+
+		When("timespan of the same type is already open", func() {
+			It("should return error", func() {
+				err := tmlg.StartTimespan(clock, TimespanTypeWork)
+				Expect(err).To(BeNil())
+
+				err = tmlg.StartTimespan(clock, TimespanTypeWork)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("timelog already has an open 'work' timespan"))
+			})
+		})
+
+		When("timelog is closed", func() {
+			It("should return error", func() {
+				err := tmlg.Close(clock, "summary")
+				Expect(err).To(BeNil())
+
+				err = tmlg.StartTimespan(clock, TimespanTypeWork)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("timelog is already closed"))
+			})
+		})
+	})
+
+	Describe("Close()", func() {
+		It("should sum durations of work timespans only", func() {
+			err := tmlg.StartTimespan(clock, TimespanTypeWork)
+			Expect(err).To(BeNil())
+			clock.AddTime(1 * time.Hour)
+			err = tmlg.StartTimespan(clock, TimespanTypeTravel)
+			Expect(err).To(BeNil())
+			clock.AddTime(1 * time.Hour)
+			err = tmlg.StartTimespan(clock, TimespanTypeWork)
+			Expect(err).To(BeNil())
+			clock.AddTime(30 * time.Minute)
+
+			err = tmlg.Close(clock, "summary")
+			Expect(err).To(BeNil())
+
+			Expect(tmlg.End).To(Equal(clock.NowFormatted()))
+			Expect(tmlg.Work).To(Equal(uint(5400)))
+			Expect(tmlg.VisitSummary).To(Equal("summary"))
+			Expect(tmlg.OpenTimespan()).To(BeNil())
+		})
+
+		When("timelog has no timespans", func() {
+			It("should consider the whole timelog as work", func() {
+				clock.AddTime(1 * time.Hour)
+
+				err := tmlg.Close(clock, "summary")
+				Expect(err).To(BeNil())
+
+				Expect(tmlg.Work).To(Equal(uint(3600)))
+				Expect(tmlg.Timespans).To(HaveLen(1))
+				Expect(tmlg.Timespans[0].Type).To(Equal(TimespanTypeWork))
+			})
+		})
+	})
+})
+
+var _ = Describe("Timelog corrections", func() {
+	var tmlg Timelog
+	var clock *mocks.FixedClock
+	var fieldEngineerActor, dispatcherActor actor.Actor
+	var correction Correction
+
+	dateTime := func(t time.Time) types.DateTime {
+		return types.DateTime(t.Format(time.RFC3339))
+	}
+
+	BeforeEach(func() {
+		clock = mocks.NewFixedClock()
+
+		feBasicUser := user.BasicUser{Name: "Alois", Surname: "Vomacka"}
+		err := feBasicUser.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+		Expect(err).To(BeNil())
+		fieldEngineerActor = actor.Actor{BasicUser: feBasicUser}
+		feUUID := ref.UUID("c546d4bb-2f45-411a-8583-9d0e6fe4807a")
+		fieldEngineerActor.SetFieldEngineerID(&feUUID)
+
+		dispatcherBasicUser := user.BasicUser{Name: "Alfred", Surname: "Koletschko"}
+		err = dispatcherBasicUser.SetUUID("8540d943-8ccd-4ff1-8a08-0c3aa338c58e")
+		Expect(err).To(BeNil())
+		dispatcherActor = actor.Actor{BasicUser: dispatcherBasicUser}
+		dispatcherActor.SetDispatcher(true)
+
+		// work 1 hour, break 30 minutes, work until the engineer forgot to stop working 5 hours later
+		start := clock.Now()
+		tmlg = Timelog{Start: dateTime(start)}
+		err = tmlg.CreatedUpdated.SetCreatedBy(feBasicUser)
+		Expect(err).To(BeNil())
+
+		clock.SetTime(start)
+		err = tmlg.StartTimespan(clock, TimespanTypeWork)
+		Expect(err).To(BeNil())
+		clock.SetTime(start.Add(1 * time.Hour))
+		err = tmlg.StartTimespan(clock, TimespanTypeBreak)
+		Expect(err).To(BeNil())
+		clock.SetTime(start.Add(90 * time.Minute))
+		err = tmlg.StartTimespan(clock, TimespanTypeWork)
+		Expect(err).To(BeNil())
+		clock.SetTime(start.Add(390 * time.Minute))
+		err = tmlg.Close(clock, "disk replaced")
+		Expect(err).To(BeNil())
+		Expect(tmlg.Work).To(Equal(uint(6 * 3600)))
+
+		clock.AddTime(24 * time.Hour)
+
+		correction = Correction{
+			Remote:       true,
+			Start:        dateTime(start),
+			End:          dateTime(start.Add(2 * time.Hour)),
+			VisitSummary: "disk replaced and tested",
+			Reason:       "forgot to stop working",
+		}
+	})
+
+	Describe("ProposeCorrection()", func() {
+		It("should add the proposed correction without changing the timelog", func() {
+			err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+			Expect(err).To(BeNil())
+
+			pending := tmlg.PendingCorrection()
+			Expect(pending).NotTo(BeNil())
+			Expect(pending.Status).To(Equal(CorrectionStatusProposed))
+			Expect(pending.ProposedBy).To(Equal(fieldEngineerActor.BasicUser.UUID()))
+			Expect(pending.ProposedAt).To(Equal(clock.NowFormatted()))
+			Expect(pending.Reason).To(Equal("forgot to stop working"))
+
+			Expect(tmlg.Work).To(Equal(uint(6 * 3600)))
+			Expect(tmlg.Remote).To(BeFalse())
+			Expect(tmlg.Original).To(BeNil())
+		})
+
+		When("actor did not create the timelog", func() {
+			It("should return error", func() {
+				err := tmlg.ProposeCorrection(dispatcherActor, clock, correction)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("user is not field engineer, only field engineer who created the timelog can propose its correction"))
+			})
+		})
+
+		When("timelog is not closed", func() {
+			It("should return error", func() {
+				tmlg.End = ""
+
+				err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("timelog is not closed yet"))
+			})
+		})
+
+		When("timelog already has pending correction", func() {
+			It("should return error", func() {
+				err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).To(BeNil())
+
+				err = tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("timelog already has a correction waiting for approval"))
+			})
+		})
+
+		When("end time is not after start time", func() {
+			It("should return error", func() {
+				correction.End = correction.Start
+
+				err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("end time must be after start time"))
+			})
+		})
+
+		When("end time is in the future", func() {
+			It("should return error", func() {
+				correction.End = dateTime(clock.Now().Add(time.Hour))
+
+				err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("end time cannot be in the future"))
+			})
+		})
+
+		When("correction does not change anything", func() {
+			It("should return error", func() {
+				correction = Correction{Start: tmlg.Start, End: tmlg.End, VisitSummary: tmlg.VisitSummary}
+
+				err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("correction does not change the timelog"))
+			})
+		})
+	})
+
+	Describe("ApproveCorrection()", func() {
+		It("should apply the correction and preserve the original values", func() {
+			original := tmlg
+
+			err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+			Expect(err).To(BeNil())
+
+			err = tmlg.ApproveCorrection(dispatcherActor, clock)
+			Expect(err).To(BeNil())
+
+			Expect(tmlg.PendingCorrection()).To(BeNil())
+			Expect(tmlg.Corrections).To(HaveLen(1))
+			Expect(tmlg.Corrections[0].Status).To(Equal(CorrectionStatusApproved))
+			Expect(tmlg.Corrections[0].DecidedBy).To(Equal(dispatcherActor.BasicUser.UUID()))
+			Expect(tmlg.Corrections[0].DecidedAt).To(Equal(clock.NowFormatted()))
+
+			Expect(tmlg.Remote).To(BeTrue())
+			Expect(tmlg.End).To(Equal(correction.End))
+			Expect(tmlg.VisitSummary).To(Equal("disk replaced and tested"))
+			Expect(tmlg.Timespans).To(HaveLen(3))
+			Expect(tmlg.Timespans[2].End).To(Equal(correction.End))
+			Expect(tmlg.Work).To(Equal(uint(90 * 60)))
+
+			Expect(tmlg.Original).NotTo(BeNil())
+			Expect(tmlg.Original.End).To(Equal(original.End))
+			Expect(tmlg.Original.Work).To(Equal(original.Work))
+			Expect(tmlg.Original.Timespans).To(Equal(original.Timespans))
+			Expect(tmlg.Original.VisitSummary).To(Equal(original.VisitSummary))
+		})
+
+		It("should keep the original values of the first approved correction", func() {
+			err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+			Expect(err).To(BeNil())
+			err = tmlg.ApproveCorrection(dispatcherActor, clock)
+			Expect(err).To(BeNil())
+			originalEnd := tmlg.Original.End
+
+			start, err := tmlg.Start.ToTime()
+			Expect(err).To(BeNil())
+			correction.End = dateTime(start.Add(30 * time.Minute))
+			err = tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+			Expect(err).To(BeNil())
+			err = tmlg.ApproveCorrection(dispatcherActor, clock)
+			Expect(err).To(BeNil())
+
+			Expect(tmlg.Original.End).To(Equal(originalEnd))
+			Expect(tmlg.Timespans).To(HaveLen(1))
+			Expect(tmlg.Work).To(Equal(uint(30 * 60)))
+		})
+
+		When("actor is not dispatcher", func() {
+			It("should return error", func() {
+				err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+				Expect(err).To(BeNil())
+
+				err = tmlg.ApproveCorrection(fieldEngineerActor, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("user is not dispatcher, only dispatcher can approve or reject timelog correction"))
+			})
+		})
+
+		When("timelog does not have pending correction", func() {
+			It("should return error", func() {
+				err := tmlg.ApproveCorrection(dispatcherActor, clock)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal("timelog does not have a correction waiting for approval"))
+			})
+		})
+	})
+
+	Describe("RejectCorrection()", func() {
+		It("should reject the correction without changing the timelog", func() {
+			err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+			Expect(err).To(BeNil())
+
+			err = tmlg.RejectCorrection(dispatcherActor, clock, "customer confirmed the original time")
+			Expect(err).To(BeNil())
+
+			Expect(tmlg.PendingCorrection()).To(BeNil())
+			Expect(tmlg.Corrections[0].Status).To(Equal(CorrectionStatusRejected))
+			Expect(tmlg.Corrections[0].DecisionNote).To(Equal("customer confirmed the original time"))
+			Expect(tmlg.Work).To(Equal(uint(6 * 3600)))
+			Expect(tmlg.Original).To(BeNil())
+		})
+	})
+
+	Describe("AllowedActions()", func() {
+		It("should allow field engineer to propose correction and dispatcher to decide it", func() {
+			Expect(tmlg.AllowedActions(fieldEngineerActor)).To(ConsistOf(ActionProposeCorrection.String()))
+			Expect(tmlg.AllowedActions(dispatcherActor)).To(BeEmpty())
+
+			err := tmlg.ProposeCorrection(fieldEngineerActor, clock, correction)
+			Expect(err).To(BeNil())
+
+			Expect(tmlg.AllowedActions(fieldEngineerActor)).To(BeEmpty())
+			Expect(tmlg.AllowedActions(dispatcherActor)).To(ConsistOf(ActionApproveCorrection.String(), ActionRejectCorrection.String()))
+		})
+	})
+})
@@ -1 +1,88 @@
 package timelog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// TimespanType values
+var (
+	TimespanTypeWork   = TimespanType{"work"}
+	TimespanTypeBreak  = TimespanType{"break"}
+	TimespanTypeTravel = TimespanType{"travel"}
+)
+
+var timespanTypeValues = []TimespanType{
+	TimespanTypeWork,
+	TimespanTypeBreak,
+	TimespanTypeTravel,
+}
+
+// TimespanType is a type of the timespan. It is enum.
+// swagger:strfmt string
+type TimespanType struct {
+	v string
+}
+
+// NewTimespanTypeFromString creates new instance from string value
+func NewTimespanTypeFromString(typeStr string) (TimespanType, error) {
+	for _, t := range timespanTypeValues {
+		if t.String() == typeStr {
+			return t, nil
+		}
+	}
+	return TimespanType{}, fmt.Errorf("unknown '%s' timespan type", typeStr)
+}
+
+// IsZero returns true if TimespanType has zero value
+func (t TimespanType) IsZero() bool {
+	return t == TimespanType{}
+}
+
+func (t TimespanType) String() string {
+	return t.v
+}
+
+// MarshalJSON returns JSON encoded TimespanType
+func (t TimespanType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// Timespan is a continuous period of time of one type (ie. work or break) in the timelog
+type Timespan struct {
+	Type TimespanType
+
+	Start types.DateTime
+
+	End types.DateTime
+}
+
+// IsOpen returns true if the timespan was not ended yet
+func (s Timespan) IsOpen() bool {
+	return s.End.IsZero()
+}
+
+// Duration returns length of the timespan in seconds, open timespan has zero duration
+func (s Timespan) Duration() (uint, error) {
+	if s.IsOpen() {
+		return 0, nil
+	}
+
+	start, err := s.Start.ToTime()
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := s.End.ToTime()
+	if err != nil {
+		return 0, err
+	}
+
+	if end.Before(start) {
+		return 0, fmt.Errorf("timespan: end time cannot be before start time")
+	}
+
+	return uint(end.Sub(start).Seconds()), nil
+}
@@ -0,0 +1,218 @@
+package sla
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+)
+
+const (
+	// clockFormat is the format of the start and end of the working hours ('24:00' is also accepted as the end of the day)
+	clockFormat = "15:04"
+
+	// endOfDay is the end of the working hours lasting until midnight
+	endOfDay = "24:00"
+
+	// dateFormat is the format of the holidays
+	dateFormat = "2006-01-02"
+
+	// maxCalendarDays limits the search for the business hours when the due time is calculated
+	maxCalendarDays = 5 * 366
+)
+
+// WorkingHours is the working interval of one day of the week, start and end are in 'HH:MM' format in the calendar location
+type WorkingHours struct {
+	Weekday time.Weekday
+
+	Start string
+
+	End string
+}
+
+// bounds returns the start and the end of the working hours in minutes since midnight
+func (wh WorkingHours) bounds() (int, int, error) {
+	start, err := time.Parse(clockFormat, wh.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	endMinutes := 24 * 60
+	if wh.End != endOfDay {
+		end, err := time.Parse(clockFormat, wh.End)
+		if err != nil {
+			return 0, 0, err
+		}
+		endMinutes = end.Hour()*60 + end.Minute()
+	}
+
+	return start.Hour()*60 + start.Minute(), endMinutes, nil
+}
+
+// Calendar defines the business hours the SLA timers run in. Calendar without any working hours means 24x7 service.
+type Calendar struct {
+	// IANA time zone name (ie. 'Europe/Prague') the working hours and holidays are in, empty value means UTC
+	Location string
+
+	WorkingHours []WorkingHours
+
+	// Dates in 'YYYY-MM-DD' format with no business hours
+	Holidays []string
+}
+
+// Validate returns error if the location is unknown, working hours overlap or they are not in correct format
+func (c Calendar) Validate() error {
+	if _, err := c.location(); err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid calendar location '%s'", c.Location)
+	}
+
+	byWeekday := make(map[time.Weekday][][2]int)
+	for _, wh := range c.WorkingHours {
+		if wh.Weekday < time.Sunday || wh.Weekday > time.Saturday {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "invalid weekday of the working hours")
+		}
+
+		start, end, err := wh.bounds()
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid working hours '%s-%s' on %s", wh.Start, wh.End, wh.Weekday)
+		}
+
+		if start >= end {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "working hours '%s-%s' on %s must end after they start", wh.Start, wh.End, wh.Weekday)
+		}
+
+		for _, other := range byWeekday[wh.Weekday] {
+			if start < other[1] && other[0] < end {
+				return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "working hours '%s-%s' on %s overlap other working hours", wh.Start, wh.End, wh.Weekday)
+			}
+		}
+		byWeekday[wh.Weekday] = append(byWeekday[wh.Weekday], [2]int{start, end})
+	}
+
+	for _, holiday := range c.Holidays {
+		if _, err := time.Parse(dateFormat, holiday); err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid holiday date '%s'", holiday)
+		}
+	}
+
+	return nil
+}
+
+// BusinessDuration returns the business time between from and to
+func (c Calendar) BusinessDuration(from, to time.Time) (time.Duration, error) {
+	if !to.After(from) {
+		return 0, nil
+	}
+
+	if len(c.WorkingHours) == 0 {
+		return to.Sub(from), nil
+	}
+
+	var d time.Duration
+	err := c.businessIntervals(from, func(start, end time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+
+		if end.After(to) {
+			end = to
+		}
+		d += end.Sub(start)
+
+		return true
+	})
+
+	return d, err
+}
+
+// AddBusinessDuration returns the time when the business time d elapses if it starts at from
+func (c Calendar) AddBusinessDuration(from time.Time, d time.Duration) (time.Time, error) {
+	if d <= 0 {
+		return from, nil
+	}
+
+	if len(c.WorkingHours) == 0 {
+		return from.Add(d), nil
+	}
+
+	var due time.Time
+	err := c.businessIntervals(from, func(start, end time.Time) bool {
+		if interval := end.Sub(start); interval < d {
+			d -= interval
+			return true
+		}
+
+		due = start.Add(d)
+		return false
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if due.IsZero() {
+		return time.Time{}, fmt.Errorf("calendar does not have any business hours within %d days", maxCalendarDays)
+	}
+
+	return due, nil
+}
+
+// businessIntervals calls fn with the business hours intervals following the from time (the first interval may be cut by it),
+// until fn returns false or maxCalendarDays are searched
+func (c Calendar) businessIntervals(from time.Time, fn func(start, end time.Time) bool) error {
+	loc, err := c.location()
+	if err != nil {
+		return err
+	}
+
+	holidays := make(map[string]bool, len(c.Holidays))
+	for _, holiday := range c.Holidays {
+		holidays[holiday] = true
+	}
+
+	byWeekday := make(map[time.Weekday][][2]int)
+	for _, wh := range c.WorkingHours {
+		start, end, err := wh.bounds()
+		if err != nil {
+			return err
+		}
+		byWeekday[wh.Weekday] = append(byWeekday[wh.Weekday], [2]int{start, end})
+	}
+	for _, intervals := range byWeekday {
+		sort.Slice(intervals, func(i, j int) bool {
+			return intervals[i][0] < intervals[j][0]
+		})
+	}
+
+	from = from.In(loc)
+	y, m, d := from.Date()
+	for i := 0; i < maxCalendarDays; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		if holidays[day.Format(dateFormat)] {
+			continue
+		}
+
+		for _, interval := range byWeekday[day.Weekday()] {
+			// minutes are normalized by time.Date, so the wall clock is correct also on the days the DST changes
+			start := time.Date(y, m, d+i, 0, interval[0], 0, 0, loc)
+			end := time.Date(y, m, d+i, 0, interval[1], 0, 0, loc)
+
+			if !end.After(from) {
+				continue
+			}
+			if start.Before(from) {
+				start = from
+			}
+
+			if !fn(start, end) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c Calendar) location() (*time.Location, error) {
+	return time.LoadLocation(c.Location)
+}
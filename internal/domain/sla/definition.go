@@ -0,0 +1,61 @@
+// Package sla contains service level agreements of the channels and the SLA timers of the incidents.
+// Timers measure the business time spent responding to and resolving the incident against the contracted targets.
+package sla
+
+import (
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// Target is the maximal business time allowed to respond to and to resolve the incident
+type Target struct {
+	Response time.Duration
+
+	Resolution time.Duration
+}
+
+// Definition of the channel's SLA. It is copied to each incident when the incident is created.
+type Definition struct {
+	// Targets by priority of the incident
+	Targets map[Priority]Target
+
+	// DefaultPriority selects the targets of the incidents without priority
+	DefaultPriority Priority
+
+	// Calendar defines the business hours the timers run in
+	Calendar Calendar
+
+	CreatedUpdated types.CreatedUpdated
+}
+
+// Validate returns error if the definition does not have target for the default priority, targets are not positive
+// or the calendar is not valid
+func (d Definition) Validate() error {
+	for priority, target := range d.Targets {
+		if priority.IsZero() {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "SLA target priority is required")
+		}
+
+		if target.Response < time.Minute || target.Resolution < time.Minute {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "SLA targets of '%s' priority must be at least one minute", priority)
+		}
+	}
+
+	if _, ok := d.Targets[d.DefaultPriority]; !ok {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "SLA definition does not have target for default priority '%s'", d.DefaultPriority)
+	}
+
+	return d.Calendar.Validate()
+}
+
+// TargetFor returns the target of the given priority, the target of the default priority is returned
+// if the priority is zero or the definition does not have target for it
+func (d Definition) TargetFor(priority Priority) (Priority, Target) {
+	if target, ok := d.Targets[priority]; ok {
+		return priority, target
+	}
+
+	return d.DefaultPriority, d.Targets[d.DefaultPriority]
+}
@@ -0,0 +1,56 @@
+package sla
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Priority values
+var (
+	PriorityCritical = Priority{"critical"}
+	PriorityHigh     = Priority{"high"}
+	PriorityModerate = Priority{"moderate"}
+	PriorityLow      = Priority{"low"}
+)
+
+var priorityValues = []Priority{
+	PriorityCritical,
+	PriorityHigh,
+	PriorityModerate,
+	PriorityLow,
+}
+
+// Priority of the incident selects the SLA targets. It is enum.
+// swagger:strfmt string
+type Priority struct {
+	v string
+}
+
+// Priorities returns all priorities, the most urgent priority is the first one
+func Priorities() []Priority {
+	return append([]Priority(nil), priorityValues...)
+}
+
+// NewPriorityFromString creates new instance from string value
+func NewPriorityFromString(priorityStr string) (Priority, error) {
+	for _, priority := range priorityValues {
+		if priority.String() == priorityStr {
+			return priority, nil
+		}
+	}
+	return Priority{}, fmt.Errorf("unknown '%s' priority", priorityStr)
+}
+
+// IsZero returns true if Priority has zero value
+func (p Priority) IsZero() bool {
+	return p == Priority{}
+}
+
+func (p Priority) String() string {
+	return p.v
+}
+
+// MarshalJSON returns JSON encoded Priority
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
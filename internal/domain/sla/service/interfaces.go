@@ -0,0 +1,20 @@
+package slasvc
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+)
+
+// SLAService provides operations on the SLA definitions of the channels
+type SLAService interface {
+	// SetDefinition validates the SLA definition and stores it in the repository, the current definition of the channel is replaced.
+	// Incidents that already exist keep the targets they were created with.
+	SetDefinition(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.SetSLADefinitionParams) error
+
+	// GetDefinition returns the SLA definition of the channel from the repository
+	GetDefinition(ctx context.Context, channelID ref.ChannelID, actor actor.Actor) (sla.Definition, error)
+}
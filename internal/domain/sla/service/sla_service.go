@@ -0,0 +1,98 @@
+package slasvc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// NewSLAService creates the SLA service
+func NewSLAService(repo repository.SLARepository) SLAService {
+	return &slaService{
+		repo: repo,
+	}
+}
+
+type slaService struct {
+	repo repository.SLARepository
+}
+
+func (s *slaService) SetDefinition(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.SetSLADefinitionParams) error {
+	def := sla.Definition{
+		Targets: make(map[sla.Priority]sla.Target),
+		Calendar: sla.Calendar{
+			Location: params.Calendar.Location,
+			Holidays: params.Calendar.Holidays,
+		},
+	}
+
+	var err error
+	def.DefaultPriority, err = sla.NewPriorityFromString(params.DefaultPriority)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid default priority")
+	}
+
+	for _, target := range params.Targets {
+		priority, err := sla.NewPriorityFromString(target.Priority)
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid target priority")
+		}
+
+		if _, ok := def.Targets[priority]; ok {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "duplicate target for '%s' priority", priority)
+		}
+
+		def.Targets[priority] = sla.Target{
+			Response:   time.Duration(target.ResponseTime) * time.Second,
+			Resolution: time.Duration(target.ResolutionTime) * time.Second,
+		}
+	}
+
+	for _, wh := range params.Calendar.WorkingHours {
+		weekday, err := weekdayFromString(wh.Weekday)
+		if err != nil {
+			return err
+		}
+
+		def.Calendar.WorkingHours = append(def.Calendar.WorkingHours, sla.WorkingHours{
+			Weekday: weekday,
+			Start:   wh.Start,
+			End:     wh.End,
+		})
+	}
+
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	if err := def.CreatedUpdated.SetCreatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+	if err := def.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return err
+	}
+
+	return s.repo.SetSLADefinition(ctx, channelID, def)
+}
+
+func (s *slaService) GetDefinition(ctx context.Context, channelID ref.ChannelID, _ actor.Actor) (sla.Definition, error) {
+	return s.repo.GetSLADefinition(ctx, channelID)
+}
+
+// weekdayFromString returns the weekday of its lowercase English name (ie. "monday")
+func weekdayFromString(weekdayStr string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.ToLower(d.String()) == weekdayStr {
+			return d, nil
+		}
+	}
+
+	return 0, domain.NewErrorf(domain.ErrorCodeInvalidArgument, "invalid weekday '%s' of the working hours", weekdayStr)
+}
@@ -0,0 +1,150 @@
+package slasvc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	slasvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/sla/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_slaService_SetAndGetDefinition(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{BasicUser: basicUser}
+
+	clock := mocks.NewFixedClock()
+	svc := slasvc.NewSLAService(memory.NewSLARepositoryMemory(clock, basicUserRepository))
+
+	_, err = svc.GetDefinition(ctx, channelID, actorUser)
+	require.Error(t, err)
+
+	params := api.SetSLADefinitionParams{
+		Targets: []api.SLATarget{
+			{Priority: "critical", ResponseTime: 900, ResolutionTime: 14400},
+			{Priority: "moderate", ResponseTime: 3600, ResolutionTime: 57600},
+		},
+		DefaultPriority: "moderate",
+		Calendar: api.SLACalendar{
+			Location: "Europe/Prague",
+			WorkingHours: []api.SLAWorkingHours{
+				{Weekday: "monday", Start: "08:00", End: "16:30"},
+				{Weekday: "friday", Start: "08:00", End: "14:00"},
+			},
+			Holidays: []string{"2021-04-05"},
+		},
+	}
+
+	err = svc.SetDefinition(ctx, channelID, actorUser, params)
+	require.NoError(t, err)
+
+	def, err := svc.GetDefinition(ctx, channelID, actorUser)
+	require.NoError(t, err)
+	assert.Equal(t, map[sla.Priority]sla.Target{
+		sla.PriorityCritical: {Response: 15 * time.Minute, Resolution: 4 * time.Hour},
+		sla.PriorityModerate: {Response: time.Hour, Resolution: 16 * time.Hour},
+	}, def.Targets)
+	assert.Equal(t, sla.PriorityModerate, def.DefaultPriority)
+	assert.Equal(t, sla.Calendar{
+		Location: "Europe/Prague",
+		WorkingHours: []sla.WorkingHours{
+			{Weekday: time.Monday, Start: "08:00", End: "16:30"},
+			{Weekday: time.Friday, Start: "08:00", End: "14:00"},
+		},
+		Holidays: []string{"2021-04-05"},
+	}, def.Calendar)
+	assert.Equal(t, basicUserID, def.CreatedUpdated.CreatedByID())
+	assert.Equal(t, basicUserID, def.CreatedUpdated.UpdatedByID())
+}
+
+func Test_slaService_SetDefinition_InvalidParams(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	clock := mocks.NewFixedClock()
+	svc := slasvc.NewSLAService(memory.NewSLARepositoryMemory(clock, &memory.BasicUserRepositoryMemory{}))
+
+	validTargets := []api.SLATarget{{Priority: "high", ResponseTime: 3600, ResolutionTime: 28800}}
+
+	tests := []struct {
+		name   string
+		params api.SetSLADefinitionParams
+		errMsg string
+	}{
+		{
+			name:   "unknown default priority",
+			params: api.SetSLADefinitionParams{Targets: validTargets, DefaultPriority: "urgent"},
+			errMsg: "invalid default priority: unknown 'urgent' priority",
+		},
+		{
+			name: "unknown target priority",
+			params: api.SetSLADefinitionParams{
+				Targets:         []api.SLATarget{{Priority: "urgent", ResponseTime: 3600, ResolutionTime: 28800}},
+				DefaultPriority: "high",
+			},
+			errMsg: "invalid target priority: unknown 'urgent' priority",
+		},
+		{
+			name: "duplicate target",
+			params: api.SetSLADefinitionParams{
+				Targets:         append(validTargets, validTargets[0]),
+				DefaultPriority: "high",
+			},
+			errMsg: "duplicate target for 'high' priority",
+		},
+		{
+			name:   "missing target of default priority",
+			params: api.SetSLADefinitionParams{Targets: validTargets, DefaultPriority: "low"},
+			errMsg: "SLA definition does not have target for default priority 'low'",
+		},
+		{
+			name: "unknown weekday",
+			params: api.SetSLADefinitionParams{
+				Targets:         validTargets,
+				DefaultPriority: "high",
+				Calendar: api.SLACalendar{
+					WorkingHours: []api.SLAWorkingHours{{Weekday: "Monday", Start: "08:00", End: "16:00"}},
+				},
+			},
+			errMsg: "invalid weekday 'Monday' of the working hours",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.SetDefinition(ctx, channelID, actor.Actor{}, tt.params)
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.errMsg)
+
+			var domainErr *domain.Error
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, domain.ErrorCodeInvalidArgument, domainErr.Code())
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package sla
+
+import (
+	"time"
+)
+
+// SLA of the incident. The targets and the calendar are copied from the channel's SLA definition when the incident is created,
+// so later changes of the definition do not affect the incidents that already exist.
+type SLA struct {
+	// Priority the targets were selected by
+	Priority Priority
+
+	Calendar Calendar
+
+	// Response timer measures the time until the incident is taken over
+	Response Timer
+
+	// Resolution timer measures the time until the incident is resolved
+	Resolution Timer
+}
+
+// New returns the SLA with the targets of the given priority (or of the default priority), both timers are started at now time
+func New(def Definition, priority Priority, now time.Time) (*SLA, error) {
+	priority, target := def.TargetFor(priority)
+
+	s := &SLA{
+		Priority:   priority,
+		Calendar:   def.Calendar,
+		Response:   Timer{Target: target.Response},
+		Resolution: Timer{Target: target.Resolution},
+	}
+
+	if err := s.Update(now, TimerStateRunning, TimerStateRunning); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Retarget replaces the targets by the targets of the given priority (or of the default priority) from the definition,
+// the time measured by the timers and the calendar are kept
+func (s *SLA) Retarget(def Definition, priority Priority) {
+	priority, target := def.TargetFor(priority)
+
+	s.Priority = priority
+	s.Response.Target = target.Response
+	s.Resolution.Target = target.Resolution
+}
+
+// Update moves the response and resolution timers to the given states at now time
+func (s *SLA) Update(now time.Time, response, resolution TimerState) error {
+	// timestamps are stored with one second precision
+	now = now.Truncate(time.Second)
+
+	if err := s.Response.setState(s.Calendar, response, now); err != nil {
+		return err
+	}
+
+	return s.Resolution.setState(s.Calendar, resolution, now)
+}
+
+// Status returns the state of the timers at now time
+func (s SLA) Status(now time.Time) (Status, error) {
+	now = now.Truncate(time.Second)
+
+	response, err := s.Response.Status(s.Calendar, now)
+	if err != nil {
+		return Status{}, err
+	}
+
+	resolution, err := s.Resolution.Status(s.Calendar, now)
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{
+		Response:   response,
+		Resolution: resolution,
+	}, nil
+}
+
+// Status of the SLA timers at some time
+type Status struct {
+	Response TimerStatus
+
+	Resolution TimerStatus
+}
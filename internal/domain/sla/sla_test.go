@@ -0,0 +1,330 @@
+package sla_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// TestInit initializes test suite
+func TestInit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SLA tests")
+}
+
+var prague, _ = time.LoadLocation("Europe/Prague")
+
+// officeCalendar has business hours Monday to Friday from 9 to 17
+func officeCalendar() Calendar {
+	cal := Calendar{Location: "Europe/Prague"}
+	for _, d := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday} {
+		cal.WorkingHours = append(cal.WorkingHours, WorkingHours{Weekday: d, Start: "09:00", End: "17:00"})
+	}
+	return cal
+}
+
+var _ = Describe("Calendar behavior", func() {
+	var cal Calendar
+
+	BeforeEach(func() {
+		cal = officeCalendar()
+	})
+
+	Describe("Validate()", func() {
+		It("should accept valid calendar", func() {
+			Expect(cal.Validate()).To(BeNil())
+			Expect(Calendar{}.Validate()).To(BeNil())
+
+			cal.WorkingHours = append(cal.WorkingHours, WorkingHours{Weekday: time.Saturday, Start: "20:00", End: "24:00"})
+			cal.Holidays = []string{"2021-12-24"}
+			Expect(cal.Validate()).To(BeNil())
+		})
+
+		It("should return error if the location is unknown", func() {
+			cal.Location = "Europe/Atlantis"
+			expectInvalidArgumentError(cal.Validate(), "invalid calendar location 'Europe/Atlantis'")
+		})
+
+		It("should return error if the working hours are not valid", func() {
+			cal.WorkingHours = []WorkingHours{{Weekday: time.Monday, Start: "9am", End: "17:00"}}
+			expectInvalidArgumentError(cal.Validate(), "invalid working hours '9am-17:00' on Monday")
+
+			cal.WorkingHours = []WorkingHours{{Weekday: time.Monday, Start: "17:00", End: "09:00"}}
+			expectInvalidArgumentError(cal.Validate(), "working hours '17:00-09:00' on Monday must end after they start")
+
+			cal.WorkingHours = []WorkingHours{{Weekday: 7, Start: "09:00", End: "17:00"}}
+			expectInvalidArgumentError(cal.Validate(), "invalid weekday of the working hours")
+		})
+
+		It("should return error if the working hours overlap", func() {
+			cal.WorkingHours = append(cal.WorkingHours, WorkingHours{Weekday: time.Friday, Start: "16:00", End: "18:00"})
+			expectInvalidArgumentError(cal.Validate(), "working hours '16:00-18:00' on Friday overlap other working hours")
+		})
+
+		It("should return error if the holiday is not valid date", func() {
+			cal.Holidays = []string{"2021-02-30"}
+			expectInvalidArgumentError(cal.Validate(), "invalid holiday date '2021-02-30'")
+		})
+	})
+
+	Describe("BusinessDuration()", func() {
+		It("should return the whole duration if the calendar does not have any working hours", func() {
+			from := time.Date(2021, 4, 2, 16, 0, 0, 0, prague)
+			d, err := Calendar{}.BusinessDuration(from, from.Add(65*time.Hour))
+			Expect(err).To(BeNil())
+			Expect(d).To(Equal(65 * time.Hour))
+		})
+
+		It("should count only the working hours", func() {
+			// Friday 16:00 => Monday 10:30
+			d, err := cal.BusinessDuration(time.Date(2021, 4, 2, 16, 0, 0, 0, prague), time.Date(2021, 4, 5, 10, 30, 0, 0, prague))
+			Expect(err).To(BeNil())
+			Expect(d).To(Equal(2*time.Hour + 30*time.Minute))
+
+			// both times out of working hours
+			d, err = cal.BusinessDuration(time.Date(2021, 4, 1, 7, 0, 0, 0, prague), time.Date(2021, 4, 1, 20, 0, 0, 0, prague))
+			Expect(err).To(BeNil())
+			Expect(d).To(Equal(8 * time.Hour))
+
+			// times in other time zone
+			d, err = cal.BusinessDuration(time.Date(2021, 4, 1, 7, 0, 0, 0, time.UTC), time.Date(2021, 4, 1, 8, 0, 0, 0, time.UTC))
+			Expect(err).To(BeNil())
+			Expect(d).To(Equal(time.Hour))
+		})
+
+		It("should skip holidays", func() {
+			cal.Holidays = []string{"2021-04-05"}
+
+			d, err := cal.BusinessDuration(time.Date(2021, 4, 2, 16, 0, 0, 0, prague), time.Date(2021, 4, 6, 10, 30, 0, 0, prague))
+			Expect(err).To(BeNil())
+			Expect(d).To(Equal(2*time.Hour + 30*time.Minute))
+		})
+
+		It("should respect daylight saving time changes", func() {
+			cal.WorkingHours = []WorkingHours{{Weekday: time.Sunday, Start: "00:00", End: "24:00"}}
+
+			// 28th March 2021 has only 23 hours in Prague
+			d, err := cal.BusinessDuration(time.Date(2021, 3, 27, 12, 0, 0, 0, prague), time.Date(2021, 3, 29, 12, 0, 0, 0, prague))
+			Expect(err).To(BeNil())
+			Expect(d).To(Equal(23 * time.Hour))
+		})
+
+		It("should return zero if the end is before the start", func() {
+			from := time.Date(2021, 4, 1, 12, 0, 0, 0, prague)
+			d, err := cal.BusinessDuration(from, from.Add(-time.Hour))
+			Expect(err).To(BeNil())
+			Expect(d).To(BeZero())
+		})
+	})
+
+	Describe("AddBusinessDuration()", func() {
+		It("should add the whole duration if the calendar does not have any working hours", func() {
+			from := time.Date(2021, 4, 2, 16, 0, 0, 0, prague)
+			due, err := Calendar{}.AddBusinessDuration(from, 2*time.Hour)
+			Expect(err).To(BeNil())
+			Expect(due).To(Equal(from.Add(2 * time.Hour)))
+		})
+
+		It("should move the due time over non-working hours", func() {
+			due, err := cal.AddBusinessDuration(time.Date(2021, 4, 2, 16, 0, 0, 0, prague), 2*time.Hour)
+			Expect(err).To(BeNil())
+			Expect(due.Equal(time.Date(2021, 4, 5, 10, 0, 0, 0, prague))).To(BeTrue())
+
+			cal.Holidays = []string{"2021-04-05"}
+			due, err = cal.AddBusinessDuration(time.Date(2021, 4, 2, 16, 0, 0, 0, prague), 2*time.Hour)
+			Expect(err).To(BeNil())
+			Expect(due.Equal(time.Date(2021, 4, 6, 10, 0, 0, 0, prague))).To(BeTrue())
+		})
+
+		It("should return the end of the working hours if the duration ends exactly there", func() {
+			due, err := cal.AddBusinessDuration(time.Date(2021, 4, 1, 8, 0, 0, 0, prague), 8*time.Hour)
+			Expect(err).To(BeNil())
+			Expect(due.Equal(time.Date(2021, 4, 1, 17, 0, 0, 0, prague))).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Definition behavior", func() {
+	var def Definition
+
+	BeforeEach(func() {
+		def = Definition{
+			Targets: map[Priority]Target{
+				PriorityCritical: {Response: 15 * time.Minute, Resolution: 4 * time.Hour},
+				PriorityModerate: {Response: time.Hour, Resolution: 16 * time.Hour},
+			},
+			DefaultPriority: PriorityModerate,
+			Calendar:        officeCalendar(),
+		}
+	})
+
+	Describe("Validate()", func() {
+		It("should accept valid definition", func() {
+			Expect(def.Validate()).To(BeNil())
+		})
+
+		It("should return error if there is no target for the default priority", func() {
+			def.DefaultPriority = PriorityLow
+			expectInvalidArgumentError(def.Validate(), "SLA definition does not have target for default priority 'low'")
+		})
+
+		It("should return error if the target is too short", func() {
+			def.Targets[PriorityCritical] = Target{Response: time.Second, Resolution: time.Hour}
+			expectInvalidArgumentError(def.Validate(), "SLA targets of 'critical' priority must be at least one minute")
+		})
+
+		It("should return error if the calendar is not valid", func() {
+			def.Calendar.Holidays = []string{"tomorrow"}
+			expectInvalidArgumentError(def.Validate(), "invalid holiday date 'tomorrow'")
+		})
+	})
+
+	Describe("TargetFor()", func() {
+		It("should return the target of the priority", func() {
+			priority, target := def.TargetFor(PriorityCritical)
+			Expect(priority).To(Equal(PriorityCritical))
+			Expect(target.Response).To(Equal(15 * time.Minute))
+		})
+
+		It("should return the target of the default priority if there is no target for the priority", func() {
+			priority, target := def.TargetFor(PriorityHigh)
+			Expect(priority).To(Equal(PriorityModerate))
+			Expect(target.Response).To(Equal(time.Hour))
+
+			priority, _ = def.TargetFor(Priority{})
+			Expect(priority).To(Equal(PriorityModerate))
+		})
+	})
+})
+
+var _ = Describe("SLA timers behavior", func() {
+	var clock *mocks.FixedClock
+	var def Definition
+	var s *SLA
+
+	BeforeEach(func() {
+		// Thursday 2021-04-01 12:34:56
+		clock = mocks.NewFixedClock()
+
+		def = Definition{
+			Targets: map[Priority]Target{
+				PriorityModerate: {Response: time.Hour, Resolution: 8 * time.Hour},
+				PriorityCritical: {Response: 15 * time.Minute, Resolution: 2 * time.Hour},
+			},
+			DefaultPriority: PriorityModerate,
+			Calendar:        officeCalendar(),
+		}
+
+		var err error
+		s, err = New(def, Priority{}, clock.Now())
+		Expect(err).To(BeNil())
+	})
+
+	It("should start both timers", func() {
+		Expect(s.Priority).To(Equal(PriorityModerate))
+
+		status, err := s.Status(clock.Now())
+		Expect(err).To(BeNil())
+
+		Expect(status.Response.State).To(Equal(TimerStateRunning))
+		Expect(status.Response.Target).To(Equal(time.Hour))
+		Expect(status.Response.Elapsed).To(BeZero())
+		Expect(status.Response.DueAt).To(Equal(types.DateTime("2021-04-01T13:34:56+02:00")))
+		Expect(status.Resolution.State).To(Equal(TimerStateRunning))
+	})
+
+	It("should calculate remaining time and due time in business hours", func() {
+		clock.AddTime(4 * time.Hour) // 16:34:56
+
+		status, err := s.Status(clock.Now())
+		Expect(err).To(BeNil())
+
+		Expect(status.Resolution.Elapsed).To(Equal(4 * time.Hour))
+		Expect(status.Resolution.Remaining).To(Equal(4 * time.Hour))
+		Expect(status.Resolution.Breached).To(BeFalse())
+		Expect(status.Resolution.DueAt).To(Equal(types.DateTime("2021-04-02T12:34:56+02:00")))
+
+		Expect(status.Response.Remaining).To(Equal(-3 * time.Hour))
+		Expect(status.Response.Breached).To(BeTrue())
+		Expect(status.Response.DueAt.IsZero()).To(BeTrue())
+	})
+
+	It("should not measure the time when the timer is paused", func() {
+		clock.AddTime(30 * time.Minute)
+		Expect(s.Update(clock.Now(), TimerStatePaused, TimerStatePaused)).To(BeNil())
+
+		clock.AddTime(2 * time.Hour)
+		status, err := s.Status(clock.Now())
+		Expect(err).To(BeNil())
+		Expect(status.Response.State).To(Equal(TimerStatePaused))
+		Expect(status.Response.Elapsed).To(Equal(30 * time.Minute))
+		Expect(status.Response.DueAt.IsZero()).To(BeTrue())
+
+		Expect(s.Update(clock.Now(), TimerStateRunning, TimerStateRunning)).To(BeNil())
+
+		clock.AddTime(20 * time.Minute)
+		status, err = s.Status(clock.Now())
+		Expect(err).To(BeNil())
+		Expect(status.Response.State).To(Equal(TimerStateRunning))
+		Expect(status.Response.Elapsed).To(Equal(50 * time.Minute))
+		Expect(status.Response.Remaining).To(Equal(10 * time.Minute))
+	})
+
+	It("should keep the measured time when the timer is stopped and resumed", func() {
+		clock.AddTime(45 * time.Minute)
+		Expect(s.Update(clock.Now(), TimerStateStopped, TimerStateStopped)).To(BeNil())
+		Expect(s.Response.StoppedAt).To(Equal(types.DateTime("2021-04-01T13:19:56+02:00")))
+
+		clock.AddTime(24 * time.Hour)
+		status, err := s.Status(clock.Now())
+		Expect(err).To(BeNil())
+		Expect(status.Response.State).To(Equal(TimerStateStopped))
+		Expect(status.Response.Elapsed).To(Equal(45 * time.Minute))
+		Expect(status.Response.Breached).To(BeFalse())
+
+		Expect(s.Update(clock.Now(), TimerStateStopped, TimerStateRunning)).To(BeNil())
+		Expect(s.Resolution.StoppedAt.IsZero()).To(BeTrue())
+
+		clock.AddTime(time.Hour)
+		status, err = s.Status(clock.Now())
+		Expect(err).To(BeNil())
+		Expect(status.Resolution.State).To(Equal(TimerStateRunning))
+		Expect(status.Resolution.Elapsed).To(Equal(time.Hour + 45*time.Minute))
+	})
+
+	It("should keep the measured time when the targets are selected by other priority", func() {
+		clock.AddTime(30 * time.Minute)
+		s.Retarget(def, PriorityCritical)
+
+		Expect(s.Priority).To(Equal(PriorityCritical))
+		status, err := s.Status(clock.Now())
+		Expect(err).To(BeNil())
+		Expect(status.Response.State).To(Equal(TimerStateRunning))
+		Expect(status.Response.Target).To(Equal(15 * time.Minute))
+		Expect(status.Response.Elapsed).To(Equal(30 * time.Minute))
+		Expect(status.Response.Breached).To(BeTrue())
+		Expect(status.Resolution.Target).To(Equal(2 * time.Hour))
+		Expect(status.Resolution.Remaining).To(Equal(90 * time.Minute))
+
+		// priority without targets selects the targets of the default priority
+		s.Retarget(def, PriorityLow)
+		Expect(s.Priority).To(Equal(PriorityModerate))
+		Expect(s.Resolution.Target).To(Equal(8 * time.Hour))
+	})
+})
+
+func expectInvalidArgumentError(err error, msg string) {
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(HavePrefix(msg))
+
+	var domainErr *domain.Error
+	Expect(errors.As(err, &domainErr)).To(BeTrue())
+	Expect(domainErr.Code()).To(Equal(domain.ErrorCodeInvalidArgument))
+}
@@ -0,0 +1,150 @@
+package sla
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// TimerState values
+var (
+	TimerStateRunning = TimerState{"running"}
+	TimerStatePaused  = TimerState{"paused"}
+	TimerStateStopped = TimerState{"stopped"}
+)
+
+// TimerState of the SLA timer is enum. It is derived from the timer timestamps.
+// swagger:strfmt string
+type TimerState struct {
+	v string
+}
+
+func (s TimerState) String() string {
+	return s.v
+}
+
+// MarshalJSON returns JSON encoded TimerState
+func (s TimerState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Timer measures the business time spent on the incident. It can be paused and resumed, stopped timer can be resumed too
+// (ie. when the resolved incident is reopened).
+type Timer struct {
+	// Target is the business time allowed
+	Target time.Duration
+
+	// Elapsed is the business time measured until the timer was paused or stopped the last time
+	Elapsed time.Duration
+
+	// RunningSince is the time the timer was started or resumed, it is zero if the timer is not running
+	RunningSince types.DateTime
+
+	// StoppedAt is the time the timer was stopped, it is zero if the timer is running or paused
+	StoppedAt types.DateTime
+}
+
+// State returns the state of the timer
+func (t Timer) State() TimerState {
+	if !t.RunningSince.IsZero() {
+		return TimerStateRunning
+	}
+
+	if !t.StoppedAt.IsZero() {
+		return TimerStateStopped
+	}
+
+	return TimerStatePaused
+}
+
+// ElapsedAt returns the business time measured until now
+func (t Timer) ElapsedAt(cal Calendar, now time.Time) (time.Duration, error) {
+	if t.RunningSince.IsZero() {
+		return t.Elapsed, nil
+	}
+
+	since, err := t.RunningSince.ToTime()
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := cal.BusinessDuration(since, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Elapsed + d, nil
+}
+
+// setState moves the timer to the given state at now time, the time measured so far is added to Elapsed if the running timer is paused or stopped
+func (t *Timer) setState(cal Calendar, s TimerState, now time.Time) error {
+	if t.State() == s {
+		return nil
+	}
+
+	elapsed, err := t.ElapsedAt(cal, now)
+	if err != nil {
+		return err
+	}
+	t.Elapsed = elapsed
+
+	nowFormatted := types.DateTime(now.Format(time.RFC3339))
+
+	switch s {
+	case TimerStateRunning:
+		t.RunningSince = nowFormatted
+		t.StoppedAt = ""
+	case TimerStatePaused:
+		t.RunningSince = ""
+		t.StoppedAt = ""
+	case TimerStateStopped:
+		t.RunningSince = ""
+		t.StoppedAt = nowFormatted
+	}
+
+	return nil
+}
+
+// Status returns the state of the timer and the business time measured until now compared to the target
+func (t Timer) Status(cal Calendar, now time.Time) (TimerStatus, error) {
+	elapsed, err := t.ElapsedAt(cal, now)
+	if err != nil {
+		return TimerStatus{}, err
+	}
+
+	status := TimerStatus{
+		State:     t.State(),
+		Target:    t.Target,
+		Elapsed:   elapsed,
+		Remaining: t.Target - elapsed,
+		Breached:  elapsed > t.Target,
+	}
+
+	if status.State == TimerStateRunning && !status.Breached {
+		dueAt, err := cal.AddBusinessDuration(now, status.Remaining)
+		if err != nil {
+			return TimerStatus{}, err
+		}
+		status.DueAt = types.DateTime(dueAt.Format(time.RFC3339))
+	}
+
+	return status, nil
+}
+
+// TimerStatus is the state of the timer at some time
+type TimerStatus struct {
+	State TimerState
+
+	Target time.Duration
+
+	Elapsed time.Duration
+
+	// Remaining business time until the target is breached, it is negative if the target was breached
+	Remaining time.Duration
+
+	// DueAt is the time the target will be breached, it is set only if the timer is running and the target was not breached yet
+	DueAt types.DateTime
+
+	Breached bool
+}
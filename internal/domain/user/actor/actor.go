@@ -9,6 +9,8 @@ import (
 type Actor struct {
 	BasicUser       user.BasicUser
 	fieldEngineerID *ref.UUID
+	dispatcher      bool
+	requestedBy     *user.BasicUser
 }
 
 // ExternalUserUUID returns UUID of the actor in the external user microservice
@@ -32,3 +34,23 @@ func (e *Actor) SetFieldEngineerID(fieldEngineerID *ref.UUID) {
 		e.fieldEngineerID = fieldEngineerID
 	}
 }
+
+// IsDispatcher returns true if the actor is dispatcher (ie. approves corrections of the field engineers' timelogs)
+func (e Actor) IsDispatcher() bool {
+	return e.dispatcher
+}
+
+// SetDispatcher sets whether the actor is dispatcher
+func (e *Actor) SetDispatcher(dispatcher bool) {
+	e.dispatcher = dispatcher
+}
+
+// RequestedBy returns the user who sent the request on behalf of the actor or nil if the request was not made on behalf of other user
+func (e Actor) RequestedBy() *user.BasicUser {
+	return e.requestedBy
+}
+
+// SetRequestedBy sets the user who sent the request on behalf of the actor
+func (e *Actor) SetRequestedBy(requestedBy *user.BasicUser) {
+	e.requestedBy = requestedBy
+}
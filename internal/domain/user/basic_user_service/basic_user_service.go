@@ -0,0 +1,30 @@
+package basicusersvc
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// NewBasicUserService creates the basic user service
+func NewBasicUserService(repo repository.BasicUserRepository) BasicUserService {
+	return &basicUserService{
+		repo: repo,
+	}
+}
+
+type basicUserService struct {
+	repo repository.BasicUserRepository
+}
+
+func (s *basicUserService) GetBasicUser(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, ID ref.UUID) (user.BasicUser, error) {
+	return s.repo.GetBasicUser(ctx, channelID, ID)
+}
+
+func (s *basicUserService) ListBasicUsers(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, params converters.PaginationParams) (repository.BasicUserList, error) {
+	return s.repo.ListBasicUsers(ctx, channelID, params.Page(), params.ItemsPerPage())
+}
@@ -0,0 +1,20 @@
+package basicusersvc
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// BasicUserService provides basic user operations
+type BasicUserService interface {
+	// GetBasicUser returns the basic user with the given ID from the repository
+	GetBasicUser(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (user.BasicUser, error)
+
+	// ListBasicUsers returns the list of basic users from the repository
+	ListBasicUsers(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams converters.PaginationParams) (repository.BasicUserList, error)
+}
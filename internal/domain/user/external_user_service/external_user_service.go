@@ -2,7 +2,8 @@ package externalusersvc
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
 
 	usermanagement "github.com/crywolf/itsm-ticket-management-service/external/itsm-user-service/api"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
@@ -19,7 +20,7 @@ import (
 // Service provides basic info about user
 type Service interface {
 	// ActorFromRequest calls external use service and returns an Actor object that represents a user who initiated the request
-	// or about user this request is made on behalf of
+	// or about user this request is made on behalf of (the user who initiated such request is available via Actor.RequestedBy)
 	ActorFromRequest(ctx context.Context, authToken string, channelID ref.ChannelID, onBehalf string) (actor.Actor, error)
 }
 
@@ -27,12 +28,18 @@ type Service interface {
 type ServiceCloser interface {
 	Service
 
+	// InvalidateFieldEngineer removes the cached field engineer identity of the basic user,
+	// it must be called when the basic user becomes or stops being the field engineer
+	InvalidateFieldEngineer(channelID ref.ChannelID, basicUserID ref.UUID)
+
 	// Close tears down connection to external user service
 	Close() error
 }
 
-// NewService creates new user service with initialized client for connection to external user service
-func NewService(basicUserRepository repository.BasicUserRepository) (ServiceCloser, error) {
+// NewService creates new user service with initialized client for connection to external user service.
+// Field engineer identities of the users are cached for 'FieldEngineerCacheTTLInSeconds'.
+// Users of the 'DispatcherUserType' type in external user service are dispatchers.
+func NewService(basicUserRepository repository.BasicUserRepository, fieldEngineerRepository repository.FieldEngineerRepository) (ServiceCloser, error) {
 	conn, err := grpc.Dial(
 		viper.GetString("UserServiceGRPCDialTarget"),
 		grpc.WithInsecure(),
@@ -42,16 +49,22 @@ func NewService(basicUserRepository repository.BasicUserRepository) (ServiceClos
 	}
 
 	return &userService{
-		conn:                conn,
-		client:              usermanagement.NewUserManagementServiceClient(conn),
-		basicUserRepository: basicUserRepository,
+		conn:                    conn,
+		client:                  usermanagement.NewUserManagementServiceClient(conn),
+		basicUserRepository:     basicUserRepository,
+		fieldEngineerRepository: fieldEngineerRepository,
+		fieldEngineerCache:      newFieldEngineerCache(time.Duration(viper.GetInt("FieldEngineerCacheTTLInSeconds")) * time.Second),
+		dispatcherUserType:      viper.GetString("DispatcherUserType"),
 	}, nil
 }
 
 type userService struct {
-	conn                *grpc.ClientConn
-	client              usermanagement.UserManagementServiceClient
-	basicUserRepository repository.BasicUserRepository
+	conn                    *grpc.ClientConn
+	client                  usermanagement.UserManagementServiceClient
+	basicUserRepository     repository.BasicUserRepository
+	fieldEngineerRepository repository.FieldEngineerRepository
+	fieldEngineerCache      *fieldEngineerCache
+	dispatcherUserType      string
 }
 
 func (s userService) Close() error {
@@ -59,24 +72,78 @@ func (s userService) Close() error {
 }
 
 func (s userService) ActorFromRequest(ctx context.Context, authToken string, channelID ref.ChannelID, onBehalf string) (actor.Actor, error) {
-	basicUser, err := s.basicUserFromRequest(ctx, authToken, channelID, onBehalf)
+	externalUser, err := s.externalUserFromRequest(authToken, channelID, onBehalf)
+	if err != nil {
+		return actor.Actor{}, err
+	}
+
+	basicUser, err := s.basicUserOfExternalUser(ctx, channelID, externalUser)
 	if err != nil {
 		return actor.Actor{}, err
 	}
 
-	fmt.Println(basicUser)
 	actorUser := actor.Actor{
 		BasicUser: basicUser,
 	}
 
-	// TODO - try to find field engineer with this basicUser in repository and assign it
-	//fieldEngineer := &fieldengineer.FieldEngineer{}
-	//actorUser.SetFieldEngineerID(fieldEngineer)
+	fieldEngineerID, err := s.fieldEngineerIDOfBasicUser(ctx, channelID, basicUser)
+	if err != nil {
+		return actor.Actor{}, err
+	}
+	actorUser.SetFieldEngineerID(fieldEngineerID)
+
+	actorUser.SetDispatcher(s.dispatcherUserType != "" && externalUser.GetType() == s.dispatcherUserType)
+
+	if onBehalf != "" {
+		// keep identity of the user who actually sent the request
+		requestingUser, err := s.externalUserFromRequest(authToken, channelID, "")
+		if err != nil {
+			return actor.Actor{}, err
+		}
+
+		requestedBy, err := s.basicUserOfExternalUser(ctx, channelID, requestingUser)
+		if err != nil {
+			return actor.Actor{}, err
+		}
+		actorUser.SetRequestedBy(&requestedBy)
+	}
 
 	return actorUser, nil
 }
 
-func (s userService) basicUserFromRequest(ctx context.Context, authToken string, channelID ref.ChannelID, onBehalf string) (user.BasicUser, error) {
+// fieldEngineerIDOfBasicUser returns ID of the field engineer belonging to the basic user or nil if the user is not a field engineer
+func (s userService) fieldEngineerIDOfBasicUser(ctx context.Context, channelID ref.ChannelID, basicUser user.BasicUser) (*ref.UUID, error) {
+	if fieldEngineerID, found := s.fieldEngineerCache.get(channelID, basicUser.UUID()); found {
+		return &fieldEngineerID, nil
+	}
+
+	fe, err := s.fieldEngineerRepository.GetFieldEngineerByBasicUser(ctx, channelID, basicUser.UUID())
+	if err != nil {
+		var domainErr *domain.Error
+		if errors.As(err, &domainErr) && domainErr.Code() == domain.ErrorCodeNotFound {
+			return nil, nil
+		}
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUserNotAuthorized, "user could not be authorized")
+	}
+
+	// deactivated field engineer cannot act as the field engineer anymore
+	if fe.IsDeactivated() {
+		return nil, nil
+	}
+
+	fieldEngineerID := fe.UUID()
+	s.fieldEngineerCache.set(channelID, basicUser.UUID(), fieldEngineerID)
+
+	return &fieldEngineerID, nil
+}
+
+// InvalidateFieldEngineer removes the cached field engineer identity of the basic user
+func (s userService) InvalidateFieldEngineer(channelID ref.ChannelID, basicUserID ref.UUID) {
+	s.fieldEngineerCache.invalidate(channelID, basicUserID)
+}
+
+// externalUserFromRequest returns the user who initiated the request or the user this request is made on behalf of from external user service
+func (s userService) externalUserFromRequest(authToken string, channelID ref.ChannelID, onBehalf string) (*usermanagement.User, error) {
 	md := metadata.New(map[string]string{
 		"grpc-metadata-space": channelID.String(),
 		"authorization":       authToken,
@@ -91,24 +158,63 @@ func (s userService) basicUserFromRequest(ctx context.Context, authToken string,
 		resp, err = s.client.UserGet(grpcCtx, &usermanagement.UserRequest{Uuid: onBehalf})
 		if err != nil {
 			err = domain.WrapErrorf(err, domain.ErrorCodeUnknown, "authorization failed")
-			return user.BasicUser{}, err
+			return nil, err
 		}
 	} else {
 		resp, err = s.client.UserGetMyPersonalDetails(grpcCtx, &emptypb.Empty{})
 		if err != nil {
 			err = domain.WrapErrorf(err, domain.ErrorCodeUnknown, "authorization failed")
-			return user.BasicUser{}, err
+			return nil, err
 		}
 	}
 
-	u := resp.GetResult()
+	return resp.GetResult(), nil
+}
 
+// basicUserOfExternalUser returns the basic user belonging to the external user, the basic user is created if it does not exist yet
+func (s userService) basicUserOfExternalUser(ctx context.Context, channelID ref.ChannelID, u *usermanagement.User) (user.BasicUser, error) {
 	//take returned ExternalUserUUID and get BasicUser from repository
-	externalID := ref.ExternalUserUUID(u.Uuid)
+	externalID := ref.ExternalUserUUID(u.GetUuid())
 	basicUser, err := s.basicUserRepository.GetBasicUserByExternalID(ctx, channelID, externalID)
 	if err != nil {
-		err = domain.WrapErrorf(err, domain.ErrorCodeUserNotAuthorized, "user could not be authorized")
+		var domainErr *domain.Error
+		if !errors.As(err, &domainErr) || domainErr.Code() != domain.ErrorCodeNotFound {
+			return user.BasicUser{}, domain.WrapErrorf(err, domain.ErrorCodeUserNotAuthorized, "user could not be authorized")
+		}
+
+		// user is not in the repository yet, so create it (just-in-time provisioning)
+		return s.provisionBasicUser(ctx, channelID, u)
+	}
+
+	return basicUser, nil
+}
+
+// provisionBasicUser creates new basic user from the user data returned by external user service and adds it to the repository
+func (s userService) provisionBasicUser(ctx context.Context, channelID ref.ChannelID, u *usermanagement.User) (user.BasicUser, error) {
+	if u.GetUuid() == "" {
+		return user.BasicUser{}, domain.NewErrorf(domain.ErrorCodeUserNotAuthorized, "user could not be authorized: external user UUID is missing")
+	}
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: ref.ExternalUserUUID(u.GetUuid()),
+		Name:             u.GetName(),
+		Surname:          u.GetSurname(),
+		OrgDisplayName:   u.GetOrgDisplayName(),
+		OrgName:          u.GetOrgName(),
+	}
+
+	basicUserID, err := s.basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	if err != nil {
+		// the user could have been provisioned by a concurrent request in the meantime
+		if existingUser, getErr := s.basicUserRepository.GetBasicUserByExternalID(ctx, channelID, basicUser.ExternalUserUUID); getErr == nil {
+			return existingUser, nil
+		}
+		return user.BasicUser{}, domain.WrapErrorf(err, domain.ErrorCodeUserNotAuthorized, "user could not be provisioned")
+	}
+
+	if err := basicUser.SetUUID(basicUserID); err != nil {
+		return user.BasicUser{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "user could not be provisioned")
 	}
 
-	return basicUser, err
+	return basicUser, nil
 }
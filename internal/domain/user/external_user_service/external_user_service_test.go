@@ -0,0 +1,362 @@
+package externalusersvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	usermanagement "github.com/crywolf/itsm-ticket-management-service/external/itsm-user-service/api"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// userManagementClientStub returns fixed user from UserGetMyPersonalDetails call and fixed onBehalfUser from UserGet call
+type userManagementClientStub struct {
+	usermanagement.UserManagementServiceClient
+	user         *usermanagement.User
+	onBehalfUser *usermanagement.User
+}
+
+func (c userManagementClientStub) UserGetMyPersonalDetails(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*usermanagement.UserPersonalDetailsResponse, error) {
+	return &usermanagement.UserPersonalDetailsResponse{Result: c.user}, nil
+}
+
+func (c userManagementClientStub) UserGet(_ context.Context, _ *usermanagement.UserRequest, _ ...grpc.CallOption) (*usermanagement.UserPersonalDetailsResponse, error) {
+	return &usermanagement.UserPersonalDetailsResponse{Result: c.onBehalfUser}, nil
+}
+
+// countingFieldEngineerRepository counts GetFieldEngineerByBasicUser calls
+type countingFieldEngineerRepository struct {
+	repository.FieldEngineerRepository
+	calls int
+}
+
+func (r *countingFieldEngineerRepository) GetFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	r.calls++
+	return r.FieldEngineerRepository.GetFieldEngineerByBasicUser(ctx, channelID, basicUserID)
+}
+
+// racingBasicUserRepository simulates concurrent request that provisions the same basic user
+// between the first lookup of the user and its provisioning
+type racingBasicUserRepository struct {
+	*memory.BasicUserRepositoryMemory
+	concurrentUser   user.BasicUser
+	concurrentUserID ref.UUID
+}
+
+func (r *racingBasicUserRepository) GetBasicUserByExternalID(ctx context.Context, channelID ref.ChannelID, externalID ref.ExternalUserUUID) (user.BasicUser, error) {
+	if r.concurrentUserID.IsZero() {
+		basicUser, err := r.BasicUserRepositoryMemory.GetBasicUserByExternalID(ctx, channelID, externalID)
+		if err != nil {
+			r.concurrentUserID, _ = r.BasicUserRepositoryMemory.AddBasicUser(ctx, channelID, r.concurrentUser)
+		}
+		return basicUser, err
+	}
+	return r.BasicUserRepositoryMemory.GetBasicUserByExternalID(ctx, channelID, externalID)
+}
+
+func newTestUserService(client usermanagement.UserManagementServiceClient, basicUserRepository *memory.BasicUserRepositoryMemory) userService {
+	return userService{
+		client:                  client,
+		basicUserRepository:     basicUserRepository,
+		fieldEngineerRepository: memory.NewFieldEngineerRepositoryMemory(mocks.NewFixedClock(), basicUserRepository),
+		fieldEngineerCache:      newFieldEngineerCache(time.Minute),
+	}
+}
+
+func TestUserService_ActorFromRequest(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	externalUser := &usermanagement.User{
+		Uuid:           "83b231f2-5898-2658-70f4-5db03d1ccbc1",
+		Name:           "Jan",
+		Surname:        "Novak",
+		OrgDisplayName: "KompiTech",
+		OrgName:        "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	t.Run("when basic user is already in the repository", func(t *testing.T) {
+		basicUserRepository := &memory.BasicUserRepositoryMemory{}
+		existingUser := user.BasicUser{
+			ExternalUserUUID: ref.ExternalUserUUID(externalUser.Uuid),
+			Name:             "Jan",
+			Surname:          "Novak",
+		}
+		existingID, err := basicUserRepository.AddBasicUser(ctx, channelID, existingUser)
+		require.NoError(t, err)
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository)
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.Equal(t, existingID, actorUser.BasicUser.UUID())
+
+		list, err := basicUserRepository.ListBasicUsers(ctx, channelID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total, "no new basic user should be created")
+	})
+
+	t.Run("when basic user is not in the repository yet", func(t *testing.T) {
+		basicUserRepository := &memory.BasicUserRepositoryMemory{}
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository)
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.False(t, actorUser.BasicUser.IsZero())
+		assert.Equal(t, ref.ExternalUserUUID(externalUser.Uuid), actorUser.BasicUser.ExternalUserUUID)
+		assert.Equal(t, "Jan", actorUser.BasicUser.Name)
+		assert.Equal(t, "Novak", actorUser.BasicUser.Surname)
+		assert.Equal(t, "KompiTech", actorUser.BasicUser.OrgDisplayName)
+		assert.Equal(t, "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com", actorUser.BasicUser.OrgName)
+
+		storedUser, err := basicUserRepository.GetBasicUser(ctx, channelID, actorUser.BasicUser.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, actorUser.BasicUser, storedUser)
+
+		// second request must use already provisioned user
+		actorUser2, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.Equal(t, actorUser.BasicUser.UUID(), actorUser2.BasicUser.UUID())
+	})
+
+	t.Run("when basic user is provisioned by concurrent request", func(t *testing.T) {
+		basicUserRepository := &racingBasicUserRepository{
+			BasicUserRepositoryMemory: &memory.BasicUserRepositoryMemory{},
+			concurrentUser: user.BasicUser{
+				ExternalUserUUID: ref.ExternalUserUUID(externalUser.Uuid),
+				Name:             "Jan",
+				Surname:          "Novak",
+			},
+		}
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository.BasicUserRepositoryMemory)
+		svc.basicUserRepository = basicUserRepository
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		require.False(t, basicUserRepository.concurrentUserID.IsZero())
+		assert.Equal(t, basicUserRepository.concurrentUserID, actorUser.BasicUser.UUID())
+
+		list, err := basicUserRepository.ListBasicUsers(ctx, channelID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total, "no new basic user should be created")
+	})
+
+	t.Run("when request is made on behalf of other user", func(t *testing.T) {
+		basicUserRepository := &memory.BasicUserRepositoryMemory{}
+
+		onBehalfUser := &usermanagement.User{
+			Uuid:    "cfa1ea4e-3d2a-4f1e-8f6a-9f6e0f5c1b2d",
+			Name:    "Alfred",
+			Surname: "Koletschko",
+		}
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser, onBehalfUser: onBehalfUser}, basicUserRepository)
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, onBehalfUser.Uuid)
+		require.NoError(t, err)
+		assert.Equal(t, ref.ExternalUserUUID(onBehalfUser.Uuid), actorUser.BasicUser.ExternalUserUUID)
+
+		// user who sent the request is kept
+		require.NotNil(t, actorUser.RequestedBy())
+		assert.Equal(t, ref.ExternalUserUUID(externalUser.Uuid), actorUser.RequestedBy().ExternalUserUUID)
+		assert.False(t, actorUser.RequestedBy().UUID().IsZero())
+
+		// request made without on behalf has no requesting user
+		actorUser, err = svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.Nil(t, actorUser.RequestedBy())
+	})
+
+	t.Run("when external user service returns user without UUID", func(t *testing.T) {
+		svc := newTestUserService(userManagementClientStub{user: &usermanagement.User{Name: "Jan"}}, &memory.BasicUserRepositoryMemory{})
+
+		_, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.EqualError(t, err, "user could not be authorized: external user UUID is missing")
+	})
+}
+
+func TestUserService_ActorFromRequestFieldEngineer(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	otherChannelID := ref.ChannelID("1a2b5d2d-bf4d-4c3a-93a3-0f1c2b0a6ea2")
+	ctx := context.Background()
+
+	externalUser := &usermanagement.User{
+		Uuid:    "83b231f2-5898-2658-70f4-5db03d1ccbc1",
+		Name:    "Jan",
+		Surname: "Novak",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUser := user.BasicUser{
+		ExternalUserUUID: ref.ExternalUserUUID(externalUser.Uuid),
+		Name:             "Jan",
+		Surname:          "Novak",
+	}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+	err = basicUser.SetUUID(basicUserID)
+	require.NoError(t, err)
+
+	t.Run("when basic user is not field engineer", func(t *testing.T) {
+		feRepo := &countingFieldEngineerRepository{
+			FieldEngineerRepository: memory.NewFieldEngineerRepositoryMemory(mocks.NewFixedClock(), basicUserRepository),
+		}
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository)
+		svc.fieldEngineerRepository = feRepo
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.False(t, actorUser.IsFieldEngineer())
+
+		// negative result is not cached, so the newly created field engineer is recognized immediately
+		actorUser, err = svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.False(t, actorUser.IsFieldEngineer())
+		assert.Equal(t, 2, feRepo.calls, "repository lookups")
+	})
+
+	t.Run("when basic user is field engineer", func(t *testing.T) {
+		feRepo := &countingFieldEngineerRepository{
+			FieldEngineerRepository: memory.NewFieldEngineerRepositoryMemory(mocks.NewFixedClock(), basicUserRepository),
+		}
+
+		fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+		err := fe.CreatedUpdated.SetCreatedBy(basicUser)
+		require.NoError(t, err)
+		err = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+		require.NoError(t, err)
+		feID, err := feRepo.AddFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository)
+		svc.fieldEngineerRepository = feRepo
+
+		for i := 0; i < 3; i++ {
+			actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+			require.NoError(t, err)
+			require.True(t, actorUser.IsFieldEngineer())
+			assert.Equal(t, feID, *actorUser.FieldEngineerID())
+		}
+		assert.Equal(t, 1, feRepo.calls, "repository lookups")
+
+		// cache is per channel
+		_, err = svc.ActorFromRequest(ctx, "some valid Bearer token", otherChannelID, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, feRepo.calls, "repository lookups")
+	})
+
+	addFieldEngineer := func(t *testing.T, feRepo *countingFieldEngineerRepository) ref.UUID {
+		fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+		err := fe.CreatedUpdated.SetCreatedBy(basicUser)
+		require.NoError(t, err)
+		err = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+		require.NoError(t, err)
+		feID, err := feRepo.AddFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+		return feID
+	}
+
+	t.Run("when cached entry expires", func(t *testing.T) {
+		feRepo := &countingFieldEngineerRepository{
+			FieldEngineerRepository: memory.NewFieldEngineerRepositoryMemory(mocks.NewFixedClock(), basicUserRepository),
+		}
+		addFieldEngineer(t, feRepo)
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository)
+		svc.fieldEngineerRepository = feRepo
+
+		now := time.Now()
+		svc.fieldEngineerCache.now = func() time.Time { return now }
+
+		_, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, feRepo.calls, "repository lookups")
+
+		now = now.Add(2 * time.Minute)
+		_, err = svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, feRepo.calls, "repository lookups")
+	})
+
+	t.Run("when cached entry is invalidated", func(t *testing.T) {
+		feRepo := &countingFieldEngineerRepository{
+			FieldEngineerRepository: memory.NewFieldEngineerRepositoryMemory(mocks.NewFixedClock(), basicUserRepository),
+		}
+		feID := addFieldEngineer(t, feRepo)
+
+		svc := newTestUserService(userManagementClientStub{user: externalUser}, basicUserRepository)
+		svc.fieldEngineerRepository = feRepo
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		require.True(t, actorUser.IsFieldEngineer())
+		assert.Equal(t, 1, feRepo.calls, "repository lookups")
+
+		fe, err := feRepo.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+		err = fe.Deactivate(actor.Actor{BasicUser: basicUser})
+		require.NoError(t, err)
+		_, err = feRepo.UpdateFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+
+		svc.InvalidateFieldEngineer(channelID, basicUser.UUID())
+		assert.Empty(t, svc.fieldEngineerCache.entries, "empty channel entries are removed")
+
+		// deactivated field engineer loses the field engineer identity
+		actorUser, err = svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.False(t, actorUser.IsFieldEngineer())
+		assert.Equal(t, 2, feRepo.calls, "repository lookups")
+	})
+}
+
+func TestUserService_ActorFromRequestDispatcher(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	dispatcherUser := &usermanagement.User{
+		Uuid:    "83b231f2-5898-2658-70f4-5db03d1ccbc1",
+		Name:    "Jan",
+		Surname: "Novak",
+		Type:    "dispatcher",
+	}
+
+	t.Run("when user has the dispatcher type", func(t *testing.T) {
+		svc := newTestUserService(userManagementClientStub{user: dispatcherUser}, &memory.BasicUserRepositoryMemory{})
+		svc.dispatcherUserType = "dispatcher"
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.True(t, actorUser.IsDispatcher())
+	})
+
+	t.Run("when user has other type", func(t *testing.T) {
+		svc := newTestUserService(userManagementClientStub{user: &usermanagement.User{Uuid: dispatcherUser.Uuid, Type: "agent"}}, &memory.BasicUserRepositoryMemory{})
+		svc.dispatcherUserType = "dispatcher"
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.False(t, actorUser.IsDispatcher())
+	})
+
+	t.Run("when dispatcher role is disabled", func(t *testing.T) {
+		svc := newTestUserService(userManagementClientStub{user: &usermanagement.User{Uuid: dispatcherUser.Uuid}}, &memory.BasicUserRepositoryMemory{})
+
+		actorUser, err := svc.ActorFromRequest(ctx, "some valid Bearer token", channelID, "")
+		require.NoError(t, err)
+		assert.False(t, actorUser.IsDispatcher())
+	})
+}
@@ -0,0 +1,91 @@
+package externalusersvc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+)
+
+// fieldEngineerCache caches field engineer IDs of basic users per channel, so the repository does not have to be queried on every request.
+// Only basic users that are field engineers are cached. Entries expire after ttl, zero ttl disables caching.
+type fieldEngineerCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[ref.ChannelID]map[ref.UUID]fieldEngineerCacheEntry
+}
+
+type fieldEngineerCacheEntry struct {
+	fieldEngineerID ref.UUID
+	expiresAt       time.Time
+}
+
+func newFieldEngineerCache(ttl time.Duration) *fieldEngineerCache {
+	return &fieldEngineerCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[ref.ChannelID]map[ref.UUID]fieldEngineerCacheEntry),
+	}
+}
+
+// get returns cached field engineer ID of the basic user; found is false if there is no valid entry in the cache.
+// Expired entry is removed from the cache.
+func (c *fieldEngineerCache) get(channelID ref.ChannelID, basicUserID ref.UUID) (fieldEngineerID ref.UUID, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[channelID][basicUserID]
+	if !ok {
+		return "", false
+	}
+
+	if !c.now().Before(entry.expiresAt) {
+		c.delete(channelID, basicUserID)
+		return "", false
+	}
+
+	return entry.fieldEngineerID, true
+}
+
+// set stores field engineer ID of the basic user
+func (c *fieldEngineerCache) set(channelID ref.ChannelID, basicUserID ref.UUID, fieldEngineerID ref.UUID) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channelEntries, ok := c.entries[channelID]
+	if !ok {
+		channelEntries = make(map[ref.UUID]fieldEngineerCacheEntry)
+		c.entries[channelID] = channelEntries
+	}
+
+	channelEntries[basicUserID] = fieldEngineerCacheEntry{
+		fieldEngineerID: fieldEngineerID,
+		expiresAt:       c.now().Add(c.ttl),
+	}
+}
+
+// invalidate removes cached field engineer ID of the basic user
+func (c *fieldEngineerCache) invalidate(channelID ref.ChannelID, basicUserID ref.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.delete(channelID, basicUserID)
+}
+
+// delete removes the entry, the caller must hold the lock
+func (c *fieldEngineerCache) delete(channelID ref.ChannelID, basicUserID ref.UUID) {
+	channelEntries, ok := c.entries[channelID]
+	if !ok {
+		return
+	}
+
+	delete(channelEntries, basicUserID)
+	if len(channelEntries) == 0 {
+		delete(c.entries, channelID)
+	}
+}
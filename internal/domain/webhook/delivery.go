@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// DeliveryStatus of the webhook delivery
+type DeliveryStatus string
+
+func (s DeliveryStatus) String() string {
+	return string(s)
+}
+
+// DeliveryStatus values
+const (
+	// DeliveryStatusPending means the payload was not delivered yet and the delivery will be attempted (again)
+	DeliveryStatusPending DeliveryStatus = "Pending"
+
+	// DeliveryStatusDelivered means the receiver accepted the payload
+	DeliveryStatusDelivered DeliveryStatus = "Delivered"
+
+	// DeliveryStatusDeadLettered means all delivery attempts failed, the delivery will not be attempted again
+	DeliveryStatusDeadLettered DeliveryStatus = "DeadLettered"
+)
+
+// NewDeliveryStatusFromString returns the delivery status with the given name or error if the status does not exist
+func NewDeliveryStatusFromString(statusStr string) (DeliveryStatus, error) {
+	switch s := DeliveryStatus(statusStr); s {
+	case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusDeadLettered:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown webhook delivery status '%s'", statusStr)
+	}
+}
+
+// Payload is the JSON body sent to the subscribed URL
+type Payload struct {
+	// ID of the event, it is the same in all deliveries of the event (receivers can use it to detect duplicates)
+	ID ref.UUID `json:"id"`
+
+	Type event.Type `json:"type"`
+
+	ChannelID ref.ChannelID `json:"channel_id"`
+
+	// ID of the aggregate that recorded the event (ie. incident or field engineer)
+	AggregateID ref.UUID `json:"aggregate_id"`
+
+	OccurredAt types.DateTime `json:"occurred_at"`
+
+	// The event itself
+	Data json.RawMessage `json:"data"`
+}
+
+// Delivery of one event to the subscribed URL, it keeps the state of the delivery attempts
+type Delivery struct {
+	uuid ref.UUID
+
+	SubscriptionID ref.UUID
+
+	// ID of the delivered event
+	EventID ref.UUID
+
+	EventType event.Type
+
+	// JSON encoded Payload, the same body is sent in every attempt
+	Payload []byte
+
+	Status DeliveryStatus
+
+	// Number of failed and successful attempts
+	Attempts uint
+
+	// Time of the next attempt of the pending delivery
+	NextAttemptAt types.DateTime
+
+	LastAttemptAt types.DateTime
+
+	// HTTP status code of the last response, it is zero if the request failed without response
+	LastResponseStatus int
+
+	// Reason of the last failed attempt
+	LastError string
+
+	CreatedAt types.DateTime
+}
+
+// NewDelivery returns new pending delivery of the payload to be attempted immediately
+func NewDelivery(clock domain.Clock, subscriptionID ref.UUID, payload Payload) (Delivery, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Delivery{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not encode webhook payload")
+	}
+
+	now := clock.NowFormatted()
+
+	return Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        payload.ID,
+		EventType:      payload.Type,
+		Payload:        body,
+		Status:         DeliveryStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}, nil
+}
+
+// UUID getter
+func (e Delivery) UUID() ref.UUID {
+	return e.uuid
+}
+
+// SetUUID returns error if UUID was already set
+func (e *Delivery) SetUUID(v ref.UUID) error {
+	if !e.uuid.IsZero() {
+		return fmt.Errorf("webhook delivery: cannot set UUID, it was already set (%s)", e.uuid)
+	}
+	e.uuid = v
+	return nil
+}
+
+// RecordSuccess marks the delivery as delivered
+func (e *Delivery) RecordSuccess(clock domain.Clock, responseStatus int) {
+	e.Attempts++
+	e.Status = DeliveryStatusDelivered
+	e.LastAttemptAt = clock.NowFormatted()
+	e.LastResponseStatus = responseStatus
+	e.LastError = ""
+	e.NextAttemptAt = ""
+}
+
+// RecordFailure schedules the next attempt according to the retry policy, the delivery is dead-lettered
+// when the maximal number of attempts is reached
+func (e *Delivery) RecordFailure(clock domain.Clock, responseStatus int, reason string, policy RetryPolicy) {
+	e.Attempts++
+	e.LastAttemptAt = clock.NowFormatted()
+	e.LastResponseStatus = responseStatus
+	e.LastError = reason
+
+	if e.Attempts >= policy.MaxAttempts {
+		e.Status = DeliveryStatusDeadLettered
+		e.NextAttemptAt = ""
+		return
+	}
+
+	e.NextAttemptAt = types.DateTime(clock.Now().Add(policy.Backoff(e.Attempts)).Format(time.RFC3339))
+}
+
+// RetryPolicy defines how many times and how often the delivery is attempted
+type RetryPolicy struct {
+	// MaxAttempts is the number of failed attempts after which the delivery is dead-lettered
+	MaxAttempts uint
+
+	// InitialBackoff is the delay after the first failed attempt, it doubles after each next failed attempt
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits the delay between the attempts
+	MaxBackoff time.Duration
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts
+func (p RetryPolicy) Backoff(failedAttempts uint) time.Duration {
+	backoff := p.InitialBackoff
+	for i := uint(1); i < failedAttempts; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,47 @@
+package webhooksvc
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// WebhookService provides webhook subscription operations and delivers the domain events to the subscribed URLs
+type WebhookService interface {
+	// CreateSubscription creates new webhook subscription and adds it to the repository
+	CreateSubscription(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateWebhookParams) (ref.UUID, error)
+
+	// GetSubscription returns the webhook subscription with the given ID from the repository
+	GetSubscription(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (webhook.Subscription, error)
+
+	// ListSubscriptions returns the list of webhook subscriptions from the repository
+	ListSubscriptions(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams PaginationParams) (repository.WebhookSubscriptionList, error)
+
+	// DeleteSubscription deletes the webhook subscription and its deliveries, pending deliveries are not attempted anymore
+	DeleteSubscription(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) error
+
+	// ListDeliveries returns the delivery log of the webhook subscription
+	ListDeliveries(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, subscriptionID ref.UUID, paginationParams PaginationParams) (repository.WebhookDeliveryList, error)
+
+	// Publish creates pending deliveries of the outbox message for all subscriptions of the message's channel
+	// matching the event type. It implements outbox.Publisher.
+	Publish(ctx context.Context, msg repository.OutboxMessage) error
+
+	// DeliverDue sends one batch of the due deliveries to the subscribed URLs and returns the number of attempted deliveries.
+	// Failed deliveries are retried with exponential backoff and dead-lettered after the maximal number of attempts.
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+// PaginationParams provides information about current requested page number and a number of items per page to be displayed.
+// It has the same method set as converters.PaginationParams (which cannot be imported here because of the import cycle).
+type PaginationParams interface {
+	// Page is the requested page number to be returned
+	Page() uint
+
+	// ItemsPerPage returns how many items per page should be displayed
+	ItemsPerPage() uint
+}
@@ -0,0 +1,175 @@
+package webhooksvc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// subscriptionsPageSize is the number of subscriptions loaded at once when the event is published
+const subscriptionsPageSize = 100
+
+// maxResponseBodySize limits how much of the response body is read before the connection is reused
+const maxResponseBodySize = 64 * 1024
+
+// NewWebhookService creates the webhook service. Deliveries are sent by the client, up to batchSize deliveries are attempted at once.
+func NewWebhookService(repo repository.WebhookRepository, clock domain.Clock, client *http.Client, retryPolicy webhook.RetryPolicy, batchSize uint) WebhookService {
+	return &webhookService{
+		repo:        repo,
+		clock:       clock,
+		client:      client,
+		retryPolicy: retryPolicy,
+		batchSize:   batchSize,
+	}
+}
+
+type webhookService struct {
+	repo        repository.WebhookRepository
+	clock       domain.Clock
+	client      *http.Client
+	retryPolicy webhook.RetryPolicy
+	batchSize   uint
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateWebhookParams) (ref.UUID, error) {
+	newSubscription := webhook.Subscription{
+		URL:    params.URL,
+		Secret: params.Secret,
+	}
+
+	for _, t := range params.EventTypes {
+		newSubscription.EventTypes = append(newSubscription.EventTypes, event.Type(t))
+	}
+
+	if err := newSubscription.Validate(); err != nil {
+		return ref.UUID(""), err
+	}
+
+	if err := newSubscription.CreatedUpdated.SetCreatedBy(actor.BasicUser); err != nil {
+		return ref.UUID(""), err
+	}
+	if err := newSubscription.CreatedUpdated.SetUpdatedBy(actor.BasicUser); err != nil {
+		return ref.UUID(""), err
+	}
+
+	return s.repo.AddWebhookSubscription(ctx, channelID, newSubscription)
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, ID ref.UUID) (webhook.Subscription, error) {
+	return s.repo.GetWebhookSubscription(ctx, channelID, ID)
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, params PaginationParams) (repository.WebhookSubscriptionList, error) {
+	return s.repo.ListWebhookSubscriptions(ctx, channelID, params.Page(), params.ItemsPerPage())
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, ID ref.UUID) error {
+	return s.repo.DeleteWebhookSubscription(ctx, channelID, ID)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, channelID ref.ChannelID, _ actor.Actor, subscriptionID ref.UUID, params PaginationParams) (repository.WebhookDeliveryList, error) {
+	return s.repo.ListWebhookDeliveries(ctx, channelID, subscriptionID, params.Page(), params.ItemsPerPage())
+}
+
+func (s *webhookService) Publish(ctx context.Context, msg repository.OutboxMessage) error {
+	payload := webhook.Payload{
+		ID:          msg.ID,
+		Type:        msg.EventType,
+		ChannelID:   msg.ChannelID,
+		AggregateID: msg.AggregateID,
+		OccurredAt:  msg.OccurredAt,
+		Data:        msg.Payload,
+	}
+
+	for page := uint(1); ; page++ {
+		list, err := s.repo.ListWebhookSubscriptions(ctx, msg.ChannelID, page, subscriptionsPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range list.Result {
+			if !sub.Matches(msg.EventType) {
+				continue
+			}
+
+			delivery, err := webhook.NewDelivery(s.clock, sub.UUID(), payload)
+			if err != nil {
+				return err
+			}
+
+			if _, err := s.repo.AddWebhookDelivery(ctx, msg.ChannelID, delivery); err != nil {
+				return err
+			}
+		}
+
+		if list.Next == 0 {
+			return nil
+		}
+	}
+}
+
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	due, err := s.repo.ListDueWebhookDeliveries(ctx, s.clock.Now(), s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, d := range due {
+		sub, err := s.repo.GetWebhookSubscription(ctx, d.ChannelID, d.SubscriptionID)
+		if err != nil {
+			return i, err
+		}
+
+		delivery := d.Delivery
+		responseStatus, err := s.send(ctx, sub, delivery)
+		if err != nil {
+			delivery.RecordFailure(s.clock, responseStatus, err.Error(), s.retryPolicy)
+		} else {
+			delivery.RecordSuccess(s.clock, responseStatus)
+		}
+
+		if err := s.repo.UpdateWebhookDelivery(ctx, d.ChannelID, delivery); err != nil {
+			return i + 1, err
+		}
+	}
+
+	return len(due), nil
+}
+
+// send posts the delivery payload to the subscribed URL, it returns error if the response status is not 2xx
+func (s *webhookService) send(ctx context.Context, sub webhook.Subscription, delivery webhook.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventTypeHeader, delivery.EventType.String())
+	req.Header.Set(webhook.DeliveryIDHeader, delivery.UUID().String())
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(sub.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
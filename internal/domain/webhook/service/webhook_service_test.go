@@ -0,0 +1,315 @@
+package webhooksvc_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	webhooksvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "0123456789abcdef"
+
+// receivedRequest is a request received by the test receiver
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a webhook receiver responding with the preset status codes, the last status code is repeated
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+func (rcv *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	rcv.requests = append(rcv.requests, receivedRequest{header: r.Header.Clone(), body: body})
+
+	status := rcv.statuses[0]
+	if len(rcv.statuses) > 1 {
+		rcv.statuses = rcv.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rcv *receiver) received() []receivedRequest {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	return append([]receivedRequest(nil), rcv.requests...)
+}
+
+type testEnv struct {
+	ctx       context.Context
+	channelID ref.ChannelID
+	actor     actor.Actor
+	clock     *mocks.FixedClock
+	svc       webhooksvc.WebhookService
+}
+
+func newTestEnv(t *testing.T) testEnv {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	basicUserRepository := &memory.BasicUserRepositoryMemory{}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+	require.NoError(t, basicUser.SetUUID(basicUserID))
+
+	clock := mocks.NewFixedClock()
+	webhookRepository := memory.NewWebhookRepositoryMemory(clock, basicUserRepository)
+
+	retryPolicy := webhook.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+	}
+
+	return testEnv{
+		ctx:       ctx,
+		channelID: channelID,
+		actor:     actor.Actor{BasicUser: basicUser},
+		clock:     clock,
+		svc:       webhooksvc.NewWebhookService(webhookRepository, clock, &http.Client{Timeout: 5 * time.Second}, retryPolicy, 10),
+	}
+}
+
+func (env testEnv) stateChangedMessage(t *testing.T, id ref.UUID) repository.OutboxMessage {
+	payload, err := json.Marshal(event.IncidentStateChanged{
+		IncidentID: "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e",
+		From:       "New",
+		To:         "In progress",
+	})
+	require.NoError(t, err)
+
+	return repository.OutboxMessage{
+		ID:          id,
+		ChannelID:   env.channelID,
+		EventType:   event.TypeIncidentStateChanged,
+		AggregateID: "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e",
+		Payload:     payload,
+		OccurredAt:  env.clock.NowFormatted(),
+	}
+}
+
+func paginationParams() *mocks.PaginationParamsMock {
+	params := new(mocks.PaginationParamsMock)
+	params.On("Page").Return(uint(1))
+	params.On("ItemsPerPage").Return(uint(10))
+	return params
+}
+
+func Test_webhookService_Subscriptions(t *testing.T) {
+	env := newTestEnv(t)
+
+	subID, err := env.svc.CreateSubscription(env.ctx, env.channelID, env.actor, api.CreateWebhookParams{
+		URL:        "https://example.com/hooks",
+		Secret:     secret,
+		EventTypes: []string{"IncidentStateChanged"},
+	})
+	require.NoError(t, err)
+
+	sub, err := env.svc.GetSubscription(env.ctx, env.channelID, env.actor, subID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hooks", sub.URL)
+	assert.Equal(t, []event.Type{event.TypeIncidentStateChanged}, sub.EventTypes)
+	assert.Equal(t, env.actor.BasicUser.UUID(), sub.CreatedUpdated.CreatedByID())
+
+	_, err = env.svc.CreateSubscription(env.ctx, env.channelID, env.actor, api.CreateWebhookParams{
+		URL:        "https://example.com/hooks",
+		Secret:     secret,
+		EventTypes: []string{"IncidentExploded"},
+	})
+	require.Error(t, err)
+	var domainErr *domain.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeInvalidArgument, domainErr.Code())
+
+	list, err := env.svc.ListSubscriptions(env.ctx, env.channelID, env.actor, paginationParams())
+	require.NoError(t, err)
+	require.Len(t, list.Result, 1)
+	assert.Equal(t, subID, list.Result[0].UUID())
+
+	require.NoError(t, env.svc.DeleteSubscription(env.ctx, env.channelID, env.actor, subID))
+
+	_, err = env.svc.GetSubscription(env.ctx, env.channelID, env.actor, subID)
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNotFound, domainErr.Code())
+}
+
+func Test_webhookService_PublishAndDeliver(t *testing.T) {
+	env := newTestEnv(t)
+
+	rcv := &receiver{statuses: []int{http.StatusNoContent}}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	subID, err := env.svc.CreateSubscription(env.ctx, env.channelID, env.actor, api.CreateWebhookParams{
+		URL:        server.URL,
+		Secret:     secret,
+		EventTypes: []string{"IncidentStateChanged"},
+	})
+	require.NoError(t, err)
+
+	// subscription not matching the event type
+	otherSubID, err := env.svc.CreateSubscription(env.ctx, env.channelID, env.actor, api.CreateWebhookParams{
+		URL:        server.URL + "/other",
+		Secret:     secret,
+		EventTypes: []string{"IncidentFieldEngineerAssigned"},
+	})
+	require.NoError(t, err)
+
+	msg := env.stateChangedMessage(t, "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e")
+	require.NoError(t, env.svc.Publish(env.ctx, msg))
+
+	n, err := env.svc.DeliverDue(env.ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	requests := rcv.received()
+	require.Len(t, requests, 1)
+	req := requests[0]
+
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "IncidentStateChanged", req.header.Get(webhook.EventTypeHeader))
+	assert.True(t, webhook.VerifySignature(secret, req.body, req.header.Get(webhook.SignatureHeader)))
+	assert.JSONEq(t, `{
+		"id": "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e",
+		"type": "IncidentStateChanged",
+		"channel_id": "e27ddcd0-0e1f-4bc5-93df-f6f04155beec",
+		"aggregate_id": "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e",
+		"occurred_at": "`+env.clock.NowFormatted().String()+`",
+		"data": {"incident_id": "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e", "from": "New", "to": "In progress"}
+	}`, string(req.body))
+
+	deliveries, err := env.svc.ListDeliveries(env.ctx, env.channelID, env.actor, subID, paginationParams())
+	require.NoError(t, err)
+	require.Len(t, deliveries.Result, 1)
+	delivery := deliveries.Result[0]
+	assert.Equal(t, delivery.UUID().String(), req.header.Get(webhook.DeliveryIDHeader))
+	assert.Equal(t, webhook.DeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, uint(1), delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.LastResponseStatus)
+
+	deliveries, err = env.svc.ListDeliveries(env.ctx, env.channelID, env.actor, otherSubID, paginationParams())
+	require.NoError(t, err)
+	assert.Empty(t, deliveries.Result)
+
+	// nothing is due anymore
+	n, err = env.svc.DeliverDue(env.ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func Test_webhookService_RetryAndDeadLetter(t *testing.T) {
+	env := newTestEnv(t)
+
+	rcv := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusOK}}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	subID, err := env.svc.CreateSubscription(env.ctx, env.channelID, env.actor, api.CreateWebhookParams{
+		URL:    server.URL,
+		Secret: secret,
+	})
+	require.NoError(t, err)
+
+	listDeliveries := func() []webhook.Delivery {
+		deliveries, err := env.svc.ListDeliveries(env.ctx, env.channelID, env.actor, subID, paginationParams())
+		require.NoError(t, err)
+		return deliveries.Result
+	}
+
+	t.Run("failed delivery is retried with backoff", func(t *testing.T) {
+		require.NoError(t, env.svc.Publish(env.ctx, env.stateChangedMessage(t, "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e")))
+
+		n, err := env.svc.DeliverDue(env.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		deliveries := listDeliveries()
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, webhook.DeliveryStatusPending, deliveries[0].Status)
+		assert.Equal(t, http.StatusInternalServerError, deliveries[0].LastResponseStatus)
+		assert.Equal(t, "unexpected response status 500", deliveries[0].LastError)
+
+		// not due before the backoff elapses
+		env.clock.AddTime(59 * time.Second)
+		n, err = env.svc.DeliverDue(env.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+
+		env.clock.AddTime(time.Second)
+		n, err = env.svc.DeliverDue(env.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		deliveries = listDeliveries()
+		assert.Equal(t, webhook.DeliveryStatusDelivered, deliveries[0].Status)
+		assert.Equal(t, uint(2), deliveries[0].Attempts)
+		assert.Empty(t, deliveries[0].LastError)
+
+		requests := rcv.received()
+		require.Len(t, requests, 2)
+		assert.Equal(t, requests[0].body, requests[1].body)
+		assert.Equal(t, requests[0].header.Get(webhook.DeliveryIDHeader), requests[1].header.Get(webhook.DeliveryIDHeader))
+	})
+
+	t.Run("delivery is dead-lettered after the maximal number of attempts", func(t *testing.T) {
+		server.Close() // receiver is down
+
+		require.NoError(t, env.svc.Publish(env.ctx, env.stateChangedMessage(t, "0f2b53c4-2c1f-4c57-9e62-2a7e28e4d5d1")))
+
+		for _, backoff := range []time.Duration{0, time.Minute, 2 * time.Minute} {
+			env.clock.AddTime(backoff)
+			n, err := env.svc.DeliverDue(env.ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+		}
+
+		deliveries := listDeliveries()
+		require.Len(t, deliveries, 2)
+		dead := deliveries[1]
+		assert.Equal(t, webhook.DeliveryStatusDeadLettered, dead.Status)
+		assert.Equal(t, uint(3), dead.Attempts)
+		assert.Zero(t, dead.LastResponseStatus)
+		assert.NotEmpty(t, dead.LastError)
+		assert.True(t, dead.NextAttemptAt.IsZero())
+
+		env.clock.AddTime(24 * time.Hour)
+		n, err := env.svc.DeliverDue(env.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
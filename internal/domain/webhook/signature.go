@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HTTP headers sent with the payload
+const (
+	// SignatureHeader contains HMAC-SHA256 of the request body computed with the subscription secret ('sha256=<hex digest>')
+	SignatureHeader = "X-Webhook-Signature"
+
+	// EventTypeHeader contains the type of the delivered event
+	EventTypeHeader = "X-Webhook-Event"
+
+	// DeliveryIDHeader contains ID of the delivery, it is the same in all attempts
+	DeliveryIDHeader = "X-Webhook-Delivery"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the body to be sent in SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature returns true if the signature of the body is valid, receivers can use it to check the payload authenticity
+func VerifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
@@ -0,0 +1,80 @@
+// Package webhook contains webhook subscriptions of the channels and the deliveries of the domain events to the subscribed URLs
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// MinSecretLength is the minimal length of the secret the payloads are signed with
+const MinSecretLength = 16
+
+// Subscription of the channel to the domain events, the events are sent to the URL as HMAC signed JSON payloads
+type Subscription struct {
+	uuid ref.UUID
+
+	// URL the events are sent to
+	URL string
+
+	// Secret the payloads are signed with, it is never returned by the API
+	Secret string
+
+	// Types of the events sent to the URL, empty list means all events
+	EventTypes []event.Type
+
+	CreatedUpdated types.CreatedUpdated
+}
+
+// UUID getter
+func (e Subscription) UUID() ref.UUID {
+	return e.uuid
+}
+
+// SetUUID returns error if UUID was already set
+func (e *Subscription) SetUUID(v ref.UUID) error {
+	if !e.uuid.IsZero() {
+		return fmt.Errorf("webhook subscription: cannot set UUID, it was already set (%s)", e.uuid)
+	}
+	e.uuid = v
+	return nil
+}
+
+// Validate returns error if the URL is not absolute HTTP(S) URL, the secret is too short or the event type is unknown
+func (e Subscription) Validate() error {
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "webhook URL must be absolute HTTP or HTTPS URL")
+	}
+
+	if len(e.Secret) < MinSecretLength {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "webhook secret must be at least %d characters long", MinSecretLength)
+	}
+
+	for _, t := range e.EventTypes {
+		if _, err := event.NewTypeFromString(t.String()); err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid webhook event type")
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the events of the given type are sent to the subscribed URL
+func (e Subscription) Matches(t event.Type) bool {
+	if len(e.EventTypes) == 0 {
+		return true
+	}
+
+	for _, et := range e.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+
+	return false
+}
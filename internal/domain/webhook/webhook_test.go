@@ -0,0 +1,202 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	. "github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// TestInit initializes test suite
+func TestInit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Webhook tests")
+}
+
+var _ = Describe("Subscription behavior", func() {
+	var sub Subscription
+
+	BeforeEach(func() {
+		sub = Subscription{
+			URL:    "https://example.com/hooks/itsm",
+			Secret: "0123456789abcdef",
+		}
+	})
+
+	Describe("Validate()", func() {
+		It("should accept valid subscription", func() {
+			Expect(sub.Validate()).To(BeNil())
+
+			sub.EventTypes = []event.Type{event.TypeIncidentStateChanged, event.TypeIncidentFieldEngineerAssigned}
+			Expect(sub.Validate()).To(BeNil())
+		})
+
+		Context("if the subscription is not valid", func() {
+			It("should return error if the URL is not absolute HTTP URL", func() {
+				sub.URL = "/hooks"
+				expectInvalidArgumentError(sub.Validate(), "webhook URL must be absolute HTTP or HTTPS URL")
+
+				sub.URL = "ftp://example.com/hooks"
+				expectInvalidArgumentError(sub.Validate(), "webhook URL must be absolute HTTP or HTTPS URL")
+			})
+
+			It("should return error if the secret is too short", func() {
+				sub.Secret = "secret"
+				expectInvalidArgumentError(sub.Validate(), "webhook secret must be at least 16 characters long")
+			})
+
+			It("should return error if the event type is unknown", func() {
+				sub.EventTypes = []event.Type{event.TypeIncidentStateChanged, "IncidentExploded"}
+				expectInvalidArgumentError(sub.Validate(), "unknown event type 'IncidentExploded'")
+			})
+		})
+	})
+
+	Describe("Matches()", func() {
+		It("should match all events if no event types are set", func() {
+			for _, t := range event.Types() {
+				Expect(sub.Matches(t)).To(BeTrue())
+			}
+		})
+
+		It("should match only the event types that are set", func() {
+			sub.EventTypes = []event.Type{event.TypeIncidentStateChanged}
+			Expect(sub.Matches(event.TypeIncidentStateChanged)).To(BeTrue())
+			Expect(sub.Matches(event.TypeIncidentFieldEngineerAssigned)).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("Delivery behavior", func() {
+	var clock *mocks.FixedClock
+	var policy RetryPolicy
+	var delivery Delivery
+
+	BeforeEach(func() {
+		clock = mocks.NewFixedClock()
+		policy = RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Minute,
+			MaxBackoff:     time.Hour,
+		}
+
+		var err error
+		delivery, err = NewDelivery(clock, "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", Payload{
+			ID:          "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e",
+			Type:        event.TypeIncidentStateChanged,
+			ChannelID:   "e27ddcd0-0e1f-4bc5-93df-f6f04155beec",
+			AggregateID: "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e",
+			OccurredAt:  clock.NowFormatted(),
+			Data:        json.RawMessage(`{"from":"New","to":"In progress"}`),
+		})
+		Expect(err).To(BeNil())
+	})
+
+	Describe("NewDelivery()", func() {
+		It("should create pending delivery due immediately", func() {
+			Expect(delivery.Status).To(Equal(DeliveryStatusPending))
+			Expect(delivery.Attempts).To(BeZero())
+			Expect(delivery.NextAttemptAt).To(Equal(clock.NowFormatted()))
+			Expect(delivery.EventID.String()).To(Equal("b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e"))
+			Expect(delivery.EventType).To(Equal(event.TypeIncidentStateChanged))
+			Expect(string(delivery.Payload)).To(MatchJSON(`{
+				"id": "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e",
+				"type": "IncidentStateChanged",
+				"channel_id": "e27ddcd0-0e1f-4bc5-93df-f6f04155beec",
+				"aggregate_id": "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e",
+				"occurred_at": "` + clock.NowFormatted().String() + `",
+				"data": {"from": "New", "to": "In progress"}
+			}`))
+		})
+	})
+
+	Describe("RecordSuccess()", func() {
+		It("should mark the delivery as delivered", func() {
+			delivery.RecordFailure(clock, http.StatusBadGateway, "unexpected response status 502", policy)
+			clock.AddTime(time.Minute)
+
+			delivery.RecordSuccess(clock, http.StatusNoContent)
+			Expect(delivery.Status).To(Equal(DeliveryStatusDelivered))
+			Expect(delivery.Attempts).To(Equal(uint(2)))
+			Expect(delivery.LastAttemptAt).To(Equal(clock.NowFormatted()))
+			Expect(delivery.LastResponseStatus).To(Equal(http.StatusNoContent))
+			Expect(delivery.LastError).To(BeEmpty())
+			Expect(delivery.NextAttemptAt.IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("RecordFailure()", func() {
+		It("should schedule the next attempt with exponential backoff and dead-letter the delivery after the last attempt", func() {
+			delivery.RecordFailure(clock, http.StatusInternalServerError, "unexpected response status 500", policy)
+			Expect(delivery.Status).To(Equal(DeliveryStatusPending))
+			Expect(delivery.Attempts).To(Equal(uint(1)))
+			Expect(delivery.LastResponseStatus).To(Equal(http.StatusInternalServerError))
+			Expect(delivery.LastError).To(Equal("unexpected response status 500"))
+			Expect(delivery.NextAttemptAt).To(Equal(types.DateTime(clock.Now().Add(time.Minute).Format(time.RFC3339))))
+
+			clock.AddTime(time.Minute)
+			delivery.RecordFailure(clock, 0, "connection refused", policy)
+			Expect(delivery.Status).To(Equal(DeliveryStatusPending))
+			Expect(delivery.LastResponseStatus).To(BeZero())
+			Expect(delivery.NextAttemptAt).To(Equal(types.DateTime(clock.Now().Add(2 * time.Minute).Format(time.RFC3339))))
+
+			clock.AddTime(2 * time.Minute)
+			delivery.RecordFailure(clock, 0, "connection refused", policy)
+			Expect(delivery.Status).To(Equal(DeliveryStatusDeadLettered))
+			Expect(delivery.Attempts).To(Equal(uint(3)))
+			Expect(delivery.LastAttemptAt).To(Equal(clock.NowFormatted()))
+			Expect(delivery.NextAttemptAt.IsZero()).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("RetryPolicy behavior", func() {
+	Describe("Backoff()", func() {
+		It("should double the backoff after each failed attempt up to the maximal backoff", func() {
+			policy := RetryPolicy{
+				MaxAttempts:    10,
+				InitialBackoff: 30 * time.Second,
+				MaxBackoff:     5 * time.Minute,
+			}
+
+			Expect(policy.Backoff(1)).To(Equal(30 * time.Second))
+			Expect(policy.Backoff(2)).To(Equal(time.Minute))
+			Expect(policy.Backoff(3)).To(Equal(2 * time.Minute))
+			Expect(policy.Backoff(4)).To(Equal(4 * time.Minute))
+			Expect(policy.Backoff(5)).To(Equal(5 * time.Minute))
+			Expect(policy.Backoff(50)).To(Equal(5 * time.Minute))
+		})
+	})
+})
+
+var _ = Describe("Signature", func() {
+	It("should sign the body with HMAC-SHA256", func() {
+		body := []byte(`{"id":"b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e"}`)
+
+		signature := Sign("0123456789abcdef", body)
+		Expect(signature).To(HavePrefix("sha256="))
+		Expect(signature).To(HaveLen(len("sha256=") + 64))
+
+		Expect(VerifySignature("0123456789abcdef", body, signature)).To(BeTrue())
+		Expect(VerifySignature("other secret 123", body, signature)).To(BeFalse())
+		Expect(VerifySignature("0123456789abcdef", []byte(`{}`), signature)).To(BeFalse())
+	})
+})
+
+func expectInvalidArgumentError(err error, expectedMsg string) {
+	Expect(err).ToNot(BeNil())
+
+	var domainErr *domain.Error
+	Expect(errors.As(err, &domainErr)).To(BeTrue())
+	Expect(domainErr.Code()).To(Equal(domain.ErrorCodeInvalidArgument))
+	Expect(err.Error()).To(ContainSubstring(expectedMsg))
+}
@@ -0,0 +1,197 @@
+package grpcserver
+
+import (
+	"strings"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/api/ticketmanagement"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type paginationParams struct {
+	page         uint
+	itemsPerPage uint
+}
+
+// newPaginationParams returns params of the requested page, the first page is requested if the page number is not set
+func newPaginationParams(page uint32, actorUser actor.Actor) converters.PaginationParams {
+	if page == 0 {
+		page = 1
+	}
+
+	return &paginationParams{
+		page:         uint(page),
+		itemsPerPage: actorUser.BasicUser.ItemsPerPage(),
+	}
+}
+
+func (p paginationParams) Page() uint {
+	return p.page
+}
+
+func (p paginationParams) ItemsPerPage() uint {
+	return p.itemsPerPage
+}
+
+func (p paginationParams) CursorPage() *repository.CursorPage {
+	return nil
+}
+
+// fieldEngineerIDFromRequest returns nil if the field engineer UUID is empty
+func fieldEngineerIDFromRequest(feID string) *api.UUID {
+	if feID == "" {
+		return nil
+	}
+
+	id := api.UUID(feID)
+	return &id
+}
+
+// newIncidentFilter returns the filter and the sort order of the listed incidents
+func newIncidentFilter(req *ticketmanagement.ListIncidentsRequest) (repository.IncidentFilter, error) {
+	var filter repository.IncidentFilter
+	var err error
+
+	for _, stateStr := range req.GetStates() {
+		state, err := incident.NewStateFromString(strings.ReplaceAll(strings.TrimSpace(stateStr), "_", " "))
+		if err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "incorrect 'states' value: '%s'", stateStr)
+		}
+		filter.States = append(filter.States, state)
+	}
+
+	if feID := req.GetFieldEngineer(); feID != "" {
+		if _, err := uuid.Parse(feID); err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "incorrect 'field_engineer' value: '%s'", feID)
+		}
+		id := ref.UUID(feID)
+		filter.FieldEngineerID = &id
+	}
+
+	filter.Number = req.GetNumber()
+	filter.ExternalID = req.GetExternalId()
+	filter.Text = strings.TrimSpace(req.GetText())
+
+	if filter.CreatedFrom, err = parseDateTime(req.GetCreatedFrom(), "created_from"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseDateTime(req.GetCreatedTo(), "created_to"); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedFrom, err = parseDateTime(req.GetUpdatedFrom(), "updated_from"); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedTo, err = parseDateTime(req.GetUpdatedTo(), "updated_to"); err != nil {
+		return filter, err
+	}
+
+	if sortParam := req.GetSort(); sortParam != "" {
+		sortField := strings.TrimPrefix(sortParam, "-")
+		switch repository.IncidentSortField(sortField) {
+		case repository.IncidentSortByCreatedAt, repository.IncidentSortByUpdatedAt, repository.IncidentSortByNumber, repository.IncidentSortByPriority:
+			filter.Sort = repository.IncidentSort{
+				Field:      repository.IncidentSortField(sortField),
+				Descending: strings.HasPrefix(sortParam, "-"),
+			}
+		default:
+			return filter, status.Errorf(codes.InvalidArgument, "incorrect 'sort' value: '%s'", sortParam)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseDateTime parses RFC3339 date time, empty value means unbounded range
+func parseDateTime(value, name string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "incorrect '%s' value: '%s'", name, value)
+	}
+
+	return t, nil
+}
+
+func convertIncidentToProto(inc incident.Incident) *ticketmanagement.Incident {
+	var timelogUUIDs []string
+	for _, timelogID := range inc.Timelogs {
+		timelogUUIDs = append(timelogUUIDs, timelogID.String())
+	}
+
+	var feUUID string
+	if inc.FieldEngineerID != nil {
+		feUUID = inc.FieldEngineerID.String()
+	}
+
+	protoInc := &ticketmanagement.Incident{
+		Uuid:             inc.UUID().String(),
+		Number:           inc.Number,
+		ExternalId:       inc.ExternalID,
+		ShortDescription: inc.ShortDescription,
+		Description:      inc.Description,
+		FieldEngineer:    feUUID,
+		State:            inc.State().String(),
+		Timelogs:         timelogUUIDs,
+		CreatedBy:        inc.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:        inc.CreatedUpdated.CreatedAt().String(),
+		UpdatedBy:        inc.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:        inc.CreatedUpdated.UpdatedAt().String(),
+		Version:          uint32(inc.Version()),
+	}
+
+	if onHold := inc.OnHold(); onHold != nil {
+		protoInc.OnHoldReason = onHold.Reason.String()
+		protoInc.RemindAt = onHold.RemindAt.String()
+	}
+
+	if resolution := inc.Resolution(); resolution != nil {
+		protoInc.ResolutionCode = resolution.Code.String()
+		protoInc.ResolutionNotes = resolution.Notes
+		protoInc.ResolvedAt = resolution.ResolvedAt.String()
+	}
+
+	return protoInc
+}
+
+func convertTimelogToProto(tmlg timelog.Timelog) (*ticketmanagement.Timelog, error) {
+	protoTimelog := &ticketmanagement.Timelog{
+		Uuid:         tmlg.UUID().String(),
+		Remote:       tmlg.Remote,
+		Start:        tmlg.Start.String(),
+		End:          tmlg.End.String(),
+		Work:         uint32(tmlg.Work),
+		VisitSummary: tmlg.VisitSummary,
+		CreatedBy:    tmlg.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:    tmlg.CreatedUpdated.CreatedAt().String(),
+		UpdatedBy:    tmlg.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:    tmlg.CreatedUpdated.UpdatedAt().String(),
+	}
+
+	for _, span := range tmlg.Timespans {
+		duration, err := span.Duration()
+		if err != nil {
+			return nil, err
+		}
+
+		protoTimelog.Timespans = append(protoTimelog.Timespans, &ticketmanagement.Timespan{
+			Type:     span.Type.String(),
+			Start:    span.Start.String(),
+			End:      span.End.String(),
+			Duration: uint32(duration),
+		})
+	}
+
+	return protoTimelog, nil
+}
@@ -0,0 +1,66 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusFromError converts domain (or input validation) error to gRPC status error, the codes match the HTTP statuses of the REST API
+func statusFromError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var httpErr *presenters.HTTPError
+	if errors.As(err, &httpErr) {
+		return status.Error(codeFromHTTPStatus(httpErr.Code()), httpErr.Error())
+	}
+
+	var dErr *domain.Error
+	if !errors.As(err, &dErr) {
+		return status.Error(codes.Internal, fmt.Sprintf("internal error: %s", err.Error()))
+	}
+
+	var code codes.Code
+	switch dErr.Code() {
+	case domain.ErrorCodeInvalidArgument:
+		code = codes.InvalidArgument
+	case domain.ErrorCodeNotFound:
+		code = codes.NotFound
+	case domain.ErrorCodeUserNotAuthorized:
+		code = codes.Unauthenticated
+	case domain.ErrorCodeActionForbidden:
+		code = codes.PermissionDenied
+	case domain.ErrorCodeVersionMismatch:
+		code = codes.Aborted
+	case domain.ErrorCodeUnknown:
+		fallthrough
+	default:
+		code = codes.Internal
+	}
+
+	return status.Error(code, dErr.Error())
+}
+
+func codeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,242 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/api/ticketmanagement"
+	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateIncident creates a new incident
+func (s *Server) CreateIncident(ctx context.Context, req *ticketmanagement.CreateIncidentRequest) (*ticketmanagement.CreateIncidentResponse, error) {
+	params := api.CreateIncidentParams{
+		Number:           req.GetNumber(),
+		ExternalID:       req.GetExternalId(),
+		ShortDescription: req.GetShortDescription(),
+		Description:      req.GetDescription(),
+		FieldEngineerID:  fieldEngineerIDFromRequest(req.GetFieldEngineer()),
+	}
+	if err := s.validator.Validate(params); err != nil {
+		s.logger.Warnw("CreateIncident call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newID, err := s.incidentService.CreateIncident(ctx, channelID, actorUser, params)
+	if err != nil {
+		s.logger.Errorw("CreateIncident call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	return &ticketmanagement.CreateIncidentResponse{Uuid: newID.String()}, nil
+}
+
+// UpdateIncident updates the incident. If the request contains the incident version, the update is aborted
+// when the incident was modified in the meantime. Missing version is rejected in strict mode.
+func (s *Server) UpdateIncident(ctx context.Context, req *ticketmanagement.UpdateIncidentRequest) (*emptypb.Empty, error) {
+	if req.GetUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing incident 'uuid'")
+	}
+
+	params := api.UpdateIncidentParams{
+		ShortDescription: req.GetShortDescription(),
+		Description:      req.GetDescription(),
+		FieldEngineerID:  fieldEngineerIDFromRequest(req.GetFieldEngineer()),
+	}
+	if err := s.validator.Validate(params); err != nil {
+		s.logger.Warnw("UpdateIncident call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version := req.GetVersion(); version != 0 {
+		ctx = incidentsvc.WithExpectedVersions(ctx, uint(version))
+	} else if s.requireVersion {
+		err := status.Error(codes.FailedPrecondition, "incident 'version' is required")
+		s.logger.Warnw("UpdateIncident call failed", "error", err)
+		return nil, err
+	}
+
+	_, err = s.incidentService.UpdateIncident(ctx, channelID, actorUser, ref.UUID(req.GetUuid()), params)
+	if err != nil {
+		s.logger.Errorw("UpdateIncident call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetIncident returns a single incident
+func (s *Server) GetIncident(ctx context.Context, req *ticketmanagement.IncidentRequest) (*ticketmanagement.IncidentResponse, error) {
+	if req.GetUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing incident 'uuid'")
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inc, err := s.incidentService.GetIncident(ctx, channelID, actorUser, ref.UUID(req.GetUuid()))
+	if err != nil {
+		s.logger.Errorw("GetIncident call failed", "ID", req.GetUuid(), "error", err)
+		return nil, statusFromError(err)
+	}
+
+	return &ticketmanagement.IncidentResponse{Result: convertIncidentToProto(inc)}, nil
+}
+
+// ListIncidents returns a list of incidents matching the filter
+func (s *Server) ListIncidents(ctx context.Context, req *ticketmanagement.ListIncidentsRequest) (*ticketmanagement.ListIncidentsResponse, error) {
+	filter, err := newIncidentFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.incidentService.ListIncidents(ctx, channelID, actorUser, filter, newPaginationParams(req.GetPage(), actorUser))
+	if err != nil {
+		s.logger.Errorw("ListIncidents call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	resp := &ticketmanagement.ListIncidentsResponse{
+		Total: uint32(list.Total),
+		Size:  uint32(list.Size),
+		Page:  uint32(list.Page),
+	}
+	for _, inc := range list.Result {
+		resp.Result = append(resp.Result, convertIncidentToProto(inc))
+	}
+
+	return resp, nil
+}
+
+// IncidentStartWorking is used by the field engineer to start working on the incident
+func (s *Server) IncidentStartWorking(ctx context.Context, req *ticketmanagement.IncidentStartWorkingRequest) (*emptypb.Empty, error) {
+	if req.GetUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing incident 'uuid'")
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := api.IncidentStartWorkingParams{
+		Remote: req.GetRemote(),
+	}
+
+	err = s.incidentService.StartWorking(ctx, channelID, actorUser, ref.UUID(req.GetUuid()), params, s.clock)
+	if err != nil {
+		s.logger.Errorw("IncidentStartWorking call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// IncidentStopWorking is used by the field engineer to stop working on the incident
+func (s *Server) IncidentStopWorking(ctx context.Context, req *ticketmanagement.IncidentStopWorkingRequest) (*emptypb.Empty, error) {
+	if req.GetUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing incident 'uuid'")
+	}
+
+	params := api.IncidentStopWorkingParams{
+		VisitSummary: req.GetVisitSummary(),
+	}
+	if err := s.validator.Validate(params); err != nil {
+		s.logger.Warnw("IncidentStopWorking call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.incidentService.StopWorking(ctx, channelID, actorUser, ref.UUID(req.GetUuid()), params, s.clock)
+	if err != nil {
+		s.logger.Errorw("IncidentStopWorking call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetIncidentTimelog returns a single timelog of the incident
+func (s *Server) GetIncidentTimelog(ctx context.Context, req *ticketmanagement.TimelogRequest) (*ticketmanagement.TimelogResponse, error) {
+	if req.GetIncidentUuid() == "" || req.GetTimelogUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing 'incident_uuid' or 'timelog_uuid'")
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmlg, err := s.incidentService.GetIncidentTimelog(ctx, channelID, actorUser, ref.UUID(req.GetIncidentUuid()), ref.UUID(req.GetTimelogUuid()))
+	if err != nil {
+		s.logger.Errorw("GetIncidentTimelog call failed", "ID", req.GetTimelogUuid(), "error", err)
+		return nil, statusFromError(err)
+	}
+
+	protoTimelog, err := convertTimelogToProto(tmlg)
+	if err != nil {
+		s.logger.Errorw("GetIncidentTimelog call failed", "ID", req.GetTimelogUuid(), "error", err)
+		return nil, status.Errorf(codes.Internal, "error converting timelog: %v", err)
+	}
+
+	return &ticketmanagement.TimelogResponse{Result: protoTimelog}, nil
+}
+
+// ListIncidentTimelogs returns a list of the incident's timelogs
+func (s *Server) ListIncidentTimelogs(ctx context.Context, req *ticketmanagement.ListIncidentTimelogsRequest) (*ticketmanagement.ListTimelogsResponse, error) {
+	if req.GetIncidentUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing 'incident_uuid'")
+	}
+
+	channelID, actorUser, err := s.channelAndActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.incidentService.ListIncidentTimelogs(ctx, channelID, actorUser, ref.UUID(req.GetIncidentUuid()), newPaginationParams(req.GetPage(), actorUser))
+	if err != nil {
+		s.logger.Errorw("ListIncidentTimelogs call failed", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	resp := &ticketmanagement.ListTimelogsResponse{
+		Total: uint32(list.Total),
+		Size:  uint32(list.Size),
+		Page:  uint32(list.Page),
+	}
+	for _, tmlg := range list.Result {
+		protoTimelog, err := convertTimelogToProto(tmlg)
+		if err != nil {
+			s.logger.Errorw("ListIncidentTimelogs call failed", "error", err)
+			return nil, status.Errorf(codes.Internal, "error converting timelog: %v", err)
+		}
+		resp.Result = append(resp.Result, protoTimelog)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,525 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/api/ticketmanagement"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	channelID   = "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken = "some valid Bearer token"
+)
+
+// newTestClient starts the server on in-memory connection and returns the client connected to it
+func newTestClient(t *testing.T, cfg Config) ticketmanagement.TicketManagementServiceClient {
+	logger, _ := testutils.NewTestLogger()
+	cfg.Logger = logger
+	cfg.Clock = mocks.NewFixedClock()
+
+	server := NewServer(cfg)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.GracefulStop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return ticketmanagement.NewTicketManagementServiceClient(conn)
+}
+
+// authorizedContext returns context with the metadata sent by authorized client
+func authorizedContext() context.Context {
+	md := metadata.New(map[string]string{
+		ChannelIDMetadataKey:     channelID,
+		AuthorizationMetadataKey: bearerToken,
+	})
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+func testActor(t *testing.T) actor.Actor {
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	err := basicUser.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+	require.NoError(t, err)
+
+	fieldEngineerUUID := ref.UUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+	actorUser := actor.Actor{
+		BasicUser: basicUser,
+	}
+	actorUser.SetFieldEngineerID(&fieldEngineerUUID)
+
+	return actorUser
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	t.Run("when channel ID metadata is missing", func(t *testing.T) {
+		client := newTestClient(t, Config{})
+
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+			AuthorizationMetadataKey: bearerToken,
+		}))
+
+		_, err := client.GetIncident(ctx, &ticketmanagement.IncidentRequest{Uuid: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "status code")
+		assert.Equal(t, "'grpc-metadata-space' metadata missing or invalid", status.Convert(err).Message())
+	})
+
+	t.Run("when authorization metadata is missing", func(t *testing.T) {
+		client := newTestClient(t, Config{})
+
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+			ChannelIDMetadataKey: channelID,
+		}))
+
+		_, err := client.GetIncident(ctx, &ticketmanagement.IncidentRequest{Uuid: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "status code")
+	})
+
+	t.Run("when user is acting on behalf of other user", func(t *testing.T) {
+		actorUser := testActor(t)
+		onBehalf := "83b231f2-5898-2658-70f4-5db03d1ccbc1"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), onBehalf).
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncident", ref.ChannelID(channelID), actorUser, ref.UUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")).
+			Return(incident.Incident{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		ctx := metadata.AppendToOutgoingContext(authorizedContext(), OnBehalfMetadataKey, onBehalf)
+
+		_, err := client.GetIncident(ctx, &ticketmanagement.IncidentRequest{Uuid: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"})
+		assert.Equal(t, codes.NotFound, status.Code(err), "status code")
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+}
+
+func TestCreateIncident(t *testing.T) {
+	actorUser := testActor(t)
+
+	t.Parallel()
+
+	t.Run("when request is not valid (ie. validation fails)", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us})
+
+		_, err := client.CreateIncident(authorizedContext(), &ticketmanagement.CreateIncidentRequest{
+			Number: "A123456",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "status code")
+		assert.Equal(t, "'short_description' is a required field", status.Convert(err).Message())
+
+		us.AssertExpectations(t)
+	})
+
+	t.Run("when request is valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		feID := api.UUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("CreateIncident", ref.ChannelID(channelID), actorUser, api.CreateIncidentParams{
+			Number:           "A123456",
+			ShortDescription: "Test incident",
+			FieldEngineerID:  &feID,
+		}).Return(ref.UUID("38316161-3035-4864-ad30-6231392d3433"), nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		resp, err := client.CreateIncident(authorizedContext(), &ticketmanagement.CreateIncidentRequest{
+			Number:           "A123456",
+			ShortDescription: "Test incident",
+			FieldEngineer:    "1adb8393-cff0-489c-a82f-3fe5d15708d4",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "38316161-3035-4864-ad30-6231392d3433", resp.GetUuid())
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+}
+
+// versionRecordingIncidentService records the expected incident versions the update is called with
+type versionRecordingIncidentService struct {
+	*mocks.IncidentServiceMock
+	versions    []uint
+	hasVersions bool
+}
+
+func (s *versionRecordingIncidentService) UpdateIncident(ctx context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, params api.UpdateIncidentParams) (ref.UUID, error) {
+	s.versions, s.hasVersions = incidentsvc.ExpectedVersionsFromContext(ctx)
+	return s.IncidentServiceMock.UpdateIncident(ctx, channelID, actor, ID, params)
+}
+
+func TestUpdateIncident(t *testing.T) {
+	actorUser := testActor(t)
+	uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+	params := api.UpdateIncidentParams{ShortDescription: "Test incident"}
+
+	t.Parallel()
+
+	t.Run("when request contains incident version", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := &versionRecordingIncidentService{IncidentServiceMock: new(mocks.IncidentServiceMock)}
+		incidentSvc.On("UpdateIncident", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), params).
+			Return(ref.UUID(uuid), nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc, RequireVersion: true})
+
+		_, err := client.UpdateIncident(authorizedContext(), &ticketmanagement.UpdateIncidentRequest{
+			Uuid:             uuid,
+			ShortDescription: "Test incident",
+			Version:          3,
+		})
+		require.NoError(t, err)
+		assert.True(t, incidentSvc.hasVersions, "expected versions in context")
+		assert.Equal(t, []uint{3}, incidentSvc.versions)
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+
+	t.Run("when incident was modified in the meantime", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("UpdateIncident", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), params).
+			Return(ref.UUID(""), domain.NewErrorf(domain.ErrorCodeVersionMismatch, "incident was modified"))
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		_, err := client.UpdateIncident(authorizedContext(), &ticketmanagement.UpdateIncidentRequest{
+			Uuid:             uuid,
+			ShortDescription: "Test incident",
+			Version:          2,
+		})
+		assert.Equal(t, codes.Aborted, status.Code(err), "status code")
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+
+	t.Run("when request does not contain incident version", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := &versionRecordingIncidentService{IncidentServiceMock: new(mocks.IncidentServiceMock)}
+		incidentSvc.On("UpdateIncident", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), params).
+			Return(ref.UUID(uuid), nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		_, err := client.UpdateIncident(authorizedContext(), &ticketmanagement.UpdateIncidentRequest{
+			Uuid:             uuid,
+			ShortDescription: "Test incident",
+		})
+		require.NoError(t, err)
+		assert.False(t, incidentSvc.hasVersions, "expected versions in context")
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+
+	t.Run("when request does not contain incident version in strict mode", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc, RequireVersion: true})
+
+		_, err := client.UpdateIncident(authorizedContext(), &ticketmanagement.UpdateIncidentRequest{
+			Uuid:             uuid,
+			ShortDescription: "Test incident",
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "status code")
+		assert.Equal(t, "incident 'version' is required", status.Convert(err).Message())
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertNotCalled(t, "UpdateIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetIncident(t *testing.T) {
+	actorUser := testActor(t)
+	uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+	t.Parallel()
+
+	t.Run("when incident does not exist", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncident", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(incident.Incident{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		_, err := client.GetIncident(authorizedContext(), &ticketmanagement.IncidentRequest{Uuid: uuid})
+		assert.Equal(t, codes.NotFound, status.Code(err), "status code")
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+
+	t.Run("when incident exists", func(t *testing.T) {
+		fieldEngineerUUID := ref.UUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		inc := incident.Incident{
+			Number:           "A123456",
+			ShortDescription: "Test incident 1",
+			FieldEngineerID:  &fieldEngineerUUID,
+			Timelogs:         []ref.UUID{"0ac5ebce-17e7-4edc-9552-fefe16e127fb"},
+		}
+		err := inc.SetUUID(ref.UUID(uuid))
+		require.NoError(t, err)
+		inc.SetVersion(3)
+		state, err := incident.NewStateFromString("new")
+		require.NoError(t, err)
+		err = inc.RestoreState(state)
+		require.NoError(t, err)
+		err = inc.CreatedUpdated.SetCreated(actorUser.BasicUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+		err = inc.CreatedUpdated.SetUpdated(actorUser.BasicUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncident", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(inc, nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		resp, err := client.GetIncident(authorizedContext(), &ticketmanagement.IncidentRequest{Uuid: uuid})
+		require.NoError(t, err)
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		result := resp.GetResult()
+		assert.Equal(t, uuid, result.GetUuid())
+		assert.Equal(t, "A123456", result.GetNumber())
+		assert.Equal(t, "Test incident 1", result.GetShortDescription())
+		assert.Equal(t, "1adb8393-cff0-489c-a82f-3fe5d15708d4", result.GetFieldEngineer())
+		assert.Equal(t, "new", result.GetState())
+		assert.Equal(t, []string{"0ac5ebce-17e7-4edc-9552-fefe16e127fb"}, result.GetTimelogs())
+		assert.Equal(t, "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", result.GetCreatedBy())
+		assert.Equal(t, "2021-04-01T12:34:56+02:00", result.GetCreatedAt())
+		assert.Equal(t, uint32(3), result.GetVersion())
+	})
+}
+
+func TestListIncidents(t *testing.T) {
+	actorUser := testActor(t)
+
+	t.Parallel()
+
+	t.Run("when filter is not valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us})
+
+		_, err := client.ListIncidents(authorizedContext(), &ticketmanagement.ListIncidentsRequest{Sort: "description"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "status code")
+		assert.Equal(t, "incorrect 'sort' value: 'description'", status.Convert(err).Message())
+	})
+
+	t.Run("when filter is valid", func(t *testing.T) {
+		inc := incident.Incident{
+			Number:           "A123456",
+			ShortDescription: "Test incident 1",
+		}
+		err := inc.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		inProgress, err := incident.NewStateFromString("in progress")
+		require.NoError(t, err)
+		expectedFilter := repository.IncidentFilter{
+			States: []incident.State{inProgress},
+			Text:   "printer",
+			Sort:   repository.IncidentSort{Field: repository.IncidentSortByCreatedAt, Descending: true},
+		}
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, expectedFilter,
+			mock.MatchedBy(func(params converters.PaginationParams) bool {
+				return params.Page() == 2 && params.CursorPage() == nil
+			})).
+			Return(repository.IncidentList{
+				Result:     []incident.Incident{inc},
+				Pagination: repository.NewPagination(11, 2, 10),
+			}, nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		resp, err := client.ListIncidents(authorizedContext(), &ticketmanagement.ListIncidentsRequest{
+			Page:   2,
+			States: []string{"in_progress"},
+			Text:   " printer ",
+			Sort:   "-created_at",
+		})
+		require.NoError(t, err)
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, uint32(11), resp.GetTotal())
+		assert.Equal(t, uint32(2), resp.GetPage())
+		require.Len(t, resp.GetResult(), 1)
+		assert.Equal(t, "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", resp.GetResult()[0].GetUuid())
+	})
+}
+
+func TestIncidentStartAndStopWorking(t *testing.T) {
+	actorUser := testActor(t)
+	uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+	t.Parallel()
+
+	t.Run("when field engineer can start working", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("StartWorking", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), api.IncidentStartWorkingParams{Remote: true}).
+			Return(nil)
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		_, err := client.IncidentStartWorking(authorizedContext(), &ticketmanagement.IncidentStartWorkingRequest{Uuid: uuid, Remote: true})
+		require.NoError(t, err)
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+
+	t.Run("when action is forbidden", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("StopWorking", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), api.IncidentStopWorkingParams{VisitSummary: "done"}).
+			Return(domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not assigned to this incident"))
+
+		client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+		_, err := client.IncidentStopWorking(authorizedContext(), &ticketmanagement.IncidentStopWorkingRequest{Uuid: uuid, VisitSummary: "done"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "status code")
+		assert.Equal(t, "user is not assigned to this incident", status.Convert(err).Message())
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+	})
+}
+
+func TestGetIncidentTimelog(t *testing.T) {
+	actorUser := testActor(t)
+	incidentUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+	timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+	tmlg := timelog.Timelog{
+		Remote:       true,
+		Start:        "2021-04-01T12:00:00Z",
+		End:          "2021-04-01T15:00:00Z",
+		Work:         9000,
+		VisitSummary: "disk replaced",
+		Timespans: []timelog.Timespan{
+			{Type: timelog.TimespanTypeWork, Start: "2021-04-01T12:00:00Z", End: "2021-04-01T13:00:00Z"},
+			{Type: timelog.TimespanTypeBreak, Start: "2021-04-01T13:00:00Z", End: "2021-04-01T13:30:00Z"},
+			{Type: timelog.TimespanTypeWork, Start: "2021-04-01T13:30:00Z", End: "2021-04-01T15:00:00Z"},
+		},
+	}
+	err := tmlg.SetUUID(ref.UUID(timelogUUID))
+	require.NoError(t, err)
+
+	us := new(mocks.ExternalUserServiceMock)
+	us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+		Return(actorUser, nil)
+
+	incidentSvc := new(mocks.IncidentServiceMock)
+	incidentSvc.On("GetIncidentTimelog", ref.ChannelID(channelID), actorUser, ref.UUID(incidentUUID), ref.UUID(timelogUUID)).
+		Return(tmlg, nil)
+
+	client := newTestClient(t, Config{ExternalUserService: us, IncidentService: incidentSvc})
+
+	resp, err := client.GetIncidentTimelog(authorizedContext(), &ticketmanagement.TimelogRequest{
+		IncidentUuid: incidentUUID,
+		TimelogUuid:  timelogUUID,
+	})
+	require.NoError(t, err)
+
+	us.AssertExpectations(t)
+	incidentSvc.AssertExpectations(t)
+
+	result := resp.GetResult()
+	assert.Equal(t, timelogUUID, result.GetUuid())
+	assert.True(t, result.GetRemote())
+	assert.Equal(t, uint32(9000), result.GetWork())
+	assert.Equal(t, "disk replaced", result.GetVisitSummary())
+	require.Len(t, result.GetTimespans(), 3)
+	assert.Equal(t, "break", result.GetTimespans()[1].GetType())
+	assert.Equal(t, uint32(1800), result.GetTimespans()[1].GetDuration())
+}
@@ -0,0 +1,135 @@
+// Package grpcserver provides the ticket management gRPC API. It uses the same domain services as the REST server.
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"github.com/crywolf/itsm-ticket-management-service/api/ticketmanagement"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	externalusersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/user/external_user_service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters/validators"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys the channel ID, the authorization token and the on behalf user are read from.
+// They are the same as the keys used by the external user service.
+const (
+	ChannelIDMetadataKey     = "grpc-metadata-space"
+	AuthorizationMetadataKey = "authorization"
+	OnBehalfMetadataKey      = "on_behalf"
+)
+
+// Server is a gRPC server providing the ticket management API
+type Server struct {
+	ticketmanagement.UnimplementedTicketManagementServiceServer
+	grpcServer          *grpc.Server
+	clock               domain.Clock
+	logger              *zap.SugaredLogger
+	validator           validators.PayloadValidator
+	externalUserService externalusersvc.Service
+	incidentService     incidentsvc.IncidentService
+	requireVersion      bool
+}
+
+// Config contains server configuration and dependencies
+type Config struct {
+	Clock               domain.Clock
+	Logger              *zap.SugaredLogger
+	ExternalUserService externalusersvc.Service
+	IncidentService     incidentsvc.IncidentService
+	// RequireVersion enables strict mode, incident updates without the incident version are rejected (as REST API does with 'If-Match' header)
+	RequireVersion bool
+}
+
+// NewServer creates new gRPC server with the ticket management service registered
+func NewServer(cfg Config) *Server {
+	s := &Server{
+		clock:               cfg.Clock,
+		logger:              cfg.Logger,
+		validator:           validators.NewPayloadValidator(),
+		externalUserService: cfg.ExternalUserService,
+		incidentService:     cfg.IncidentService,
+		requireVersion:      cfg.RequireVersion,
+	}
+
+	s.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(s.authInterceptor))
+	ticketmanagement.RegisterTicketManagementServiceServer(s.grpcServer, s)
+
+	return s
+}
+
+// Serve accepts incoming connections on the listener, it returns when the server is stopped
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpcServer.Serve(lis)
+}
+
+// GracefulStop stops the server from accepting new connections and blocks until all pending RPCs are finished
+func (s *Server) GracefulStop() {
+	s.grpcServer.GracefulStop()
+}
+
+type actorKeyType int
+
+var actorKey actorKeyType
+
+type channelIDKeyType int
+
+var channelIDKey channelIDKeyType
+
+// authInterceptor gets the Actor from the external user service and adds it and the channel ID to the context of the call
+func (s *Server) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s.logger.Infow("gRPC call", "method", info.FullMethod)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	authToken := firstMetadataValue(md, AuthorizationMetadataKey)
+	if authToken == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "'%s' metadata missing", AuthorizationMetadataKey)
+	}
+
+	channelID := firstMetadataValue(md, ChannelIDMetadataKey)
+	if channelID == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "'%s' metadata missing or invalid", ChannelIDMetadataKey)
+	}
+
+	actorUser, err := s.externalUserService.ActorFromRequest(ctx, authToken, ref.ChannelID(channelID), firstMetadataValue(md, OnBehalfMetadataKey))
+	if err != nil {
+		s.logger.Errorw("externalUserService.ActorFromRequest failed:", "error", err)
+		return nil, statusFromError(err)
+	}
+
+	ctx = context.WithValue(ctx, channelIDKey, ref.ChannelID(channelID))
+	ctx = context.WithValue(ctx, actorKey, &actorUser)
+
+	return handler(ctx, req)
+}
+
+// channelAndActor returns the channel ID and the Actor stored in the context by authInterceptor
+func (s *Server) channelAndActor(ctx context.Context) (ref.ChannelID, actor.Actor, error) {
+	channelID, ok := ctx.Value(channelIDKey).(ref.ChannelID)
+	if !ok {
+		return "", actor.Actor{}, status.Error(codes.Internal, "could not get channel ID from context")
+	}
+
+	act, ok := ctx.Value(actorKey).(*actor.Actor)
+	if !ok {
+		return "", actor.Actor{}, status.Error(codes.Internal, "could not get actor from context")
+	}
+
+	return channelID, *act, nil
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
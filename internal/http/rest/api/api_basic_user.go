@@ -28,3 +28,34 @@ type BasicUser struct {
 
 	CreatedUpdated
 }
+
+// BasicUserResponse ...
+type BasicUserResponse struct {
+	BasicUser
+	Links HypermediaLinks `json:"_links,omitempty"`
+}
+
+// Data structure representing a single basic user
+// swagger:response basicUserResponse
+type basicUserResponseWrapper struct {
+	// in: body
+	Body struct {
+		BasicUserResponse
+	}
+}
+
+// BasicUserListResponse ...
+type BasicUserListResponse struct {
+	PageInfo
+	Result []BasicUserResponse `json:"_embedded,omitempty"`
+	Links  HypermediaListLinks `json:"_links,omitempty"`
+}
+
+// Data structure representing a list of basic users
+// swagger:response basicUserListResponse
+type basicUserListResponseWrapper struct {
+	// in: body
+	Body struct {
+		BasicUserListResponse
+	}
+}
@@ -22,12 +22,78 @@ package api
 // swagger:strfmt uuid
 type UUID string
 
-// swagger:parameters CreateIncident ListIncidents
+// swagger:parameters CreateIncident CreateFieldEngineer ListFieldEngineers ListBasicUsers CreateWebhook ListWebhooks
 type generalNoParameterWrapper struct {
 	AuthorizationHeaders
 }
 
-// swagger:parameters GetIncident UpdateIncident IncidentStartWorking IncidentStopWorking
+// swagger:parameters ListIncidents
+type listIncidentsParameterWrapper struct {
+	AuthorizationHeaders
+
+	// Page number, it cannot be combined with the cursor parameters
+	// in: query
+	Page uint `json:"page"`
+
+	// Opaque cursor from the 'next' or 'last' link, the page starts after it
+	// in: query
+	After string `json:"after"`
+
+	// Opaque cursor from the 'prev' link, the page ends before it
+	// in: query
+	Before string `json:"before"`
+
+	// Maximum number of incidents on the page requested by the cursor (1-100)
+	// in: query
+	Limit uint `json:"limit"`
+
+	// Incident state (new, in_progress, on_hold, resolved, closed), can be repeated or contain comma separated values
+	// in: query
+	State []string `json:"state"`
+
+	// Incident priority (critical, high, moderate, low), can be repeated or contain comma separated values
+	// in: query
+	Priority []string `json:"priority"`
+
+	// ID of the assigned field engineer
+	// in: query
+	FieldEngineerID UUID `json:"field_engineer_id"`
+
+	// Incident number
+	// in: query
+	Number string `json:"number"`
+
+	// External ID of the incident
+	// in: query
+	ExternalID string `json:"external_id"`
+
+	// Incidents created at or after the date time (RFC3339 or YYYY-MM-DD)
+	// in: query
+	CreatedFrom string `json:"created_from"`
+
+	// Incidents created at or before the date time (RFC3339 or YYYY-MM-DD, the date includes the whole day)
+	// in: query
+	CreatedTo string `json:"created_to"`
+
+	// Incidents updated at or after the date time (RFC3339 or YYYY-MM-DD)
+	// in: query
+	UpdatedFrom string `json:"updated_from"`
+
+	// Incidents updated at or before the date time (RFC3339 or YYYY-MM-DD, the date includes the whole day)
+	// in: query
+	UpdatedTo string `json:"updated_to"`
+
+	// Text to search for in the short description and the description (case-insensitive)
+	// in: query
+	Q string `json:"q"`
+
+	// Sort field (created_at, updated_at, number, priority), prefix '-' means descending order.
+	// Ascending priority order lists the most urgent incidents first.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// swagger:parameters GetIncident UpdateIncident IncidentAccept IncidentReject IncidentStartWorking IncidentStopWorking IncidentPauseWorking IncidentResumeWorking IncidentPutOnHold IncidentResume IncidentResolve IncidentClose IncidentReopen FieldEngineerStartTravelling FieldEngineerStartTravellingBack FieldEngineerStartBreak FieldEngineerEndBreak FieldEngineerCloseTimeSession GetFieldEngineer FieldEngineerDeactivate GetBasicUser GetWebhook DeleteWebhook
 type generalIDParameterWrapper struct {
 	AuthorizationHeaders
 
@@ -37,6 +103,14 @@ type generalIDParameterWrapper struct {
 	UUID UUID `json:"uuid"`
 }
 
+// swagger:parameters UpdateIncident IncidentAccept IncidentReject IncidentStartWorking IncidentStopWorking IncidentPauseWorking IncidentResumeWorking IncidentPutOnHold IncidentResume IncidentResolve IncidentClose IncidentReopen
+type ifMatchParameterWrapper struct {
+	// ETag of the incident the client expects to modify (from GetIncident response), '*' matches any version.
+	// Required if the server runs in strict mode.
+	// in: header
+	IfMatch string `json:"If-Match"`
+}
+
 // AuthorizationHeaders represents general authorization header parameters used in many API calls
 type AuthorizationHeaders struct {
 	// Bearer token
@@ -79,6 +153,13 @@ func (l *HypermediaLinks) AppendSelfLink(url string) {
 	}
 }
 
+// AppendActionLink adds resource's action link
+func (l *HypermediaLinks) AppendActionLink(name, url string) {
+	(*l)[name] = map[string]string{
+		"href": url,
+	}
+}
+
 // Link represents HAL hypermedia link
 type Link struct {
 	// swagger:strfmt uri
@@ -141,3 +222,7 @@ type errorResponseWrapper404 errorResponseWrapper
 // Conflict
 // swagger:response errorResponse409
 type errorResponseWrapper409 errorResponseWrapper
+
+// Precondition Failed
+// swagger:response errorResponse412
+type errorResponseWrapper412 errorResponseWrapper
@@ -9,4 +9,94 @@ type FieldEngineer struct {
 
 	// required: true
 	BasicUser BasicUser `json:"basic_user"`
+
+	// Deactivated field engineer cannot work on tickets anymore
+	// required: true
+	Deactivated bool `json:"deactivated"`
+
+	// List of time sessions
+	TimeSessions []UUID `json:"time_sessions,omitempty"`
+
+	CreatedUpdated
+}
+
+// CreateFieldEngineerParams is the payload used to create new field engineer
+// swagger:model
+type CreateFieldEngineerParams struct {
+	// ID of the existing basic user that will become field engineer
+	// required: true
+	BasicUserID UUID `json:"basic_user" validate:"required,uuid4"`
+}
+
+// swagger:parameters CreateFieldEngineer
+type createFieldEngineerParameterWrapper struct {
+	// in: body
+	// required: true
+	Body CreateFieldEngineerParams
+}
+
+// FieldEngineerResponse ...
+type FieldEngineerResponse struct {
+	FieldEngineer
+	Links    HypermediaLinks   `json:"_links,omitempty"`
+	Embedded EmbeddedResources `json:"_embedded,omitempty"`
+}
+
+// Data structure representing a single field engineer
+// swagger:response fieldEngineerResponse
+type fieldEngineerResponseWrapper struct {
+	// in: body
+	Body struct {
+		FieldEngineerResponse
+	}
+}
+
+// FieldEngineerListResponse ...
+type FieldEngineerListResponse struct {
+	PageInfo
+	Result []FieldEngineerResponse `json:"_embedded,omitempty"`
+	Links  HypermediaListLinks     `json:"_links,omitempty"`
+}
+
+// Data structure representing a list of field engineers
+// swagger:response fieldEngineerListResponse
+type fieldEngineerListResponseWrapper struct {
+	// in: body
+	Body struct {
+		FieldEngineerListResponse
+	}
+}
+
+// Created
+// swagger:response fieldEngineerCreatedResponse
+type fieldEngineerCreatedResponseWrapper struct {
+	// URI of the resource
+	// example: http://localhost:8080/field_engineers/2af4f493-0bd5-4513-b440-6cbb465feadb
+	// in: header
+	Location string
+}
+
+///////////////////
+// Actions
+
+// FieldEngineerCloseTimeSessionParams is the payload used to close the open time session of the field engineer
+// swagger:model
+type FieldEngineerCloseTimeSessionParams struct {
+	// Distance travelled to the customer and back in 'travel units'
+	TravelDistanceInTravelUnits uint `json:"travel_distance_in_travel_units"`
+}
+
+// swagger:parameters FieldEngineerCloseTimeSession
+type fieldEngineerCloseTimeSessionParameterWrapper struct {
+	// in: body
+	// required: true
+	Body FieldEngineerCloseTimeSessionParams
+}
+
+// No content
+// swagger:response fieldEngineerNoContentResponse
+type fieldEngineerNoContentResponseWrapper struct {
+	// URI of the resource
+	// example: http://localhost:8080/field_engineers/2af4f493-0bd5-4513-b440-6cbb465feadb
+	Location string
 }
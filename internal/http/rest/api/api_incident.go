@@ -25,14 +25,54 @@ type Incident struct {
 
 	FieldEngineer *UUID `json:"field_engineer"`
 
+	// True if the assigned field engineer accepted the ticket, the work on the ticket cannot be started until it is accepted
+	FieldEngineerAccepted bool `json:"field_engineer_accepted,omitempty"`
+
 	// State of the ticket
 	// required: true
 	// example: new
 	State incident.State `json:"state"`
 
+	// Impact of the ticket on the business
+	// example: medium
+	Impact string `json:"impact,omitempty"`
+
+	// Urgency of the ticket
+	// example: medium
+	Urgency string `json:"urgency,omitempty"`
+
+	// Priority derived from the impact and urgency by the channel's priority matrix or set manually
+	// example: moderate
+	Priority string `json:"priority,omitempty"`
+
+	// True if the priority was set manually, ie. it is not derived from the impact and urgency
+	PriorityOverridden bool `json:"priority_overridden,omitempty"`
+
+	// Reason why the ticket is on hold
+	// example: awaiting caller
+	OnHoldReason string `json:"on_hold_reason,omitempty"`
+
+	// Time when the assignee wants to be reminded of the ticket that is on hold
+	// swagger:strfmt date-time
+	RemindAt string `json:"remind_at,omitempty"`
+
+	// Code describing how the ticket was resolved
+	// example: solved (permanently)
+	ResolutionCode string `json:"resolution_code,omitempty"`
+
+	// Notes describing the resolution
+	ResolutionNotes string `json:"resolution_notes,omitempty"`
+
+	// Time when the ticket was resolved
+	// swagger:strfmt date-time
+	ResolvedAt string `json:"resolved_at,omitempty"`
+
 	// List of timelogs
 	Timelogs []UUID `json:"timelogs,omitempty"`
 
+	// SLA timers of the ticket, they are missing if the channel did not have SLA defined when the ticket was created
+	SLA *IncidentSLA `json:"sla,omitempty"`
+
 	CreatedUpdated
 }
 
@@ -52,6 +92,14 @@ type CreateIncidentParams struct {
 	Description string `json:"description"`
 
 	FieldEngineerID *UUID `json:"field_engineer" validate:"omitempty,uuid4"`
+
+	// Impact of the ticket on the business, medium impact is used if it is empty
+	// enum: high,medium,low
+	Impact string `json:"impact"`
+
+	// Urgency of the ticket, medium urgency is used if it is empty
+	// enum: high,medium,low
+	Urgency string `json:"urgency"`
 }
 
 // swagger:parameters CreateIncident
@@ -69,6 +117,22 @@ type UpdateIncidentParams struct {
 	Description string `json:"description"`
 
 	FieldEngineerID *UUID `json:"field_engineer" validate:"omitempty,uuid4"`
+
+	// Impact of the ticket on the business, the current impact is kept if it is empty
+	// enum: high,medium,low
+	Impact string `json:"impact"`
+
+	// Urgency of the ticket, the current urgency is kept if it is empty
+	// enum: high,medium,low
+	Urgency string `json:"urgency"`
+
+	// Manually set priority overriding the priority derived from the impact and urgency. The current priority is kept
+	// if it is missing, empty value removes the override.
+	// enum: critical,high,moderate,low
+	Priority *string `json:"priority"`
+
+	// Reason of the priority override, it is required if the priority is overridden
+	PriorityOverrideReason string `json:"priority_override_reason"`
 }
 
 // swagger:parameters UpdateIncident
@@ -88,6 +152,11 @@ type IncidentResponse struct {
 // Data structure representing a single incident
 // swagger:response incidentResponse
 type incidentResponseWrapper struct {
+	// Version of the incident, send it back in 'If-Match' header when modifying the incident
+	// example: "3"
+	// in: header
+	ETag string
+
 	// in: body
 	Body struct {
 		IncidentResponse
@@ -130,6 +199,21 @@ type incidentNoContentResponseWrapper struct {
 ///////////////////
 // Actions
 
+// IncidentRejectParams is the payload used by the assigned field engineer to reject the incident
+// swagger:model
+type IncidentRejectParams struct {
+	// Reason why the field engineer rejects the ticket
+	// required: true
+	Reason string `json:"reason" validate:"required"`
+}
+
+// swagger:parameters IncidentReject
+type incidentRejectParameterWrapper struct {
+	// in: body
+	// required: true
+	Body IncidentRejectParams
+}
+
 // IncidentStartWorkingParams is the payload used to start working on the incident
 // swagger:model
 type IncidentStartWorkingParams struct {
@@ -155,3 +239,74 @@ type incidentStopWorkingParameterWrapper struct {
 	// required: true
 	Body IncidentStopWorkingParams
 }
+
+// IncidentPauseWorkingParams is the payload used to pause the work on the incident
+// swagger:model
+type IncidentPauseWorkingParams struct {
+	// Type of the timespan that pauses the work
+	// required: true
+	// enum: break,travel
+	TimespanType string `json:"timespan_type" validate:"required,oneof=break travel"`
+}
+
+// swagger:parameters IncidentPauseWorking
+type incidentPauseWorkingParameterWrapper struct {
+	// in: body
+	// required: true
+	Body IncidentPauseWorkingParams
+}
+
+// IncidentPutOnHoldParams is the payload used to put the incident on hold
+// swagger:model
+type IncidentPutOnHoldParams struct {
+	// Reason why the ticket is put on hold
+	// required: true
+	// enum: awaiting caller,awaiting change,awaiting problem,awaiting supplier
+	Reason string `json:"reason" validate:"required"`
+
+	// Optional time when the assignee wants to be reminded of the ticket
+	// swagger:strfmt date-time
+	RemindAt string `json:"remind_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// swagger:parameters IncidentPutOnHold
+type incidentPutOnHoldParameterWrapper struct {
+	// in: body
+	// required: true
+	Body IncidentPutOnHoldParams
+}
+
+// IncidentResolveParams is the payload used to resolve the incident
+// swagger:model
+type IncidentResolveParams struct {
+	// Code describing how the ticket was resolved
+	// required: true
+	// enum: solved (permanently),solved (work around),solved remotely,not solved (not reproducible),not solved (too costly),closed/resolved by caller
+	ResolutionCode string `json:"resolution_code" validate:"required"`
+
+	// Notes describing the resolution
+	// required: true
+	ResolutionNotes string `json:"resolution_notes" validate:"required"`
+}
+
+// swagger:parameters IncidentResolve
+type incidentResolveParameterWrapper struct {
+	// in: body
+	// required: true
+	Body IncidentResolveParams
+}
+
+// IncidentReopenParams is the payload used to reopen the resolved incident
+// swagger:model
+type IncidentReopenParams struct {
+	// Reason why the ticket is reopened
+	// required: true
+	Reason string `json:"reason" validate:"required"`
+}
+
+// swagger:parameters IncidentReopen
+type incidentReopenParameterWrapper struct {
+	// in: body
+	// required: true
+	Body IncidentReopenParams
+}
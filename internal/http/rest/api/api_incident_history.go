@@ -0,0 +1,78 @@
+package api
+
+// HistoryEntry is an immutable record of one change of the incident
+// swagger:model
+type HistoryEntry struct {
+	// required: true
+	UUID UUID `json:"uuid"`
+
+	// Action that changed the incident
+	// required: true
+	// example: UpdateIncident
+	Action string `json:"action"`
+
+	// ID of the basic user who performed the action, it is missing if the action was performed automatically (ie. AutoClose)
+	ActorID UUID `json:"actor_id,omitempty"`
+
+	// ID of the basic user who sent the request on behalf of the actor
+	RequestedByID UUID `json:"requested_by_id,omitempty"`
+
+	// Time when the action was performed
+	// required: true
+	// swagger:strfmt date-time
+	Time string `json:"time"`
+
+	// Fields changed by the action
+	Changes []HistoryChange `json:"changes,omitempty"`
+}
+
+// HistoryChange is a change of one field of the incident
+// swagger:model
+type HistoryChange struct {
+	// Name of the changed field, timelog fields are prefixed with the timelog ID (ie. 'timelogs.<uuid>.start')
+	// required: true
+	// example: description
+	Field string `json:"field"`
+
+	// Value before the change, it is missing if the field was not set
+	Before string `json:"before,omitempty"`
+
+	// Value after the change, it is missing if the field was unset
+	After string `json:"after,omitempty"`
+}
+
+// HistoryEntryResponse ...
+type HistoryEntryResponse struct {
+	HistoryEntry
+	Links HypermediaLinks `json:"_links,omitempty"`
+}
+
+// HistoryListResponse ...
+type HistoryListResponse struct {
+	PageInfo
+	Result []HistoryEntryResponse `json:"_embedded,omitempty"`
+	Links  HypermediaListLinks    `json:"_links,omitempty"`
+}
+
+// A list of the incident's history entries
+// swagger:response historyListResponse
+type historyListResponseWrapper struct {
+	// in: body
+	Body struct {
+		HistoryListResponse
+	}
+}
+
+// swagger:parameters ListIncidentHistory
+type listHistoryParameterWrapper struct {
+	AuthorizationHeaders
+
+	// ID of the incident
+	// in: path
+	// required: true
+	UUID UUID `json:"uuid"`
+
+	// Page number
+	// in: query
+	Page uint `json:"page"`
+}
@@ -23,9 +23,39 @@ type Timelog struct {
 
 	VisitSummary string `json:"visit_summary,omitempty"`
 
+	// List of timespans the timelog consists of
+	Timespans []Timespan `json:"timespans,omitempty"`
+
+	// List of the proposed, approved and rejected corrections of the timelog
+	Corrections []TimelogCorrection `json:"corrections,omitempty"`
+
+	// Values of the timelog before the first correction was approved
+	Original *TimelogOriginal `json:"original,omitempty"`
+
 	CreatedUpdated
 }
 
+// Timespan is a continuous period of time of one type in the timelog
+// swagger:model
+type Timespan struct {
+	// required: true
+	// enum: work,break,travel
+	Type string `json:"type"`
+
+	// Time when the timespan started
+	// required: true
+	// swagger:strfmt date-time
+	Start string `json:"start"`
+
+	// Time when the timespan ended
+	// swagger:strfmt date-time
+	End string `json:"end,omitempty"`
+
+	// Duration of the ended timespan in seconds
+	// minimum: 0
+	Duration uint `json:"duration"`
+}
+
 // TimelogResponse ...
 type TimelogResponse struct {
 	Timelog
@@ -42,7 +72,37 @@ type timelogResponseWrapper struct {
 	}
 }
 
-// swagger:parameters GetIncidentTimelog
+// TimelogListResponse ...
+type TimelogListResponse struct {
+	PageInfo
+	Result []TimelogResponse   `json:"_embedded,omitempty"`
+	Links  HypermediaListLinks `json:"_links,omitempty"`
+}
+
+// A list of timelogs
+// swagger:response timelogListResponse
+type timelogListResponseWrapper struct {
+	// in: body
+	Body struct {
+		TimelogListResponse
+	}
+}
+
+// swagger:parameters ListIncidentTimelogs
+type listTimelogsParameterWrapper struct {
+	AuthorizationHeaders
+
+	// ID of the incident
+	// in: path
+	// required: true
+	UUID UUID `json:"uuid"`
+
+	// Page number
+	// in: query
+	Page uint `json:"page"`
+}
+
+// swagger:parameters GetIncidentTimelog ProposeTimelogCorrection ApproveTimelogCorrection RejectTimelogCorrection
 type generalTimelogParameterWrapper struct {
 	AuthorizationHeaders
 
@@ -56,3 +116,116 @@ type generalTimelogParameterWrapper struct {
 	// required: true
 	UUID UUID `json:"timelog_uuid"`
 }
+
+// TimelogCorrection is a change of the closed timelog proposed by the field engineer
+// swagger:model
+type TimelogCorrection struct {
+	// required: true
+	Remote bool `json:"remote"`
+
+	// Corrected time when the timelog was created
+	// required: true
+	// swagger:strfmt date-time
+	Start string `json:"start"`
+
+	// Corrected time when the timelog was closed
+	// required: true
+	// swagger:strfmt date-time
+	End string `json:"end"`
+
+	VisitSummary string `json:"visit_summary,omitempty"`
+
+	// Reason why the correction was proposed
+	// required: true
+	Reason string `json:"reason"`
+
+	// required: true
+	// enum: proposed,approved,rejected
+	Status string `json:"status"`
+
+	// ID of the basic user who proposed the correction
+	// required: true
+	ProposedBy UUID `json:"proposed_by"`
+
+	// Time when the correction was proposed
+	// required: true
+	// swagger:strfmt date-time
+	ProposedAt string `json:"proposed_at"`
+
+	// ID of the basic user who approved or rejected the correction
+	DecidedBy UUID `json:"decided_by,omitempty"`
+
+	// Time when the correction was approved or rejected
+	// swagger:strfmt date-time
+	DecidedAt string `json:"decided_at,omitempty"`
+
+	// Note of the dispatcher who approved or rejected the correction
+	DecisionNote string `json:"decision_note,omitempty"`
+}
+
+// TimelogOriginal contains the values of the timelog before the first correction was approved
+// swagger:model
+type TimelogOriginal struct {
+	// required: true
+	Remote bool `json:"remote"`
+
+	// swagger:strfmt date-time
+	Start string `json:"start,omitempty"`
+
+	// swagger:strfmt date-time
+	End string `json:"end,omitempty"`
+
+	// Time spent working in seconds
+	// minimum: 0
+	Work uint `json:"work,omitempty"`
+
+	VisitSummary string `json:"visit_summary,omitempty"`
+
+	Timespans []Timespan `json:"timespans,omitempty"`
+}
+
+// ProposeTimelogCorrectionParams is the payload used to propose correction of the closed timelog
+// swagger:model
+type ProposeTimelogCorrectionParams struct {
+	// required: true
+	Remote bool `json:"remote"`
+
+	// Corrected time when the timelog was created
+	// required: true
+	// swagger:strfmt date-time
+	Start string `json:"start" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+
+	// Corrected time when the timelog was closed
+	// required: true
+	// swagger:strfmt date-time
+	End string `json:"end" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+
+	// Corrected summary of the visit
+	VisitSummary string `json:"visit_summary"`
+
+	// Reason why the correction is proposed
+	// required: true
+	Reason string `json:"reason" validate:"required"`
+}
+
+// swagger:parameters ProposeTimelogCorrection
+type proposeTimelogCorrectionParameterWrapper struct {
+	// in: body
+	// required: true
+	Body ProposeTimelogCorrectionParams
+}
+
+// RejectTimelogCorrectionParams is the payload used to reject the proposed timelog correction
+// swagger:model
+type RejectTimelogCorrectionParams struct {
+	// Reason why the correction is rejected
+	// required: true
+	Note string `json:"note" validate:"required"`
+}
+
+// swagger:parameters RejectTimelogCorrection
+type rejectTimelogCorrectionParameterWrapper struct {
+	// in: body
+	// required: true
+	Body RejectTimelogCorrectionParams
+}
@@ -0,0 +1,61 @@
+package api
+
+// PriorityMatrix of the channel derives the priority of the incident from its impact and urgency
+// swagger:model
+type PriorityMatrix struct {
+	// Priorities by impact and urgency of the incident
+	// required: true
+	Priorities []PriorityMatrixEntry `json:"priorities"`
+
+	CreatedUpdated
+}
+
+// PriorityMatrixEntry is the priority of the incidents with the impact and urgency
+// swagger:model
+type PriorityMatrixEntry struct {
+	// required: true
+	// enum: high,medium,low
+	Impact string `json:"impact" validate:"required"`
+
+	// required: true
+	// enum: high,medium,low
+	Urgency string `json:"urgency" validate:"required"`
+
+	// required: true
+	// enum: critical,high,moderate,low
+	Priority string `json:"priority" validate:"required"`
+}
+
+// SetPriorityMatrixParams is the payload used to set the priority matrix of the channel
+// swagger:model
+type SetPriorityMatrixParams struct {
+	// Priorities by impact and urgency of the incident, every combination of impact and urgency must be present
+	// required: true
+	Priorities []PriorityMatrixEntry `json:"priorities" validate:"required,min=1,dive"`
+}
+
+// swagger:parameters SetPriorityMatrix
+type setPriorityMatrixParameterWrapper struct {
+	// in: body
+	// required: true
+	Body SetPriorityMatrixParams
+}
+
+// PriorityMatrixResponse ...
+type PriorityMatrixResponse struct {
+	PriorityMatrix
+	Links HypermediaLinks `json:"_links,omitempty"`
+}
+
+// Data structure representing the priority matrix of the channel
+// swagger:response priorityMatrixResponse
+type priorityMatrixResponseWrapper struct {
+	// in: body
+	Body struct {
+		PriorityMatrixResponse
+	}
+}
+
+// No content
+// swagger:response priorityMatrixNoContentResponse
+type priorityMatrixNoContentResponseWrapper struct{}
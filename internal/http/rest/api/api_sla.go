@@ -0,0 +1,161 @@
+package api
+
+// SLADefinition is the service level agreement of the channel. It is copied to each incident when the incident is created.
+// swagger:model
+type SLADefinition struct {
+	// Targets by priority of the incident
+	// required: true
+	Targets []SLATarget `json:"targets"`
+
+	// Priority selecting the targets of the incidents without priority
+	// required: true
+	// enum: critical,high,moderate,low
+	DefaultPriority string `json:"default_priority"`
+
+	// Business hours the SLA timers run in
+	// required: true
+	Calendar SLACalendar `json:"calendar"`
+
+	CreatedUpdated
+}
+
+// SLATarget is the maximal business time allowed to respond to and to resolve the incident of the priority
+// swagger:model
+type SLATarget struct {
+	// required: true
+	// enum: critical,high,moderate,low
+	Priority string `json:"priority" validate:"required"`
+
+	// Business time (in seconds) allowed until the incident is taken over
+	// required: true
+	// minimum: 60
+	// example: 3600
+	ResponseTime uint `json:"response_time" validate:"required"`
+
+	// Business time (in seconds) allowed until the incident is resolved
+	// required: true
+	// minimum: 60
+	// example: 28800
+	ResolutionTime uint `json:"resolution_time" validate:"required"`
+}
+
+// SLACalendar defines the business hours, the timers run all the time if there are no working hours
+// swagger:model
+type SLACalendar struct {
+	// IANA time zone of the working hours, UTC is used if it is empty
+	// example: Europe/Prague
+	Location string `json:"location"`
+
+	// Working hours by day of the week
+	WorkingHours []SLAWorkingHours `json:"working_hours" validate:"dive"`
+
+	// Days without working hours
+	// example: ["2021-12-24","2021-12-25"]
+	Holidays []string `json:"holidays" validate:"dive,datetime=2006-01-02"`
+}
+
+// SLAWorkingHours are the working hours on the day of the week
+// swagger:model
+type SLAWorkingHours struct {
+	// required: true
+	// enum: monday,tuesday,wednesday,thursday,friday,saturday,sunday
+	Weekday string `json:"weekday" validate:"required"`
+
+	// Start of the working hours
+	// required: true
+	// example: 08:00
+	Start string `json:"start" validate:"required"`
+
+	// End of the working hours, 24:00 ends at midnight
+	// required: true
+	// example: 16:30
+	End string `json:"end" validate:"required"`
+}
+
+// SetSLADefinitionParams is the payload used to set the SLA definition of the channel
+// swagger:model
+type SetSLADefinitionParams struct {
+	// Targets by priority of the incident
+	// required: true
+	Targets []SLATarget `json:"targets" validate:"required,min=1,dive"`
+
+	// Priority selecting the targets of the incidents without priority, the targets must contain it
+	// required: true
+	// enum: critical,high,moderate,low
+	DefaultPriority string `json:"default_priority" validate:"required"`
+
+	// Business hours the SLA timers run in
+	Calendar SLACalendar `json:"calendar"`
+}
+
+// swagger:parameters SetSLADefinition
+type setSLADefinitionParameterWrapper struct {
+	// in: body
+	// required: true
+	Body SetSLADefinitionParams
+}
+
+// SLADefinitionResponse ...
+type SLADefinitionResponse struct {
+	SLADefinition
+	Links HypermediaLinks `json:"_links,omitempty"`
+}
+
+// Data structure representing the SLA definition of the channel
+// swagger:response slaDefinitionResponse
+type slaDefinitionResponseWrapper struct {
+	// in: body
+	Body struct {
+		SLADefinitionResponse
+	}
+}
+
+// No content
+// swagger:response slaDefinitionNoContentResponse
+type slaDefinitionNoContentResponseWrapper struct{}
+
+// IncidentSLA is the state of the incident's SLA timers
+// swagger:model
+type IncidentSLA struct {
+	// Priority the targets were selected by
+	// required: true
+	// example: moderate
+	Priority string `json:"priority"`
+
+	// Timer measuring the time until the incident is taken over
+	// required: true
+	Response SLATimer `json:"response"`
+
+	// Timer measuring the time until the incident is resolved
+	// required: true
+	Resolution SLATimer `json:"resolution"`
+}
+
+// SLATimer is the state of the SLA timer, times are business times in seconds
+// swagger:model
+type SLATimer struct {
+	// required: true
+	// enum: running,paused,stopped
+	State string `json:"state"`
+
+	// required: true
+	// example: 3600
+	Target int64 `json:"target"`
+
+	// required: true
+	// example: 1200
+	Elapsed int64 `json:"elapsed"`
+
+	// Time remaining until the target is breached, it is negative if the target was breached
+	// required: true
+	// example: 2400
+	Remaining int64 `json:"remaining"`
+
+	// Time the target will be breached, it is set only if the timer is running and the target was not breached yet
+	// swagger:strfmt date-time
+	DueAt string `json:"due_at,omitempty"`
+
+	// True if the target was breached
+	// required: true
+	Breached bool `json:"breached"`
+}
@@ -0,0 +1,172 @@
+package api
+
+import "encoding/json"
+
+// Webhook is a subscription of the channel to the domain events, the events are sent to the URL as HMAC signed JSON payloads
+// swagger:model
+type Webhook struct {
+	// required: true
+	UUID UUID `json:"uuid"`
+
+	// URL the events are sent to
+	// required: true
+	// example: https://example.com/hooks/itsm
+	URL string `json:"url"`
+
+	// Types of the events sent to the URL, all events are sent if the list is empty
+	// example: ["IncidentStateChanged","IncidentFieldEngineerAssigned"]
+	EventTypes []string `json:"event_types,omitempty"`
+
+	CreatedUpdated
+}
+
+// CreateWebhookParams is the payload used to create new webhook subscription
+// swagger:model
+type CreateWebhookParams struct {
+	// Absolute HTTP or HTTPS URL the events are sent to
+	// required: true
+	// example: https://example.com/hooks/itsm
+	URL string `json:"url" validate:"required,url"`
+
+	// Secret used to sign the payloads, the signature is sent in 'X-Webhook-Signature' header ('sha256=<hex encoded HMAC-SHA256 of the body>').
+	// The secret is never returned by the API.
+	// required: true
+	// min length: 16
+	Secret string `json:"secret" validate:"required,min=16"`
+
+	// Types of the events sent to the URL, all events are sent if the list is empty
+	// example: ["IncidentStateChanged","IncidentFieldEngineerAssigned"]
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// swagger:parameters CreateWebhook
+type createWebhookParameterWrapper struct {
+	// in: body
+	// required: true
+	Body CreateWebhookParams
+}
+
+// WebhookResponse ...
+type WebhookResponse struct {
+	Webhook
+	Links HypermediaLinks `json:"_links,omitempty"`
+}
+
+// Data structure representing a single webhook subscription
+// swagger:response webhookResponse
+type webhookResponseWrapper struct {
+	// in: body
+	Body struct {
+		WebhookResponse
+	}
+}
+
+// WebhookListResponse ...
+type WebhookListResponse struct {
+	PageInfo
+	Result []WebhookResponse   `json:"_embedded,omitempty"`
+	Links  HypermediaListLinks `json:"_links,omitempty"`
+}
+
+// Data structure representing a list of webhook subscriptions
+// swagger:response webhookListResponse
+type webhookListResponseWrapper struct {
+	// in: body
+	Body struct {
+		WebhookListResponse
+	}
+}
+
+// Created
+// swagger:response webhookCreatedResponse
+type webhookCreatedResponseWrapper struct {
+	// URI of the resource
+	// example: http://localhost:8080/webhooks/2af4f493-0bd5-4513-b440-6cbb465feadb
+	// in: header
+	Location string
+}
+
+// No content
+// swagger:response webhookNoContentResponse
+type webhookNoContentResponseWrapper struct{}
+
+// WebhookDelivery is a delivery of one event to the subscribed URL
+// swagger:model
+type WebhookDelivery struct {
+	// required: true
+	UUID UUID `json:"uuid"`
+
+	// ID of the delivered event, it is the same in all deliveries of the event
+	// required: true
+	EventID UUID `json:"event_id"`
+
+	// required: true
+	// example: IncidentStateChanged
+	EventType string `json:"event_type"`
+
+	// Pending deliveries are attempted again, dead-lettered deliveries failed too many times and are not attempted anymore
+	// required: true
+	// enum: Pending,Delivered,DeadLettered
+	Status string `json:"status"`
+
+	// Number of delivery attempts
+	// required: true
+	Attempts uint `json:"attempts"`
+
+	// Time of the next attempt of the pending delivery
+	// swagger:strfmt date-time
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+
+	// swagger:strfmt date-time
+	LastAttemptAt string `json:"last_attempt_at,omitempty"`
+
+	// HTTP status code of the last response, it is missing if the request failed without response
+	LastResponseStatus int `json:"last_response_status,omitempty"`
+
+	// Reason of the last failed attempt
+	LastError string `json:"last_error,omitempty"`
+
+	// JSON payload sent to the URL
+	// required: true
+	Payload json.RawMessage `json:"payload"`
+
+	// required: true
+	// swagger:strfmt date-time
+	CreatedAt string `json:"created_at"`
+}
+
+// WebhookDeliveryResponse ...
+type WebhookDeliveryResponse struct {
+	WebhookDelivery
+	Links HypermediaLinks `json:"_links,omitempty"`
+}
+
+// WebhookDeliveryListResponse ...
+type WebhookDeliveryListResponse struct {
+	PageInfo
+	Result []WebhookDeliveryResponse `json:"_embedded,omitempty"`
+	Links  HypermediaListLinks       `json:"_links,omitempty"`
+}
+
+// A list of the webhook subscription's deliveries
+// swagger:response webhookDeliveryListResponse
+type webhookDeliveryListResponseWrapper struct {
+	// in: body
+	Body struct {
+		WebhookDeliveryListResponse
+	}
+}
+
+// swagger:parameters ListWebhookDeliveries
+type listWebhookDeliveriesParameterWrapper struct {
+	AuthorizationHeaders
+
+	// ID of the webhook subscription
+	// in: path
+	// required: true
+	UUID UUID `json:"uuid"`
+
+	// Page number
+	// in: query
+	Page uint `json:"page"`
+}
@@ -1,6 +1,7 @@
 package api
 
-// EmbeddedResourceLinks can append 'self' link to its '_links' object
+// EmbeddedResourceLinks can append 'self' and action links to its '_links' object
 type EmbeddedResourceLinks interface {
 	AppendSelfLink(url string)
+	AppendActionLink(name, url string)
 }
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/julienschmidt/httprouter"
+)
+
+func (s Server) registerBasicUserRoutes() {
+	s.router.GET("/basic_users/:id", s.GetBasicUser())
+	s.router.GET("/basic_users", s.ListBasicUsers())
+}
+
+// swagger:route GET /basic_users/{uuid} basic_users GetBasicUser
+// Returns a single basic user from the repository
+// responses:
+//
+//	200: basicUserResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const getBasicUserRoute = "/basic_users/{uuid}"
+
+// GetBasicUser returns handler for getting single basic user
+func (s *Server) GetBasicUser() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		if id == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("GetBasicUser handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("GetBasicUser handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		basicUser, err := s.basicUserService.GetBasicUser(r.Context(), channelID, actorUser, ref.UUID(id))
+		if err != nil {
+			s.logger.Errorw("GetBasicUser handler failed", "ID", id, "error", err)
+			s.presenters.base.RenderError(w, "basic user not found", err)
+			return
+		}
+
+		hypermediaMapper := NewBasicUserHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.basicUser.RenderBasicUser(w, basicUser, hypermediaMapper)
+	}
+}
+
+// swagger:route GET /basic_users basic_users ListBasicUsers
+// Returns a list of basic users
+// responses:
+//
+//	200: basicUserListResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+
+// ListBasicUsers returns handler for listing basic users
+func (s *Server) ListBasicUsers() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ListBasicUsers handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		paginationParams, err := s.PaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		list, err := s.basicUserService.ListBasicUsers(r.Context(), channelID, actorUser, paginationParams)
+		if err != nil {
+			s.logger.Errorw("ListBasicUsers handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		hypermediaMapper := NewBasicUserHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.basicUser.RenderBasicUserList(w, list, hypermediaMapper)
+	}
+}
+
+// BasicUserHypermediaMapper implements hypermedia mapping functionality for basic user resource
+type BasicUserHypermediaMapper struct {
+	*hypermedia.BaseHypermediaMapper
+}
+
+// NewBasicUserHypermediaMapper returns new hypermedia mapper for basic user resource
+func NewBasicUserHypermediaMapper(serverAddr string, currentURL *url.URL, actor actor.Actor) BasicUserHypermediaMapper {
+	return BasicUserHypermediaMapper{
+		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+	}
+}
+
+// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links (basic user does not have any actions)
+func (h BasicUserHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
+	return hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+}
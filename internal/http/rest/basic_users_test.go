@@ -0,0 +1,245 @@
+package rest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBasicUserHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	err := basicUser.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{
+		BasicUser: basicUser,
+	}
+
+	t.Parallel()
+
+	t.Run("when basic user does not exist", func(t *testing.T) {
+		uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		basicUserSvc := new(mocks.BasicUserServiceMock)
+		basicUserSvc.On("GetBasicUser", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(user.BasicUser{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			BasicUserService:        basicUserSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/basic_users/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		basicUserSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"basic user not found"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when basic user exists", func(t *testing.T) {
+		uuid := basicUser.UUID().String()
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		basicUserSvc := new(mocks.BasicUserServiceMock)
+		basicUserSvc.On("GetBasicUser", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(basicUser, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			BasicUserService:        basicUserSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/basic_users/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		basicUserSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{
+			"uuid":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+			"external_user_uuid":"b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+			"name":"Alfred",
+			"surname":"Koletschko",
+			"org_display_name":"KompiTech",
+			"org_name":"a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+			"_links":{
+				"self":{"href":"http://service.url/basic_users/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+			}
+		}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+}
+
+func TestListBasicUsersHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	basicUser1 := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	err := basicUser1.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+	require.NoError(t, err)
+
+	basicUser2 := user.BasicUser{
+		ExternalUserUUID: "ee824cad-d7a6-4f48-87dc-e8461a9201c4",
+		Name:             "Jan",
+		Surname:          "Novak",
+	}
+	err = basicUser2.SetUUID("00271cb4-3716-4203-9124-1d2f515ae0b2")
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{
+		BasicUser: basicUser1,
+	}
+
+	us := new(mocks.ExternalUserServiceMock)
+	us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+		Return(actorUser, nil)
+
+	basicUserSvc := new(mocks.BasicUserServiceMock)
+	result := repository.BasicUserList{
+		Result: []user.BasicUser{basicUser1, basicUser2},
+		Pagination: &repository.Pagination{
+			Total: 2,
+			Size:  2,
+			Page:  1,
+			First: 1,
+			Last:  1,
+		},
+	}
+	basicUserSvc.On("ListBasicUsers", ref.ChannelID(channelID), actorUser, mock.AnythingOfType("*converters.paginationParams")).
+		Return(result, nil)
+
+	server := NewServer(Config{
+		Addr:                    "service.url",
+		Logger:                  logger,
+		ExternalUserService:     us,
+		BasicUserService:        basicUserSvc,
+		ExternalLocationAddress: "http://service.url",
+	})
+
+	req := httptest.NewRequest("GET", "/basic_users", nil)
+	req.Header.Set("channel-id", channelID)
+	req.Header.Set("authorization", bearerToken)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	resp := w.Result()
+
+	defer func() { _ = resp.Body.Close() }()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read response: %v", err)
+	}
+
+	us.AssertExpectations(t)
+	basicUserSvc.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+	expectedJSON := `{
+		"total":2,
+		"size":2,
+		"page":1,
+		"_embedded":[
+			{
+				"uuid":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+				"external_user_uuid":"b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+				"name":"Alfred",
+				"surname":"Koletschko",
+				"_links":{
+					"self":{"href":"http://service.url/basic_users/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+				}
+			},
+			{
+				"uuid":"00271cb4-3716-4203-9124-1d2f515ae0b2",
+				"external_user_uuid":"ee824cad-d7a6-4f48-87dc-e8461a9201c4",
+				"name":"Jan",
+				"surname":"Novak",
+				"_links":{
+					"self":{"href":"http://service.url/basic_users/00271cb4-3716-4203-9124-1d2f515ae0b2"}
+				}
+			}
+		],
+		"_links":{
+			"self":{"href":"http://service.url/basic_users"},
+			"first":{"href":"http://service.url/basic_users"},
+			"last":{"href":"http://service.url/basic_users"}
+		}
+	}`
+	assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+}
@@ -0,0 +1,79 @@
+// Package cursor encodes positions of the cursor based pagination to opaque signed tokens
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// ErrInvalidCursor is returned if the token is malformed, its signature does not match or it was issued for another sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Codec encodes cursors to tokens signed with HMAC-SHA256 and decodes them back
+type Codec struct {
+	secret []byte
+}
+
+// NewCodec returns new codec signing the tokens with the secret
+func NewCodec(secret []byte) *Codec {
+	return &Codec{
+		secret: secret,
+	}
+}
+
+type payload struct {
+	Sort      string `json:"s,omitempty"`
+	SortValue string `json:"v,omitempty"`
+	Seq       uint64 `json:"q"`
+}
+
+// Encode returns the signed token of the cursor. The token is valid only for the list with the same sort parameter.
+func (c *Codec) Encode(cursor repository.Cursor, sortParam string) string {
+	data, _ := json.Marshal(payload{
+		Sort:      sortParam,
+		SortValue: cursor.SortValue,
+		Seq:       cursor.Seq,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(c.sign(data))
+}
+
+// Decode verifies the token and returns the cursor, it returns ErrInvalidCursor if the token is not valid for the sort parameter
+func (c *Codec) Decode(token string, sortParam string) (repository.Cursor, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return repository.Cursor{}, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return repository.Cursor{}, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, c.sign(data)) {
+		return repository.Cursor{}, ErrInvalidCursor
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil || p.Sort != sortParam {
+		return repository.Cursor{}, ErrInvalidCursor
+	}
+
+	return repository.Cursor{
+		SortValue: p.SortValue,
+		Seq:       p.Seq,
+	}, nil
+}
+
+func (c *Codec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,50 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec(t *testing.T) {
+	codec := NewCodec([]byte("secret"))
+	cursor := repository.Cursor{SortValue: "2021-10-17T15:04:05+02:00", Seq: 42}
+
+	t.Run("decodes encoded cursor", func(t *testing.T) {
+		token := codec.Encode(cursor, "-created_at")
+
+		decoded, err := codec.Decode(token, "-created_at")
+		require.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+
+	t.Run("rejects invalid tokens", func(t *testing.T) {
+		token := codec.Encode(cursor, "-created_at")
+		parts := strings.Split(token, ".")
+		tampered := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"-created_at","q":1}`)) + "." + parts[1]
+
+		tests := []struct {
+			name      string
+			codec     *Codec
+			token     string
+			sortParam string
+		}{
+			{"malformed token", codec, "abc", "-created_at"},
+			{"malformed signature", codec, parts[0] + ".%%%", "-created_at"},
+			{"tampered payload", codec, tampered, "-created_at"},
+			{"another secret", NewCodec([]byte("another secret")), token, "-created_at"},
+			{"another sort order", codec, token, "created_at"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := tt.codec.Decode(tt.token, tt.sortParam)
+				assert.ErrorIs(t, err, ErrInvalidCursor)
+			})
+		}
+	})
+}
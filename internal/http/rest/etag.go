@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/julienschmidt/httprouter"
+)
+
+// incidentETag returns the entity tag of the incident, it changes with each update of the incident
+func incidentETag(inc incident.Incident) string {
+	return fmt.Sprintf(`"%d"`, inc.Version())
+}
+
+// parseIncidentETags returns the incident versions from the comma-separated list of entity tags (RFC 7232, section 3.1).
+// Weak entity tags are compared by their strong-equivalent value, i.e. W/"7" is the same as "7".
+func parseIncidentETags(header string) ([]uint, error) {
+	var versions []uint
+
+	rest := header
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if rest == "" {
+			break
+		}
+
+		opaque := strings.TrimPrefix(rest, "W/")
+		if !strings.HasPrefix(opaque, `"`) {
+			return nil, fmt.Errorf("malformed entity tag %s", strings.TrimSpace(header))
+		}
+
+		end := strings.Index(opaque[1:], `"`)
+		if end < 0 {
+			return nil, fmt.Errorf("malformed entity tag %s", strings.TrimSpace(header))
+		}
+		etag := rest[:len(rest)-len(opaque)+end+2]
+		rest = strings.TrimLeft(opaque[end+2:], " \t")
+		if rest != "" && !strings.HasPrefix(rest, ",") {
+			return nil, fmt.Errorf("malformed entity tag %s", strings.TrimSpace(header))
+		}
+
+		version, err := strconv.ParseUint(opaque[1:end+1], 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("malformed entity tag %s", etag)
+		}
+
+		versions = append(versions, uint(version))
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("malformed entity tag %s", strings.TrimSpace(header))
+	}
+
+	return versions, nil
+}
+
+// withIfMatch wraps the handler modifying the incident. The incident versions from 'If-Match' header are added to the request's context
+// and the modification is rejected by the incident service if the incident version does not match any of them.
+// Missing header is rejected in strict mode, 'If-Match: *' disables the check.
+func (s *Server) withIfMatch(handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		ifMatch := r.Header.Get("If-Match")
+
+		if ifMatch == "" {
+			if s.requireIfMatch {
+				err := presenters.NewErrorf(http.StatusConflict, "'If-Match' header is required")
+				s.logger.Warnw("If-Match check failed", "error", err)
+				s.presenters.base.RenderError(w, "", err)
+				return
+			}
+
+			handle(w, r, params)
+			return
+		}
+
+		if strings.TrimSpace(ifMatch) == "*" {
+			handle(w, r, params)
+			return
+		}
+
+		versions, err := parseIncidentETags(ifMatch)
+		if err != nil {
+			err = presenters.WrapErrorf(err, http.StatusPreconditionFailed, "'If-Match' header does not match")
+			s.logger.Warnw("If-Match check failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		ctx := incidentsvc.WithExpectedVersions(r.Context(), versions...)
+		handle(w, r.WithContext(ctx), params)
+	}
+}
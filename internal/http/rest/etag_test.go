@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatch(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		requireIfMatch   bool
+		ifMatch          string
+		expectedStatus   int
+		expectedJSON     string
+		expectedVersions []uint
+	}{
+		{
+			name:           "when header is missing",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "when header is missing in strict mode",
+			requireIfMatch: true,
+			expectedStatus: http.StatusConflict,
+			expectedJSON:   `{"error":"'If-Match' header is required"}`,
+		},
+		{
+			name:           "when header matches any version",
+			requireIfMatch: true,
+			ifMatch:        "*",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:             "when header contains entity tag",
+			requireIfMatch:   true,
+			ifMatch:          `"7"`,
+			expectedStatus:   http.StatusNoContent,
+			expectedVersions: []uint{7},
+		},
+		{
+			name:             "when header contains weak entity tag",
+			ifMatch:          `W/"7"`,
+			expectedStatus:   http.StatusNoContent,
+			expectedVersions: []uint{7},
+		},
+		{
+			name:             "when header contains list of entity tags",
+			ifMatch:          `"2", W/"3" ,"5"`,
+			expectedStatus:   http.StatusNoContent,
+			expectedVersions: []uint{2, 3, 5},
+		},
+		{
+			name:           "when header is malformed",
+			ifMatch:        `"abc"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedJSON:   `{"error":"'If-Match' header does not match: malformed entity tag \"abc\""}`,
+		},
+		{
+			name:           "when entity tag in the list is malformed",
+			ifMatch:        `"2", W/"abc"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedJSON:   `{"error":"'If-Match' header does not match: malformed entity tag W/\"abc\""}`,
+		},
+		{
+			name:           "when entity tag is not quoted",
+			ifMatch:        `"2", 3`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedJSON:   `{"error":"'If-Match' header does not match: malformed entity tag \"2\", 3"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(Config{
+				Addr:           "service.url",
+				Logger:         logger,
+				RequireIfMatch: tt.requireIfMatch,
+			})
+
+			var handlerCalled bool
+			var versions []uint
+			var hasVersions bool
+			handle := server.withIfMatch(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+				handlerCalled = true
+				versions, hasVersions = incidentsvc.ExpectedVersionsFromContext(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest("POST", "/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/resolve", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+			handle(w, req, nil)
+			resp := w.Result()
+
+			defer func() { _ = resp.Body.Close() }()
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("could not read response: %v", err)
+			}
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode, "Status code")
+			assert.Equal(t, tt.expectedStatus == http.StatusNoContent, handlerCalled, "handler called")
+
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, string(b), "response does not match")
+			}
+
+			if tt.expectedVersions != nil {
+				assert.True(t, hasVersions, "expected versions in context")
+				assert.Equal(t, tt.expectedVersions, versions)
+			} else {
+				assert.False(t, hasVersions, "expected versions in context")
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/julienschmidt/httprouter"
+)
+
+func (s Server) registerFieldEngineerRoutes() {
+	s.router.POST("/field_engineers", s.CreateFieldEngineer())
+	s.router.GET("/field_engineers/:id", s.GetFieldEngineer())
+	s.router.GET("/field_engineers", s.ListFieldEngineers())
+	s.router.POST("/field_engineers/:id/deactivate", s.FieldEngineerDeactivate())
+	s.router.POST("/field_engineers/:id/start_travelling", s.FieldEngineerStartTravelling())
+	s.router.POST("/field_engineers/:id/start_travelling_back", s.FieldEngineerStartTravellingBack())
+	s.router.POST("/field_engineers/:id/start_break", s.FieldEngineerStartBreak())
+	s.router.POST("/field_engineers/:id/end_break", s.FieldEngineerEndBreak())
+	s.router.POST("/field_engineers/:id/close_time_session", s.FieldEngineerCloseTimeSession())
+}
+
+// swagger:route POST /field_engineers field_engineers CreateFieldEngineer
+// Creates a new field engineer from the existing basic user
+// responses:
+//
+//	201: fieldEngineerCreatedResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+
+// CreateFieldEngineer returns handler for creating single field engineer
+func (s *Server) CreateFieldEngineer() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		fePayload, err := s.inputPayloadConverters.fieldEngineer.FieldEngineerCreateParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("CreateFieldEngineer handler failed", "error", err)
+			s.presenters.fieldEngineer.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("CreateFieldEngineer handler failed", "error", err)
+			s.presenters.fieldEngineer.RenderError(w, "", err)
+			return
+		}
+
+		newID, err := s.fieldEngineerService.CreateFieldEngineer(r.Context(), channelID, actorUser, fePayload)
+		if err != nil {
+			s.logger.Errorw("CreateFieldEngineer handler failed", "error", err)
+			s.presenters.fieldEngineer.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.fieldEngineer.RenderCreatedHeader(w, listFieldEngineersRoute, newID)
+	}
+}
+
+// swagger:route GET /field_engineers/{uuid} field_engineers GetFieldEngineer
+// Returns a single field engineer from the repository
+// responses:
+//
+//	200: fieldEngineerResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const getFieldEngineerRoute = "/field_engineers/{uuid}"
+
+// GetFieldEngineer returns handler for getting single field engineer
+func (s *Server) GetFieldEngineer() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		if id == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("GetFieldEngineer handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("GetFieldEngineer handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		fe, err := s.fieldEngineerService.GetFieldEngineer(r.Context(), channelID, actorUser, ref.UUID(id))
+		if err != nil {
+			s.logger.Errorw("GetFieldEngineer handler failed", "ID", id, "error", err)
+			s.presenters.base.RenderError(w, "field engineer not found", err)
+			return
+		}
+
+		hypermediaMapper := NewFieldEngineerHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.fieldEngineer.RenderFieldEngineer(w, fe, hypermediaMapper)
+	}
+}
+
+// swagger:route GET /field_engineers field_engineers ListFieldEngineers
+// Returns a list of field engineers
+// responses:
+//
+//	200: fieldEngineerListResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+const listFieldEngineersRoute = "/field_engineers"
+
+// ListFieldEngineers returns handler for listing field engineers
+func (s *Server) ListFieldEngineers() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ListFieldEngineers handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		paginationParams, err := s.PaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		list, err := s.fieldEngineerService.ListFieldEngineers(r.Context(), channelID, actorUser, paginationParams)
+		if err != nil {
+			s.logger.Errorw("ListFieldEngineers handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		hypermediaMapper := NewFieldEngineerHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.fieldEngineer.RenderFieldEngineerList(w, list, hypermediaMapper)
+	}
+}
+
+// swagger:route POST /field_engineers/{uuid}/deactivate field_engineers FieldEngineerDeactivate
+// Deactivates the field engineer so that he cannot work on tickets anymore
+// responses:
+//
+//	204: fieldEngineerNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const fieldEngineerDeactivateRoute = "/field_engineers/{uuid}/deactivate"
+
+// FieldEngineerDeactivate returns handler for deactivate action
+func (s *Server) FieldEngineerDeactivate() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		feID := params.ByName("id")
+		if feID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("FieldEngineerDeactivate handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerDeactivate handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.fieldEngineerService.Deactivate(r.Context(), channelID, actorUser, ref.UUID(feID))
+		if err != nil {
+			s.logger.Errorw("FieldEngineerDeactivate handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.base.RenderNoContentHeader(w, listFieldEngineersRoute, ref.UUID(feID))
+	}
+}
+
+// swagger:route POST /field_engineers/{uuid}/start_travelling field_engineers FieldEngineerStartTravelling
+// Opens new time session of the field engineer and starts travelling to the customer
+// responses:
+//
+//	204: fieldEngineerNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const fieldEngineerStartTravellingRoute = "/field_engineers/{uuid}/start_travelling"
+
+// FieldEngineerStartTravelling returns handler for start travelling action
+func (s *Server) FieldEngineerStartTravelling() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		feID := params.ByName("id")
+		if feID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("FieldEngineerStartTravelling handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerStartTravelling handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.fieldEngineerService.StartTravelling(r.Context(), channelID, actorUser, ref.UUID(feID), s.clock)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerStartTravelling handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.base.RenderNoContentHeader(w, listFieldEngineersRoute, ref.UUID(feID))
+	}
+}
+
+// swagger:route POST /field_engineers/{uuid}/start_travelling_back field_engineers FieldEngineerStartTravellingBack
+// Starts travelling from the customer
+// responses:
+//
+//	204: fieldEngineerNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const fieldEngineerStartTravellingBackRoute = "/field_engineers/{uuid}/start_travelling_back"
+
+// FieldEngineerStartTravellingBack returns handler for start travelling back action
+func (s *Server) FieldEngineerStartTravellingBack() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		feID := params.ByName("id")
+		if feID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("FieldEngineerStartTravellingBack handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerStartTravellingBack handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.fieldEngineerService.StartTravellingBack(r.Context(), channelID, actorUser, ref.UUID(feID), s.clock)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerStartTravellingBack handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.base.RenderNoContentHeader(w, listFieldEngineersRoute, ref.UUID(feID))
+	}
+}
+
+// swagger:route POST /field_engineers/{uuid}/start_break field_engineers FieldEngineerStartBreak
+// Pauses the work in the open time session
+// responses:
+//
+//	204: fieldEngineerNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const fieldEngineerStartBreakRoute = "/field_engineers/{uuid}/start_break"
+
+// FieldEngineerStartBreak returns handler for start break action
+func (s *Server) FieldEngineerStartBreak() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		feID := params.ByName("id")
+		if feID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("FieldEngineerStartBreak handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerStartBreak handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.fieldEngineerService.StartBreak(r.Context(), channelID, actorUser, ref.UUID(feID), s.clock)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerStartBreak handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.base.RenderNoContentHeader(w, listFieldEngineersRoute, ref.UUID(feID))
+	}
+}
+
+// swagger:route POST /field_engineers/{uuid}/end_break field_engineers FieldEngineerEndBreak
+// Resumes the work in the open time session
+// responses:
+//
+//	204: fieldEngineerNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const fieldEngineerEndBreakRoute = "/field_engineers/{uuid}/end_break"
+
+// FieldEngineerEndBreak returns handler for end break action
+func (s *Server) FieldEngineerEndBreak() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		feID := params.ByName("id")
+		if feID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("FieldEngineerEndBreak handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerEndBreak handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.fieldEngineerService.EndBreak(r.Context(), channelID, actorUser, ref.UUID(feID), s.clock)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerEndBreak handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.base.RenderNoContentHeader(w, listFieldEngineersRoute, ref.UUID(feID))
+	}
+}
+
+// swagger:route POST /field_engineers/{uuid}/close_time_session field_engineers FieldEngineerCloseTimeSession
+// Closes the open time session of the field engineer
+// responses:
+//
+//	204: fieldEngineerNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const fieldEngineerCloseTimeSessionRoute = "/field_engineers/{uuid}/close_time_session"
+
+// FieldEngineerCloseTimeSession returns handler for close time session action
+func (s *Server) FieldEngineerCloseTimeSession() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		feID := params.ByName("id")
+		if feID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("FieldEngineerCloseTimeSession handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.fieldEngineer.FieldEngineerCloseTimeSessionParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("FieldEngineerCloseTimeSession handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerCloseTimeSession handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.fieldEngineerService.CloseTimeSession(r.Context(), channelID, actorUser, ref.UUID(feID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("FieldEngineerCloseTimeSession handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.base.RenderNoContentHeader(w, listFieldEngineersRoute, ref.UUID(feID))
+	}
+}
+
+// fieldEngineerActionLinks maps field engineer domain actions to hypermedia action links
+func fieldEngineerActionLinks(mapper *hypermedia.BaseHypermediaMapper) hypermedia.ActionLinks {
+	links := hypermedia.NewActionLinks(mapper)
+
+	links.Add(fieldengineer.ActionStartTravelling.String(), "FieldEngineerStartTravelling", fieldEngineerStartTravellingRoute)
+	links.Add(fieldengineer.ActionStartTravellingBack.String(), "FieldEngineerStartTravellingBack", fieldEngineerStartTravellingBackRoute)
+	links.Add(fieldengineer.ActionStartBreak.String(), "FieldEngineerStartBreak", fieldEngineerStartBreakRoute)
+	links.Add(fieldengineer.ActionEndBreak.String(), "FieldEngineerEndBreak", fieldEngineerEndBreakRoute)
+	links.Add(fieldengineer.ActionCloseTimeSession.String(), "FieldEngineerCloseTimeSession", fieldEngineerCloseTimeSessionRoute)
+	links.Add(fieldengineer.ActionDeactivate.String(), "FieldEngineerDeactivate", fieldEngineerDeactivateRoute)
+
+	return links
+}
+
+// FieldEngineerHypermediaMapper implements hypermedia mapping functionality for field engineer resource
+type FieldEngineerHypermediaMapper struct {
+	*hypermedia.BaseHypermediaMapper
+}
+
+// NewFieldEngineerHypermediaMapper returns new hypermedia mapper for field engineer resource
+func NewFieldEngineerHypermediaMapper(serverAddr string, currentURL *url.URL, actor actor.Actor) FieldEngineerHypermediaMapper {
+	return FieldEngineerHypermediaMapper{
+		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+	}
+}
+
+// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links
+func (h FieldEngineerHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
+	return fieldEngineerActionLinks(h.BaseHypermediaMapper)
+}
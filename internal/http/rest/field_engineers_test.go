@@ -0,0 +1,625 @@
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFieldEngineerHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+		},
+	}
+	err := actorUser.BasicUser.SetUUID("8183eaca-56c0-41d9-9291-1d295dd53763")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when body payload is not valid (ie. validation fails)", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{"basic_user": "not an uuid"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/field_engineers", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"'basic_user' must be a valid version 4 UUID"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when body payload is valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+		fieldEngineerSvc.On("CreateFieldEngineer", ref.ChannelID(channelID), actorUser, api.CreateFieldEngineerParams{
+			BasicUserID: "f49d5fd5-8da4-4779-b5ba-32e78aa2c444",
+		}).Return(ref.UUID("38316161-3035-4864-ad30-6231392d3433"), nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			FieldEngineerService:    fieldEngineerSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{"basic_user": "f49d5fd5-8da4-4779-b5ba-32e78aa2c444"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/field_engineers", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		fieldEngineerSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/field_engineers/38316161-3035-4864-ad30-6231392d3433"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestGetFieldEngineerHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	createdByUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	err := createdByUser.SetUUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+	require.NoError(t, err)
+
+	// actor is not a field engineer, so he is allowed to deactivate field engineers
+	actorUser := actor.Actor{
+		BasicUser: createdByUser,
+	}
+
+	t.Parallel()
+
+	t.Run("when field engineer does not exist", func(t *testing.T) {
+		uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+		fieldEngineerSvc.On("GetFieldEngineer", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(fieldengineer.FieldEngineer{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			FieldEngineerService:    fieldEngineerSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/field_engineers/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		fieldEngineerSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"field engineer not found"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when field engineer exists", func(t *testing.T) {
+		uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+		fe := fieldengineer.FieldEngineer{
+			BasicUser: user.BasicUser{
+				ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+				Name:             "Alois",
+				Surname:          "Vomacka",
+				OrgDisplayName:   "CGI",
+				OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+			},
+			TimeSessions: []ref.UUID{"b8d49f19-5e54-44cf-b547-f16bacb69294"},
+		}
+		err := fe.BasicUser.SetUUID("f49d5fd5-8da4-4779-b5ba-32e78aa2c444")
+		require.NoError(t, err)
+		err = fe.SetUUID(ref.UUID(uuid))
+		require.NoError(t, err)
+		err = fe.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+		err = fe.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+		fieldEngineerSvc.On("GetFieldEngineer", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(fe, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			FieldEngineerService:    fieldEngineerSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/field_engineers/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		fieldEngineerSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{
+			"uuid":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
+			"basic_user":{
+				"uuid":"f49d5fd5-8da4-4779-b5ba-32e78aa2c444",
+				"external_user_uuid":"5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+				"name":"Alois",
+				"surname":"Vomacka",
+				"org_display_name":"CGI",
+				"org_name":"1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com"
+			},
+			"deactivated":false,
+			"time_sessions":["b8d49f19-5e54-44cf-b547-f16bacb69294"],
+			"created_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+			"created_at":"2021-04-01T12:34:56+02:00",
+			"updated_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+			"updated_at":"2021-04-01T12:34:56+02:00",
+			"_embedded":{
+				"created_by":{
+					"_links": {
+						"self": {"href": "http://service.url/basic_users/0ac5ebce-17e7-4edc-9552-fefe16e127fb"}
+					},
+					"external_user_uuid": "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+					"name":"Alfred",
+					"surname":"Koletschko",
+					"org_name":"a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+					"org_display_name":"KompiTech",
+					"uuid": "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+				}
+			},
+			"_links":{
+				"self":{"href":"http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4"},
+				"FieldEngineerDeactivate":{"href":"http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4/deactivate"}
+			}
+		}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+}
+
+func TestListFieldEngineersHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	createdByUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	err := createdByUser.SetUUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{
+		BasicUser: createdByUser,
+	}
+
+	t.Parallel()
+
+	fe1 := fieldengineer.FieldEngineer{
+		BasicUser: user.BasicUser{
+			Name:    "Alois",
+			Surname: "Vomacka",
+		},
+	}
+	err = fe1.BasicUser.SetUUID("f49d5fd5-8da4-4779-b5ba-32e78aa2c444")
+	require.NoError(t, err)
+	err = fe1.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+	require.NoError(t, err)
+	err = fe1.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+	require.NoError(t, err)
+	err = fe1.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
+	require.NoError(t, err)
+
+	fe2 := fieldengineer.FieldEngineer{
+		BasicUser: user.BasicUser{
+			Name:    "Jan",
+			Surname: "Novak",
+		},
+	}
+	err = fe2.BasicUser.SetUUID("00271cb4-3716-4203-9124-1d2f515ae0b2")
+	require.NoError(t, err)
+	err = fe2.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+	require.NoError(t, err)
+	fe2.SetDeactivated(true)
+	err = fe2.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+	require.NoError(t, err)
+	err = fe2.CreatedUpdated.SetUpdated(createdByUser, "2021-04-02T09:10:32+02:00")
+	require.NoError(t, err)
+
+	us := new(mocks.ExternalUserServiceMock)
+	us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+		Return(actorUser, nil)
+
+	fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+	result := repository.FieldEngineerList{
+		Result: []fieldengineer.FieldEngineer{fe1, fe2},
+		Pagination: &repository.Pagination{
+			Total: 2,
+			Size:  2,
+			Page:  1,
+			First: 1,
+			Last:  1,
+		},
+	}
+	fieldEngineerSvc.On("ListFieldEngineers", ref.ChannelID(channelID), actorUser, mock.AnythingOfType("*converters.paginationParams")).
+		Return(result, nil)
+
+	server := NewServer(Config{
+		Addr:                    "service.url",
+		Logger:                  logger,
+		ExternalUserService:     us,
+		FieldEngineerService:    fieldEngineerSvc,
+		ExternalLocationAddress: "http://service.url",
+	})
+
+	req := httptest.NewRequest("GET", "/field_engineers", nil)
+	req.Header.Set("channel-id", channelID)
+	req.Header.Set("authorization", bearerToken)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	resp := w.Result()
+
+	defer func() { _ = resp.Body.Close() }()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read response: %v", err)
+	}
+
+	us.AssertExpectations(t)
+	fieldEngineerSvc.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+	expectedJSON := `{
+		"total":2,
+		"size":2,
+		"page":1,
+		"_embedded":[
+			{
+				"uuid":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
+				"basic_user":{"uuid":"f49d5fd5-8da4-4779-b5ba-32e78aa2c444","name":"Alois","surname":"Vomacka"},
+				"deactivated":false,
+				"created_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"created_at":"2021-04-01T12:34:56+02:00",
+				"updated_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"updated_at":"2021-04-01T12:34:56+02:00",
+				"_links":{
+					"self":{"href":"http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4"},
+					"FieldEngineerDeactivate":{"href":"http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4/deactivate"}
+				}
+			},
+			{
+				"uuid":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+				"basic_user":{"uuid":"00271cb4-3716-4203-9124-1d2f515ae0b2","name":"Jan","surname":"Novak"},
+				"deactivated":true,
+				"created_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"created_at":"2021-04-01T12:34:56+02:00",
+				"updated_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"updated_at":"2021-04-02T09:10:32+02:00",
+				"_links":{
+					"self":{"href":"http://service.url/field_engineers/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+				}
+			}
+		],
+		"_links":{
+			"self":{"href":"http://service.url/field_engineers"},
+			"first":{"href":"http://service.url/field_engineers"},
+			"last":{"href":"http://service.url/field_engineers"}
+		}
+	}`
+	assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+}
+
+func TestFieldEngineerDeactivateHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+	uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+			Name:             "Admin",
+			Surname:          "User",
+		},
+	}
+
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		statusCode int
+	}{
+		{"when field engineer can be deactivated", nil, http.StatusNoContent},
+		{"when field engineer has an open time session", domain.NewErrorf(domain.ErrorCodeActionForbidden, "field engineer has an open time session"), http.StatusForbidden},
+		{"when field engineer does not exist", domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			us := new(mocks.ExternalUserServiceMock)
+			us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+				Return(actorUser, nil)
+
+			fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+			fieldEngineerSvc.On("Deactivate", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+				Return(tt.svcErr)
+
+			server := NewServer(Config{
+				Addr:                    "service.url",
+				Logger:                  logger,
+				ExternalUserService:     us,
+				FieldEngineerService:    fieldEngineerSvc,
+				ExternalLocationAddress: "http://service.url",
+			})
+
+			req := httptest.NewRequest("POST", "/field_engineers/"+uuid+"/deactivate", nil)
+			req.Header.Set("channel-id", channelID)
+			req.Header.Set("authorization", bearerToken)
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+			resp := w.Result()
+
+			us.AssertExpectations(t)
+			fieldEngineerSvc.AssertExpectations(t)
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode, "Status code")
+			if tt.statusCode == http.StatusNoContent {
+				expectedLocation := "http://service.url/field_engineers/" + uuid
+				assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+			}
+		})
+	}
+}
+
+func TestFieldEngineerTimeSessionHandlers(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+	uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+	feUUID := ref.UUID(uuid)
+	actorUser.SetFieldEngineerID(&feUUID)
+
+	t.Parallel()
+
+	tests := []struct {
+		action     string
+		svcMethod  string
+		svcErr     error
+		statusCode int
+	}{
+		{"start_travelling", "StartTravelling", nil, http.StatusNoContent},
+		{"start_travelling_back", "StartTravellingBack", nil, http.StatusNoContent},
+		{"start_break", "StartBreak", nil, http.StatusNoContent},
+		{"end_break", "EndBreak", nil, http.StatusNoContent},
+		{"end_break", "EndBreak", domain.NewErrorf(domain.ErrorCodeActionForbidden, "time session is not in Break state"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.action+" returns "+http.StatusText(tt.statusCode), func(t *testing.T) {
+			us := new(mocks.ExternalUserServiceMock)
+			us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+				Return(actorUser, nil)
+
+			fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+			fieldEngineerSvc.On(tt.svcMethod, ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+				Return(tt.svcErr)
+
+			server := NewServer(Config{
+				Addr:                    "service.url",
+				Logger:                  logger,
+				ExternalUserService:     us,
+				FieldEngineerService:    fieldEngineerSvc,
+				ExternalLocationAddress: "http://service.url",
+			})
+
+			req := httptest.NewRequest("POST", "/field_engineers/"+uuid+"/"+tt.action, nil)
+			req.Header.Set("channel-id", channelID)
+			req.Header.Set("authorization", bearerToken)
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+			resp := w.Result()
+
+			us.AssertExpectations(t)
+			fieldEngineerSvc.AssertExpectations(t)
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode, "Status code")
+			if tt.statusCode == http.StatusNoContent {
+				expectedLocation := "http://service.url/field_engineers/" + uuid
+				assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+			}
+		})
+	}
+
+	t.Run("close_time_session with valid payload", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+		fieldEngineerSvc.On("CloseTimeSession", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), api.FieldEngineerCloseTimeSessionParams{
+			TravelDistanceInTravelUnits: 42,
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			FieldEngineerService:    fieldEngineerSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"travel_distance_in_travel_units":42}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/field_engineers/"+uuid+"/close_time_session", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		fieldEngineerSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/field_engineers/" + uuid
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+
+	t.Run("close_time_session with invalid payload", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		fieldEngineerSvc := new(mocks.FieldEngineerServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			FieldEngineerService:    fieldEngineerSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"travel_distance_in_travel_units":-1}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/field_engineers/"+uuid+"/close_time_session", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		fieldEngineerSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+}
@@ -2,11 +2,13 @@ package rest
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
 	fieldengineersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/service"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
@@ -16,12 +18,26 @@ import (
 
 func (s Server) registerIncidentRoutes() {
 	s.router.POST("/incidents", s.CreateIncident())
-	s.router.PATCH("/incidents/:id", s.UpdateIncident())
+	s.router.PATCH("/incidents/:id", s.withIfMatch(s.UpdateIncident()))
 	s.router.GET("/incidents/:id", s.GetIncident())
 	s.router.GET("/incidents", s.ListIncidents())
-	s.router.POST("/incidents/:id/start_working", s.IncidentStartWorking())
-	s.router.POST("/incidents/:id/stop_working", s.IncidentStopWorking())
+	s.router.POST("/incidents/:id/accept", s.withIfMatch(s.IncidentAccept()))
+	s.router.POST("/incidents/:id/reject", s.withIfMatch(s.IncidentReject()))
+	s.router.POST("/incidents/:id/start_working", s.withIfMatch(s.IncidentStartWorking()))
+	s.router.POST("/incidents/:id/stop_working", s.withIfMatch(s.IncidentStopWorking()))
+	s.router.POST("/incidents/:id/pause_working", s.withIfMatch(s.IncidentPauseWorking()))
+	s.router.POST("/incidents/:id/resume_working", s.withIfMatch(s.IncidentResumeWorking()))
+	s.router.POST("/incidents/:id/put_on_hold", s.withIfMatch(s.IncidentPutOnHold()))
+	s.router.POST("/incidents/:id/resume", s.withIfMatch(s.IncidentResume()))
+	s.router.POST("/incidents/:id/resolve", s.withIfMatch(s.IncidentResolve()))
+	s.router.POST("/incidents/:id/close", s.withIfMatch(s.IncidentClose()))
+	s.router.POST("/incidents/:id/reopen", s.withIfMatch(s.IncidentReopen()))
+	s.router.GET("/incidents/:id/timelogs", s.ListIncidentTimelogs())
 	s.router.GET("/incidents/:id/timelogs/:timelog_uuid", s.GetIncidentTimelog())
+	s.router.POST("/incidents/:id/timelogs/:timelog_uuid/propose_correction", s.ProposeTimelogCorrection())
+	s.router.POST("/incidents/:id/timelogs/:timelog_uuid/approve_correction", s.ApproveTimelogCorrection())
+	s.router.POST("/incidents/:id/timelogs/:timelog_uuid/reject_correction", s.RejectTimelogCorrection())
+	s.router.GET("/incidents/:id/history", s.ListIncidentHistory())
 }
 
 // swagger:route POST /incidents incidents CreateIncident
@@ -74,6 +90,8 @@ func (s *Server) CreateIncident() func(w http.ResponseWriter, r *http.Request, _
 //	401: errorResponse401
 //  403: errorResponse403
 //	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
 
 // UpdateIncident returns handler for creating single incident
 func (s *Server) UpdateIncident() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -155,13 +173,15 @@ func (s *Server) GetIncident() func(w http.ResponseWriter, r *http.Request, _ ht
 			return
 		}
 
+		w.Header().Set("ETag", incidentETag(inc))
+
 		hypermediaMapper := NewIncidentHypermediaMapper(r.Context(), channelID, s.ExternalLocationAddress, r.URL, actorUser, s.fieldEngineerService)
 		s.presenters.incident.RenderIncident(w, inc, hypermediaMapper)
 	}
 }
 
 // swagger:route GET /incidents incidents ListIncidents
-// Returns a list of incidents
+// Returns a list of incidents, the list can be filtered and sorted
 // responses:
 //	200: incidentListResponse
 //	400: errorResponse400
@@ -184,13 +204,19 @@ func (s *Server) ListIncidents() func(w http.ResponseWriter, r *http.Request, _
 			return
 		}
 
-		paginationParams, err := s.PaginationParams(r, actorUser)
+		paginationParams, err := s.IncidentPaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		filter, err := s.IncidentFilter(r)
 		if err != nil {
 			s.presenters.base.RenderError(w, "", err)
 			return
 		}
 
-		list, err := s.incidentService.ListIncidents(r.Context(), channelID, actorUser, paginationParams)
+		list, err := s.incidentService.ListIncidents(r.Context(), channelID, actorUser, filter, paginationParams)
 		if err != nil {
 			s.logger.Errorw("ListIncidents handler failed", "error", err)
 			s.presenters.base.RenderError(w, "", err)
@@ -202,6 +228,105 @@ func (s *Server) ListIncidents() func(w http.ResponseWriter, r *http.Request, _
 	}
 }
 
+// swagger:route POST /incidents/{uuid}/accept incidents IncidentAccept
+// Accepts incident by the assigned field engineer. Field engineer cannot start working on the incident until it is accepted.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//  403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentAcceptRoute = "/incidents/{uuid}/accept"
+
+// IncidentAccept returns handler for accept action
+func (s *Server) IncidentAccept() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentAccept handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentAccept handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.Accept(r.Context(), channelID, actorUser, ref.UUID(incID), s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentAccept handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
+}
+
+// swagger:route POST /incidents/{uuid}/reject incidents IncidentReject
+// Rejects incident by the assigned field engineer. The field engineer is unassigned from the incident, so that it can be assigned to other one.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//  403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentRejectRoute = "/incidents/{uuid}/reject"
+
+// IncidentReject returns handler for reject action
+func (s *Server) IncidentReject() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentReject handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.incident.IncidentRejectParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("IncidentReject handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentReject handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.Reject(r.Context(), channelID, actorUser, ref.UUID(incID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentReject handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
+}
+
 // swagger:route POST /incidents/{uuid}/start_working incidents IncidentStartWorking
 // Starts working on incident by field engineer
 // responses:
@@ -209,6 +334,8 @@ func (s *Server) ListIncidents() func(w http.ResponseWriter, r *http.Request, _
 //	400: errorResponse400
 //	401: errorResponse401
 //  403: errorResponse403
+//	409: errorResponse409
+//	412: errorResponse412
 const incidentStartWorkingRoute = "/incidents/{uuid}/start_working"
 
 // IncidentStartWorking returns handler for start working action
@@ -259,6 +386,8 @@ func (s *Server) IncidentStartWorking() func(w http.ResponseWriter, r *http.Requ
 //	400: errorResponse400
 //	401: errorResponse401
 //  403: errorResponse403
+//	409: errorResponse409
+//	412: errorResponse412
 const incidentStopWorkingRoute = "/incidents/{uuid}/stop_working"
 
 // IncidentStopWorking returns handler for stop working action
@@ -302,70 +431,724 @@ func (s *Server) IncidentStopWorking() func(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// swagger:route GET /incidents/{uuid}/timelogs/{timelog_uuid} incidents GetIncidentTimelog
-// Returns a single timelog for the incident
+// swagger:route POST /incidents/{uuid}/pause_working incidents IncidentPauseWorking
+// Pauses working on incident by field engineer (ie. break or travelling) without closing the open timelog
 // responses:
-//	200: timelogResponse
+//	204: incidentNoContentResponse
 //	400: errorResponse400
-//  401: errorResponse401
+//	401: errorResponse401
 //  403: errorResponse403
-//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentPauseWorkingRoute = "/incidents/{uuid}/pause_working"
 
-// GetIncidentTimelog returns handler for GetIncidentTimelog action
-func (s *Server) GetIncidentTimelog() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+// IncidentPauseWorking returns handler for pause working action
+func (s *Server) IncidentPauseWorking() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentPauseWorking handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.incident.IncidentPauseWorkingParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("IncidentPauseWorking handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentPauseWorking handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
 
-		// TODO
+		err = s.incidentService.PauseWorking(r.Context(), channelID, actorUser, ref.UUID(incID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentPauseWorking handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
 
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
 	}
 }
 
-// IncidentHypermediaMapper implements hypermedia mapping functionality for incident resource
-type IncidentHypermediaMapper struct {
-	ctx       context.Context
-	channelID ref.ChannelID
-	feSvc     fieldengineersvc.FieldEngineerService
-	*hypermedia.BaseHypermediaMapper
-}
+// swagger:route POST /incidents/{uuid}/resume_working incidents IncidentResumeWorking
+// Resumes paused working on incident by field engineer
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//  403: errorResponse403
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentResumeWorkingRoute = "/incidents/{uuid}/resume_working"
 
-// NewIncidentHypermediaMapper returns new hypermedia mapper for incident resource
-func NewIncidentHypermediaMapper(ctx context.Context, channelID ref.ChannelID, serverAddr string, currentURL *url.URL, actor actor.Actor, feSvc fieldengineersvc.FieldEngineerService) IncidentHypermediaMapper {
-	return IncidentHypermediaMapper{
-		ctx:                  ctx,
-		channelID:            channelID,
-		feSvc:                feSvc,
-		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+// IncidentResumeWorking returns handler for resume working action
+func (s *Server) IncidentResumeWorking() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentResumeWorking handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentResumeWorking handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.ResumeWorking(r.Context(), channelID, actorUser, ref.UUID(incID), s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentResumeWorking handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
 	}
 }
 
-// Ctx ...
-func (h IncidentHypermediaMapper) Ctx() context.Context {
-	return h.ctx
-}
+// swagger:route POST /incidents/{uuid}/put_on_hold incidents IncidentPutOnHold
+// Puts incident on hold. If called by field engineer, the incident is moved to 'pre on hold' state and must be confirmed by other user.
+// Open timelog is closed.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentPutOnHoldRoute = "/incidents/{uuid}/put_on_hold"
 
-// ChannelID ...
-func (h IncidentHypermediaMapper) ChannelID() ref.ChannelID {
-	return h.channelID
-}
+// IncidentPutOnHold returns handler for put on hold action
+func (s *Server) IncidentPutOnHold() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentPutOnHold handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
 
-// FieldEngineerSvc ...
-func (h IncidentHypermediaMapper) FieldEngineerSvc() fieldengineersvc.FieldEngineerService {
-	return h.feSvc
+		payload, err := s.inputPayloadConverters.incident.IncidentPutOnHoldParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("IncidentPutOnHold handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentPutOnHold handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.PutOnHold(r.Context(), channelID, actorUser, ref.UUID(incID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentPutOnHold handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
 }
 
-// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links
-func (h IncidentHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
-	links := hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+// swagger:route POST /incidents/{uuid}/resume incidents IncidentResume
+// Resumes incident that is on hold
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentResumeRoute = "/incidents/{uuid}/resume"
 
-	links.Add(incident.ActionCancel.String(), "CancelIncident", cancelIncidentRoute)
-	links.Add(incident.ActionStartWorking.String(), "IncidentStartWorking", incidentStartWorkingRoute)
-	links.Add(incident.ActionStopWorking.String(), "IncidentStopWorking", incidentStopWorkingRoute)
+// IncidentResume returns handler for resume action
+func (s *Server) IncidentResume() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentResume handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
 
-	return links
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentResume handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.Resume(r.Context(), channelID, actorUser, ref.UUID(incID), s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentResume handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
 }
 
-// TODO this route will be in used in hypermedia
-const getBasicUserRoute = "/basic_user/{uuid}"
+// swagger:route POST /incidents/{uuid}/resolve incidents IncidentResolve
+// Resolves incident. Incident must have at least one closed timelog and no open timelog.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentResolveRoute = "/incidents/{uuid}/resolve"
+
+// IncidentResolve returns handler for resolve action
+func (s *Server) IncidentResolve() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentResolve handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.incident.IncidentResolveParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("IncidentResolve handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentResolve handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.Resolve(r.Context(), channelID, actorUser, ref.UUID(incID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentResolve handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
+}
+
+// swagger:route POST /incidents/{uuid}/close incidents IncidentClose
+// Closes resolved incident
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentCloseRoute = "/incidents/{uuid}/close"
+
+// IncidentClose returns handler for close action
+func (s *Server) IncidentClose() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentClose handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentClose handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.Close(r.Context(), channelID, actorUser, ref.UUID(incID), s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentClose handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
+}
+
+// swagger:route POST /incidents/{uuid}/reopen incidents IncidentReopen
+// Reopens resolved incident. Incident can be reopened only before it is closed.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+//	409: errorResponse409
+//	412: errorResponse412
+const incidentReopenRoute = "/incidents/{uuid}/reopen"
+
+// IncidentReopen returns handler for reopen action
+func (s *Server) IncidentReopen() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("IncidentReopen handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.incident.IncidentReopenParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("IncidentReopen handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("IncidentReopen handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.Reopen(r.Context(), channelID, actorUser, ref.UUID(incID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("IncidentReopen handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, listIncidentsRoute, ref.UUID(incID))
+	}
+}
+
+// swagger:route GET /incidents/{uuid}/timelogs incidents ListIncidentTimelogs
+// Returns a list of the incident's timelogs
+// responses:
+//	200: timelogListResponse
+//	400: errorResponse400
+//  401: errorResponse401
+//  403: errorResponse403
+//	404: errorResponse404
+
+// ListIncidentTimelogs returns handler for listing the incident's timelogs
+func (s *Server) ListIncidentTimelogs() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("ListIncidentTimelogs handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ListIncidentTimelogs handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		paginationParams, err := s.PaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		list, err := s.incidentService.ListIncidentTimelogs(r.Context(), channelID, actorUser, ref.UUID(incID), paginationParams)
+		if err != nil {
+			s.logger.Errorw("ListIncidentTimelogs handler failed", "ID", incID, "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		hypermediaMapper := NewIncidentHypermediaMapper(r.Context(), channelID, s.ExternalLocationAddress, r.URL, actorUser, s.fieldEngineerService)
+		s.presenters.incident.RenderTimelogList(w, ref.UUID(incID), list, hypermediaMapper)
+	}
+}
+
+// swagger:route GET /incidents/{uuid}/timelogs/{timelog_uuid} incidents GetIncidentTimelog
+// Returns a single timelog for the incident
+// responses:
+//	200: timelogResponse
+//	400: errorResponse400
+//  401: errorResponse401
+//  403: errorResponse403
+//	404: errorResponse404
+
+// GetIncidentTimelog returns handler for GetIncidentTimelog action
+func (s *Server) GetIncidentTimelog() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		timelogID := params.ByName("timelog_uuid")
+		if incID == "" || timelogID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("GetIncidentTimelog handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("GetIncidentTimelog handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		tmlg, err := s.incidentService.GetIncidentTimelog(r.Context(), channelID, actorUser, ref.UUID(incID), ref.UUID(timelogID))
+		if err != nil {
+			s.logger.Errorw("GetIncidentTimelog handler failed", "ID", incID, "timelogID", timelogID, "error", err)
+			s.presenters.incident.RenderError(w, "timelog not found", err)
+			return
+		}
+
+		hypermediaMapper := NewIncidentHypermediaMapper(r.Context(), channelID, s.ExternalLocationAddress, r.URL, actorUser, s.fieldEngineerService)
+		s.presenters.incident.RenderTimelog(w, ref.UUID(incID), tmlg, hypermediaMapper)
+	}
+}
+
+// swagger:route POST /incidents/{uuid}/timelogs/{timelog_uuid}/propose_correction incidents ProposeTimelogCorrection
+// Proposes correction of the closed timelog. Only field engineer who created the timelog can propose its correction.
+// Proposed values are applied to the timelog after the correction is approved by the dispatcher.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const proposeTimelogCorrectionRoute = "/incidents/{uuid}/timelogs/{timelog_uuid}/propose_correction"
+
+// ProposeTimelogCorrection returns handler for propose timelog correction action
+func (s *Server) ProposeTimelogCorrection() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		timelogID := params.ByName("timelog_uuid")
+		if incID == "" || timelogID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("ProposeTimelogCorrection handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.incident.TimelogCorrectionProposeParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("ProposeTimelogCorrection handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ProposeTimelogCorrection handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.ProposeTimelogCorrection(r.Context(), channelID, actorUser, ref.UUID(incID), ref.UUID(timelogID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("ProposeTimelogCorrection handler failed", "ID", incID, "timelogID", timelogID, "error", err)
+			s.presenters.incident.RenderError(w, "timelog not found", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, timelogLocationRoute(incID), ref.UUID(timelogID))
+	}
+}
+
+// swagger:route POST /incidents/{uuid}/timelogs/{timelog_uuid}/approve_correction incidents ApproveTimelogCorrection
+// Approves the proposed timelog correction. Corrected values replace the values of the timelog, the original values are preserved.
+// Only dispatcher can approve the correction.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const approveTimelogCorrectionRoute = "/incidents/{uuid}/timelogs/{timelog_uuid}/approve_correction"
+
+// ApproveTimelogCorrection returns handler for approve timelog correction action
+func (s *Server) ApproveTimelogCorrection() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		timelogID := params.ByName("timelog_uuid")
+		if incID == "" || timelogID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("ApproveTimelogCorrection handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ApproveTimelogCorrection handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.ApproveTimelogCorrection(r.Context(), channelID, actorUser, ref.UUID(incID), ref.UUID(timelogID), s.clock)
+		if err != nil {
+			s.logger.Errorw("ApproveTimelogCorrection handler failed", "ID", incID, "timelogID", timelogID, "error", err)
+			s.presenters.incident.RenderError(w, "timelog not found", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, timelogLocationRoute(incID), ref.UUID(timelogID))
+	}
+}
+
+// swagger:route POST /incidents/{uuid}/timelogs/{timelog_uuid}/reject_correction incidents RejectTimelogCorrection
+// Rejects the proposed timelog correction, the timelog is not changed. Only dispatcher can reject the correction.
+// responses:
+//	204: incidentNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const rejectTimelogCorrectionRoute = "/incidents/{uuid}/timelogs/{timelog_uuid}/reject_correction"
+
+// RejectTimelogCorrection returns handler for reject timelog correction action
+func (s *Server) RejectTimelogCorrection() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		timelogID := params.ByName("timelog_uuid")
+		if incID == "" || timelogID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("RejectTimelogCorrection handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		payload, err := s.inputPayloadConverters.incident.TimelogCorrectionRejectParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("RejectTimelogCorrection handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("RejectTimelogCorrection handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.RejectTimelogCorrection(r.Context(), channelID, actorUser, ref.UUID(incID), ref.UUID(timelogID), payload, s.clock)
+		if err != nil {
+			s.logger.Errorw("RejectTimelogCorrection handler failed", "ID", incID, "timelogID", timelogID, "error", err)
+			s.presenters.incident.RenderError(w, "timelog not found", err)
+			return
+		}
+
+		s.presenters.incident.RenderNoContentHeader(w, timelogLocationRoute(incID), ref.UUID(timelogID))
+	}
+}
+
+// swagger:route GET /incidents/{uuid}/history incidents ListIncidentHistory
+// Returns a list of changes made to the incident (audit trail), the oldest change first
+// responses:
+//	200: historyListResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+
+// ListIncidentHistory returns handler for listing the incident's history entries
+func (s *Server) ListIncidentHistory() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		incID := params.ByName("id")
+		if incID == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("ListIncidentHistory handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ListIncidentHistory handler failed", "error", err)
+			s.presenters.incident.RenderError(w, "", err)
+			return
+		}
+
+		paginationParams, err := s.PaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		list, err := s.incidentService.ListIncidentHistory(r.Context(), channelID, actorUser, ref.UUID(incID), paginationParams)
+		if err != nil {
+			s.logger.Errorw("ListIncidentHistory handler failed", "ID", incID, "error", err)
+			s.presenters.incident.RenderError(w, "incident not found", err)
+			return
+		}
+
+		hypermediaMapper := NewIncidentHypermediaMapper(r.Context(), channelID, s.ExternalLocationAddress, r.URL, actorUser, s.fieldEngineerService)
+		s.presenters.incident.RenderHistoryList(w, ref.UUID(incID), list, hypermediaMapper)
+	}
+}
+
+// timelogLocationRoute returns route of the incident's timelog collection
+func timelogLocationRoute(incID string) string {
+	return fmt.Sprintf("/incidents/%s/timelogs", incID)
+}
+
+// IncidentHypermediaMapper implements hypermedia mapping functionality for incident resource
+type IncidentHypermediaMapper struct {
+	ctx       context.Context
+	channelID ref.ChannelID
+	feSvc     fieldengineersvc.FieldEngineerService
+	*hypermedia.BaseHypermediaMapper
+}
+
+// NewIncidentHypermediaMapper returns new hypermedia mapper for incident resource
+func NewIncidentHypermediaMapper(ctx context.Context, channelID ref.ChannelID, serverAddr string, currentURL *url.URL, actor actor.Actor, feSvc fieldengineersvc.FieldEngineerService) IncidentHypermediaMapper {
+	return IncidentHypermediaMapper{
+		ctx:                  ctx,
+		channelID:            channelID,
+		feSvc:                feSvc,
+		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+	}
+}
+
+// Ctx ...
+func (h IncidentHypermediaMapper) Ctx() context.Context {
+	return h.ctx
+}
+
+// ChannelID ...
+func (h IncidentHypermediaMapper) ChannelID() ref.ChannelID {
+	return h.channelID
+}
+
+// FieldEngineerSvc ...
+func (h IncidentHypermediaMapper) FieldEngineerSvc() fieldengineersvc.FieldEngineerService {
+	return h.feSvc
+}
+
+// FieldEngineerActionLinks maps embedded field engineer actions to hypermedia action links
+func (h IncidentHypermediaMapper) FieldEngineerActionLinks() hypermedia.ActionLinks {
+	return fieldEngineerActionLinks(h.BaseHypermediaMapper)
+}
+
+// TimelogActionLinks maps timelog actions to hypermedia action links
+func (h IncidentHypermediaMapper) TimelogActionLinks() hypermedia.ActionLinks {
+	links := hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+
+	links.Add(timelog.ActionProposeCorrection.String(), "ProposeTimelogCorrection", proposeTimelogCorrectionRoute)
+	links.Add(timelog.ActionApproveCorrection.String(), "ApproveTimelogCorrection", approveTimelogCorrectionRoute)
+	links.Add(timelog.ActionRejectCorrection.String(), "RejectTimelogCorrection", rejectTimelogCorrectionRoute)
+
+	return links
+}
+
+// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links
+func (h IncidentHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
+	links := hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+
+	links.Add(incident.ActionCancel.String(), "CancelIncident", cancelIncidentRoute)
+	links.Add(incident.ActionAccept.String(), "IncidentAccept", incidentAcceptRoute)
+	links.Add(incident.ActionReject.String(), "IncidentReject", incidentRejectRoute)
+	links.Add(incident.ActionStartWorking.String(), "IncidentStartWorking", incidentStartWorkingRoute)
+	links.Add(incident.ActionStopWorking.String(), "IncidentStopWorking", incidentStopWorkingRoute)
+	links.Add(incident.ActionPauseWorking.String(), "IncidentPauseWorking", incidentPauseWorkingRoute)
+	links.Add(incident.ActionResumeWorking.String(), "IncidentResumeWorking", incidentResumeWorkingRoute)
+	links.Add(incident.ActionPutOnHold.String(), "IncidentPutOnHold", incidentPutOnHoldRoute)
+	links.Add(incident.ActionResume.String(), "IncidentResume", incidentResumeRoute)
+	links.Add(incident.ActionResolve.String(), "IncidentResolve", incidentResolveRoute)
+	links.Add(incident.ActionClose.String(), "IncidentClose", incidentCloseRoute)
+	links.Add(incident.ActionReopen.String(), "IncidentReopen", incidentReopenRoute)
+
+	return links
+}
 
 // TODO implement routes - they are just for testing at the moment
 const cancelIncidentRoute = "/incidents/{uuid}/cancel"
@@ -2,17 +2,26 @@ package rest
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/cursor"
+	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
 	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
@@ -286,6 +295,54 @@ func TestUpdateIncidentHandler(t *testing.T) {
 		expectedLocation := "http://service.url/incidents/7e0d38d1-e5f5-4211-b2aa-3b142e4da80e"
 		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
 	})
+
+	t.Run("when incident was modified after the client loaded it", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("UpdateIncident", ref.ChannelID(channelID), actorUser, ref.UUID("7e0d38d1-e5f5-4211-b2aa-3b142e4da80e"),
+			mock.AnythingOfType("api.UpdateIncidentParams")).
+			Return(ref.UUID(""), domain.NewErrorf(domain.ErrorCodeVersionMismatch, "incident was modified"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{
+			"short_description": "changed description"
+		}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PATCH", "/incidents/7e0d38d1-e5f5-4211-b2aa-3b142e4da80e", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+		req.Header.Set("If-Match", `"1"`)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"incident was modified"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
 }
 
 func TestGetIncidentHandler(t *testing.T) {
@@ -409,12 +466,14 @@ func TestGetIncidentHandler(t *testing.T) {
 		require.NoError(t, err)
 		state, err := incident.NewStateFromString("new")
 		require.NoError(t, err)
-		err = retInc.SetState(state)
+		err = retInc.RestoreState(state)
 		require.NoError(t, err)
 		err = retInc.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
 		require.NoError(t, err)
 		err = retInc.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
 		require.NoError(t, err)
+		retInc.RestorePriority(incident.ImpactLow, incident.UrgencyHigh, sla.PriorityCritical, true)
+		retInc.SetVersion(3)
 
 		us := new(mocks.ExternalUserServiceMock)
 		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
@@ -458,6 +517,7 @@ func TestGetIncidentHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
 		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+		assert.Equal(t, `"3"`, resp.Header.Get("ETag"), "ETag header")
 
 		expectedJSON := `{
 			"uuid":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
@@ -465,6 +525,10 @@ func TestGetIncidentHandler(t *testing.T) {
 			"short_description":"Test incident 1",
 			"field_engineer":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
 			"state":"new",
+			"impact":"low",
+			"urgency":"high",
+			"priority":"critical",
+			"priority_overridden":true,
 			"created_by":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
 			"created_at":"2021-04-01T12:34:56+02:00",
 			"updated_by":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
@@ -472,7 +536,8 @@ func TestGetIncidentHandler(t *testing.T) {
 			"_embedded":{
 				"field_engineer":{
 					"_links": {
-						"self": {"href": "http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4"}
+						"self": {"href": "http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4"},
+						"FieldEngineerStartTravelling": {"href": "http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4/start_travelling"}
 					},
 				    "external_user_uuid": "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
 					"name":"Alois",
@@ -496,11 +561,106 @@ func TestGetIncidentHandler(t *testing.T) {
 			"_links":{
 				"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"},
 				"CancelIncident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/cancel"},
-				"IncidentStartWorking":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/start_working"}
+				"IncidentPutOnHold":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/put_on_hold"},
+				"IncidentAccept":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/accept"},
+				"IncidentReject":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/reject"}
 			}
 		}`
 		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
 	})
+
+	t.Run("when incident has SLA", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		clock := mocks.NewFixedClock()
+
+		retInc := incident.Incident{
+			Number:           "A123456",
+			ShortDescription: "Test incident 1",
+		}
+		err := retInc.SetUUID(ref.UUID(uuid))
+		require.NoError(t, err)
+		err = retInc.RestoreState(incident.StateNew)
+		require.NoError(t, err)
+		err = retInc.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+		err = retInc.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+		err = retInc.StartSLA(clock, sla.Definition{
+			Targets: map[sla.Priority]sla.Target{
+				sla.PriorityHigh: {Response: time.Hour, Resolution: 8 * time.Hour},
+			},
+			DefaultPriority: sla.PriorityHigh,
+			Calendar:        sla.Calendar{Location: "Europe/Prague"},
+		})
+		require.NoError(t, err)
+		retInc.SetVersion(1)
+
+		clock.AddTime(20 * time.Minute)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncident", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(retInc, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			Clock:                   clock,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+
+		var body struct {
+			SLA json.RawMessage `json:"sla"`
+		}
+		err = json.Unmarshal(b, &body)
+		require.NoError(t, err)
+
+		expectedJSON := `{
+			"priority": "high",
+			"response": {
+				"state": "running",
+				"target": 3600,
+				"elapsed": 1200,
+				"remaining": 2400,
+				"due_at": "2021-04-01T13:34:56+02:00",
+				"breached": false
+			},
+			"resolution": {
+				"state": "running",
+				"target": 28800,
+				"elapsed": 1200,
+				"remaining": 27600,
+				"due_at": "2021-04-01T20:34:56+02:00",
+				"breached": false
+			}
+		}`
+		assert.JSONEq(t, expectedJSON, string(body.SLA), "response does not match")
+	})
 }
 
 func TestListIncidentsHandler(t *testing.T) {
@@ -568,7 +728,7 @@ func TestListIncidentsHandler(t *testing.T) {
 				Last:  1,
 			},
 		}
-		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, mock.AnythingOfType("*converters.paginationParams")).Return(result, nil)
+		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, repository.IncidentFilter{}, mock.AnythingOfType("*converters.paginationParams")).Return(result, nil)
 
 		server := NewServer(Config{
 			Addr:                    "service.url",
@@ -601,6 +761,294 @@ func TestListIncidentsHandler(t *testing.T) {
 		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
 	})
 
+	t.Run("when filter and sort parameters are set", func(t *testing.T) {
+		expectedJSON := `{
+			"total":3,
+			"size":0,
+			"page":2,
+			"_links":{
+				"self":{"href":"http://service.url/incidents?state=new&state=in_progress&field_engineer_id=1adb8393-cff0-489c-a82f-3fe5d15708d4&q=printer&created_from=2021-04-01&created_to=2021-04-30&sort=-number&page=2"},
+				"first":{"href":"http://service.url/incidents?created_from=2021-04-01&created_to=2021-04-30&field_engineer_id=1adb8393-cff0-489c-a82f-3fe5d15708d4&q=printer&sort=-number&state=new&state=in_progress"},
+				"prev":{"href":"http://service.url/incidents?created_from=2021-04-01&created_to=2021-04-30&field_engineer_id=1adb8393-cff0-489c-a82f-3fe5d15708d4&q=printer&sort=-number&state=new&state=in_progress"},
+				"next":{"href":"http://service.url/incidents?created_from=2021-04-01&created_to=2021-04-30&field_engineer_id=1adb8393-cff0-489c-a82f-3fe5d15708d4&page=3&q=printer&sort=-number&state=new&state=in_progress"},
+				"last":{"href":"http://service.url/incidents?created_from=2021-04-01&created_to=2021-04-30&field_engineer_id=1adb8393-cff0-489c-a82f-3fe5d15708d4&page=3&q=printer&sort=-number&state=new&state=in_progress"}
+			}
+		}`
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		expectedFilter := repository.IncidentFilter{
+			States:          []incident.State{incident.StateNew, incident.StateInProgress},
+			FieldEngineerID: &fieldEngineerUUID,
+			CreatedFrom:     time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			CreatedTo:       time.Date(2021, 4, 30, 23, 59, 59, 999999999, time.UTC),
+			Text:            "printer",
+			Sort: repository.IncidentSort{
+				Field:      repository.IncidentSortByNumber,
+				Descending: true,
+			},
+		}
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		result := repository.IncidentList{
+			Pagination: &repository.Pagination{
+				Total: 3,
+				Page:  2,
+				Prev:  1,
+				Next:  3,
+				First: 1,
+				Last:  3,
+			},
+		}
+		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, expectedFilter, mock.AnythingOfType("*converters.paginationParams")).Return(result, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents?state=new&state=in_progress&field_engineer_id=1adb8393-cff0-489c-a82f-3fe5d15708d4&q=printer&created_from=2021-04-01&created_to=2021-04-30&sort=-number&page=2", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when priority filter and sort parameters are set", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		expectedFilter := repository.IncidentFilter{
+			Priorities: []sla.Priority{sla.PriorityCritical, sla.PriorityHigh, sla.PriorityLow},
+			Sort: repository.IncidentSort{
+				Field: repository.IncidentSortByPriority,
+			},
+		}
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		result := repository.IncidentList{
+			Pagination: &repository.Pagination{
+				Total: 0,
+				Page:  1,
+				First: 1,
+				Last:  1,
+			},
+		}
+		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, expectedFilter, mock.AnythingOfType("*converters.paginationParams")).Return(result, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents?priority=critical,high&priority=low&sort=priority", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+		_ = resp.Body.Close()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+	})
+
+	t.Run("when cursor parameters are set", func(t *testing.T) {
+		codec := cursor.NewCodec([]byte("secret"))
+		afterCursor := repository.Cursor{SortValue: "2021-10-17T15:00:00+02:00", Seq: 2}
+		prevCursor := repository.Cursor{SortValue: "2021-10-17T14:00:00+02:00", Seq: 3}
+		nextCursor := repository.Cursor{SortValue: "2021-10-17T13:00:00+02:00", Seq: 4}
+		lastCursor := repository.Cursor{SortValue: "2021-10-17T12:00:00+02:00", Seq: 5}
+		afterToken := codec.Encode(afterCursor, "-created_at")
+
+		expectedJSON := fmt.Sprintf(`{
+			"total":5,
+			"size":2,
+			"page":2,
+			"_links":{
+				"self":{"href":"http://service.url/incidents?sort=-created_at&limit=2&after=%s"},
+				"first":{"href":"http://service.url/incidents?limit=2&sort=-created_at"},
+				"prev":{"href":"http://service.url/incidents?before=%s&limit=2&sort=-created_at"},
+				"next":{"href":"http://service.url/incidents?after=%s&limit=2&sort=-created_at"},
+				"last":{"href":"http://service.url/incidents?after=%s&limit=2&sort=-created_at"}
+			}
+		}`, afterToken, codec.Encode(prevCursor, "-created_at"), codec.Encode(nextCursor, "-created_at"), codec.Encode(lastCursor, "-created_at"))
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		expectedFilter := repository.IncidentFilter{
+			Sort: repository.IncidentSort{
+				Field:      repository.IncidentSortByCreatedAt,
+				Descending: true,
+			},
+		}
+		expectedCursorPage := &repository.CursorPage{After: &afterCursor, Limit: 2}
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		result := repository.IncidentList{
+			Pagination: repository.NewCursorPagination(5, 2, 2, &repository.PageCursors{
+				Prev:  &prevCursor,
+				Next:  &nextCursor,
+				Last:  &lastCursor,
+				Limit: 2,
+			}),
+		}
+		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, expectedFilter,
+			mock.MatchedBy(func(params converters.PaginationParams) bool {
+				return assert.ObjectsAreEqual(expectedCursorPage, params.CursorPage())
+			})).Return(result, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+			CursorSecret:            []byte("secret"),
+		})
+
+		req := httptest.NewRequest("GET", "/incidents?sort=-created_at&limit=2&after="+afterToken, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when cursor parameter is invalid", func(t *testing.T) {
+		otherToken := cursor.NewCodec([]byte("other secret")).Encode(repository.Cursor{Seq: 1}, "")
+		sortToken := cursor.NewCodec([]byte("secret")).Encode(repository.Cursor{Seq: 1}, "number")
+
+		tests := []struct {
+			query        string
+			expectedJSON string
+		}{
+			{"after=" + otherToken, `{"error":"incorrect 'after' parameter: invalid cursor"}`},
+			{"before=" + sortToken, `{"error":"incorrect 'before' parameter: invalid cursor"}`},
+			{"after=" + sortToken + "&before=" + sortToken + "&sort=number", `{"error":"'after' and 'before' parameters cannot be combined"}`},
+			{"limit=2&page=2", `{"error":"'page' parameter cannot be combined with 'after', 'before' or 'limit' parameters"}`},
+			{"limit=0", `{"error":"incorrect 'limit' parameter: '0' (must be between 1 and 100)"}`},
+			{"limit=101", `{"error":"incorrect 'limit' parameter: '101' (must be between 1 and 100)"}`},
+		}
+
+		for _, tt := range tests {
+			us := new(mocks.ExternalUserServiceMock)
+			us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+				Return(actorUser, nil)
+
+			server := NewServer(Config{
+				Addr:                    "service.url",
+				Logger:                  logger,
+				ExternalUserService:     us,
+				IncidentService:         new(mocks.IncidentServiceMock),
+				ExternalLocationAddress: "http://service.url",
+				CursorSecret:            []byte("secret"),
+			})
+
+			req := httptest.NewRequest("GET", "/incidents?"+tt.query, nil)
+			req.Header.Set("channel-id", channelID)
+			req.Header.Set("authorization", bearerToken)
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+			resp := w.Result()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				t.Fatalf("could not read response: %v", err)
+			}
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+			assert.JSONEq(t, tt.expectedJSON, string(b), "response does not match")
+		}
+	})
+
+	t.Run("when filter parameter is invalid", func(t *testing.T) {
+		tests := []struct {
+			query        string
+			expectedJSON string
+		}{
+			{"state=unknown", `{"error":"incorrect 'state' parameter: 'unknown'"}`},
+			{"field_engineer_id=123", `{"error":"incorrect 'field_engineer_id' parameter: '123'"}`},
+			{"created_from=yesterday", `{"error":"incorrect 'created_from' parameter: 'yesterday'"}`},
+			{"priority=urgent", `{"error":"incorrect 'priority' parameter: 'urgent'"}`},
+			{"sort=severity", `{"error":"incorrect 'sort' parameter: 'severity'"}`},
+		}
+
+		for _, tt := range tests {
+			us := new(mocks.ExternalUserServiceMock)
+			us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+				Return(actorUser, nil)
+
+			server := NewServer(Config{
+				Addr:                    "service.url",
+				Logger:                  logger,
+				ExternalUserService:     us,
+				IncidentService:         new(mocks.IncidentServiceMock),
+				ExternalLocationAddress: "http://service.url",
+			})
+
+			req := httptest.NewRequest("GET", "/incidents?"+tt.query, nil)
+			req.Header.Set("channel-id", channelID)
+			req.Header.Set("authorization", bearerToken)
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+			resp := w.Result()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				t.Fatalf("could not read response: %v", err)
+			}
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+			assert.JSONEq(t, tt.expectedJSON, string(b), "response does not match")
+		}
+	})
+
 	t.Run("when some incidents were found", func(t *testing.T) {
 		expectedJSON := `{
 			"total":2,
@@ -612,6 +1060,7 @@ func TestListIncidentsHandler(t *testing.T) {
 					"number": "Accc265871",
 					"short_description":"Test incident 1",
 					"field_engineer":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
+					"field_engineer_accepted":true,
 					"state":"new",
 					"created_by":"8183eaca-56c0-41d9-9291-1d295dd53763",
 					"created_at":"2021-04-01T12:34:56+02:00",
@@ -620,7 +1069,8 @@ func TestListIncidentsHandler(t *testing.T) {
 					"_embedded":{
 						"field_engineer":{
 							"_links": {
-								"self": {"href": "http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4"}
+								"self": {"href": "http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4"},
+								"FieldEngineerStartTravelling": {"href": "http://service.url/field_engineers/1adb8393-cff0-489c-a82f-3fe5d15708d4/start_travelling"}
 							},
 							"external_user_uuid": "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
 							"name":"Alois",
@@ -633,6 +1083,7 @@ func TestListIncidentsHandler(t *testing.T) {
 					"_links":{
 						"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"},
 						"CancelIncident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/cancel"},
+						"IncidentPutOnHold":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/put_on_hold"},
 						"IncidentStartWorking":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/start_working"}
 					}
 				},
@@ -673,8 +1124,9 @@ func TestListIncidentsHandler(t *testing.T) {
 		require.NoError(t, err)
 		state, err := incident.NewStateFromString("new")
 		require.NoError(t, err)
-		err = fInc1.SetState(state)
+		err = fInc1.RestoreState(state)
 		require.NoError(t, err)
+		fInc1.SetFieldEngineerAccepted(true)
 		err = fInc1.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
 		require.NoError(t, err)
 		err = fInc1.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
@@ -689,7 +1141,7 @@ func TestListIncidentsHandler(t *testing.T) {
 		require.NoError(t, err)
 		state, err = incident.NewStateFromString("resolved")
 		require.NoError(t, err)
-		err = fInc2.SetState(state)
+		err = fInc2.RestoreState(state)
 		require.NoError(t, err)
 		err = fInc2.CreatedUpdated.SetCreated(createdByUser, "2021-04-11T00:45:42+02:00")
 		require.NoError(t, err)
@@ -712,7 +1164,7 @@ func TestListIncidentsHandler(t *testing.T) {
 				Last:  1,
 			},
 		}
-		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, mock.AnythingOfType("*converters.paginationParams")).Return(result, nil)
+		incidentSvc.On("ListIncidents", ref.ChannelID(channelID), actorUser, repository.IncidentFilter{}, mock.AnythingOfType("*converters.paginationParams")).Return(result, nil)
 
 		feSvc := new(mocks.FieldEngineerServiceMock)
 		feSvc.On("GetFieldEngineer", ref.ChannelID(channelID), actorUser, fieldEngineerUUID).
@@ -787,14 +1239,14 @@ func TestListIncidentsHandler(t *testing.T) {
 	})
 }
 
-func TestIncidentStartWorkingHandler(t *testing.T) {
+func TestIncidentAcceptHandler(t *testing.T) {
 	logger, _ := testutils.NewTestLogger()
 	defer func() { _ = logger.Sync() }()
 
 	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
 	bearerToken := "some valid Bearer token"
 
-	fieldEngineer := &fieldengineer.FieldEngineer{
+	actorUser := actor.Actor{
 		BasicUser: user.BasicUser{
 			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
 			Name:             "Alois",
@@ -803,11 +1255,191 @@ func TestIncidentStartWorkingHandler(t *testing.T) {
 			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
 		},
 	}
-	err := fieldEngineer.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
-	require.NoError(t, err)
-	//	fieldEngineerUUID := fieldEngineer.UUID()
 
-	actorUser := actor.Actor{
+	t.Parallel()
+
+	t.Run("when service returns error", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("Accept", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(domain.NewErrorf(domain.ErrorCodeActionForbidden, "ticket was already accepted"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/accept", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("Accept", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/accept", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestIncidentRejectHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("when reason is missing", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/reject", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("Reject", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), api.IncidentRejectParams{Reason: "out of my region"}).
+			Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"reason":"out of my region"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/reject", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestIncidentStartWorkingHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	fieldEngineer := &fieldengineer.FieldEngineer{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+	err := fieldEngineer.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+	require.NoError(t, err)
+	//	fieldEngineerUUID := fieldEngineer.UUID()
+
+	actorUser := actor.Actor{
 		BasicUser: fieldEngineer.BasicUser,
 	}
 	//	actorUser.SetFieldEngineerID(&fieldEngineerUUID)
@@ -859,3 +1491,1284 @@ func TestIncidentStartWorkingHandler(t *testing.T) {
 		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
 	})
 }
+
+func TestIncidentPutOnHoldHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("when payload is not valid", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"remind_at":"tomorrow"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/put_on_hold", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("PutOnHold", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), mock.AnythingOfType("api.IncidentPutOnHoldParams")).
+			Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"reason":"awaiting caller","remind_at":"2021-04-01T12:34:56+02:00"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/put_on_hold", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestIncidentResumeHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("everything is ok", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("Resume", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/resume", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestIncidentResolveHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("when payload is not valid", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"resolution_code":"solved (permanently)"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/resolve", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("Resolve", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), api.IncidentResolveParams{
+			ResolutionCode:  "solved (permanently)",
+			ResolutionNotes: "disk replaced",
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"resolution_code":"solved (permanently)","resolution_notes":"disk replaced"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/resolve", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestIncidentPauseWorkingHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("when payload is not valid", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"timespan_type":"work"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/pause_working", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		uuid := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("PauseWorking", ref.ChannelID(channelID), actorUser, ref.UUID(uuid), api.IncidentPauseWorkingParams{
+			TimespanType: "break",
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"timespan_type":"break"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+uuid+"/pause_working", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestGetIncidentTimelogHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	createdByUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	err := createdByUser.SetUUID("8540d943-8ccd-4ff1-8a08-0c3aa338c58e")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when timelog exists", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		tmlg := timelog.Timelog{
+			Remote:       true,
+			Start:        "2021-04-01T12:00:00Z",
+			End:          "2021-04-01T15:00:00Z",
+			Work:         9000,
+			VisitSummary: "disk replaced",
+			Timespans: []timelog.Timespan{
+				{Type: timelog.TimespanTypeWork, Start: "2021-04-01T12:00:00Z", End: "2021-04-01T13:00:00Z"},
+				{Type: timelog.TimespanTypeBreak, Start: "2021-04-01T13:00:00Z", End: "2021-04-01T13:30:00Z"},
+				{Type: timelog.TimespanTypeWork, Start: "2021-04-01T13:30:00Z", End: "2021-04-01T15:00:00Z"},
+			},
+		}
+		err := tmlg.SetUUID(ref.UUID(timelogUUID))
+		require.NoError(t, err)
+		err = tmlg.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T15:00:00Z")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncidentTimelog", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID)).
+			Return(tmlg, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/timelogs/"+timelogUUID, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		expectedJSON := `{
+			"uuid":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+			"remote":true,
+			"start":"2021-04-01T12:00:00Z",
+			"end":"2021-04-01T15:00:00Z",
+			"work":9000,
+			"visit_summary":"disk replaced",
+			"created_by":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+			"created_at":"2021-04-01T15:00:00Z",
+			"timespans":[
+				{"type":"work","start":"2021-04-01T12:00:00Z","end":"2021-04-01T13:00:00Z","duration":3600},
+				{"type":"break","start":"2021-04-01T13:00:00Z","end":"2021-04-01T13:30:00Z","duration":1800},
+				{"type":"work","start":"2021-04-01T13:30:00Z","end":"2021-04-01T15:00:00Z","duration":5400}
+			],
+			"_embedded":{
+				"created_by":{
+					"_links":{
+						"self":{"href":"http://service.url/basic_users/8540d943-8ccd-4ff1-8a08-0c3aa338c58e"}
+					},
+					"external_user_uuid":"b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+					"name":"Alfred",
+					"surname":"Koletschko",
+					"org_name":"a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+					"org_display_name":"KompiTech",
+					"uuid":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e"
+				}
+			},
+			"_links":{
+				"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb"},
+				"incident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+			}
+		}`
+
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when timelog has correction waiting for approval and actor is dispatcher", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		dispatcher := actorUser
+		dispatcher.SetDispatcher(true)
+
+		tmlg := timelog.Timelog{
+			Start: "2021-04-01T12:00:00Z",
+			End:   "2021-04-01T13:00:00Z",
+			Work:  3600,
+			Timespans: []timelog.Timespan{
+				{Type: timelog.TimespanTypeWork, Start: "2021-04-01T12:00:00Z", End: "2021-04-01T13:00:00Z"},
+			},
+			Corrections: []timelog.Correction{
+				{
+					Start:        "2021-04-01T12:00:00Z",
+					End:          "2021-04-01T13:30:00Z",
+					VisitSummary: "disk replaced",
+					Reason:       "forgot to stop working",
+					Status:       timelog.CorrectionStatusProposed,
+					ProposedBy:   "8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+					ProposedAt:   "2021-04-01T16:00:00Z",
+				},
+			},
+			Original: &timelog.OriginalValues{
+				Start: "2021-04-01T12:00:00Z",
+				End:   "2021-04-01T12:30:00Z",
+				Work:  1800,
+				Timespans: []timelog.Timespan{
+					{Type: timelog.TimespanTypeWork, Start: "2021-04-01T12:00:00Z", End: "2021-04-01T12:30:00Z"},
+				},
+			},
+		}
+		err := tmlg.SetUUID(ref.UUID(timelogUUID))
+		require.NoError(t, err)
+		err = tmlg.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T15:00:00Z")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(dispatcher, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncidentTimelog", ref.ChannelID(channelID), dispatcher, ref.UUID(incUUID), ref.UUID(timelogUUID)).
+			Return(tmlg, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/timelogs/"+timelogUUID, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		expectedJSON := `{
+			"uuid":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+			"remote":false,
+			"start":"2021-04-01T12:00:00Z",
+			"end":"2021-04-01T13:00:00Z",
+			"work":3600,
+			"created_by":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+			"created_at":"2021-04-01T15:00:00Z",
+			"timespans":[
+				{"type":"work","start":"2021-04-01T12:00:00Z","end":"2021-04-01T13:00:00Z","duration":3600}
+			],
+			"corrections":[
+				{
+					"remote":false,
+					"start":"2021-04-01T12:00:00Z",
+					"end":"2021-04-01T13:30:00Z",
+					"visit_summary":"disk replaced",
+					"reason":"forgot to stop working",
+					"status":"proposed",
+					"proposed_by":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+					"proposed_at":"2021-04-01T16:00:00Z"
+				}
+			],
+			"original":{
+				"remote":false,
+				"start":"2021-04-01T12:00:00Z",
+				"end":"2021-04-01T12:30:00Z",
+				"work":1800,
+				"timespans":[
+					{"type":"work","start":"2021-04-01T12:00:00Z","end":"2021-04-01T12:30:00Z","duration":1800}
+				]
+			},
+			"_embedded":{
+				"created_by":{
+					"_links":{
+						"self":{"href":"http://service.url/basic_users/8540d943-8ccd-4ff1-8a08-0c3aa338c58e"}
+					},
+					"external_user_uuid":"b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+					"name":"Alfred",
+					"surname":"Koletschko",
+					"org_name":"a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+					"org_display_name":"KompiTech",
+					"uuid":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e"
+				}
+			},
+			"_links":{
+				"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb"},
+				"incident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"},
+				"ApproveTimelogCorrection":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb/approve_correction"},
+				"RejectTimelogCorrection":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb/reject_correction"}
+			}
+		}`
+
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when timelog does not exist", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("GetIncidentTimelog", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID)).
+			Return(timelog.Timelog{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/timelogs/"+timelogUUID, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+	})
+}
+
+func TestListIncidentTimelogsHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	createdByUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	err := createdByUser.SetUUID("8540d943-8ccd-4ff1-8a08-0c3aa338c58e")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when incident has timelogs", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		tmlg := timelog.Timelog{
+			Remote:       false,
+			Start:        "2021-04-01T12:00:00Z",
+			End:          "2021-04-01T13:00:00Z",
+			Work:         3600,
+			VisitSummary: "cable replaced",
+		}
+		err := tmlg.SetUUID(ref.UUID(timelogUUID))
+		require.NoError(t, err)
+		err = tmlg.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T13:00:00Z")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		result := repository.TimelogList{
+			Result: []timelog.Timelog{tmlg},
+			Pagination: &repository.Pagination{
+				Total: 3,
+				Size:  1,
+				Page:  2,
+				Prev:  1,
+				Next:  3,
+				First: 1,
+				Last:  3,
+			},
+		}
+		incidentSvc.On("ListIncidentTimelogs", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), mock.AnythingOfType("*converters.paginationParams")).
+			Return(result, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/timelogs?page=2", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		expectedJSON := `{
+			"total":3,
+			"size":1,
+			"page":2,
+			"_embedded":[
+				{
+					"uuid":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+					"remote":false,
+					"start":"2021-04-01T12:00:00Z",
+					"end":"2021-04-01T13:00:00Z",
+					"work":3600,
+					"visit_summary":"cable replaced",
+					"created_by":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+					"created_at":"2021-04-01T13:00:00Z",
+					"_embedded":{
+						"created_by":{
+							"_links":{
+								"self":{"href":"http://service.url/basic_users/8540d943-8ccd-4ff1-8a08-0c3aa338c58e"}
+							},
+							"external_user_uuid":"b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+							"name":"Alfred",
+							"surname":"Koletschko",
+							"org_name":"a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+							"org_display_name":"KompiTech",
+							"uuid":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e"
+						}
+					},
+					"_links":{
+						"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb"},
+						"incident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+					}
+				}
+			],
+			"_links":{
+				"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs?page=2"},
+				"first":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs"},
+				"prev":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs"},
+				"next":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs?page=3"},
+				"last":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs?page=3"}
+			}
+		}`
+
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when incident does not exist", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ListIncidentTimelogs", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), mock.AnythingOfType("*converters.paginationParams")).
+			Return(repository.TimelogList{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/timelogs", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+	})
+}
+
+func TestProposeTimelogCorrectionHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("when payload is not valid", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"start":"2021-04-01T12:00:00Z","end":"yesterday","reason":"forgot to stop working"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/propose_correction", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+
+	t.Run("when actor is not allowed to propose correction", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ProposeTimelogCorrection", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID), api.ProposeTimelogCorrectionParams{
+			Start:  "2021-04-01T12:00:00Z",
+			End:    "2021-04-01T14:00:00Z",
+			Reason: "forgot to stop working",
+		}).Return(domain.NewErrorf(domain.ErrorCodeActionForbidden, "user is not field engineer"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"start":"2021-04-01T12:00:00Z","end":"2021-04-01T14:00:00Z","reason":"forgot to stop working"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/propose_correction", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ProposeTimelogCorrection", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID), api.ProposeTimelogCorrectionParams{
+			Remote:       true,
+			Start:        "2021-04-01T12:00:00Z",
+			End:          "2021-04-01T14:00:00Z",
+			VisitSummary: "disk replaced",
+			Reason:       "forgot to stop working",
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{
+			"remote":true,
+			"start":"2021-04-01T12:00:00Z",
+			"end":"2021-04-01T14:00:00Z",
+			"visit_summary":"disk replaced",
+			"reason":"forgot to stop working"
+		}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/propose_correction", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestApproveTimelogCorrectionHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+	actorUser.SetDispatcher(true)
+
+	t.Parallel()
+
+	t.Run("when timelog does not have pending correction", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ApproveTimelogCorrection", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID)).
+			Return(domain.NewErrorf(domain.ErrorCodeActionForbidden, "timelog does not have a correction waiting for approval"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/approve_correction", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ApproveTimelogCorrection", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID)).
+			Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/approve_correction", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestRejectTimelogCorrectionHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+	actorUser.SetDispatcher(true)
+
+	t.Parallel()
+
+	t.Run("when payload is not valid", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"note":""}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/reject_correction", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+	})
+
+	t.Run("everything is ok", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+		timelogUUID := "0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("RejectTimelogCorrection", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), ref.UUID(timelogUUID), api.RejectTimelogCorrectionParams{
+			Note: "the visit ended at 15:00",
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		payload := []byte(`{"note":"the visit ended at 15:00"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/incidents/"+incUUID+"/timelogs/"+timelogUUID+"/reject_correction", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/timelogs/0ac5ebce-17e7-4edc-9552-fefe16e127fb"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestListIncidentHistoryHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "5d5ef779-17cb-413a-aa4b-7bc0a80bf230",
+			Name:             "Alois",
+			Surname:          "Vomacka",
+			OrgDisplayName:   "CGI",
+			OrgName:          "1233ae78-cb08-4fd3-9d59-b3b8b07e08fc.kompitech.com",
+		},
+	}
+
+	t.Parallel()
+
+	t.Run("when incident has history", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		updated := history.Entry{
+			IncidentID:    ref.UUID(incUUID),
+			Action:        history.ActionUpdateIncident,
+			ActorID:       "8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+			RequestedByID: "b3ac7bb4-57a4-4b4e-bc5f-4bd4f8bd8a58",
+			Time:          "2021-04-01T12:34:56+02:00",
+			Changes: []history.Change{
+				{Field: "description", Before: "old description", After: "new description"},
+			},
+		}
+		err := updated.SetUUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+		require.NoError(t, err)
+
+		autoClosed := history.Entry{
+			IncidentID: ref.UUID(incUUID),
+			Action:     history.ActionAutoClose,
+			Time:       "2021-04-04T12:34:56+02:00",
+			Changes: []history.Change{
+				{Field: "state", Before: "resolved", After: "closed"},
+			},
+		}
+		err = autoClosed.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		result := repository.HistoryList{
+			Result: []history.Entry{updated, autoClosed},
+			Pagination: &repository.Pagination{
+				Total: 4,
+				Size:  2,
+				Page:  2,
+				Prev:  1,
+				First: 1,
+				Last:  2,
+			},
+		}
+		incidentSvc.On("ListIncidentHistory", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), mock.AnythingOfType("*converters.paginationParams")).
+			Return(result, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/history?page=2", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		expectedJSON := `{
+			"total":4,
+			"size":2,
+			"page":2,
+			"_embedded":[
+				{
+					"uuid":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+					"action":"UpdateIncident",
+					"actor_id":"8540d943-8ccd-4ff1-8a08-0c3aa338c58e",
+					"requested_by_id":"b3ac7bb4-57a4-4b4e-bc5f-4bd4f8bd8a58",
+					"time":"2021-04-01T12:34:56+02:00",
+					"changes":[
+						{"field":"description","before":"old description","after":"new description"}
+					],
+					"_links":{
+						"incident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+					}
+				},
+				{
+					"uuid":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
+					"action":"AutoClose",
+					"time":"2021-04-04T12:34:56+02:00",
+					"changes":[
+						{"field":"state","before":"resolved","after":"closed"}
+					],
+					"_links":{
+						"incident":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"}
+					}
+				}
+			],
+			"_links":{
+				"self":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/history?page=2"},
+				"first":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/history"},
+				"prev":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/history"},
+				"last":{"href":"http://service.url/incidents/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/history?page=2"}
+			}
+		}`
+
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when incident does not exist", func(t *testing.T) {
+		incUUID := "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("ListIncidentHistory", ref.ChannelID(channelID), actorUser, ref.UUID(incUUID), mock.AnythingOfType("*converters.paginationParams")).
+			Return(repository.HistoryList{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/incidents/"+incUUID+"/history", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+	})
+}
@@ -6,11 +6,19 @@ import (
 )
 
 type jsonInputPayloadConverters struct {
-	incident converters.IncidentPayloadConverter
+	incident       converters.IncidentPayloadConverter
+	fieldEngineer  converters.FieldEngineerPayloadConverter
+	webhook        converters.WebhookPayloadConverter
+	sla            converters.SLAPayloadConverter
+	priorityMatrix converters.PriorityMatrixPayloadConverter
 }
 
 func (s *Server) registerInputConverters() {
 	validator := validators.NewPayloadValidator()
 
 	s.inputPayloadConverters.incident = converters.NewIncidentPayloadConverter(s.logger, validator)
+	s.inputPayloadConverters.fieldEngineer = converters.NewFieldEngineerPayloadConverter(s.logger, validator)
+	s.inputPayloadConverters.webhook = converters.NewWebhookPayloadConverter(s.logger, validator)
+	s.inputPayloadConverters.sla = converters.NewSLAPayloadConverter(s.logger, validator)
+	s.inputPayloadConverters.priorityMatrix = converters.NewPriorityMatrixPayloadConverter(s.logger, validator)
 }
@@ -0,0 +1,42 @@
+package converters
+
+import (
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters/validators"
+	"go.uber.org/zap"
+)
+
+// NewFieldEngineerPayloadConverter creates a field engineer input payload converting service
+func NewFieldEngineerPayloadConverter(logger *zap.SugaredLogger, validator validators.PayloadValidator) FieldEngineerPayloadConverter {
+	return &fieldEngineerPayloadConverter{
+		BasePayloadConverter: NewBasePayloadConverter(logger, validator),
+	}
+}
+
+type fieldEngineerPayloadConverter struct {
+	*BasePayloadConverter
+}
+
+// FieldEngineerCreateParamsFromBody converts JSON payload to api.CreateFieldEngineerParams
+func (c fieldEngineerPayloadConverter) FieldEngineerCreateParamsFromBody(r *http.Request) (api.CreateFieldEngineerParams, error) {
+	var payload api.CreateFieldEngineerParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// FieldEngineerCloseTimeSessionParamsFromBody converts JSON payload to api.FieldEngineerCloseTimeSessionParams
+func (c fieldEngineerPayloadConverter) FieldEngineerCloseTimeSessionParamsFromBody(r *http.Request) (api.FieldEngineerCloseTimeSessionParams, error) {
+	var payload api.FieldEngineerCloseTimeSessionParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
@@ -41,6 +41,17 @@ func (c incidentPayloadConverter) IncidentUpdateParamsFromBody(r *http.Request)
 	return payload, nil
 }
 
+// IncidentRejectParamsFromBody converts JSON payload to api.IncidentRejectParams
+func (c incidentPayloadConverter) IncidentRejectParamsFromBody(r *http.Request) (api.IncidentRejectParams, error) {
+	var payload api.IncidentRejectParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
 // IncidentStartWorkingParamsFromBody converts JSON payload to api.IncidentStartWorkingParams
 func (c incidentPayloadConverter) IncidentStartWorkingParamsFromBody(r *http.Request) (api.IncidentStartWorkingParams, error) {
 	var payload api.IncidentStartWorkingParams
@@ -62,3 +73,69 @@ func (c incidentPayloadConverter) IncidentStopWorkingParamsFromBody(r *http.Requ
 
 	return payload, nil
 }
+
+// IncidentPauseWorkingParamsFromBody converts JSON payload to api.IncidentPauseWorkingParams
+func (c incidentPayloadConverter) IncidentPauseWorkingParamsFromBody(r *http.Request) (api.IncidentPauseWorkingParams, error) {
+	var payload api.IncidentPauseWorkingParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// IncidentPutOnHoldParamsFromBody converts JSON payload to api.IncidentPutOnHoldParams
+func (c incidentPayloadConverter) IncidentPutOnHoldParamsFromBody(r *http.Request) (api.IncidentPutOnHoldParams, error) {
+	var payload api.IncidentPutOnHoldParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// IncidentResolveParamsFromBody converts JSON payload to api.IncidentResolveParams
+func (c incidentPayloadConverter) IncidentResolveParamsFromBody(r *http.Request) (api.IncidentResolveParams, error) {
+	var payload api.IncidentResolveParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// IncidentReopenParamsFromBody converts JSON payload to api.IncidentReopenParams
+func (c incidentPayloadConverter) IncidentReopenParamsFromBody(r *http.Request) (api.IncidentReopenParams, error) {
+	var payload api.IncidentReopenParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// TimelogCorrectionProposeParamsFromBody converts JSON payload to api.ProposeTimelogCorrectionParams
+func (c incidentPayloadConverter) TimelogCorrectionProposeParamsFromBody(r *http.Request) (api.ProposeTimelogCorrectionParams, error) {
+	var payload api.ProposeTimelogCorrectionParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// TimelogCorrectionRejectParamsFromBody converts JSON payload to api.RejectTimelogCorrectionParams
+func (c incidentPayloadConverter) TimelogCorrectionRejectParamsFromBody(r *http.Request) (api.RejectTimelogCorrectionParams, error) {
+	var payload api.RejectTimelogCorrectionParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,108 @@
+package converters
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+// NewIncidentFilter parses request query and returns the filter and the sort order of the listed incidents
+func NewIncidentFilter(r *http.Request) (repository.IncidentFilter, error) {
+	var filter repository.IncidentFilter
+	var err error
+
+	queryValues := r.URL.Query()
+
+	// state can be repeated or contain comma separated values, underscores can be used instead of spaces (ie. 'in_progress')
+	for _, stateParam := range queryValues["state"] {
+		for _, stateStr := range strings.Split(stateParam, ",") {
+			state, err := incident.NewStateFromString(strings.ReplaceAll(strings.TrimSpace(stateStr), "_", " "))
+			if err != nil {
+				return filter, presenters.NewErrorf(http.StatusBadRequest, "incorrect 'state' parameter: '%s'", stateStr)
+			}
+			filter.States = append(filter.States, state)
+		}
+	}
+
+	// priority can be repeated or contain comma separated values
+	for _, priorityParam := range queryValues["priority"] {
+		for _, priorityStr := range strings.Split(priorityParam, ",") {
+			priority, err := sla.NewPriorityFromString(strings.TrimSpace(priorityStr))
+			if err != nil {
+				return filter, presenters.NewErrorf(http.StatusBadRequest, "incorrect 'priority' parameter: '%s'", priorityStr)
+			}
+			filter.Priorities = append(filter.Priorities, priority)
+		}
+	}
+
+	if feParam := queryValues.Get("field_engineer_id"); feParam != "" {
+		if _, err := uuid.Parse(feParam); err != nil {
+			return filter, presenters.NewErrorf(http.StatusBadRequest, "incorrect 'field_engineer_id' parameter: '%s'", feParam)
+		}
+		feID := ref.UUID(feParam)
+		filter.FieldEngineerID = &feID
+	}
+
+	filter.Number = queryValues.Get("number")
+	filter.ExternalID = queryValues.Get("external_id")
+	filter.Text = strings.TrimSpace(queryValues.Get("q"))
+
+	if filter.CreatedFrom, err = parseDateParam(queryValues.Get("created_from"), "created_from", false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseDateParam(queryValues.Get("created_to"), "created_to", true); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedFrom, err = parseDateParam(queryValues.Get("updated_from"), "updated_from", false); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedTo, err = parseDateParam(queryValues.Get("updated_to"), "updated_to", true); err != nil {
+		return filter, err
+	}
+
+	if sortParam := queryValues.Get("sort"); sortParam != "" {
+		sortField := strings.TrimPrefix(sortParam, "-")
+		switch repository.IncidentSortField(sortField) {
+		case repository.IncidentSortByCreatedAt, repository.IncidentSortByUpdatedAt, repository.IncidentSortByNumber, repository.IncidentSortByPriority:
+			filter.Sort = repository.IncidentSort{
+				Field:      repository.IncidentSortField(sortField),
+				Descending: strings.HasPrefix(sortParam, "-"),
+			}
+		default:
+			return filter, presenters.NewErrorf(http.StatusBadRequest, "incorrect 'sort' parameter: '%s'", sortParam)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseDateParam parses RFC3339 date time or date (YYYY-MM-DD). If endOfDay is true, the date is moved to the last moment of the day.
+func parseDateParam(value, name string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, presenters.NewErrorf(http.StatusBadRequest, "incorrect '%s' parameter: '%s'", name, value)
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
 // PaginationParams provides information about current requested page number and a number of items per page to be displayed
@@ -13,6 +14,9 @@ type PaginationParams interface {
 
 	// ItemsPerPage returns how many items per page should be displayed
 	ItemsPerPage() uint
+
+	// CursorPage returns the page requested by the cursor based pagination, it is nil if the page number is used
+	CursorPage() *repository.CursorPage
 }
 
 // IncidentPayloadConverter provides conversion from JSON request body payload to object
@@ -23,9 +27,57 @@ type IncidentPayloadConverter interface {
 	// IncidentUpdateParamsFromBody converts JSON payload to api.UpdateIncidentParams
 	IncidentUpdateParamsFromBody(r *http.Request) (api.UpdateIncidentParams, error)
 
+	// IncidentRejectParamsFromBody converts JSON payload to api.IncidentRejectParams
+	IncidentRejectParamsFromBody(r *http.Request) (api.IncidentRejectParams, error)
+
 	// IncidentStartWorkingParamsFromBody converts JSON payload to api.IncidentStartWorkingParams
 	IncidentStartWorkingParamsFromBody(r *http.Request) (api.IncidentStartWorkingParams, error)
 
 	// IncidentStopWorkingParamsFromBody converts JSON payload to api.IncidentStopWorkingParams
 	IncidentStopWorkingParamsFromBody(r *http.Request) (api.IncidentStopWorkingParams, error)
+
+	// IncidentPauseWorkingParamsFromBody converts JSON payload to api.IncidentPauseWorkingParams
+	IncidentPauseWorkingParamsFromBody(r *http.Request) (api.IncidentPauseWorkingParams, error)
+
+	// IncidentPutOnHoldParamsFromBody converts JSON payload to api.IncidentPutOnHoldParams
+	IncidentPutOnHoldParamsFromBody(r *http.Request) (api.IncidentPutOnHoldParams, error)
+
+	// IncidentResolveParamsFromBody converts JSON payload to api.IncidentResolveParams
+	IncidentResolveParamsFromBody(r *http.Request) (api.IncidentResolveParams, error)
+
+	// IncidentReopenParamsFromBody converts JSON payload to api.IncidentReopenParams
+	IncidentReopenParamsFromBody(r *http.Request) (api.IncidentReopenParams, error)
+
+	// TimelogCorrectionProposeParamsFromBody converts JSON payload to api.ProposeTimelogCorrectionParams
+	TimelogCorrectionProposeParamsFromBody(r *http.Request) (api.ProposeTimelogCorrectionParams, error)
+
+	// TimelogCorrectionRejectParamsFromBody converts JSON payload to api.RejectTimelogCorrectionParams
+	TimelogCorrectionRejectParamsFromBody(r *http.Request) (api.RejectTimelogCorrectionParams, error)
+}
+
+// FieldEngineerPayloadConverter provides conversion from JSON request body payload to object
+type FieldEngineerPayloadConverter interface {
+	// FieldEngineerCreateParamsFromBody converts JSON payload to api.CreateFieldEngineerParams
+	FieldEngineerCreateParamsFromBody(r *http.Request) (api.CreateFieldEngineerParams, error)
+
+	// FieldEngineerCloseTimeSessionParamsFromBody converts JSON payload to api.FieldEngineerCloseTimeSessionParams
+	FieldEngineerCloseTimeSessionParamsFromBody(r *http.Request) (api.FieldEngineerCloseTimeSessionParams, error)
+}
+
+// WebhookPayloadConverter provides conversion from JSON request body payload to object
+type WebhookPayloadConverter interface {
+	// WebhookCreateParamsFromBody converts JSON payload to api.CreateWebhookParams
+	WebhookCreateParamsFromBody(r *http.Request) (api.CreateWebhookParams, error)
+}
+
+// SLAPayloadConverter provides conversion from JSON request body payload to object
+type SLAPayloadConverter interface {
+	// SLADefinitionSetParamsFromBody converts JSON payload to api.SetSLADefinitionParams
+	SLADefinitionSetParamsFromBody(r *http.Request) (api.SetSLADefinitionParams, error)
+}
+
+// PriorityMatrixPayloadConverter provides conversion from JSON request body payload to object
+type PriorityMatrixPayloadConverter interface {
+	// PriorityMatrixSetParamsFromBody converts JSON payload to api.SetPriorityMatrixParams
+	PriorityMatrixSetParamsFromBody(r *http.Request) (api.SetPriorityMatrixParams, error)
 }
@@ -5,12 +5,18 @@ import (
 	"strconv"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/cursor"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
+// maxCursorLimit is the maximum number of items on the page requested by the cursor
+const maxCursorLimit = 100
+
 type paginationParams struct {
 	page         uint
 	itemsPerPage uint
+	cursorPage   *repository.CursorPage
 }
 
 // NewPaginationParams parses request query and returns params with information about requested page and items per page to be displayed
@@ -35,6 +41,65 @@ func NewPaginationParams(r *http.Request, actorUser actor.Actor) (PaginationPara
 	}, nil
 }
 
+// NewCursorPaginationParams parses request query like NewPaginationParams. If the query contains any of the cursor
+// parameters ('after', 'before', 'limit'), the params contain the page requested by the cursor based pagination.
+// Cursor tokens are verified by the codec and must be issued for the same 'sort' parameter.
+func NewCursorPaginationParams(r *http.Request, actorUser actor.Actor, codec *cursor.Codec) (PaginationParams, error) {
+	queryValues := r.URL.Query()
+
+	_, hasAfter := queryValues["after"]
+	_, hasBefore := queryValues["before"]
+	_, hasLimit := queryValues["limit"]
+	if !hasAfter && !hasBefore && !hasLimit {
+		return NewPaginationParams(r, actorUser)
+	}
+
+	if queryValues.Get("page") != "" {
+		return nil, presenters.NewErrorf(http.StatusBadRequest, "'page' parameter cannot be combined with 'after', 'before' or 'limit' parameters")
+	}
+
+	if hasAfter && hasBefore {
+		return nil, presenters.NewErrorf(http.StatusBadRequest, "'after' and 'before' parameters cannot be combined")
+	}
+
+	cursorPage := &repository.CursorPage{
+		Limit: actorUser.BasicUser.ItemsPerPage(),
+	}
+
+	if limitParam := queryValues.Get("limit"); limitParam != "" {
+		limit64, err := strconv.ParseUint(limitParam, 10, 0)
+		if err != nil || limit64 == 0 || limit64 > maxCursorLimit {
+			return nil, presenters.NewErrorf(http.StatusBadRequest, "incorrect 'limit' parameter: '%s' (must be between 1 and %d)", limitParam, maxCursorLimit)
+		}
+		cursorPage.Limit = uint(limit64)
+	}
+
+	sortParam := queryValues.Get("sort")
+	for _, name := range []string{"after", "before"} {
+		token := queryValues.Get(name)
+		if token == "" {
+			continue
+		}
+
+		c, err := codec.Decode(token, sortParam)
+		if err != nil {
+			return nil, presenters.WrapErrorf(err, http.StatusBadRequest, "incorrect '%s' parameter", name)
+		}
+
+		if name == "after" {
+			cursorPage.After = &c
+		} else {
+			cursorPage.Before = &c
+		}
+	}
+
+	return &paginationParams{
+		page:         1,
+		itemsPerPage: cursorPage.Limit,
+		cursorPage:   cursorPage,
+	}, nil
+}
+
 func (p paginationParams) Page() uint {
 	return p.page
 }
@@ -42,3 +107,7 @@ func (p paginationParams) Page() uint {
 func (p paginationParams) ItemsPerPage() uint {
 	return p.itemsPerPage
 }
+
+func (p paginationParams) CursorPage() *repository.CursorPage {
+	return p.cursorPage
+}
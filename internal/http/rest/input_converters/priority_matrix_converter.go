@@ -0,0 +1,31 @@
+package converters
+
+import (
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters/validators"
+	"go.uber.org/zap"
+)
+
+// NewPriorityMatrixPayloadConverter creates a priority matrix input payload converting service
+func NewPriorityMatrixPayloadConverter(logger *zap.SugaredLogger, validator validators.PayloadValidator) PriorityMatrixPayloadConverter {
+	return &priorityMatrixPayloadConverter{
+		BasePayloadConverter: NewBasePayloadConverter(logger, validator),
+	}
+}
+
+type priorityMatrixPayloadConverter struct {
+	*BasePayloadConverter
+}
+
+// PriorityMatrixSetParamsFromBody converts JSON payload to api.SetPriorityMatrixParams
+func (c priorityMatrixPayloadConverter) PriorityMatrixSetParamsFromBody(r *http.Request) (api.SetPriorityMatrixParams, error) {
+	var payload api.SetPriorityMatrixParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
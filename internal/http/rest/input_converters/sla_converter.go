@@ -0,0 +1,31 @@
+package converters
+
+import (
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters/validators"
+	"go.uber.org/zap"
+)
+
+// NewSLAPayloadConverter creates an SLA definition input payload converting service
+func NewSLAPayloadConverter(logger *zap.SugaredLogger, validator validators.PayloadValidator) SLAPayloadConverter {
+	return &slaPayloadConverter{
+		BasePayloadConverter: NewBasePayloadConverter(logger, validator),
+	}
+}
+
+type slaPayloadConverter struct {
+	*BasePayloadConverter
+}
+
+// SLADefinitionSetParamsFromBody converts JSON payload to api.SetSLADefinitionParams
+func (c slaPayloadConverter) SLADefinitionSetParamsFromBody(r *http.Request) (api.SetSLADefinitionParams, error) {
+	var payload api.SetSLADefinitionParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,31 @@
+package converters
+
+import (
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters/validators"
+	"go.uber.org/zap"
+)
+
+// NewWebhookPayloadConverter creates a webhook subscription input payload converting service
+func NewWebhookPayloadConverter(logger *zap.SugaredLogger, validator validators.PayloadValidator) WebhookPayloadConverter {
+	return &webhookPayloadConverter{
+		BasePayloadConverter: NewBasePayloadConverter(logger, validator),
+	}
+}
+
+type webhookPayloadConverter struct {
+	*BasePayloadConverter
+}
+
+// WebhookCreateParamsFromBody converts JSON payload to api.CreateWebhookParams
+func (c webhookPayloadConverter) WebhookCreateParamsFromBody(r *http.Request) (api.CreateWebhookParams, error) {
+	var payload api.CreateWebhookParams
+
+	if err := c.unmarshalFromBody(r, &payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
@@ -5,11 +5,21 @@ import (
 )
 
 type jsonPresenters struct {
-	base     *presenters.BasePresenter
-	incident presenters.IncidentPresenter
+	base           *presenters.BasePresenter
+	incident       presenters.IncidentPresenter
+	fieldEngineer  presenters.FieldEngineerPresenter
+	basicUser      presenters.BasicUserPresenter
+	webhook        presenters.WebhookPresenter
+	sla            presenters.SLAPresenter
+	priorityMatrix presenters.PriorityMatrixPresenter
 }
 
 func (s *Server) registerPresenters() {
 	s.presenters.base = presenters.NewBasePresenter(s.logger, s.ExternalLocationAddress)
-	s.presenters.incident = presenters.NewIncidentPresenter(s.logger, s.ExternalLocationAddress)
+	s.presenters.incident = presenters.NewIncidentPresenter(s.logger, s.ExternalLocationAddress, s.cursorCodec, s.clock)
+	s.presenters.fieldEngineer = presenters.NewFieldEngineerPresenter(s.logger, s.ExternalLocationAddress)
+	s.presenters.basicUser = presenters.NewBasicUserPresenter(s.logger, s.ExternalLocationAddress)
+	s.presenters.webhook = presenters.NewWebhookPresenter(s.logger, s.ExternalLocationAddress)
+	s.presenters.sla = presenters.NewSLAPresenter(s.logger, s.ExternalLocationAddress)
+	s.presenters.priorityMatrix = presenters.NewPriorityMatrixPresenter(s.logger, s.ExternalLocationAddress)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -80,6 +81,8 @@ func (p BasePresenter) RenderError(w http.ResponseWriter, msg string, err error)
 			status = http.StatusUnauthorized
 		case domain.ErrorCodeActionForbidden:
 			status = http.StatusForbidden
+		case domain.ErrorCodeVersionMismatch:
+			status = http.StatusPreconditionFailed
 		case domain.ErrorCodeUnknown:
 			fallthrough
 		default:
@@ -112,6 +115,16 @@ func (p BasePresenter) resourceToHypermediaLinks(domainObject hypermedia.Actions
 	return hypermediaLinks
 }
 
+// appendEmbeddedResourceActionLinks adds action links allowed by the domain object to the embedded resource
+func (p BasePresenter) appendEmbeddedResourceActionLinks(resource hypermedia.EmbeddedResource, domainObject hypermedia.ActionsMapper, actionLinks hypermedia.ActionLinks, hypermediaMapper hypermedia.Mapper) {
+	allowedActions := domainObject.AllowedActions(hypermediaMapper.Actor())
+	for _, action := range allowedActions {
+		link := actionLinks.Get(action)
+		href := strings.ReplaceAll(link.Href, "{uuid}", domainObject.UUID().String())
+		resource.AppendActionLink(link.Name, href)
+	}
+}
+
 func (p BasePresenter) resourceToEmbeddedField(domainObject hypermedia.EmbeddedResourceMapper, mappings []hypermedia.EmbeddedResourceMapping, hypermediaMapper hypermedia.Mapper) api.EmbeddedResources {
 	hypermediaResource := api.EmbeddedResources{}
 
@@ -193,6 +206,53 @@ func (p BasePresenter) hypermediaListLinks(hypermediaMapper hypermedia.Mapper, p
 	return hypermediaLinks
 }
 
+// hypermediaCursorListLinks returns list links of the cursor based pagination, encode converts the cursors to the tokens
+func (p BasePresenter) hypermediaCursorListLinks(hypermediaMapper hypermedia.Mapper, cursors *repository.PageCursors, encode func(repository.Cursor) string) api.HypermediaListLinks {
+	hypermediaLinks := api.HypermediaListLinks{}
+
+	query := hypermediaMapper.RequestURL().Query()
+	query.Del("page")
+	query.Del("after")
+	query.Del("before")
+	query.Set("limit", strconv.FormatUint(uint64(cursors.Limit), 10))
+
+	link := func(param string, c *repository.Cursor) string {
+		linkQuery := url.Values{}
+		for k, v := range query {
+			linkQuery[k] = v
+		}
+		if c != nil {
+			linkQuery.Set(param, encode(*c))
+		}
+
+		linkURL := *hypermediaMapper.RequestURL()
+		linkURL.RawQuery = linkQuery.Encode()
+		return fmt.Sprintf("%s%s", hypermediaMapper.ServerAddr(), linkURL.String())
+	}
+
+	hypermediaLinks.First = api.Link{
+		Href: link("", nil),
+	}
+	hypermediaLinks.Last = api.Link{
+		Href: link("after", cursors.Last),
+	}
+
+	if cursors.Prev != nil {
+		hypermediaLinks.Prev = &api.Link{
+			Href: link("before", cursors.Prev),
+		}
+	}
+	if cursors.Next != nil {
+		hypermediaLinks.Next = &api.Link{
+			Href: link("after", cursors.Next),
+		}
+	}
+
+	hypermediaLinks.AppendSelfLink(hypermediaMapper.SelfLink())
+
+	return hypermediaLinks
+}
+
 // renderErrorJSON replies to the request with the specified error message and HTTP code.
 // It encodes error string as JSON object {"error":"error_string"} and sets correct header.
 // It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
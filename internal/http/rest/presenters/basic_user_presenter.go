@@ -0,0 +1,76 @@
+package presenters
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// NewBasicUserPresenter creates a basic user presentation service
+func NewBasicUserPresenter(logger *zap.SugaredLogger, serverAddr string) BasicUserPresenter {
+	return &basicUserPresenter{
+		BasePresenter: NewBasePresenter(logger, serverAddr),
+	}
+}
+
+type basicUserPresenter struct {
+	*BasePresenter
+}
+
+func (p basicUserPresenter) RenderBasicUser(w http.ResponseWriter, basicUser user.BasicUser, hypermediaMapper hypermedia.Mapper) {
+	// basic user does not have any actions, so only 'self' link is rendered
+	links := api.HypermediaLinks{}
+	links.AppendSelfLink(hypermediaMapper.SelfLink())
+
+	basicUserResp := api.BasicUserResponse{
+		BasicUser: p.convertBasicUserToAPI(basicUser),
+		Links:     links,
+	}
+
+	p.renderJSON(w, basicUserResp)
+}
+
+func (p basicUserPresenter) RenderBasicUserList(w http.ResponseWriter, basicUserList repository.BasicUserList, hypermediaMapper hypermedia.Mapper) {
+	var apiList []api.BasicUserResponse
+
+	for _, basicUser := range basicUserList.Result {
+		links := api.HypermediaLinks{}
+		links.AppendSelfLink(fmt.Sprintf("%s%s/%s", hypermediaMapper.ServerAddr(), hypermediaMapper.RequestURL().Path, basicUser.UUID()))
+
+		basicUserResp := api.BasicUserResponse{
+			BasicUser: p.convertBasicUserToAPI(basicUser),
+			Links:     links,
+		}
+		apiList = append(apiList, basicUserResp)
+	}
+
+	pageInfo := api.PageInfo{
+		Total: basicUserList.Total,
+		Size:  basicUserList.Size,
+		Page:  basicUserList.Page,
+	}
+
+	resp := api.BasicUserListResponse{
+		Result:   apiList,
+		PageInfo: pageInfo,
+		Links:    p.hypermediaListLinks(hypermediaMapper, basicUserList.Pagination),
+	}
+
+	p.renderJSON(w, resp)
+}
+
+func (p basicUserPresenter) convertBasicUserToAPI(basicUser user.BasicUser) api.BasicUser {
+	return api.BasicUser{
+		UUID:             basicUser.UUID().String(),
+		ExternalUserUUID: basicUser.ExternalUserUUID,
+		Name:             basicUser.Name,
+		Surname:          basicUser.Surname,
+		OrgDisplayName:   basicUser.OrgDisplayName,
+		OrgName:          basicUser.OrgName,
+	}
+}
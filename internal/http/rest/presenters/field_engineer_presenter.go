@@ -0,0 +1,91 @@
+package presenters
+
+import (
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/embedded"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// NewFieldEngineerPresenter creates a field engineer presentation service
+func NewFieldEngineerPresenter(logger *zap.SugaredLogger, serverAddr string) FieldEngineerPresenter {
+	return &fieldEngineerPresenter{
+		BasePresenter: NewBasePresenter(logger, serverAddr),
+	}
+}
+
+type fieldEngineerPresenter struct {
+	*BasePresenter
+}
+
+func (p fieldEngineerPresenter) RenderFieldEngineer(w http.ResponseWriter, fe fieldengineer.FieldEngineer, hypermediaMapper hypermedia.Mapper) {
+	var embeddedMappings []hypermedia.EmbeddedResourceMapping
+
+	embeddedCreatedBy := api.NewEmbeddedBasicUser(fe.CreatedUpdated.CreatedBy())
+	mappingCreatedBy := *hypermedia.EmbeddedResourcesMappingDefinition[embedded.CreatedBy].AddResource(embeddedCreatedBy)
+	embeddedMappings = append(embeddedMappings, mappingCreatedBy)
+
+	feResp := api.FieldEngineerResponse{
+		FieldEngineer: p.convertFieldEngineerToAPI(fe),
+		Links:         p.resourceToHypermediaLinks(fe, hypermediaMapper, false),
+		Embedded:      p.resourceToEmbeddedField(fe, embeddedMappings, hypermediaMapper),
+	}
+
+	p.renderJSON(w, feResp)
+}
+
+func (p fieldEngineerPresenter) RenderFieldEngineerList(w http.ResponseWriter, feList repository.FieldEngineerList, hypermediaMapper hypermedia.Mapper) {
+	var apiList []api.FieldEngineerResponse
+
+	for _, fe := range feList.Result {
+		feResp := api.FieldEngineerResponse{
+			FieldEngineer: p.convertFieldEngineerToAPI(fe),
+			Links:         p.resourceToHypermediaLinks(fe, hypermediaMapper, true),
+		}
+		apiList = append(apiList, feResp)
+	}
+
+	pageInfo := api.PageInfo{
+		Total: feList.Total,
+		Size:  feList.Size,
+		Page:  feList.Page,
+	}
+
+	resp := api.FieldEngineerListResponse{
+		Result:   apiList,
+		PageInfo: pageInfo,
+		Links:    p.hypermediaListLinks(hypermediaMapper, feList.Pagination),
+	}
+
+	p.renderJSON(w, resp)
+}
+
+func (p fieldEngineerPresenter) convertFieldEngineerToAPI(fe fieldengineer.FieldEngineer) api.FieldEngineer {
+	var timeSessionUUIDs []api.UUID
+	for _, ts := range fe.TimeSessions {
+		timeSessionUUIDs = append(timeSessionUUIDs, api.UUID(ts))
+	}
+
+	basicUser := api.BasicUser{
+		UUID:             fe.BasicUser.UUID().String(),
+		ExternalUserUUID: fe.BasicUser.ExternalUserUUID,
+		Name:             fe.BasicUser.Name,
+		Surname:          fe.BasicUser.Surname,
+		OrgDisplayName:   fe.BasicUser.OrgDisplayName,
+		OrgName:          fe.BasicUser.OrgName,
+	}
+
+	apiFE := api.FieldEngineer{
+		UUID:           fe.UUID().String(),
+		BasicUser:      basicUser,
+		Deactivated:    fe.IsDeactivated(),
+		TimeSessions:   timeSessionUUIDs,
+		CreatedUpdated: api.NewCreatedUpdatedInfo(fe.CreatedUpdated),
+	}
+
+	return apiFE
+}
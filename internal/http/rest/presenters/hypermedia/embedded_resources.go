@@ -35,9 +35,9 @@ type EmbeddedResourceMapping struct {
 	Resource     EmbeddedResource
 }
 
-// AddResource ads new EmbeddedResource to the mapping
-func (m *EmbeddedResourceMapping) AddResource(resource EmbeddedResource) *EmbeddedResourceMapping {
+// AddResource returns a copy of the mapping with the EmbeddedResource added (the mapping definition itself is not modified)
+func (m EmbeddedResourceMapping) AddResource(resource EmbeddedResource) *EmbeddedResourceMapping {
 	m.Route = strings.ReplaceAll(m.Route, "{uuid}", resource.UUID())
 	m.Resource = resource
-	return m
+	return &m
 }
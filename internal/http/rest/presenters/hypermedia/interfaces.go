@@ -13,6 +13,8 @@ import (
 type IncidentMapper interface {
 	Mapper
 	FieldEngineerSvc() fieldengineersvc.FieldEngineerService
+	FieldEngineerActionLinks() ActionLinks
+	TimelogActionLinks() ActionLinks
 	Ctx() context.Context
 	ChannelID() ref.ChannelID
 }
@@ -60,4 +62,7 @@ type EmbeddedResource interface {
 
 	// AppendSelfLink adds resource's 'self' link to its '_links' object
 	AppendSelfLink(url string)
+
+	// AppendActionLink adds resource's action link to its '_links' object
+	AppendActionLink(name, url string)
 }
@@ -1,25 +1,38 @@
 package presenters
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/embedded"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/cursor"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"go.uber.org/zap"
 )
 
-// NewIncidentPresenter creates an incident presentation service
-func NewIncidentPresenter(logger *zap.SugaredLogger, serverAddr string) IncidentPresenter {
+// NewIncidentPresenter creates an incident presentation service, cursorCodec encodes the cursors in the list links,
+// clock provides the time the SLA timers are rendered at
+func NewIncidentPresenter(logger *zap.SugaredLogger, serverAddr string, cursorCodec *cursor.Codec, clock domain.Clock) IncidentPresenter {
 	return &incidentPresenter{
 		BasePresenter: NewBasePresenter(logger, serverAddr),
+		cursorCodec:   cursorCodec,
+		clock:         clock,
 	}
 }
 
 type incidentPresenter struct {
 	*BasePresenter
+	cursorCodec *cursor.Codec
+	clock       domain.Clock
 }
 
 func (p incidentPresenter) RenderIncident(w http.ResponseWriter, inc incident.Incident, hypermediaMapper hypermedia.IncidentMapper) {
@@ -34,6 +47,7 @@ func (p incidentPresenter) RenderIncident(w http.ResponseWriter, inc incident.In
 			p.RenderError(w, "", err)
 		}
 		embeddedFieldEngineer := api.NewEmbeddedFieldEngineer(fe)
+		p.appendEmbeddedResourceActionLinks(embeddedFieldEngineer, fe, hypermediaMapper.FieldEngineerActionLinks(), hypermediaMapper)
 		mappingFE := *hypermedia.EmbeddedResourcesMappingDefinition[embedded.FieldEngineer].AddResource(embeddedFieldEngineer)
 		embeddedMappings = append(embeddedMappings, mappingFE)
 	}
@@ -44,8 +58,15 @@ func (p incidentPresenter) RenderIncident(w http.ResponseWriter, inc incident.In
 
 	embeddedMappings = append(embeddedMappings, mappingCreatedBy)
 
+	apiInc, err := p.convertIncidentToAPI(inc)
+	if err != nil {
+		err = WrapErrorf(err, http.StatusInternalServerError, "error rendering incident")
+		p.RenderError(w, "", err)
+		return
+	}
+
 	incResp := api.IncidentResponse{
-		Incident: p.convertIncidentToAPI(inc),
+		Incident: apiInc,
 		Links:    p.resourceToHypermediaLinks(inc, hypermediaMapper, false),
 		Embedded: p.resourceToEmbeddedField(inc, embeddedMappings, hypermediaMapper),
 	}
@@ -68,6 +89,7 @@ func (p incidentPresenter) RenderIncidentList(w http.ResponseWriter, incidentLis
 				return
 			}
 			embeddedFieldEngineer := api.NewEmbeddedFieldEngineer(fe)
+			p.appendEmbeddedResourceActionLinks(embeddedFieldEngineer, fe, hypermediaMapper.FieldEngineerActionLinks(), hypermediaMapper)
 			mappingFE := *hypermedia.EmbeddedResourcesMappingDefinition[embedded.FieldEngineer].AddResource(embeddedFieldEngineer)
 			embeddedMappings = append(embeddedMappings, mappingFE)
 		}
@@ -77,8 +99,15 @@ func (p incidentPresenter) RenderIncidentList(w http.ResponseWriter, incidentLis
 		//mapping := *hypermedia.EmbeddedResourcesMappingDefinition[embedded.CreatedBy].AddResource(embeddedCreatedBy)
 		//embeddedMappings = append(embeddedMappings, mapping)
 
+		apiInc, err := p.convertIncidentToAPI(inc)
+		if err != nil {
+			err = WrapErrorf(err, http.StatusInternalServerError, "error rendering incident")
+			p.RenderError(w, "", err)
+			return
+		}
+
 		incResp := api.IncidentResponse{
-			Incident: p.convertIncidentToAPI(inc),
+			Incident: apiInc,
 			Links:    p.resourceToHypermediaLinks(inc, hypermediaMapper, true),
 			Embedded: p.resourceToEmbeddedField(inc, embeddedMappings, hypermediaMapper),
 		}
@@ -94,13 +123,21 @@ func (p incidentPresenter) RenderIncidentList(w http.ResponseWriter, incidentLis
 	resp := api.IncidentListResponse{
 		Result:   apiList,
 		PageInfo: pageInfo,
-		Links:    p.hypermediaListLinks(hypermediaMapper, incidentList.Pagination),
+	}
+
+	if incidentList.Cursors != nil {
+		sortParam := hypermediaMapper.RequestURL().Query().Get("sort")
+		resp.Links = p.hypermediaCursorListLinks(hypermediaMapper, incidentList.Cursors, func(c repository.Cursor) string {
+			return p.cursorCodec.Encode(c, sortParam)
+		})
+	} else {
+		resp.Links = p.hypermediaListLinks(hypermediaMapper, incidentList.Pagination)
 	}
 
 	p.renderJSON(w, resp)
 }
 
-func (p incidentPresenter) convertIncidentToAPI(inc incident.Incident) api.Incident {
+func (p incidentPresenter) convertIncidentToAPI(inc incident.Incident) (api.Incident, error) {
 	var timelogUUIDs []api.UUID
 	for _, timelog := range inc.Timelogs {
 		timelogUUIDs = append(timelogUUIDs, api.UUID(timelog))
@@ -112,17 +149,259 @@ func (p incidentPresenter) convertIncidentToAPI(inc incident.Incident) api.Incid
 		feUUID = &uuidS
 	}
 
+	var onHoldReason, remindAt string
+	if onHold := inc.OnHold(); onHold != nil {
+		onHoldReason = onHold.Reason.String()
+		remindAt = onHold.RemindAt.String()
+	}
+
+	var resolutionCode, resolutionNotes, resolvedAt string
+	if resolution := inc.Resolution(); resolution != nil {
+		resolutionCode = resolution.Code.String()
+		resolutionNotes = resolution.Notes
+		resolvedAt = resolution.ResolvedAt.String()
+	}
+
+	var incSLA *api.IncidentSLA
+	if s := inc.SLA(); s != nil {
+		status, err := s.Status(p.clock.Now())
+		if err != nil {
+			return api.Incident{}, err
+		}
+
+		incSLA = &api.IncidentSLA{
+			Priority:   s.Priority.String(),
+			Response:   convertSLATimerStatusToAPI(status.Response),
+			Resolution: convertSLATimerStatusToAPI(status.Resolution),
+		}
+	}
+
 	apiInc := api.Incident{
-		UUID:             inc.UUID().String(),
-		Number:           inc.Number,
-		ExternalID:       inc.ExternalID,
-		ShortDescription: inc.ShortDescription,
-		Description:      inc.Description,
-		FieldEngineer:    feUUID,
-		State:            inc.State(),
-		Timelogs:         timelogUUIDs,
-		CreatedUpdated:   api.NewCreatedUpdatedInfo(inc.CreatedUpdated),
-	}
-
-	return apiInc
+		UUID:                  inc.UUID().String(),
+		Number:                inc.Number,
+		ExternalID:            inc.ExternalID,
+		ShortDescription:      inc.ShortDescription,
+		Description:           inc.Description,
+		FieldEngineer:         feUUID,
+		FieldEngineerAccepted: inc.FieldEngineerAccepted(),
+		State:                 inc.State(),
+		Impact:                inc.Impact().String(),
+		Urgency:               inc.Urgency().String(),
+		Priority:              inc.Priority().String(),
+		PriorityOverridden:    inc.PriorityOverridden(),
+		OnHoldReason:          onHoldReason,
+		RemindAt:              remindAt,
+		ResolutionCode:        resolutionCode,
+		ResolutionNotes:       resolutionNotes,
+		ResolvedAt:            resolvedAt,
+		Timelogs:              timelogUUIDs,
+		SLA:                   incSLA,
+		CreatedUpdated:        api.NewCreatedUpdatedInfo(inc.CreatedUpdated),
+	}
+
+	return apiInc, nil
+}
+
+// convertSLATimerStatusToAPI converts the timer status to API object, times are in seconds
+func convertSLATimerStatusToAPI(status sla.TimerStatus) api.SLATimer {
+	return api.SLATimer{
+		State:     status.State.String(),
+		Target:    int64(status.Target / time.Second),
+		Elapsed:   int64(status.Elapsed / time.Second),
+		Remaining: int64(status.Remaining / time.Second),
+		DueAt:     status.DueAt.String(),
+		Breached:  status.Breached,
+	}
+}
+
+func (p incidentPresenter) RenderTimelog(w http.ResponseWriter, incID ref.UUID, tmlg timelog.Timelog, hypermediaMapper hypermedia.IncidentMapper) {
+	timelogResp, err := p.timelogToResponse(incID, tmlg, hypermediaMapper, false)
+	if err != nil {
+		err = WrapErrorf(err, http.StatusInternalServerError, "error rendering timelog")
+		p.RenderError(w, "", err)
+		return
+	}
+
+	p.renderJSON(w, timelogResp)
+}
+
+func (p incidentPresenter) RenderTimelogList(w http.ResponseWriter, incID ref.UUID, timelogList repository.TimelogList, hypermediaMapper hypermedia.IncidentMapper) {
+	var apiList []api.TimelogResponse
+
+	for _, tmlg := range timelogList.Result {
+		timelogResp, err := p.timelogToResponse(incID, tmlg, hypermediaMapper, true)
+		if err != nil {
+			err = WrapErrorf(err, http.StatusInternalServerError, "error rendering timelog")
+			p.RenderError(w, "", err)
+			return
+		}
+		apiList = append(apiList, timelogResp)
+	}
+
+	pageInfo := api.PageInfo{
+		Total: timelogList.Total,
+		Size:  timelogList.Size,
+		Page:  timelogList.Page,
+	}
+
+	resp := api.TimelogListResponse{
+		Result:   apiList,
+		PageInfo: pageInfo,
+		Links:    p.hypermediaListLinks(hypermediaMapper, timelogList.Pagination),
+	}
+
+	p.renderJSON(w, resp)
+}
+
+func (p incidentPresenter) RenderHistoryList(w http.ResponseWriter, incID ref.UUID, historyList repository.HistoryList, hypermediaMapper hypermedia.IncidentMapper) {
+	var apiList []api.HistoryEntryResponse
+
+	for _, entry := range historyList.Result {
+		var changes []api.HistoryChange
+		for _, change := range entry.Changes {
+			changes = append(changes, api.HistoryChange{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			})
+		}
+
+		links := api.HypermediaLinks{}
+		links["incident"] = map[string]string{
+			"href": fmt.Sprintf("%s/incidents/%s", hypermediaMapper.ServerAddr(), incID),
+		}
+
+		apiList = append(apiList, api.HistoryEntryResponse{
+			HistoryEntry: api.HistoryEntry{
+				UUID:          api.UUID(entry.UUID()),
+				Action:        entry.Action,
+				ActorID:       api.UUID(entry.ActorID),
+				RequestedByID: api.UUID(entry.RequestedByID),
+				Time:          entry.Time.String(),
+				Changes:       changes,
+			},
+			Links: links,
+		})
+	}
+
+	pageInfo := api.PageInfo{
+		Total: historyList.Total,
+		Size:  historyList.Size,
+		Page:  historyList.Page,
+	}
+
+	resp := api.HistoryListResponse{
+		Result:   apiList,
+		PageInfo: pageInfo,
+		Links:    p.hypermediaListLinks(hypermediaMapper, historyList.Pagination),
+	}
+
+	p.renderJSON(w, resp)
+}
+
+// timelogToResponse converts the timelog to API response with the action links, the link to the incident and the embedded creator
+func (p incidentPresenter) timelogToResponse(incID ref.UUID, tmlg timelog.Timelog, hypermediaMapper hypermedia.IncidentMapper, inList bool) (api.TimelogResponse, error) {
+	apiTimelog, err := p.convertTimelogToAPI(tmlg)
+	if err != nil {
+		return api.TimelogResponse{}, err
+	}
+
+	links := api.HypermediaLinks{}
+	if inList {
+		links.AppendSelfLink(fmt.Sprintf("%s%s/%s", hypermediaMapper.ServerAddr(), hypermediaMapper.RequestURL().Path, tmlg.UUID()))
+	} else {
+		links.AppendSelfLink(hypermediaMapper.SelfLink())
+	}
+	links["incident"] = map[string]string{
+		"href": fmt.Sprintf("%s/incidents/%s", hypermediaMapper.ServerAddr(), incID),
+	}
+
+	actionLinks := hypermediaMapper.TimelogActionLinks()
+	routeReplacer := strings.NewReplacer("{uuid}", incID.String(), "{timelog_uuid}", tmlg.UUID().String())
+	for _, action := range tmlg.AllowedActions(hypermediaMapper.Actor()) {
+		link := actionLinks.Get(action)
+		links.AppendActionLink(link.Name, routeReplacer.Replace(link.Href))
+	}
+
+	embeddedCreatedBy := api.NewEmbeddedBasicUser(tmlg.CreatedUpdated.CreatedBy())
+	mappingCreatedBy := *hypermedia.EmbeddedResourcesMappingDefinition[embedded.CreatedBy].AddResource(embeddedCreatedBy)
+
+	timelogResp := api.TimelogResponse{
+		Timelog:  apiTimelog,
+		Links:    links,
+		Embedded: p.resourceToEmbeddedField(tmlg, []hypermedia.EmbeddedResourceMapping{mappingCreatedBy}, hypermediaMapper),
+	}
+
+	return timelogResp, nil
+}
+
+func (p incidentPresenter) convertTimelogToAPI(tmlg timelog.Timelog) (api.Timelog, error) {
+	timespans, err := p.convertTimespansToAPI(tmlg.Timespans)
+	if err != nil {
+		return api.Timelog{}, err
+	}
+
+	apiTimelog := api.Timelog{
+		UUID:           api.UUID(tmlg.UUID()),
+		Remote:         tmlg.Remote,
+		Start:          tmlg.Start.String(),
+		End:            tmlg.End.String(),
+		Work:           tmlg.Work,
+		VisitSummary:   tmlg.VisitSummary,
+		Timespans:      timespans,
+		CreatedUpdated: api.NewCreatedUpdatedInfo(tmlg.CreatedUpdated),
+	}
+
+	for _, correction := range tmlg.Corrections {
+		apiTimelog.Corrections = append(apiTimelog.Corrections, api.TimelogCorrection{
+			Remote:       correction.Remote,
+			Start:        correction.Start.String(),
+			End:          correction.End.String(),
+			VisitSummary: correction.VisitSummary,
+			Reason:       correction.Reason,
+			Status:       correction.Status.String(),
+			ProposedBy:   api.UUID(correction.ProposedBy),
+			ProposedAt:   correction.ProposedAt.String(),
+			DecidedBy:    api.UUID(correction.DecidedBy),
+			DecidedAt:    correction.DecidedAt.String(),
+			DecisionNote: correction.DecisionNote,
+		})
+	}
+
+	if tmlg.Original != nil {
+		originalTimespans, err := p.convertTimespansToAPI(tmlg.Original.Timespans)
+		if err != nil {
+			return api.Timelog{}, err
+		}
+
+		apiTimelog.Original = &api.TimelogOriginal{
+			Remote:       tmlg.Original.Remote,
+			Start:        tmlg.Original.Start.String(),
+			End:          tmlg.Original.End.String(),
+			Work:         tmlg.Original.Work,
+			VisitSummary: tmlg.Original.VisitSummary,
+			Timespans:    originalTimespans,
+		}
+	}
+
+	return apiTimelog, nil
+}
+
+func (p incidentPresenter) convertTimespansToAPI(timespans []timelog.Timespan) ([]api.Timespan, error) {
+	var apiTimespans []api.Timespan
+	for _, span := range timespans {
+		duration, err := span.Duration()
+		if err != nil {
+			return nil, err
+		}
+
+		apiTimespans = append(apiTimespans, api.Timespan{
+			Type:     span.Type.String(),
+			Start:    span.Start.String(),
+			End:      span.End.String(),
+			Duration: duration,
+		})
+	}
+
+	return apiTimespans, nil
 }
@@ -3,7 +3,13 @@ package presenters
 import (
 	"net/http"
 
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
@@ -19,4 +25,83 @@ type IncidentPresenter interface {
 	// RenderIncidentList encodes list of incidents and writes it to 'w'.  Also sets correct Content-Type header.
 	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
 	RenderIncidentList(w http.ResponseWriter, incidentList repository.IncidentList, hypermediaMapper hypermedia.IncidentMapper)
+
+	// RenderTimelog encodes timelog and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderTimelog(w http.ResponseWriter, incID ref.UUID, timelog timelog.Timelog, hypermediaMapper hypermedia.IncidentMapper)
+
+	// RenderTimelogList encodes list of the incident's timelogs and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderTimelogList(w http.ResponseWriter, incID ref.UUID, timelogList repository.TimelogList, hypermediaMapper hypermedia.IncidentMapper)
+
+	// RenderHistoryList encodes list of the incident's history entries and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderHistoryList(w http.ResponseWriter, incID ref.UUID, historyList repository.HistoryList, hypermediaMapper hypermedia.IncidentMapper)
+}
+
+// FieldEngineerPresenter provides REST responses for field engineer resource
+type FieldEngineerPresenter interface {
+	BasicPresenters
+
+	// RenderFieldEngineer encodes field engineer and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderFieldEngineer(w http.ResponseWriter, fe fieldengineer.FieldEngineer, hypermediaMapper hypermedia.Mapper)
+
+	// RenderFieldEngineerList encodes list of field engineers and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderFieldEngineerList(w http.ResponseWriter, feList repository.FieldEngineerList, hypermediaMapper hypermedia.Mapper)
+}
+
+// BasicUserPresenter provides REST responses for basic user resource
+type BasicUserPresenter interface {
+	BasicPresenters
+
+	// RenderBasicUser encodes basic user and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderBasicUser(w http.ResponseWriter, basicUser user.BasicUser, hypermediaMapper hypermedia.Mapper)
+
+	// RenderBasicUserList encodes list of basic users and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderBasicUserList(w http.ResponseWriter, basicUserList repository.BasicUserList, hypermediaMapper hypermedia.Mapper)
+}
+
+// WebhookPresenter provides REST responses for webhook subscription resource
+type WebhookPresenter interface {
+	BasicPresenters
+
+	// RenderWebhook encodes webhook subscription and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderWebhook(w http.ResponseWriter, sub webhook.Subscription, hypermediaMapper hypermedia.Mapper)
+
+	// RenderWebhookList encodes list of webhook subscriptions and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderWebhookList(w http.ResponseWriter, subList repository.WebhookSubscriptionList, hypermediaMapper hypermedia.Mapper)
+
+	// RenderWebhookDeliveryList encodes list of the webhook subscription's deliveries and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderWebhookDeliveryList(w http.ResponseWriter, subID ref.UUID, deliveryList repository.WebhookDeliveryList, hypermediaMapper hypermedia.Mapper)
+}
+
+// SLAPresenter provides REST responses for SLA definition resource
+type SLAPresenter interface {
+	BasicPresenters
+
+	// RenderSLADefinition encodes SLA definition and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderSLADefinition(w http.ResponseWriter, def sla.Definition, hypermediaMapper hypermedia.Mapper)
+
+	// RenderSLADefinitionNoContentHeader sends Location header of the SLA definition resource and 204 status code
+	RenderSLADefinitionNoContentHeader(w http.ResponseWriter, route string)
+}
+
+// PriorityMatrixPresenter provides REST responses for priority matrix resource
+type PriorityMatrixPresenter interface {
+	BasicPresenters
+
+	// RenderPriorityMatrix encodes priority matrix and writes it to 'w'.  Also sets correct Content-Type header.
+	// It does not otherwise end the request; the caller should ensure no further writes are done to 'w'.
+	RenderPriorityMatrix(w http.ResponseWriter, matrix incident.PriorityMatrix, hypermediaMapper hypermedia.Mapper)
+
+	// RenderPriorityMatrixNoContentHeader sends Location header of the priority matrix resource and 204 status code
+	RenderPriorityMatrixNoContentHeader(w http.ResponseWriter, route string)
 }
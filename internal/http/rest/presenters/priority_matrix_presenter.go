@@ -0,0 +1,61 @@
+package presenters
+
+import (
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"go.uber.org/zap"
+)
+
+// NewPriorityMatrixPresenter creates a priority matrix presentation service
+func NewPriorityMatrixPresenter(logger *zap.SugaredLogger, serverAddr string) PriorityMatrixPresenter {
+	return &priorityMatrixPresenter{
+		BasePresenter: NewBasePresenter(logger, serverAddr),
+	}
+}
+
+type priorityMatrixPresenter struct {
+	*BasePresenter
+}
+
+func (p priorityMatrixPresenter) RenderPriorityMatrix(w http.ResponseWriter, matrix incident.PriorityMatrix, hypermediaMapper hypermedia.Mapper) {
+	links := api.HypermediaLinks{}
+	links.AppendSelfLink(hypermediaMapper.SelfLink())
+
+	resp := api.PriorityMatrixResponse{
+		PriorityMatrix: p.convertPriorityMatrixToAPI(matrix),
+		Links:          links,
+	}
+
+	p.renderJSON(w, resp)
+}
+
+func (p priorityMatrixPresenter) RenderPriorityMatrixNoContentHeader(w http.ResponseWriter, route string) {
+	w.Header().Set("Location", p.serverAddr+route)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// convertPriorityMatrixToAPI converts the matrix to API object, entries are sorted from the highest impact and urgency
+func (p priorityMatrixPresenter) convertPriorityMatrixToAPI(matrix incident.PriorityMatrix) api.PriorityMatrix {
+	apiMatrix := api.PriorityMatrix{
+		Priorities:     []api.PriorityMatrixEntry{},
+		CreatedUpdated: api.NewCreatedUpdatedInfo(matrix.CreatedUpdated),
+	}
+
+	for _, impact := range incident.Impacts() {
+		for _, urgency := range incident.Urgencies() {
+			if priority := matrix.PriorityFor(impact, urgency); !priority.IsZero() {
+				apiMatrix.Priorities = append(apiMatrix.Priorities, api.PriorityMatrixEntry{
+					Impact:   impact.String(),
+					Urgency:  urgency.String(),
+					Priority: priority.String(),
+				})
+			}
+		}
+	}
+
+	return apiMatrix
+}
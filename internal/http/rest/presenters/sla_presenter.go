@@ -0,0 +1,77 @@
+package presenters
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"go.uber.org/zap"
+)
+
+// NewSLAPresenter creates an SLA definition presentation service
+func NewSLAPresenter(logger *zap.SugaredLogger, serverAddr string) SLAPresenter {
+	return &slaPresenter{
+		BasePresenter: NewBasePresenter(logger, serverAddr),
+	}
+}
+
+type slaPresenter struct {
+	*BasePresenter
+}
+
+func (p slaPresenter) RenderSLADefinition(w http.ResponseWriter, def sla.Definition, hypermediaMapper hypermedia.Mapper) {
+	links := api.HypermediaLinks{}
+	links.AppendSelfLink(hypermediaMapper.SelfLink())
+
+	resp := api.SLADefinitionResponse{
+		SLADefinition: p.convertSLADefinitionToAPI(def),
+		Links:         links,
+	}
+
+	p.renderJSON(w, resp)
+}
+
+func (p slaPresenter) RenderSLADefinitionNoContentHeader(w http.ResponseWriter, route string) {
+	w.Header().Set("Location", p.serverAddr+route)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// convertSLADefinitionToAPI converts the definition to API object, targets are sorted from the highest priority and times are in seconds
+func (p slaPresenter) convertSLADefinitionToAPI(def sla.Definition) api.SLADefinition {
+	apiDef := api.SLADefinition{
+		Targets:         []api.SLATarget{},
+		DefaultPriority: def.DefaultPriority.String(),
+		Calendar: api.SLACalendar{
+			Location:     def.Calendar.Location,
+			WorkingHours: []api.SLAWorkingHours{},
+			Holidays:     []string{},
+		},
+		CreatedUpdated: api.NewCreatedUpdatedInfo(def.CreatedUpdated),
+	}
+
+	for _, priority := range sla.Priorities() {
+		if target, ok := def.Targets[priority]; ok {
+			apiDef.Targets = append(apiDef.Targets, api.SLATarget{
+				Priority:       priority.String(),
+				ResponseTime:   uint(target.Response / time.Second),
+				ResolutionTime: uint(target.Resolution / time.Second),
+			})
+		}
+	}
+
+	for _, wh := range def.Calendar.WorkingHours {
+		apiDef.Calendar.WorkingHours = append(apiDef.Calendar.WorkingHours, api.SLAWorkingHours{
+			Weekday: strings.ToLower(wh.Weekday.String()),
+			Start:   wh.Start,
+			End:     wh.End,
+		})
+	}
+
+	apiDef.Calendar.Holidays = append(apiDef.Calendar.Holidays, def.Calendar.Holidays...)
+
+	return apiDef
+}
@@ -0,0 +1,131 @@
+package presenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// NewWebhookPresenter creates a webhook subscription presentation service
+func NewWebhookPresenter(logger *zap.SugaredLogger, serverAddr string) WebhookPresenter {
+	return &webhookPresenter{
+		BasePresenter: NewBasePresenter(logger, serverAddr),
+	}
+}
+
+type webhookPresenter struct {
+	*BasePresenter
+}
+
+func (p webhookPresenter) RenderWebhook(w http.ResponseWriter, sub webhook.Subscription, hypermediaMapper hypermedia.Mapper) {
+	selfLink := hypermediaMapper.SelfLink()
+
+	webhookResp := api.WebhookResponse{
+		Webhook: p.convertWebhookToAPI(sub),
+		Links:   p.webhookLinks(selfLink),
+	}
+
+	p.renderJSON(w, webhookResp)
+}
+
+func (p webhookPresenter) RenderWebhookList(w http.ResponseWriter, subList repository.WebhookSubscriptionList, hypermediaMapper hypermedia.Mapper) {
+	var apiList []api.WebhookResponse
+
+	for _, sub := range subList.Result {
+		selfLink := fmt.Sprintf("%s%s/%s", hypermediaMapper.ServerAddr(), hypermediaMapper.RequestURL().Path, sub.UUID())
+
+		apiList = append(apiList, api.WebhookResponse{
+			Webhook: p.convertWebhookToAPI(sub),
+			Links:   p.webhookLinks(selfLink),
+		})
+	}
+
+	pageInfo := api.PageInfo{
+		Total: subList.Total,
+		Size:  subList.Size,
+		Page:  subList.Page,
+	}
+
+	resp := api.WebhookListResponse{
+		Result:   apiList,
+		PageInfo: pageInfo,
+		Links:    p.hypermediaListLinks(hypermediaMapper, subList.Pagination),
+	}
+
+	p.renderJSON(w, resp)
+}
+
+func (p webhookPresenter) RenderWebhookDeliveryList(w http.ResponseWriter, subID ref.UUID, deliveryList repository.WebhookDeliveryList, hypermediaMapper hypermedia.Mapper) {
+	var apiList []api.WebhookDeliveryResponse
+
+	for _, delivery := range deliveryList.Result {
+		links := api.HypermediaLinks{}
+		links["webhook"] = map[string]string{
+			"href": fmt.Sprintf("%s/webhooks/%s", hypermediaMapper.ServerAddr(), subID),
+		}
+
+		apiList = append(apiList, api.WebhookDeliveryResponse{
+			WebhookDelivery: api.WebhookDelivery{
+				UUID:               api.UUID(delivery.UUID()),
+				EventID:            api.UUID(delivery.EventID),
+				EventType:          delivery.EventType.String(),
+				Status:             delivery.Status.String(),
+				Attempts:           delivery.Attempts,
+				NextAttemptAt:      delivery.NextAttemptAt.String(),
+				LastAttemptAt:      delivery.LastAttemptAt.String(),
+				LastResponseStatus: delivery.LastResponseStatus,
+				LastError:          delivery.LastError,
+				Payload:            json.RawMessage(delivery.Payload),
+				CreatedAt:          delivery.CreatedAt.String(),
+			},
+			Links: links,
+		})
+	}
+
+	pageInfo := api.PageInfo{
+		Total: deliveryList.Total,
+		Size:  deliveryList.Size,
+		Page:  deliveryList.Page,
+	}
+
+	resp := api.WebhookDeliveryListResponse{
+		Result:   apiList,
+		PageInfo: pageInfo,
+		Links:    p.hypermediaListLinks(hypermediaMapper, deliveryList.Pagination),
+	}
+
+	p.renderJSON(w, resp)
+}
+
+// webhookLinks returns 'self' link and the link to the delivery log of the subscription
+func (p webhookPresenter) webhookLinks(selfLink string) api.HypermediaLinks {
+	links := api.HypermediaLinks{}
+	links.AppendSelfLink(selfLink)
+	links["deliveries"] = map[string]string{
+		"href": selfLink + "/deliveries",
+	}
+
+	return links
+}
+
+// convertWebhookToAPI converts the subscription to API object, the secret is never returned
+func (p webhookPresenter) convertWebhookToAPI(sub webhook.Subscription) api.Webhook {
+	var eventTypes []string
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	return api.Webhook{
+		UUID:           api.UUID(sub.UUID()),
+		URL:            sub.URL,
+		EventTypes:     eventTypes,
+		CreatedUpdated: api.NewCreatedUpdatedInfo(sub.CreatedUpdated),
+	}
+}
@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/julienschmidt/httprouter"
+)
+
+func (s Server) registerPriorityMatrixRoutes() {
+	s.router.GET("/priority_matrix", s.GetPriorityMatrix())
+	s.router.PUT("/priority_matrix", s.SetPriorityMatrix())
+}
+
+// swagger:route GET /priority_matrix priority_matrix GetPriorityMatrix
+// Returns the priority matrix of the channel, the default matrix is returned if the channel does not have its own
+// responses:
+//
+//	200: priorityMatrixResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+const priorityMatrixRoute = "/priority_matrix"
+
+// GetPriorityMatrix returns handler for getting the priority matrix of the channel
+func (s *Server) GetPriorityMatrix() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("GetPriorityMatrix handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		matrix, err := s.incidentService.GetPriorityMatrix(r.Context(), channelID, actorUser)
+		if err != nil {
+			s.logger.Errorw("GetPriorityMatrix handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		hypermediaMapper := NewPriorityMatrixHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.priorityMatrix.RenderPriorityMatrix(w, matrix, hypermediaMapper)
+	}
+}
+
+// swagger:route PUT /priority_matrix priority_matrix SetPriorityMatrix
+// Sets the priority matrix of the channel, the current matrix is replaced.
+// Incidents that already exist keep their priorities until their impact or urgency is changed.
+// responses:
+//
+//	204: priorityMatrixNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+
+// SetPriorityMatrix returns handler for setting the priority matrix of the channel
+func (s *Server) SetPriorityMatrix() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		matrixPayload, err := s.inputPayloadConverters.priorityMatrix.PriorityMatrixSetParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("SetPriorityMatrix handler failed", "error", err)
+			s.presenters.priorityMatrix.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("SetPriorityMatrix handler failed", "error", err)
+			s.presenters.priorityMatrix.RenderError(w, "", err)
+			return
+		}
+
+		err = s.incidentService.SetPriorityMatrix(r.Context(), channelID, actorUser, matrixPayload)
+		if err != nil {
+			s.logger.Errorw("SetPriorityMatrix handler failed", "error", err)
+			s.presenters.priorityMatrix.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.priorityMatrix.RenderPriorityMatrixNoContentHeader(w, priorityMatrixRoute)
+	}
+}
+
+// PriorityMatrixHypermediaMapper implements hypermedia mapping functionality for priority matrix resource
+type PriorityMatrixHypermediaMapper struct {
+	*hypermedia.BaseHypermediaMapper
+}
+
+// NewPriorityMatrixHypermediaMapper returns new hypermedia mapper for priority matrix resource
+func NewPriorityMatrixHypermediaMapper(serverAddr string, currentURL *url.URL, actor actor.Actor) PriorityMatrixHypermediaMapper {
+	return PriorityMatrixHypermediaMapper{
+		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+	}
+}
+
+// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links (priority matrix does not have any actions)
+func (h PriorityMatrixHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
+	return hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+}
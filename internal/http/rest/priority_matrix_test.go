@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPriorityMatrixHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+		},
+	}
+	err := actorUser.BasicUser.SetUUID("8183eaca-56c0-41d9-9291-1d295dd53763")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when body payload is not valid (ie. validation fails)", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{"priorities": []}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PUT", "/priority_matrix", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"'priorities' must contain at least 1 item"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when the matrix is not valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		params := api.SetPriorityMatrixParams{
+			Priorities: []api.PriorityMatrixEntry{{Impact: "high", Urgency: "high", Priority: "critical"}},
+		}
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("SetPriorityMatrix", ref.ChannelID(channelID), actorUser, params).
+			Return(domain.NewErrorf(domain.ErrorCodeInvalidArgument, "priority matrix does not have priority for 'high' impact and 'medium' urgency"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{"priorities": [{"impact": "high", "urgency": "high", "priority": "critical"}]}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PUT", "/priority_matrix", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+
+		expectedJSON := `{"error":"priority matrix does not have priority for 'high' impact and 'medium' urgency"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when body payload is valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		incidentSvc := new(mocks.IncidentServiceMock)
+		incidentSvc.On("SetPriorityMatrix", ref.ChannelID(channelID), actorUser, api.SetPriorityMatrixParams{
+			Priorities: []api.PriorityMatrixEntry{
+				{Impact: "high", Urgency: "high", Priority: "critical"},
+				{Impact: "low", Urgency: "low", Priority: "low"},
+			},
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			IncidentService:         incidentSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{
+			"priorities": [
+				{"impact": "high", "urgency": "high", "priority": "critical"},
+				{"impact": "low", "urgency": "low", "priority": "low"}
+			]
+		}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PUT", "/priority_matrix", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		incidentSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		assert.Equal(t, "http://service.url/priority_matrix", resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestGetPriorityMatrixHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+		},
+	}
+	err := actorUser.BasicUser.SetUUID("8183eaca-56c0-41d9-9291-1d295dd53763")
+	require.NoError(t, err)
+
+	us := new(mocks.ExternalUserServiceMock)
+	us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+		Return(actorUser, nil)
+
+	incidentSvc := new(mocks.IncidentServiceMock)
+	incidentSvc.On("GetPriorityMatrix", ref.ChannelID(channelID), actorUser).
+		Return(incident.DefaultPriorityMatrix(), nil)
+
+	server := NewServer(Config{
+		Addr:                    "service.url",
+		Logger:                  logger,
+		IncidentService:         incidentSvc,
+		ExternalLocationAddress: "http://service.url",
+		ExternalUserService:     us,
+	})
+
+	req := httptest.NewRequest("GET", "/priority_matrix", nil)
+	req.Header.Set("channel-id", channelID)
+	req.Header.Set("authorization", bearerToken)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	resp := w.Result()
+
+	defer func() { _ = resp.Body.Close() }()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read response: %v", err)
+	}
+
+	us.AssertExpectations(t)
+	incidentSvc.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+	expectedJSON := `{
+		"priorities": [
+			{"impact": "high", "urgency": "high", "priority": "critical"},
+			{"impact": "high", "urgency": "medium", "priority": "high"},
+			{"impact": "high", "urgency": "low", "priority": "moderate"},
+			{"impact": "medium", "urgency": "high", "priority": "high"},
+			{"impact": "medium", "urgency": "medium", "priority": "moderate"},
+			{"impact": "medium", "urgency": "low", "priority": "low"},
+			{"impact": "low", "urgency": "high", "priority": "moderate"},
+			{"impact": "low", "urgency": "medium", "priority": "low"},
+			{"impact": "low", "urgency": "low", "priority": "low"}
+		],
+		"_links": {
+			"self": {"href": "http://service.url/priority_matrix"}
+		}
+	}`
+	assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+}
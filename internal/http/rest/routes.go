@@ -13,6 +13,11 @@ var swaggerFS embed.FS
 
 func (s *Server) registerRoutes() {
 	s.registerIncidentRoutes()
+	s.registerFieldEngineerRoutes()
+	s.registerBasicUserRoutes()
+	s.registerWebhookRoutes()
+	s.registerSLARoutes()
+	s.registerPriorityMatrixRoutes()
 
 	// API documentation
 	opts := middleware.RedocOpts{Path: "/docs", SpecURL: "/swagger.yaml", Title: "Ticket management service API documentation"}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"crypto/rand"
 	"net/http"
 	"time"
 
@@ -9,10 +10,15 @@ import (
 	fieldengineersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/service"
 	incidentsvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/service"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	slasvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/sla/service"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	basicusersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/user/basic_user_service"
 	externalusersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/user/external_user_service"
+	webhooksvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/cursor"
 	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
 	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
 )
@@ -27,9 +33,14 @@ type Server struct {
 	externalUserService     externalusersvc.Service
 	incidentService         incidentsvc.IncidentService
 	fieldEngineerService    fieldengineersvc.FieldEngineerService
+	basicUserService        basicusersvc.BasicUserService
+	webhookService          webhooksvc.WebhookService
+	slaService              slasvc.SLAService
 	inputPayloadConverters  jsonInputPayloadConverters
 	presenters              jsonPresenters
 	ExternalLocationAddress string
+	requireIfMatch          bool
+	cursorCodec             *cursor.Codec
 }
 
 // Config contains server configuration and dependencies
@@ -41,13 +52,29 @@ type Config struct {
 	ExternalUserService     externalusersvc.Service
 	IncidentService         incidentsvc.IncidentService
 	FieldEngineerService    fieldengineersvc.FieldEngineerService
+	BasicUserService        basicusersvc.BasicUserService
+	WebhookService          webhooksvc.WebhookService
+	SLAService              slasvc.SLAService
 	ExternalLocationAddress string
+	// RequireIfMatch enables strict mode, incident modifications without 'If-Match' header are rejected
+	RequireIfMatch bool
+	// CursorSecret is the key the pagination cursors are signed with. If it is empty, random key is generated,
+	// so the cursors are not valid after the restart or on other server instances.
+	CursorSecret []byte
 }
 
 // NewServer creates new server with the necessary dependencies
 func NewServer(cfg Config) *Server {
 	r := httprouter.New()
 
+	cursorSecret := cfg.CursorSecret
+	if len(cursorSecret) == 0 {
+		cursorSecret = make([]byte, 32)
+		if _, err := rand.Read(cursorSecret); err != nil {
+			panic(err)
+		}
+	}
+
 	URISchema := "http://"
 	if cfg.URISchema != "" {
 		URISchema = cfg.URISchema
@@ -62,7 +89,12 @@ func NewServer(cfg Config) *Server {
 		externalUserService:     cfg.ExternalUserService,
 		incidentService:         cfg.IncidentService,
 		fieldEngineerService:    cfg.FieldEngineerService,
+		basicUserService:        cfg.BasicUserService,
+		webhookService:          cfg.WebhookService,
+		slaService:              cfg.SLAService,
 		ExternalLocationAddress: cfg.ExternalLocationAddress,
+		requireIfMatch:          cfg.RequireIfMatch,
+		cursorCodec:             cursor.NewCodec(cursorSecret),
 	}
 	s.registerInputConverters()
 	s.registerPresenters()
@@ -193,3 +225,13 @@ func authTokenFromRequest(r *http.Request) (string, bool) {
 func (s Server) PaginationParams(r *http.Request, actorUser actor.Actor) (converters.PaginationParams, error) {
 	return converters.NewPaginationParams(r, actorUser)
 }
+
+// IncidentPaginationParams parses request query like PaginationParams, the incidents can be also paginated by the cursor
+func (s Server) IncidentPaginationParams(r *http.Request, actorUser actor.Actor) (converters.PaginationParams, error) {
+	return converters.NewCursorPaginationParams(r, actorUser, s.cursorCodec)
+}
+
+// IncidentFilter parses request query and returns the filter and the sort order of the listed incidents
+func (s Server) IncidentFilter(r *http.Request) (repository.IncidentFilter, error) {
+	return converters.NewIncidentFilter(r)
+}
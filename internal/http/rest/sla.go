@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/julienschmidt/httprouter"
+)
+
+func (s Server) registerSLARoutes() {
+	s.router.GET("/sla", s.GetSLADefinition())
+	s.router.PUT("/sla", s.SetSLADefinition())
+}
+
+// swagger:route GET /sla sla GetSLADefinition
+// Returns the SLA definition of the channel
+// responses:
+//
+//	200: slaDefinitionResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const slaRoute = "/sla"
+
+// GetSLADefinition returns handler for getting the SLA definition of the channel
+func (s *Server) GetSLADefinition() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("GetSLADefinition handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		def, err := s.slaService.GetDefinition(r.Context(), channelID, actorUser)
+		if err != nil {
+			s.logger.Errorw("GetSLADefinition handler failed", "error", err)
+			s.presenters.base.RenderError(w, "SLA definition not found", err)
+			return
+		}
+
+		hypermediaMapper := NewSLAHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.sla.RenderSLADefinition(w, def, hypermediaMapper)
+	}
+}
+
+// swagger:route PUT /sla sla SetSLADefinition
+// Sets the SLA definition of the channel, the current definition is replaced.
+// Incidents that already exist keep the SLA targets they were created with.
+// responses:
+//
+//	204: slaDefinitionNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+
+// SetSLADefinition returns handler for setting the SLA definition of the channel
+func (s *Server) SetSLADefinition() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		slaPayload, err := s.inputPayloadConverters.sla.SLADefinitionSetParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("SetSLADefinition handler failed", "error", err)
+			s.presenters.sla.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("SetSLADefinition handler failed", "error", err)
+			s.presenters.sla.RenderError(w, "", err)
+			return
+		}
+
+		err = s.slaService.SetDefinition(r.Context(), channelID, actorUser, slaPayload)
+		if err != nil {
+			s.logger.Errorw("SetSLADefinition handler failed", "error", err)
+			s.presenters.sla.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.sla.RenderSLADefinitionNoContentHeader(w, slaRoute)
+	}
+}
+
+// SLAHypermediaMapper implements hypermedia mapping functionality for SLA definition resource
+type SLAHypermediaMapper struct {
+	*hypermedia.BaseHypermediaMapper
+}
+
+// NewSLAHypermediaMapper returns new hypermedia mapper for SLA definition resource
+func NewSLAHypermediaMapper(serverAddr string, currentURL *url.URL, actor actor.Actor) SLAHypermediaMapper {
+	return SLAHypermediaMapper{
+		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+	}
+}
+
+// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links (SLA definition does not have any actions)
+func (h SLAHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
+	return hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+}
@@ -0,0 +1,313 @@
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSLADefinitionHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+		},
+	}
+	err := actorUser.BasicUser.SetUUID("8183eaca-56c0-41d9-9291-1d295dd53763")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when body payload is not valid (ie. validation fails)", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{"targets": [], "default_priority": "high"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PUT", "/sla", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"'targets' must contain at least 1 item"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when the definition is not valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		params := api.SetSLADefinitionParams{
+			Targets:         []api.SLATarget{{Priority: "high", ResponseTime: 3600, ResolutionTime: 28800}},
+			DefaultPriority: "low",
+		}
+
+		slaSvc := new(mocks.SLAServiceMock)
+		slaSvc.On("SetDefinition", ref.ChannelID(channelID), actorUser, params).
+			Return(domain.NewErrorf(domain.ErrorCodeInvalidArgument, "SLA definition does not have target for default priority 'low'"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			SLAService:              slaSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{
+			"targets": [{"priority": "high", "response_time": 3600, "resolution_time": 28800}],
+			"default_priority": "low"
+		}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PUT", "/sla", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		slaSvc.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+
+		expectedJSON := `{"error":"SLA definition does not have target for default priority 'low'"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when body payload is valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		slaSvc := new(mocks.SLAServiceMock)
+		slaSvc.On("SetDefinition", ref.ChannelID(channelID), actorUser, api.SetSLADefinitionParams{
+			Targets: []api.SLATarget{
+				{Priority: "critical", ResponseTime: 900, ResolutionTime: 14400},
+				{Priority: "moderate", ResponseTime: 3600, ResolutionTime: 57600},
+			},
+			DefaultPriority: "moderate",
+			Calendar: api.SLACalendar{
+				Location:     "Europe/Prague",
+				WorkingHours: []api.SLAWorkingHours{{Weekday: "monday", Start: "08:00", End: "16:30"}},
+				Holidays:     []string{"2021-04-05"},
+			},
+		}).Return(nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			SLAService:              slaSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{
+			"targets": [
+				{"priority": "critical", "response_time": 900, "resolution_time": 14400},
+				{"priority": "moderate", "response_time": 3600, "resolution_time": 57600}
+			],
+			"default_priority": "moderate",
+			"calendar": {
+				"location": "Europe/Prague",
+				"working_hours": [{"weekday": "monday", "start": "08:00", "end": "16:30"}],
+				"holidays": ["2021-04-05"]
+			}
+		}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("PUT", "/sla", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		slaSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Status code")
+		assert.Equal(t, "http://service.url/sla", resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestGetSLADefinitionHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+		},
+	}
+	err := actorUser.BasicUser.SetUUID("8183eaca-56c0-41d9-9291-1d295dd53763")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when the channel does not have SLA definition", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		slaSvc := new(mocks.SLAServiceMock)
+		slaSvc.On("GetDefinition", ref.ChannelID(channelID), actorUser).
+			Return(sla.Definition{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			SLAService:              slaSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		req := httptest.NewRequest("GET", "/sla", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		slaSvc.AssertExpectations(t)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+
+		expectedJSON := `{"error":"SLA definition not found"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when the channel has SLA definition", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		def := sla.Definition{
+			Targets: map[sla.Priority]sla.Target{
+				sla.PriorityModerate: {Response: time.Hour, Resolution: 16 * time.Hour},
+				sla.PriorityCritical: {Response: 15 * time.Minute, Resolution: 4 * time.Hour},
+			},
+			DefaultPriority: sla.PriorityModerate,
+			Calendar: sla.Calendar{
+				Location:     "Europe/Prague",
+				WorkingHours: []sla.WorkingHours{{Weekday: time.Monday, Start: "08:00", End: "16:30"}},
+			},
+		}
+		err := def.CreatedUpdated.SetCreated(actorUser.BasicUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+		err = def.CreatedUpdated.SetUpdated(actorUser.BasicUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+
+		slaSvc := new(mocks.SLAServiceMock)
+		slaSvc.On("GetDefinition", ref.ChannelID(channelID), actorUser).
+			Return(def, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			SLAService:              slaSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		req := httptest.NewRequest("GET", "/sla", nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		slaSvc.AssertExpectations(t)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{
+			"targets": [
+				{"priority": "critical", "response_time": 900, "resolution_time": 14400},
+				{"priority": "moderate", "response_time": 3600, "resolution_time": 57600}
+			],
+			"default_priority": "moderate",
+			"calendar": {
+				"location": "Europe/Prague",
+				"working_hours": [{"weekday": "monday", "start": "08:00", "end": "16:30"}],
+				"holidays": []
+			},
+			"created_by": "8183eaca-56c0-41d9-9291-1d295dd53763",
+			"created_at": "2021-04-01T12:34:56+02:00",
+			"updated_by": "8183eaca-56c0-41d9-9291-1d295dd53763",
+			"updated_at": "2021-04-01T12:34:56+02:00",
+			"_links": {
+				"self": {"href": "http://service.url/sla"}
+			}
+		}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+}
@@ -0,0 +1,263 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/presenters/hypermedia"
+	"github.com/julienschmidt/httprouter"
+)
+
+func (s Server) registerWebhookRoutes() {
+	s.router.POST("/webhooks", s.CreateWebhook())
+	s.router.GET("/webhooks/:id", s.GetWebhook())
+	s.router.GET("/webhooks", s.ListWebhooks())
+	s.router.DELETE("/webhooks/:id", s.DeleteWebhook())
+	s.router.GET("/webhooks/:id/deliveries", s.ListWebhookDeliveries())
+}
+
+// swagger:route POST /webhooks webhooks CreateWebhook
+// Subscribes the URL to the domain events of the channel
+// responses:
+//
+//	201: webhookCreatedResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+
+// CreateWebhook returns handler for creating single webhook subscription
+func (s *Server) CreateWebhook() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		webhookPayload, err := s.inputPayloadConverters.webhook.WebhookCreateParamsFromBody(r)
+		if err != nil {
+			s.logger.Warnw("CreateWebhook handler failed", "error", err)
+			s.presenters.webhook.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("CreateWebhook handler failed", "error", err)
+			s.presenters.webhook.RenderError(w, "", err)
+			return
+		}
+
+		newID, err := s.webhookService.CreateSubscription(r.Context(), channelID, actorUser, webhookPayload)
+		if err != nil {
+			s.logger.Errorw("CreateWebhook handler failed", "error", err)
+			s.presenters.webhook.RenderError(w, "", err)
+			return
+		}
+
+		s.presenters.webhook.RenderCreatedHeader(w, listWebhooksRoute, newID)
+	}
+}
+
+// swagger:route GET /webhooks/{uuid} webhooks GetWebhook
+// Returns a single webhook subscription from the repository
+// responses:
+//
+//	200: webhookResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+const getWebhookRoute = "/webhooks/{uuid}"
+
+// GetWebhook returns handler for getting single webhook subscription
+func (s *Server) GetWebhook() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		if id == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("GetWebhook handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("GetWebhook handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		sub, err := s.webhookService.GetSubscription(r.Context(), channelID, actorUser, ref.UUID(id))
+		if err != nil {
+			s.logger.Errorw("GetWebhook handler failed", "ID", id, "error", err)
+			s.presenters.base.RenderError(w, "webhook not found", err)
+			return
+		}
+
+		hypermediaMapper := NewWebhookHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.webhook.RenderWebhook(w, sub, hypermediaMapper)
+	}
+}
+
+// swagger:route GET /webhooks webhooks ListWebhooks
+// Returns a list of webhook subscriptions of the channel
+// responses:
+//
+//	200: webhookListResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+const listWebhooksRoute = "/webhooks"
+
+// ListWebhooks returns handler for listing webhook subscriptions
+func (s *Server) ListWebhooks() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ListWebhooks handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		paginationParams, err := s.PaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		list, err := s.webhookService.ListSubscriptions(r.Context(), channelID, actorUser, paginationParams)
+		if err != nil {
+			s.logger.Errorw("ListWebhooks handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		hypermediaMapper := NewWebhookHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.webhook.RenderWebhookList(w, list, hypermediaMapper)
+	}
+}
+
+// swagger:route DELETE /webhooks/{uuid} webhooks DeleteWebhook
+// Deletes the webhook subscription together with its delivery log, pending deliveries are not attempted anymore
+// responses:
+//
+//	204: webhookNoContentResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+
+// DeleteWebhook returns handler for deleting single webhook subscription
+func (s *Server) DeleteWebhook() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		if id == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("DeleteWebhook handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("DeleteWebhook handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		err = s.webhookService.DeleteSubscription(r.Context(), channelID, actorUser, ref.UUID(id))
+		if err != nil {
+			s.logger.Errorw("DeleteWebhook handler failed", "ID", id, "error", err)
+			s.presenters.base.RenderError(w, "webhook not found", err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// swagger:route GET /webhooks/{uuid}/deliveries webhooks ListWebhookDeliveries
+// Returns the delivery log of the webhook subscription, the oldest deliveries first
+// responses:
+//
+//	200: webhookDeliveryListResponse
+//	400: errorResponse400
+//	401: errorResponse401
+//	403: errorResponse403
+//	404: errorResponse404
+
+// ListWebhookDeliveries returns handler for listing deliveries of the webhook subscription
+func (s *Server) ListWebhookDeliveries() func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		if id == "" {
+			err := presenters.NewErrorf(http.StatusBadRequest, "malformed URL: missing resource ID param")
+			s.logger.Errorw("ListWebhookDeliveries handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		channelID, err := s.assertChannelID(w, r)
+		if err != nil {
+			return
+		}
+
+		actorUser, err := s.actorFromRequest(r)
+		if err != nil {
+			s.logger.Errorw("ListWebhookDeliveries handler failed", "error", err)
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		paginationParams, err := s.PaginationParams(r, actorUser)
+		if err != nil {
+			s.presenters.base.RenderError(w, "", err)
+			return
+		}
+
+		list, err := s.webhookService.ListDeliveries(r.Context(), channelID, actorUser, ref.UUID(id), paginationParams)
+		if err != nil {
+			s.logger.Errorw("ListWebhookDeliveries handler failed", "ID", id, "error", err)
+			s.presenters.base.RenderError(w, "webhook not found", err)
+			return
+		}
+
+		hypermediaMapper := NewWebhookHypermediaMapper(s.ExternalLocationAddress, r.URL, actorUser)
+		s.presenters.webhook.RenderWebhookDeliveryList(w, ref.UUID(id), list, hypermediaMapper)
+	}
+}
+
+// WebhookHypermediaMapper implements hypermedia mapping functionality for webhook subscription resource
+type WebhookHypermediaMapper struct {
+	*hypermedia.BaseHypermediaMapper
+}
+
+// NewWebhookHypermediaMapper returns new hypermedia mapper for webhook subscription resource
+func NewWebhookHypermediaMapper(serverAddr string, currentURL *url.URL, actor actor.Actor) WebhookHypermediaMapper {
+	return WebhookHypermediaMapper{
+		BaseHypermediaMapper: hypermedia.NewBaseHypermedia(serverAddr, currentURL, actor),
+	}
+}
+
+// RoutesToHypermediaActionLinks maps domain object actions to hypermedia action links (webhook subscription does not have any actions)
+func (h WebhookHypermediaMapper) RoutesToHypermediaActionLinks() hypermedia.ActionLinks {
+	return hypermedia.NewActionLinks(h.BaseHypermediaMapper)
+}
@@ -0,0 +1,553 @@
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhookHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+		},
+	}
+	err := actorUser.BasicUser.SetUUID("8183eaca-56c0-41d9-9291-1d295dd53763")
+	require.NoError(t, err)
+
+	t.Parallel()
+
+	t.Run("when body payload is not valid (ie. validation fails)", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{"url": "https://example.com/hooks", "secret": "too short"}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/webhooks", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"'secret' must be at least 16 characters in length"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when body payload is valid", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		webhookSvc := new(mocks.WebhookServiceMock)
+		webhookSvc.On("CreateSubscription", ref.ChannelID(channelID), actorUser, api.CreateWebhookParams{
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{"IncidentStateChanged"},
+		}).Return(ref.UUID("38316161-3035-4864-ad30-6231392d3433"), nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			WebhookService:          webhookSvc,
+			ExternalLocationAddress: "http://service.url",
+			ExternalUserService:     us,
+		})
+
+		payload := []byte(`{
+			"url": "https://example.com/hooks",
+			"secret": "0123456789abcdef",
+			"event_types": ["IncidentStateChanged"]
+		}`)
+
+		body := bytes.NewReader(payload)
+		req := httptest.NewRequest("POST", "/webhooks", body)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		us.AssertExpectations(t)
+		webhookSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Status code")
+		expectedLocation := "http://service.url/webhooks/38316161-3035-4864-ad30-6231392d3433"
+		assert.Equal(t, expectedLocation, resp.Header.Get("Location"), "Location header")
+	})
+}
+
+func TestGetWebhookHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+	uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+	createdByUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	err := createdByUser.SetUUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{
+		BasicUser: createdByUser,
+	}
+
+	t.Parallel()
+
+	t.Run("when webhook does not exist", func(t *testing.T) {
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		webhookSvc := new(mocks.WebhookServiceMock)
+		webhookSvc.On("GetSubscription", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(webhook.Subscription{}, domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"))
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			WebhookService:          webhookSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/webhooks/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		webhookSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{"error":"webhook not found"}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+
+	t.Run("when webhook exists", func(t *testing.T) {
+		sub := webhook.Subscription{
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []event.Type{event.TypeIncidentStateChanged},
+		}
+		err := sub.SetUUID(ref.UUID(uuid))
+		require.NoError(t, err)
+		err = sub.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+		err = sub.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
+		require.NoError(t, err)
+
+		us := new(mocks.ExternalUserServiceMock)
+		us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+			Return(actorUser, nil)
+
+		webhookSvc := new(mocks.WebhookServiceMock)
+		webhookSvc.On("GetSubscription", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+			Return(sub, nil)
+
+		server := NewServer(Config{
+			Addr:                    "service.url",
+			Logger:                  logger,
+			ExternalUserService:     us,
+			WebhookService:          webhookSvc,
+			ExternalLocationAddress: "http://service.url",
+		})
+
+		req := httptest.NewRequest("GET", "/webhooks/"+uuid, nil)
+		req.Header.Set("channel-id", channelID)
+		req.Header.Set("authorization", bearerToken)
+
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		resp := w.Result()
+
+		defer func() { _ = resp.Body.Close() }()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response: %v", err)
+		}
+
+		us.AssertExpectations(t)
+		webhookSvc.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+		expectedJSON := `{
+			"uuid":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
+			"url":"https://example.com/hooks",
+			"event_types":["IncidentStateChanged"],
+			"created_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+			"created_at":"2021-04-01T12:34:56+02:00",
+			"updated_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+			"updated_at":"2021-04-01T12:34:56+02:00",
+			"_links":{
+				"self":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4"},
+				"deliveries":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4/deliveries"}
+			}
+		}`
+		assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+	})
+}
+
+func TestListWebhooksHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+
+	createdByUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	err := createdByUser.SetUUID("0ac5ebce-17e7-4edc-9552-fefe16e127fb")
+	require.NoError(t, err)
+
+	actorUser := actor.Actor{
+		BasicUser: createdByUser,
+	}
+
+	t.Parallel()
+
+	sub1 := webhook.Subscription{
+		URL:    "https://example.com/hooks",
+		Secret: "0123456789abcdef",
+	}
+	err = sub1.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+	require.NoError(t, err)
+	err = sub1.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+	require.NoError(t, err)
+	err = sub1.CreatedUpdated.SetUpdated(createdByUser, "2021-04-01T12:34:56+02:00")
+	require.NoError(t, err)
+
+	sub2 := webhook.Subscription{
+		URL:        "https://other.example.com/hooks",
+		Secret:     "fedcba9876543210",
+		EventTypes: []event.Type{event.TypeIncidentCancelled, event.TypeIncidentStateChanged},
+	}
+	err = sub2.SetUUID("cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0")
+	require.NoError(t, err)
+	err = sub2.CreatedUpdated.SetCreated(createdByUser, "2021-04-01T12:34:56+02:00")
+	require.NoError(t, err)
+	err = sub2.CreatedUpdated.SetUpdated(createdByUser, "2021-04-02T09:10:32+02:00")
+	require.NoError(t, err)
+
+	us := new(mocks.ExternalUserServiceMock)
+	us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+		Return(actorUser, nil)
+
+	webhookSvc := new(mocks.WebhookServiceMock)
+	result := repository.WebhookSubscriptionList{
+		Result: []webhook.Subscription{sub1, sub2},
+		Pagination: &repository.Pagination{
+			Total: 2,
+			Size:  2,
+			Page:  1,
+			First: 1,
+			Last:  1,
+		},
+	}
+	webhookSvc.On("ListSubscriptions", ref.ChannelID(channelID), actorUser, mock.AnythingOfType("*converters.paginationParams")).
+		Return(result, nil)
+
+	server := NewServer(Config{
+		Addr:                    "service.url",
+		Logger:                  logger,
+		ExternalUserService:     us,
+		WebhookService:          webhookSvc,
+		ExternalLocationAddress: "http://service.url",
+	})
+
+	req := httptest.NewRequest("GET", "/webhooks", nil)
+	req.Header.Set("channel-id", channelID)
+	req.Header.Set("authorization", bearerToken)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	resp := w.Result()
+
+	defer func() { _ = resp.Body.Close() }()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read response: %v", err)
+	}
+
+	us.AssertExpectations(t)
+	webhookSvc.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+	expectedJSON := `{
+		"total":2,
+		"size":2,
+		"page":1,
+		"_embedded":[
+			{
+				"uuid":"1adb8393-cff0-489c-a82f-3fe5d15708d4",
+				"url":"https://example.com/hooks",
+				"created_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"created_at":"2021-04-01T12:34:56+02:00",
+				"updated_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"updated_at":"2021-04-01T12:34:56+02:00",
+				"_links":{
+					"self":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4"},
+					"deliveries":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4/deliveries"}
+				}
+			},
+			{
+				"uuid":"cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+				"url":"https://other.example.com/hooks",
+				"event_types":["IncidentCancelled","IncidentStateChanged"],
+				"created_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"created_at":"2021-04-01T12:34:56+02:00",
+				"updated_by":"0ac5ebce-17e7-4edc-9552-fefe16e127fb",
+				"updated_at":"2021-04-02T09:10:32+02:00",
+				"_links":{
+					"self":{"href":"http://service.url/webhooks/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0"},
+					"deliveries":{"href":"http://service.url/webhooks/cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0/deliveries"}
+				}
+			}
+		],
+		"_links":{
+			"self":{"href":"http://service.url/webhooks"},
+			"first":{"href":"http://service.url/webhooks"},
+			"last":{"href":"http://service.url/webhooks"}
+		}
+	}`
+	assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+}
+
+func TestDeleteWebhookHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+	uuid := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+			Name:             "Admin",
+			Surname:          "User",
+		},
+	}
+
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		svcErr     error
+		statusCode int
+	}{
+		{"when webhook can be deleted", nil, http.StatusNoContent},
+		{"when webhook does not exist", domain.NewErrorf(domain.ErrorCodeNotFound, "error from repository"), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			us := new(mocks.ExternalUserServiceMock)
+			us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+				Return(actorUser, nil)
+
+			webhookSvc := new(mocks.WebhookServiceMock)
+			webhookSvc.On("DeleteSubscription", ref.ChannelID(channelID), actorUser, ref.UUID(uuid)).
+				Return(tt.svcErr)
+
+			server := NewServer(Config{
+				Addr:                    "service.url",
+				Logger:                  logger,
+				ExternalUserService:     us,
+				WebhookService:          webhookSvc,
+				ExternalLocationAddress: "http://service.url",
+			})
+
+			req := httptest.NewRequest("DELETE", "/webhooks/"+uuid, nil)
+			req.Header.Set("channel-id", channelID)
+			req.Header.Set("authorization", bearerToken)
+
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, req)
+			resp := w.Result()
+
+			us.AssertExpectations(t)
+			webhookSvc.AssertExpectations(t)
+
+			assert.Equal(t, tt.statusCode, resp.StatusCode, "Status code")
+		})
+	}
+}
+
+func TestListWebhookDeliveriesHandler(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	defer func() { _ = logger.Sync() }()
+
+	channelID := "e27ddcd0-0e1f-4bc5-93df-f6f04155beec"
+	bearerToken := "some valid Bearer token"
+	subID := "1adb8393-cff0-489c-a82f-3fe5d15708d4"
+
+	actorUser := actor.Actor{
+		BasicUser: user.BasicUser{
+			ExternalUserUUID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0",
+			Name:             "Admin",
+			Surname:          "User",
+		},
+	}
+
+	t.Parallel()
+
+	delivery := webhook.Delivery{
+		SubscriptionID:     ref.UUID(subID),
+		EventID:            "f49d5fd5-8da4-4779-b5ba-32e78aa2c444",
+		EventType:          event.TypeIncidentCancelled,
+		Payload:            []byte(`{"id":"f49d5fd5-8da4-4779-b5ba-32e78aa2c444","type":"IncidentCancelled"}`),
+		Status:             webhook.DeliveryStatusPending,
+		Attempts:           1,
+		NextAttemptAt:      "2021-04-01T12:35:56+02:00",
+		LastAttemptAt:      "2021-04-01T12:34:56+02:00",
+		LastResponseStatus: http.StatusServiceUnavailable,
+		LastError:          "unexpected response status 503",
+		CreatedAt:          "2021-04-01T12:34:50+02:00",
+	}
+	err := delivery.SetUUID("00271cb4-3716-4203-9124-1d2f515ae0b2")
+	require.NoError(t, err)
+
+	us := new(mocks.ExternalUserServiceMock)
+	us.On("ActorFromRequest", bearerToken, ref.ChannelID(channelID), "").
+		Return(actorUser, nil)
+
+	webhookSvc := new(mocks.WebhookServiceMock)
+	result := repository.WebhookDeliveryList{
+		Result: []webhook.Delivery{delivery},
+		Pagination: &repository.Pagination{
+			Total: 1,
+			Size:  1,
+			Page:  1,
+			First: 1,
+			Last:  1,
+		},
+	}
+	webhookSvc.On("ListDeliveries", ref.ChannelID(channelID), actorUser, ref.UUID(subID), mock.AnythingOfType("*converters.paginationParams")).
+		Return(result, nil)
+
+	server := NewServer(Config{
+		Addr:                    "service.url",
+		Logger:                  logger,
+		ExternalUserService:     us,
+		WebhookService:          webhookSvc,
+		ExternalLocationAddress: "http://service.url",
+	})
+
+	req := httptest.NewRequest("GET", "/webhooks/"+subID+"/deliveries", nil)
+	req.Header.Set("channel-id", channelID)
+	req.Header.Set("authorization", bearerToken)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	resp := w.Result()
+
+	defer func() { _ = resp.Body.Close() }()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read response: %v", err)
+	}
+
+	us.AssertExpectations(t)
+	webhookSvc.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), "Content-Type header")
+
+	expectedJSON := `{
+		"total":1,
+		"size":1,
+		"page":1,
+		"_embedded":[
+			{
+				"uuid":"00271cb4-3716-4203-9124-1d2f515ae0b2",
+				"event_id":"f49d5fd5-8da4-4779-b5ba-32e78aa2c444",
+				"event_type":"IncidentCancelled",
+				"status":"Pending",
+				"attempts":1,
+				"next_attempt_at":"2021-04-01T12:35:56+02:00",
+				"last_attempt_at":"2021-04-01T12:34:56+02:00",
+				"last_response_status":503,
+				"last_error":"unexpected response status 503",
+				"payload":{"id":"f49d5fd5-8da4-4779-b5ba-32e78aa2c444","type":"IncidentCancelled"},
+				"created_at":"2021-04-01T12:34:50+02:00",
+				"_links":{
+					"webhook":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4"}
+				}
+			}
+		],
+		"_links":{
+			"self":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4/deliveries"},
+			"first":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4/deliveries"},
+			"last":{"href":"http://service.url/webhooks/1adb8393-cff0-489c-a82f-3fe5d15708d4/deliveries"}
+		}
+	}`
+	assert.JSONEq(t, expectedJSON, string(b), "response does not match")
+}
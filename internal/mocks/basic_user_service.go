@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	converters "github.com/crywolf/itsm-ticket-management-service/internal/http/rest/input_converters"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// BasicUserServiceMock is a basic user service mock
+type BasicUserServiceMock struct {
+	mock.Mock
+}
+
+// GetBasicUser mock
+func (s *BasicUserServiceMock) GetBasicUser(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (user.BasicUser, error) {
+	args := s.Called(channelID, actor, ID)
+	return args.Get(0).(user.BasicUser), args.Error(1)
+}
+
+// ListBasicUsers mock
+func (s *BasicUserServiceMock) ListBasicUsers(_ context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams converters.PaginationParams) (repository.BasicUserList, error) {
+	args := s.Called(channelID, actor, paginationParams)
+	return args.Get(0).(repository.BasicUserList), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,3 +21,9 @@ func (p *PaginationParamsMock) ItemsPerPage() uint {
 	args := p.Called()
 	return args.Get(0).(uint)
 }
+
+// CursorPage mock
+func (p *PaginationParamsMock) CursorPage() *repository.CursorPage {
+	args := p.Called()
+	return args.Get(0).(*repository.CursorPage)
+}
@@ -3,9 +3,13 @@ package mocks
 import (
 	"context"
 
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	fieldengineersvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/service"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -14,8 +18,56 @@ type FieldEngineerServiceMock struct {
 	mock.Mock
 }
 
+// CreateFieldEngineer mock
+func (s *FieldEngineerServiceMock) CreateFieldEngineer(_ context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateFieldEngineerParams) (ref.UUID, error) {
+	args := s.Called(channelID, actor, params)
+	return args.Get(0).(ref.UUID), args.Error(1)
+}
+
 // GetFieldEngineer mock
 func (s *FieldEngineerServiceMock) GetFieldEngineer(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
 	args := s.Called(channelID, actor, ID)
 	return args.Get(0).(fieldengineer.FieldEngineer), args.Error(1)
 }
+
+// ListFieldEngineers mock
+func (s *FieldEngineerServiceMock) ListFieldEngineers(_ context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams fieldengineersvc.PaginationParams) (repository.FieldEngineerList, error) {
+	args := s.Called(channelID, actor, paginationParams)
+	return args.Get(0).(repository.FieldEngineerList), args.Error(1)
+}
+
+// Deactivate mock
+func (s *FieldEngineerServiceMock) Deactivate(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) error {
+	args := s.Called(channelID, actor, ID)
+	return args.Error(0)
+}
+
+// StartTravelling mock
+func (s *FieldEngineerServiceMock) StartTravelling(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, ID)
+	return args.Error(0)
+}
+
+// StartTravellingBack mock
+func (s *FieldEngineerServiceMock) StartTravellingBack(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, ID)
+	return args.Error(0)
+}
+
+// StartBreak mock
+func (s *FieldEngineerServiceMock) StartBreak(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, ID)
+	return args.Error(0)
+}
+
+// EndBreak mock
+func (s *FieldEngineerServiceMock) EndBreak(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, ID)
+	return args.Error(0)
+}
+
+// CloseTimeSession mock
+func (s *FieldEngineerServiceMock) CloseTimeSession(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID, params api.FieldEngineerCloseTimeSessionParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, ID, params)
+	return args.Error(0)
+}
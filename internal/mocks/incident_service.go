@@ -38,11 +38,23 @@ func (s *IncidentServiceMock) GetIncident(_ context.Context, channelID ref.Chann
 }
 
 // ListIncidents mock
-func (s *IncidentServiceMock) ListIncidents(_ context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams converters.PaginationParams) (repository.IncidentList, error) {
-	args := s.Called(channelID, actor, paginationParams)
+func (s *IncidentServiceMock) ListIncidents(_ context.Context, channelID ref.ChannelID, actor actor.Actor, filter repository.IncidentFilter, paginationParams converters.PaginationParams) (repository.IncidentList, error) {
+	args := s.Called(channelID, actor, filter, paginationParams)
 	return args.Get(0).(repository.IncidentList), args.Error(1)
 }
 
+// Accept mock
+func (s *IncidentServiceMock) Accept(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID)
+	return args.Error(0)
+}
+
+// Reject mock
+func (s *IncidentServiceMock) Reject(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentRejectParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, params)
+	return args.Error(0)
+}
+
 // StartWorking mock
 func (s *IncidentServiceMock) StartWorking(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentStartWorkingParams, _ domain.Clock) error {
 	args := s.Called(channelID, actor, incID, params)
@@ -55,8 +67,98 @@ func (s *IncidentServiceMock) StopWorking(_ context.Context, channelID ref.Chann
 	return args.Error(0)
 }
 
+// PauseWorking mock
+func (s *IncidentServiceMock) PauseWorking(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentPauseWorkingParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, params)
+	return args.Error(0)
+}
+
+// ResumeWorking mock
+func (s *IncidentServiceMock) ResumeWorking(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID)
+	return args.Error(0)
+}
+
+// PutOnHold mock
+func (s *IncidentServiceMock) PutOnHold(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentPutOnHoldParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, params)
+	return args.Error(0)
+}
+
+// Resume mock
+func (s *IncidentServiceMock) Resume(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID)
+	return args.Error(0)
+}
+
+// Resolve mock
+func (s *IncidentServiceMock) Resolve(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentResolveParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, params)
+	return args.Error(0)
+}
+
+// Close mock
+func (s *IncidentServiceMock) Close(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID)
+	return args.Error(0)
+}
+
+// Reopen mock
+func (s *IncidentServiceMock) Reopen(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, params api.IncidentReopenParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, params)
+	return args.Error(0)
+}
+
+// AutoCloseResolved mock
+func (s *IncidentServiceMock) AutoCloseResolved(_ context.Context, limit uint) (int, error) {
+	args := s.Called(limit)
+	return args.Int(0), args.Error(1)
+}
+
 // GetIncidentTimelog mock
 func (s *IncidentServiceMock) GetIncidentTimelog(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
 	args := s.Called(channelID, actor, incID, timelogID)
 	return args.Get(0).(timelog.Timelog), args.Error(1)
 }
+
+// ListIncidentTimelogs mock
+func (s *IncidentServiceMock) ListIncidentTimelogs(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, paginationParams converters.PaginationParams) (repository.TimelogList, error) {
+	args := s.Called(channelID, actor, incID, paginationParams)
+	return args.Get(0).(repository.TimelogList), args.Error(1)
+}
+
+// ProposeTimelogCorrection mock
+func (s *IncidentServiceMock) ProposeTimelogCorrection(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, params api.ProposeTimelogCorrectionParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, timelogID, params)
+	return args.Error(0)
+}
+
+// ApproveTimelogCorrection mock
+func (s *IncidentServiceMock) ApproveTimelogCorrection(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, timelogID)
+	return args.Error(0)
+}
+
+// RejectTimelogCorrection mock
+func (s *IncidentServiceMock) RejectTimelogCorrection(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, timelogID ref.UUID, params api.RejectTimelogCorrectionParams, _ domain.Clock) error {
+	args := s.Called(channelID, actor, incID, timelogID, params)
+	return args.Error(0)
+}
+
+// ListIncidentHistory mock
+func (s *IncidentServiceMock) ListIncidentHistory(_ context.Context, channelID ref.ChannelID, actor actor.Actor, incID ref.UUID, paginationParams converters.PaginationParams) (repository.HistoryList, error) {
+	args := s.Called(channelID, actor, incID, paginationParams)
+	return args.Get(0).(repository.HistoryList), args.Error(1)
+}
+
+// GetPriorityMatrix mock
+func (s *IncidentServiceMock) GetPriorityMatrix(_ context.Context, channelID ref.ChannelID, actor actor.Actor) (incident.PriorityMatrix, error) {
+	args := s.Called(channelID, actor)
+	return args.Get(0).(incident.PriorityMatrix), args.Error(1)
+}
+
+// SetPriorityMatrix mock
+func (s *IncidentServiceMock) SetPriorityMatrix(_ context.Context, channelID ref.ChannelID, actor actor.Actor, params api.SetPriorityMatrixParams) error {
+	args := s.Called(channelID, actor, params)
+	return args.Error(0)
+}
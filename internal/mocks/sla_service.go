@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/stretchr/testify/mock"
+)
+
+// SLAServiceMock is an SLA service mock
+type SLAServiceMock struct {
+	mock.Mock
+}
+
+// SetDefinition mock
+func (s *SLAServiceMock) SetDefinition(_ context.Context, channelID ref.ChannelID, actor actor.Actor, params api.SetSLADefinitionParams) error {
+	args := s.Called(channelID, actor, params)
+	return args.Error(0)
+}
+
+// GetDefinition mock
+func (s *SLAServiceMock) GetDefinition(_ context.Context, channelID ref.ChannelID, actor actor.Actor) (sla.Definition, error) {
+	args := s.Called(channelID, actor)
+	return args.Get(0).(sla.Definition), args.Error(1)
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	webhooksvc "github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook/service"
+	"github.com/crywolf/itsm-ticket-management-service/internal/http/rest/api"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookServiceMock is a webhook service mock
+type WebhookServiceMock struct {
+	mock.Mock
+}
+
+// CreateSubscription mock
+func (s *WebhookServiceMock) CreateSubscription(_ context.Context, channelID ref.ChannelID, actor actor.Actor, params api.CreateWebhookParams) (ref.UUID, error) {
+	args := s.Called(channelID, actor, params)
+	return args.Get(0).(ref.UUID), args.Error(1)
+}
+
+// GetSubscription mock
+func (s *WebhookServiceMock) GetSubscription(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) (webhook.Subscription, error) {
+	args := s.Called(channelID, actor, ID)
+	return args.Get(0).(webhook.Subscription), args.Error(1)
+}
+
+// ListSubscriptions mock
+func (s *WebhookServiceMock) ListSubscriptions(_ context.Context, channelID ref.ChannelID, actor actor.Actor, paginationParams webhooksvc.PaginationParams) (repository.WebhookSubscriptionList, error) {
+	args := s.Called(channelID, actor, paginationParams)
+	return args.Get(0).(repository.WebhookSubscriptionList), args.Error(1)
+}
+
+// DeleteSubscription mock
+func (s *WebhookServiceMock) DeleteSubscription(_ context.Context, channelID ref.ChannelID, actor actor.Actor, ID ref.UUID) error {
+	args := s.Called(channelID, actor, ID)
+	return args.Error(0)
+}
+
+// ListDeliveries mock
+func (s *WebhookServiceMock) ListDeliveries(_ context.Context, channelID ref.ChannelID, actor actor.Actor, subscriptionID ref.UUID, paginationParams webhooksvc.PaginationParams) (repository.WebhookDeliveryList, error) {
+	args := s.Called(channelID, actor, subscriptionID, paginationParams)
+	return args.Get(0).(repository.WebhookDeliveryList), args.Error(1)
+}
+
+// Publish mock
+func (s *WebhookServiceMock) Publish(_ context.Context, msg repository.OutboxMessage) error {
+	args := s.Called(msg)
+	return args.Error(0)
+}
+
+// DeliverDue mock
+func (s *WebhookServiceMock) DeliverDue(_ context.Context) (int, error) {
+	args := s.Called()
+	return args.Int(0), args.Error(1)
+}
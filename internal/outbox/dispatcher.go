@@ -0,0 +1,76 @@
+// Package outbox delivers the domain events stored in the outbox to other systems
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Dispatcher periodically publishes the pending outbox messages. Message is removed from the outbox only after
+// it was published successfully, so every message is delivered at least once.
+type Dispatcher struct {
+	repository repository.OutboxRepository
+	publisher  Publisher
+	logger     *zap.SugaredLogger
+	interval   time.Duration
+	batchSize  uint
+}
+
+// NewDispatcher returns new dispatcher publishing up to batchSize messages every interval
+func NewDispatcher(repo repository.OutboxRepository, publisher Publisher, logger *zap.SugaredLogger, interval time.Duration, batchSize uint) *Dispatcher {
+	return &Dispatcher{
+		repository: repo,
+		publisher:  publisher,
+		logger:     logger,
+		interval:   interval,
+		batchSize:  batchSize,
+	}
+}
+
+// Run dispatches the pending messages periodically until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := d.DispatchPending(ctx)
+				if err != nil {
+					d.logger.Errorw("could not dispatch outbox messages", "error", err)
+					break
+				}
+				if uint(n) < d.batchSize { // outbox is drained
+					break
+				}
+			}
+		}
+	}
+}
+
+// DispatchPending publishes one batch of the pending messages in the order they were stored and returns the number of published messages.
+// It stops at the first message that could not be published, so the order is kept and the message is published again next time.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	messages, err := d.repository.ListPendingMessages(ctx, d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range messages {
+		if err := d.publisher.Publish(ctx, msg); err != nil {
+			return i, err
+		}
+
+		if err := d.repository.MarkMessageDelivered(ctx, msg.ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(messages), nil
+}
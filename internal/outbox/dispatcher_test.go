@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outboxRepositoryStub keeps the pending messages in a slice
+type outboxRepositoryStub struct {
+	mu      sync.Mutex
+	pending []repository.OutboxMessage
+}
+
+func (r *outboxRepositoryStub) ListPendingMessages(_ context.Context, limit uint) ([]repository.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if uint(len(r.pending)) < limit {
+		limit = uint(len(r.pending))
+	}
+	return append([]repository.OutboxMessage(nil), r.pending[:limit]...), nil
+}
+
+func (r *outboxRepositoryStub) MarkMessageDelivered(_ context.Context, ID ref.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, msg := range r.pending {
+		if msg.ID == ID {
+			r.pending = append(r.pending[:i:i], r.pending[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("message not found")
+}
+
+func (r *outboxRepositoryStub) Pending() []repository.OutboxMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]repository.OutboxMessage(nil), r.pending...)
+}
+
+func newOutboxRepositoryStub() *outboxRepositoryStub {
+	return &outboxRepositoryStub{
+		pending: []repository.OutboxMessage{
+			{ID: "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", ChannelID: "e27ddcd0-0e1f-4bc5-93df-f6f04155beec", EventType: event.TypeIncidentWorkStarted},
+			{ID: "0ac5ebce-17e7-4edc-9552-fefe16e127fb", ChannelID: "e27ddcd0-0e1f-4bc5-93df-f6f04155beec", EventType: event.TypeTimeSessionStateChanged},
+			{ID: "e1c1c5a8-5d3d-4f4b-8a0a-7a8f7c7d9a01", ChannelID: "e27ddcd0-0e1f-4bc5-93df-f6f04155beec", EventType: event.TypeIncidentWorkStopped},
+		},
+	}
+}
+
+func TestDispatcher_DispatchPending(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+	ctx := context.Background()
+
+	t.Run("publishes pending messages in order and removes them from outbox", func(t *testing.T) {
+		repo := newOutboxRepositoryStub()
+		expected := repo.Pending()
+		publisher := &MemoryPublisher{}
+
+		d := NewDispatcher(repo, publisher, logger, time.Second, 2)
+
+		n, err := d.DispatchPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, expected[:2], publisher.Messages())
+		assert.Equal(t, expected[2:], repo.Pending())
+
+		n, err = d.DispatchPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, expected, publisher.Messages())
+		assert.Empty(t, repo.Pending())
+
+		n, err = d.DispatchPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("when publishing fails, messages stay in outbox and are published again", func(t *testing.T) {
+		repo := newOutboxRepositoryStub()
+		expected := repo.Pending()
+		publisher := &MemoryPublisher{}
+		errPublish := errors.New("broker is not available")
+		publisher.FailWith(errPublish)
+
+		d := NewDispatcher(repo, publisher, logger, time.Second, 10)
+
+		n, err := d.DispatchPending(ctx)
+		require.ErrorIs(t, err, errPublish)
+		assert.Equal(t, 0, n)
+		assert.Empty(t, publisher.Messages())
+		assert.Equal(t, expected, repo.Pending())
+
+		publisher.FailWith(nil)
+
+		n, err = d.DispatchPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, expected, publisher.Messages())
+		assert.Empty(t, repo.Pending())
+	})
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	logger, _ := testutils.NewTestLogger()
+
+	repo := newOutboxRepositoryStub()
+	expected := repo.Pending()
+	publisher := &MemoryPublisher{}
+
+	// batch is smaller than the number of pending messages, all of them are dispatched in one run anyway
+	d := NewDispatcher(repo, publisher, logger, 10*time.Millisecond, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(repo.Pending()) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Equal(t, expected, publisher.Messages())
+}
@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Publisher delivers the outbox messages to other systems.
+// Messages can be published more than once, so the receivers must be able to handle duplicates (ie. by message ID).
+type Publisher interface {
+	// Publish delivers the message, the message stays in the outbox and is published again if error is returned
+	Publish(ctx context.Context, msg repository.OutboxMessage) error
+}
+
+// LogPublisher writes the messages to the log
+type LogPublisher struct {
+	logger *zap.SugaredLogger
+}
+
+// NewLogPublisher returns new publisher writing to the logger
+func NewLogPublisher(logger *zap.SugaredLogger) *LogPublisher {
+	return &LogPublisher{
+		logger: logger,
+	}
+}
+
+// Publish writes the message to the log
+func (p *LogPublisher) Publish(_ context.Context, msg repository.OutboxMessage) error {
+	p.logger.Infow("domain event published",
+		"id", msg.ID,
+		"channel_id", msg.ChannelID,
+		"event_type", msg.EventType,
+		"aggregate_id", msg.AggregateID,
+		"occurred_at", msg.OccurredAt,
+		"payload", string(msg.Payload),
+	)
+
+	return nil
+}
+
+// MemoryPublisher keeps the published messages in memory, it is intended for tests
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []repository.OutboxMessage
+	err      error
+}
+
+// Publish appends the message to the published messages or returns the error set by FailWith
+func (p *MemoryPublisher) Publish(_ context.Context, msg repository.OutboxMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages returns the published messages in the order they were published
+func (p *MemoryPublisher) Messages() []repository.OutboxMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]repository.OutboxMessage(nil), p.messages...)
+}
+
+// FailWith makes Publish return err, nil error makes publishing succeed again
+func (p *MemoryPublisher) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
@@ -0,0 +1,16 @@
+package boltdb
+
+// BasicUser stored in bolt database
+type BasicUser struct {
+	ID string `json:"id"`
+
+	ExternalUserUUID string `json:"external_user_uuid"`
+
+	Name string `json:"name"`
+
+	Surname string `json:"surname"`
+
+	OrgDisplayName string `json:"org_display_name"`
+
+	OrgName string `json:"org_name"`
+}
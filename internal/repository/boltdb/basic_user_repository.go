@@ -0,0 +1,173 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BasicUserRepositoryBolt keeps data in bolt database
+type BasicUserRepositoryBolt struct {
+	store txStore
+	Rand  io.Reader
+}
+
+// NewBasicUserRepositoryBolt returns new initialized repository
+func NewBasicUserRepositoryBolt(store *Store) *BasicUserRepositoryBolt {
+	return &BasicUserRepositoryBolt{
+		store: txStore{Store: store},
+	}
+}
+
+// AddBasicUser adds the Basic User to the repository
+func (r *BasicUserRepositoryBolt) AddBasicUser(_ context.Context, channelID ref.ChannelID, user user.BasicUser) (ref.UUID, error) {
+	id, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	err = r.store.update(func(tx *bolt.Tx) error {
+		_, err := findBasicUser(tx, channelID, func(u BasicUser) bool {
+			return u.ExternalUserUUID == user.ExternalUserUUID.String()
+		})
+		if err == nil {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "basic user with external ID '%s' already exists", user.ExternalUserUUID)
+		}
+		if err != ErrNotFound {
+			return err
+		}
+
+		storedUser := BasicUser{
+			ID:               id.String(),
+			ExternalUserUUID: user.ExternalUserUUID.String(),
+			Name:             user.Name,
+			Surname:          user.Surname,
+			OrgDisplayName:   user.OrgDisplayName,
+			OrgName:          user.OrgName,
+		}
+
+		return putRecord(tx, channelID, basicUsersBucket, storedUser.ID, storedUser)
+	})
+	if err != nil {
+		return ref.UUID(""), wrapError(err, "repo AddBasicUser")
+	}
+
+	return id, nil
+}
+
+// GetBasicUser returns the Basic User with the given ID from the repository
+func (r *BasicUserRepositoryBolt) GetBasicUser(_ context.Context, channelID ref.ChannelID, ID ref.UUID) (user.BasicUser, error) {
+	var storedUser BasicUser
+	err := r.store.view(func(tx *bolt.Tx) error {
+		return getRecord(tx, channelID, basicUsersBucket, ID.String(), &storedUser)
+	})
+	if err != nil {
+		return user.BasicUser{}, wrapError(err, "repo GetBasicUser")
+	}
+
+	return convertStoredToDomainBasicUser(storedUser)
+}
+
+// GetBasicUserByExternalID returns the Basic User with the given external ID from the repository
+func (r *BasicUserRepositoryBolt) GetBasicUserByExternalID(_ context.Context, channelID ref.ChannelID, externalID ref.ExternalUserUUID) (user.BasicUser, error) {
+	var storedUser BasicUser
+	err := r.store.view(func(tx *bolt.Tx) error {
+		var err error
+		storedUser, err = findBasicUser(tx, channelID, func(u BasicUser) bool {
+			return u.ExternalUserUUID == externalID.String()
+		})
+		return err
+	})
+	if err != nil {
+		return user.BasicUser{}, wrapError(err, "repo GetBasicUserByExternalID")
+	}
+
+	return convertStoredToDomainBasicUser(storedUser)
+}
+
+// ListBasicUsers returns the list of Basic Users from the repository
+func (r *BasicUserRepositoryBolt) ListBasicUsers(_ context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.BasicUserList, error) {
+	var pagination *repository.Pagination
+	var storedUsers []*BasicUser
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		total, err := countRecords(tx, channelID, basicUsersBucket)
+		if err != nil {
+			return err
+		}
+
+		pagination = repository.NewPagination(total, page, itemsPerPage)
+
+		return listRecords(tx, channelID, basicUsersBucket, pagination.FirstElementIndex, pagination.Size, func() interface{} {
+			storedUser := &BasicUser{}
+			storedUsers = append(storedUsers, storedUser)
+			return storedUser
+		})
+	})
+	if err != nil {
+		return repository.BasicUserList{}, wrapError(err, "repo ListBasicUsers")
+	}
+
+	var list []user.BasicUser
+	for _, storedUser := range storedUsers {
+		basicUser, err := convertStoredToDomainBasicUser(*storedUser)
+		if err != nil {
+			return repository.BasicUserList{}, err
+		}
+		list = append(list, basicUser)
+	}
+
+	basicUserList := repository.BasicUserList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return basicUserList, nil
+}
+
+// findBasicUser returns the first stored basic user matching the predicate or ErrNotFound
+func findBasicUser(tx *bolt.Tx, channelID ref.ChannelID, match func(u BasicUser) bool) (BasicUser, error) {
+	var found *BasicUser
+	err := forEachRecord(tx, channelID, basicUsersBucket, func(data []byte) (bool, error) {
+		var storedUser BasicUser
+		if err := json.Unmarshal(data, &storedUser); err != nil {
+			return false, err
+		}
+
+		if match(storedUser) {
+			found = &storedUser
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return BasicUser{}, err
+	}
+
+	if found == nil {
+		return BasicUser{}, ErrNotFound
+	}
+
+	return *found, nil
+}
+
+func convertStoredToDomainBasicUser(storedUser BasicUser) (user.BasicUser, error) {
+	basicUser := user.BasicUser{
+		ExternalUserUUID: ref.ExternalUserUUID(storedUser.ExternalUserUUID),
+		Name:             storedUser.Name,
+		Surname:          storedUser.Surname,
+		OrgDisplayName:   storedUser.OrgDisplayName,
+		OrgName:          storedUser.OrgName,
+	}
+
+	if err := basicUser.SetUUID(ref.UUID(storedUser.ID)); err != nil {
+		return user.BasicUser{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "error loading basic user from repository (storedUser.ID)")
+	}
+
+	return basicUser, nil
+}
@@ -0,0 +1,43 @@
+package boltdb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryBolt_Contract(t *testing.T) {
+	repositorytest.RunContractTests(t, func(t *testing.T, clock repository.Clock) repositorytest.Repositories {
+		store := openTestStore(t)
+
+		basicUserRepository := NewBasicUserRepositoryBolt(store)
+		fieldEngineerRepository := NewFieldEngineerRepositoryBolt(store, clock, basicUserRepository)
+		incidentRepository := NewIncidentRepositoryBolt(store, clock, basicUserRepository, fieldEngineerRepository)
+
+		return repositorytest.Repositories{
+			BasicUser:      basicUserRepository,
+			FieldEngineer:  fieldEngineerRepository,
+			Incident:       incidentRepository,
+			UnitOfWork:     NewUnitOfWorkBolt(store, basicUserRepository, fieldEngineerRepository, incidentRepository),
+			Outbox:         NewOutboxRepositoryBolt(store),
+			Webhook:        NewWebhookRepositoryBolt(store, clock, basicUserRepository),
+			SLA:            NewSLARepositoryBolt(store, clock, basicUserRepository),
+			PriorityMatrix: NewPriorityMatrixRepositoryBolt(store, clock, basicUserRepository),
+		}
+	})
+}
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "itsm.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
@@ -0,0 +1,20 @@
+package boltdb
+
+// FieldEngineer stored in bolt database
+type FieldEngineer struct {
+	ID string `json:"id"`
+
+	BasicUserID string `json:"basic_user_id"`
+
+	TimeSessions []string `json:"time_sessions"`
+
+	Deactivated bool `json:"deactivated"`
+
+	CreatedAt string `json:"created_at"`
+
+	CreatedBy string `json:"created_by"`
+
+	UpdatedAt string `json:"updated_at"`
+
+	UpdatedBy string `json:"updated_by"`
+}
@@ -0,0 +1,428 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// FieldEngineerRepositoryBolt keeps data in bolt database
+type FieldEngineerRepositoryBolt struct {
+	store               txStore
+	basicUserRepository repository.BasicUserRepository
+	Rand                io.Reader
+	clock               repository.Clock
+}
+
+// NewFieldEngineerRepositoryBolt returns new initialized repository
+func NewFieldEngineerRepositoryBolt(store *Store, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *FieldEngineerRepositoryBolt {
+	return &FieldEngineerRepositoryBolt{
+		store:               txStore{Store: store},
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// AddFieldEngineer adds the given field engineer to the repository
+func (r *FieldEngineerRepositoryBolt) AddFieldEngineer(_ context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	feID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	err = r.store.update(func(tx *bolt.Tx) error {
+		_, err := findFieldEngineer(tx, channelID, func(storedFE FieldEngineer) bool {
+			return storedFE.BasicUserID == fe.BasicUser.UUID().String()
+		})
+		if err == nil {
+			return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "field engineer for basic user '%s' already exists", fe.BasicUser.UUID())
+		}
+		if err != ErrNotFound {
+			return err
+		}
+
+		storedFE := FieldEngineer{
+			ID:          feID.String(),
+			BasicUserID: fe.BasicUser.UUID().String(),
+			CreatedBy:   fe.CreatedUpdated.CreatedByID().String(),
+			CreatedAt:   now,
+			UpdatedBy:   fe.CreatedUpdated.UpdatedByID().String(),
+			UpdatedAt:   now,
+		}
+
+		return putRecord(tx, channelID, fieldEngineersBucket, storedFE.ID, storedFE)
+	})
+	if err != nil {
+		return ref.UUID(""), wrapError(err, "error adding field engineer to repository")
+	}
+
+	return feID, nil
+}
+
+// UpdateFieldEngineer updates the given field engineer together with its open time session in one transaction
+func (r *FieldEngineerRepositoryBolt) UpdateFieldEngineer(_ context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	err := r.store.update(func(tx *bolt.Tx) error {
+		var storedFE FieldEngineer
+		if err := getRecord(tx, channelID, fieldEngineersBucket, fe.UUID().String(), &storedFE); err != nil {
+			return err
+		}
+
+		tsUUIDs := storedFE.TimeSessions
+		events := fe.Events()
+
+		if fe.HasOpenTimeSession() {
+			tSessionID, isNew, err := r.saveOpenTimeSession(tx, channelID, fe.UUID(), fe.OpenTimeSession(), now)
+			if err != nil {
+				return err
+			}
+
+			if isNew {
+				tsUUIDs = append(tsUUIDs, tSessionID.String())
+				events = fe.EventsWithOpenTimeSessionID(tSessionID)
+			}
+		}
+
+		storedFE = FieldEngineer{
+			ID:           fe.UUID().String(),
+			BasicUserID:  fe.BasicUser.UUID().String(),
+			TimeSessions: tsUUIDs,
+			Deactivated:  fe.IsDeactivated(),
+			CreatedBy:    storedFE.CreatedBy,
+			CreatedAt:    storedFE.CreatedAt,
+			UpdatedBy:    fe.CreatedUpdated.UpdatedByID().String(),
+			UpdatedAt:    now,
+		}
+
+		if err := putRecord(tx, channelID, fieldEngineersBucket, storedFE.ID, storedFE); err != nil {
+			return err
+		}
+
+		return putOutboxMessages(tx, r.Rand, r.clock, channelID, events)
+	})
+	if err != nil {
+		return fe.UUID(), wrapError(err, "error updating field engineer in repository")
+	}
+
+	return fe.UUID(), nil
+}
+
+// saveOpenTimeSession stores newly opened time session or updates the existing one
+func (r *FieldEngineerRepositoryBolt) saveOpenTimeSession(tx *bolt.Tx, channelID ref.ChannelID, feID ref.UUID, openTS *tsession.TimeSession, now string) (ref.UUID, bool, error) {
+	var err error
+
+	createdAt := openTS.CreatedUpdated.CreatedAt().String()
+	updatedAt := openTS.CreatedUpdated.UpdatedAt().String()
+
+	tSessionID := openTS.UUID()
+	isNew := tSessionID.IsZero()
+	if isNew { // newly opened => set new UUID
+		tSessionID, err = repository.GenerateUUID(r.Rand)
+		if err != nil {
+			return tSessionID, false, err
+		}
+
+		createdAt = now
+		updatedAt = now
+	} else {
+		var storedTS TimeSession
+		if err := getRecord(tx, channelID, timeSessionsBucket, tSessionID.String(), &storedTS); err != nil {
+			return tSessionID, false, err
+		}
+
+		if storedTS.FieldEngineerID != feID.String() {
+			return tSessionID, false, domain.NewErrorf(domain.ErrorCodeInvalidArgument, "time session '%s' does not belong to field engineer '%s'", tSessionID, feID)
+		}
+	}
+
+	var incidents []IncidentInfo
+	for _, incInfo := range openTS.Incidents {
+		incidents = append(incidents, IncidentInfo{
+			ID:                 incInfo.IncidentID.String(),
+			HasSupplierProduct: incInfo.HasSupplierProduct,
+		})
+	}
+
+	storedTS := TimeSession{
+		ID:                          tSessionID.String(),
+		FieldEngineerID:             feID.String(),
+		State:                       openTS.State().String(),
+		StateChangedAt:              openTS.StateChangedAt().String(),
+		Incidents:                   incidents,
+		Work:                        openTS.Work,
+		Travel:                      openTS.Travel,
+		TravelBack:                  openTS.TravelBack,
+		TravelDistanceInTravelUnits: openTS.TravelDistanceInTravelUnits,
+		CreatedAt:                   createdAt,
+		CreatedBy:                   openTS.CreatedUpdated.CreatedByID().String(),
+		UpdatedAt:                   updatedAt,
+		UpdatedBy:                   openTS.CreatedUpdated.UpdatedByID().String(),
+	}
+
+	return tSessionID, isNew, putRecord(tx, channelID, timeSessionsBucket, storedTS.ID, storedTS)
+}
+
+// GetFieldEngineer returns the field engineer with given ID from the repository
+func (r *FieldEngineerRepositoryBolt) GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	var storedFE FieldEngineer
+	var openTS *TimeSession
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		if err := getRecord(tx, channelID, fieldEngineersBucket, ID.String(), &storedFE); err != nil {
+			return err
+		}
+
+		var err error
+		openTS, err = loadOpenTimeSession(tx, channelID, storedFE)
+		return err
+	})
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, wrapError(err, "error loading field engineer from repository")
+	}
+
+	return r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE, openTS)
+}
+
+// GetFieldEngineerByBasicUser returns the field engineer belonging to the basic user with the given ID from the repository
+func (r *FieldEngineerRepositoryBolt) GetFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	var storedFE FieldEngineer
+	var openTS *TimeSession
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		var err error
+		storedFE, err = findFieldEngineer(tx, channelID, func(storedFE FieldEngineer) bool {
+			return storedFE.BasicUserID == basicUserID.String()
+		})
+		if err != nil {
+			return err
+		}
+
+		openTS, err = loadOpenTimeSession(tx, channelID, storedFE)
+		return err
+	})
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, wrapError(err, "error loading field engineer from repository")
+	}
+
+	return r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE, openTS)
+}
+
+// ListFieldEngineers returns the list of field engineers from the repository
+func (r *FieldEngineerRepositoryBolt) ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.FieldEngineerList, error) {
+	var pagination *repository.Pagination
+	var storedFEs []*FieldEngineer
+	var openTSs []*TimeSession
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		total, err := countRecords(tx, channelID, fieldEngineersBucket)
+		if err != nil {
+			return err
+		}
+
+		pagination = repository.NewPagination(total, page, itemsPerPage)
+
+		err = listRecords(tx, channelID, fieldEngineersBucket, pagination.FirstElementIndex, pagination.Size, func() interface{} {
+			storedFE := &FieldEngineer{}
+			storedFEs = append(storedFEs, storedFE)
+			return storedFE
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, storedFE := range storedFEs {
+			openTS, err := loadOpenTimeSession(tx, channelID, *storedFE)
+			if err != nil {
+				return err
+			}
+			openTSs = append(openTSs, openTS)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return repository.FieldEngineerList{}, wrapError(err, "error loading field engineers from repository")
+	}
+
+	var list []fieldengineer.FieldEngineer
+	for i, storedFE := range storedFEs {
+		fe, err := r.convertStoredToDomainFieldEngineer(ctx, channelID, *storedFE, openTSs[i])
+		if err != nil {
+			return repository.FieldEngineerList{}, err
+		}
+
+		list = append(list, fe)
+	}
+
+	fieldEngineerList := repository.FieldEngineerList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return fieldEngineerList, nil
+}
+
+// findFieldEngineer returns the first stored field engineer matching the predicate or ErrNotFound
+func findFieldEngineer(tx *bolt.Tx, channelID ref.ChannelID, match func(storedFE FieldEngineer) bool) (FieldEngineer, error) {
+	var found *FieldEngineer
+	err := forEachRecord(tx, channelID, fieldEngineersBucket, func(data []byte) (bool, error) {
+		var storedFE FieldEngineer
+		if err := json.Unmarshal(data, &storedFE); err != nil {
+			return false, err
+		}
+
+		if match(storedFE) {
+			found = &storedFE
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return FieldEngineer{}, err
+	}
+
+	if found == nil {
+		return FieldEngineer{}, ErrNotFound
+	}
+
+	return *found, nil
+}
+
+// loadOpenTimeSession loads field engineer's open time session if any
+func loadOpenTimeSession(tx *bolt.Tx, channelID ref.ChannelID, storedFE FieldEngineer) (*TimeSession, error) {
+	for _, tsID := range storedFE.TimeSessions {
+		var storedTS TimeSession
+		if err := getRecord(tx, channelID, timeSessionsBucket, tsID, &storedTS); err != nil {
+			return nil, err
+		}
+
+		if storedTS.State != tsession.StateClosed.String() { // time session is open
+			return &storedTS, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r FieldEngineerRepositoryBolt) convertStoredToDomainFieldEngineer(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer, storedTS *TimeSession) (fieldengineer.FieldEngineer, error) {
+	var fe fieldengineer.FieldEngineer
+	errMsg := "error loading field engineer from repository (%s)"
+
+	err := fe.SetUUID(ref.UUID(storedFE.ID))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.ID")
+	}
+
+	basicUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedFE.BasicUserID))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.BasicUser")
+	}
+
+	fe.BasicUser = basicUser
+
+	// set Time sessions (UUIDs)
+	var tsUUIDs []ref.UUID
+	for _, tsID := range storedFE.TimeSessions {
+		tsUUIDs = append(tsUUIDs, ref.UUID(tsID))
+	}
+
+	fe.TimeSessions = tsUUIDs
+
+	fe.SetDeactivated(storedFE.Deactivated)
+
+	if storedTS != nil {
+		openTS, err := r.convertStoredToDomainTimeSession(ctx, channelID, *storedTS)
+		if err != nil {
+			return fieldengineer.FieldEngineer{}, err
+		}
+		fe.SetOpenTimeSession(openTS)
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedFE.CreatedBy))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.CreatedBy")
+	}
+
+	err = fe.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedFE.CreatedAt))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedFE.UpdatedBy))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.UpdatedBy")
+	}
+
+	err = fe.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedFE.UpdatedAt))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.UpdatedAt")
+	}
+
+	return fe, nil
+}
+
+func (r FieldEngineerRepositoryBolt) convertStoredToDomainTimeSession(ctx context.Context, channelID ref.ChannelID, storedTS TimeSession) (*tsession.TimeSession, error) {
+	errMsg := "error loading field engineer from repository (%s)"
+
+	var incidents []tsession.IncidentInfo
+	for _, incInfo := range storedTS.Incidents {
+		incidents = append(incidents, tsession.IncidentInfo{
+			IncidentID:         ref.UUID(incInfo.ID),
+			HasSupplierProduct: incInfo.HasSupplierProduct,
+		})
+	}
+
+	openTS := &tsession.TimeSession{
+		Incidents:                   incidents,
+		Work:                        storedTS.Work,
+		Travel:                      storedTS.Travel,
+		TravelBack:                  storedTS.TravelBack,
+		TravelDistanceInTravelUnits: storedTS.TravelDistanceInTravelUnits,
+	}
+
+	state, err := tsession.NewStateFromString(storedTS.State)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.state")
+	}
+
+	if err := openTS.SetUUID(ref.UUID(storedTS.ID)); err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.ID")
+	}
+
+	if err := openTS.RestoreState(state); err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.RestoreState")
+	}
+	openTS.SetStateChangedAt(types.DateTime(storedTS.StateChangedAt))
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTS.CreatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.createdBy")
+	}
+
+	err = openTS.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedTS.CreatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.createdAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTS.UpdatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.UpdatedBy")
+	}
+
+	err = openTS.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedTS.UpdatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.UpdatedAt")
+	}
+
+	return openTS, nil
+}
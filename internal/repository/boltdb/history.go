@@ -0,0 +1,27 @@
+package boltdb
+
+// HistoryEntry stored in bolt database
+type HistoryEntry struct {
+	ID string `json:"id"`
+
+	IncidentID string `json:"incident_id"`
+
+	Action string `json:"action"`
+
+	ActorID string `json:"actor_id"`
+
+	RequestedByID string `json:"requested_by_id,omitempty"`
+
+	Time string `json:"time"`
+
+	Changes []HistoryChange `json:"changes"`
+}
+
+// HistoryChange stored in bolt database
+type HistoryChange struct {
+	Field string `json:"field"`
+
+	Before string `json:"before"`
+
+	After string `json:"after"`
+}
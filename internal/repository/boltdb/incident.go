@@ -0,0 +1,105 @@
+package boltdb
+
+import (
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+)
+
+// Incident stored in bolt database
+type Incident struct {
+	ID string `json:"id"`
+
+	Number string `json:"number"`
+
+	ExternalID string `json:"external_id"`
+
+	ShortDescription string `json:"short_description"`
+
+	Description string `json:"description"`
+
+	FieldEngineerID string `json:"field_engineer_id"`
+
+	// FieldEngineerAccepted is nil in incidents stored before the field engineer acceptance was introduced
+	FieldEngineerAccepted *bool `json:"field_engineer_accepted,omitempty"`
+
+	State string `json:"state"`
+
+	OnHoldReason string `json:"on_hold_reason"`
+
+	RemindAt string `json:"remind_at"`
+
+	ResolutionCode string `json:"resolution_code"`
+
+	ResolutionNotes string `json:"resolution_notes"`
+
+	ResolvedAt string `json:"resolved_at"`
+
+	Impact string `json:"impact"`
+
+	Urgency string `json:"urgency"`
+
+	Priority string `json:"priority"`
+
+	PriorityOverridden bool `json:"priority_overridden"`
+
+	AuditTrail []AuditRecord `json:"audit_trail"`
+
+	SLA *IncidentSLA `json:"sla,omitempty"`
+
+	Timelogs []string `json:"timelogs"`
+
+	CreatedAt string `json:"created_at"`
+
+	CreatedBy string `json:"created_by"`
+
+	UpdatedAt string `json:"updated_at"`
+
+	UpdatedBy string `json:"updated_by"`
+
+	Version uint `json:"version"`
+}
+
+// AuditRecord stored in bolt database
+type AuditRecord struct {
+	Action string `json:"action"`
+
+	ActorID string `json:"actor_id"`
+
+	Time string `json:"time"`
+
+	Note string `json:"note"`
+}
+
+// restoreIncidentPriority sets the stored impact, urgency and priority to the incident, empty values are restored as zero values
+func restoreIncidentPriority(inc *incident.Incident, impactStr, urgencyStr, priorityStr string, overridden bool) error {
+	var impact incident.Impact
+	var urgency incident.Urgency
+	var priority sla.Priority
+	var err error
+
+	if impactStr != "" {
+		if impact, err = incident.NewImpactFromString(impactStr); err != nil {
+			return err
+		}
+	}
+
+	if urgencyStr != "" {
+		if urgency, err = incident.NewUrgencyFromString(urgencyStr); err != nil {
+			return err
+		}
+	}
+
+	if priorityStr != "" {
+		if priority, err = sla.NewPriorityFromString(priorityStr); err != nil {
+			return err
+		}
+	}
+
+	inc.RestorePriority(impact, urgency, priority, overridden)
+
+	return nil
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,974 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// IncidentRepositoryBolt keeps data in bolt database
+type IncidentRepositoryBolt struct {
+	store                   txStore
+	basicUserRepository     repository.BasicUserRepository
+	fieldEngineerRepository repository.FieldEngineerRepository
+	Rand                    io.Reader
+	clock                   repository.Clock
+}
+
+// NewIncidentRepositoryBolt returns new initialized repository
+func NewIncidentRepositoryBolt(store *Store, clock repository.Clock, basicUserRepo repository.BasicUserRepository, fieldEngineerRepository repository.FieldEngineerRepository) *IncidentRepositoryBolt {
+	return &IncidentRepositoryBolt{
+		store:                   txStore{Store: store},
+		basicUserRepository:     basicUserRepo,
+		fieldEngineerRepository: fieldEngineerRepository,
+		clock:                   clock,
+	}
+}
+
+// AddIncident adds the given incident to the repository
+func (r *IncidentRepositoryBolt) AddIncident(_ context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	incidentID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	feUUID := ""
+	if inc.FieldEngineerID != nil {
+		feUUID = inc.FieldEngineerID.String()
+	}
+
+	storedInc := Incident{
+		ID:                    incidentID.String(),
+		Number:                inc.Number,
+		ExternalID:            inc.ExternalID,
+		ShortDescription:      inc.ShortDescription,
+		Description:           inc.Description,
+		FieldEngineerID:       feUUID,
+		FieldEngineerAccepted: boolPtr(inc.FieldEngineerAccepted()),
+		State:                 inc.State().String(),
+		Impact:                inc.Impact().String(),
+		Urgency:               inc.Urgency().String(),
+		Priority:              inc.Priority().String(),
+		PriorityOverridden:    inc.PriorityOverridden(),
+		SLA:                   convertIncidentSLAToStored(inc.SLA()),
+		CreatedBy:             inc.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:             now,
+		UpdatedBy:             inc.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:             now,
+		Version:               1,
+	}
+
+	err = r.store.update(func(tx *bolt.Tx) error {
+		if err := putRecord(tx, channelID, incidentsBucket, storedInc.ID, storedInc); err != nil {
+			return err
+		}
+
+		return putOutboxMessages(tx, r.Rand, r.clock, channelID, inc.EventsWithIncidentID(incidentID))
+	})
+	if err != nil {
+		return ref.UUID(""), wrapError(err, "error adding incident to repository")
+	}
+
+	return incidentID, nil
+}
+
+// UpdateIncident updates the given incident together with its open timelog in one transaction
+func (r *IncidentRepositoryBolt) UpdateIncident(_ context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	feUUID := ""
+	if inc.FieldEngineerID != nil {
+		feUUID = inc.FieldEngineerID.String()
+	}
+
+	var onHoldReason, remindAt string
+	if onHold := inc.OnHold(); onHold != nil {
+		onHoldReason = onHold.Reason.String()
+		remindAt = onHold.RemindAt.String()
+	}
+
+	var resolutionCode, resolutionNotes, resolvedAt string
+	if resolution := inc.Resolution(); resolution != nil {
+		resolutionCode = resolution.Code.String()
+		resolutionNotes = resolution.Notes
+		resolvedAt = resolution.ResolvedAt.String()
+	}
+
+	var auditTrail []AuditRecord
+	for _, record := range inc.AuditTrail() {
+		auditTrail = append(auditTrail, AuditRecord{
+			Action:  record.Action.String(),
+			ActorID: record.ActorID.String(),
+			Time:    record.Time.String(),
+			Note:    record.Note,
+		})
+	}
+
+	err := r.store.update(func(tx *bolt.Tx) error {
+		var storedInc Incident
+		if err := getRecord(tx, channelID, incidentsBucket, inc.UUID().String(), &storedInc); err != nil {
+			return err
+		}
+
+		if inc.Version() != storedInc.Version {
+			return domain.NewErrorf(domain.ErrorCodeVersionMismatch,
+				"incident '%s' was modified concurrently (version %d, stored version %d)", inc.UUID(), inc.Version(), storedInc.Version)
+		}
+
+		timelogUUIDs := storedInc.Timelogs
+
+		if inc.HasOpenTimelog() {
+			timelogID, isNew, err := r.saveOpenTimelog(tx, channelID, inc.UUID(), inc.OpenTimelog(), now)
+			if err != nil {
+				return err
+			}
+
+			if isNew {
+				timelogUUIDs = append(timelogUUIDs, timelogID.String())
+			}
+		}
+
+		storedInc = Incident{
+			ID:                    inc.UUID().String(),
+			Number:                inc.Number,
+			ExternalID:            inc.ExternalID,
+			ShortDescription:      inc.ShortDescription,
+			Description:           inc.Description,
+			FieldEngineerID:       feUUID,
+			FieldEngineerAccepted: boolPtr(inc.FieldEngineerAccepted()),
+			State:                 inc.State().String(),
+			OnHoldReason:          onHoldReason,
+			RemindAt:              remindAt,
+			ResolutionCode:        resolutionCode,
+			ResolutionNotes:       resolutionNotes,
+			ResolvedAt:            resolvedAt,
+			Impact:                inc.Impact().String(),
+			Urgency:               inc.Urgency().String(),
+			Priority:              inc.Priority().String(),
+			PriorityOverridden:    inc.PriorityOverridden(),
+			AuditTrail:            auditTrail,
+			SLA:                   convertIncidentSLAToStored(inc.SLA()),
+			Timelogs:              timelogUUIDs,
+			CreatedBy:             storedInc.CreatedBy,
+			CreatedAt:             storedInc.CreatedAt,
+			UpdatedBy:             inc.CreatedUpdated.UpdatedByID().String(),
+			UpdatedAt:             now,
+			Version:               storedInc.Version + 1,
+		}
+
+		if err := putRecord(tx, channelID, incidentsBucket, storedInc.ID, storedInc); err != nil {
+			return err
+		}
+
+		return putOutboxMessages(tx, r.Rand, r.clock, channelID, inc.Events())
+	})
+	if err != nil {
+		return inc.UUID(), wrapError(err, "error updating incident in repository")
+	}
+
+	return inc.UUID(), nil
+}
+
+// saveOpenTimelog stores newly opened timelog or updates the existing one
+func (r *IncidentRepositoryBolt) saveOpenTimelog(tx *bolt.Tx, channelID ref.ChannelID, incID ref.UUID, openTimelog *timelog.Timelog, now string) (ref.UUID, bool, error) {
+	var err error
+
+	createdAt := openTimelog.CreatedUpdated.CreatedAt().String()
+	updatedAt := openTimelog.CreatedUpdated.UpdatedAt().String()
+
+	timelogID := openTimelog.UUID()
+	isNew := timelogID.IsZero()
+	if isNew { // newly opened => set new UUID
+		timelogID, err = repository.GenerateUUID(r.Rand)
+		if err != nil {
+			return timelogID, false, err
+		}
+
+		createdAt = now
+		updatedAt = now
+	} else {
+		var storedTimelog Timelog
+		if err := getRecord(tx, channelID, timelogsBucket, timelogID.String(), &storedTimelog); err != nil {
+			return timelogID, false, err
+		}
+
+		if storedTimelog.IncidentID != incID.String() {
+			return timelogID, false, domain.NewErrorf(domain.ErrorCodeInvalidArgument, "timelog '%s' does not belong to incident '%s'", timelogID, incID)
+		}
+	}
+
+	storedTimelog := Timelog{
+		ID:           timelogID.String(),
+		IncidentID:   incID.String(),
+		Remote:       openTimelog.Remote,
+		Start:        openTimelog.Start.String(),
+		End:          openTimelog.End.String(),
+		Work:         openTimelog.Work,
+		Timespans:    convertTimespansToStored(openTimelog.Timespans),
+		VisitSummary: openTimelog.VisitSummary,
+		CreatedBy:    openTimelog.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:    createdAt,
+		UpdatedBy:    openTimelog.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:    updatedAt,
+	}
+
+	return timelogID, isNew, putRecord(tx, channelID, timelogsBucket, storedTimelog.ID, storedTimelog)
+}
+
+// GetIncident returns the incident with given ID from the repository
+func (r *IncidentRepositoryBolt) GetIncident(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error) {
+	var storedInc Incident
+	var openTimelog *Timelog
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		if err := getRecord(tx, channelID, incidentsBucket, ID.String(), &storedInc); err != nil {
+			return err
+		}
+
+		var err error
+		openTimelog, err = loadOpenTimelog(tx, channelID, storedInc)
+		return err
+	})
+	if err != nil {
+		return incident.Incident{}, wrapError(err, "error loading incident from repository")
+	}
+
+	return r.convertStoredToDomainIncident(ctx, channelID, storedInc, openTimelog)
+}
+
+// ListIncidents returns the list of incidents matching the filter from the repository
+func (r *IncidentRepositoryBolt) ListIncidents(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page, itemsPerPage uint) (repository.IncidentList, error) {
+	var storedIncidents []Incident
+	var openTimelogs []*Timelog
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		return forEachRecord(tx, channelID, incidentsBucket, func(data []byte) (bool, error) {
+			var storedInc Incident
+			if err := json.Unmarshal(data, &storedInc); err != nil {
+				return false, err
+			}
+
+			openTimelog, err := loadOpenTimelog(tx, channelID, storedInc)
+			if err != nil {
+				return false, err
+			}
+
+			storedIncidents = append(storedIncidents, storedInc)
+			openTimelogs = append(openTimelogs, openTimelog)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return repository.IncidentList{}, wrapError(err, "error loading incidents from repository")
+	}
+
+	var matched []incident.Incident
+	for i, storedInc := range storedIncidents {
+		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc, openTimelogs[i])
+		if err != nil {
+			return repository.IncidentList{}, err
+		}
+
+		if filter.Match(inc) {
+			matched = append(matched, inc)
+		}
+	}
+
+	filter.Sort.SortIncidents(matched)
+
+	total := len(matched)
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []incident.Incident
+	if total > 0 {
+		list = matched[pagination.FirstElementIndex : pagination.LastElementIndex+1]
+	}
+
+	incidentList := repository.IncidentList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return incidentList, nil
+}
+
+// ListIncidentsByCursor returns the page of incidents matching the filter requested by the cursor from the repository
+func (r *IncidentRepositoryBolt) ListIncidentsByCursor(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page repository.CursorPage) (repository.IncidentList, error) {
+	var storedIncidents []Incident
+	var openTimelogs []*Timelog
+	var seqs []uint64
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		return forEachRecordWithSeq(tx, channelID, incidentsBucket, func(seq uint64, data []byte) (bool, error) {
+			var storedInc Incident
+			if err := json.Unmarshal(data, &storedInc); err != nil {
+				return false, err
+			}
+
+			openTimelog, err := loadOpenTimelog(tx, channelID, storedInc)
+			if err != nil {
+				return false, err
+			}
+
+			storedIncidents = append(storedIncidents, storedInc)
+			openTimelogs = append(openTimelogs, openTimelog)
+			seqs = append(seqs, seq)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return repository.IncidentList{}, wrapError(err, "error loading incidents from repository")
+	}
+
+	var matched []repository.SequencedIncident
+	for i, storedInc := range storedIncidents {
+		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc, openTimelogs[i])
+		if err != nil {
+			return repository.IncidentList{}, err
+		}
+
+		if filter.Match(inc) {
+			matched = append(matched, repository.SequencedIncident{Incident: inc, Seq: seqs[i]})
+		}
+	}
+
+	return repository.PageIncidentsByCursor(matched, filter.Sort, page), nil
+}
+
+// ListResolvedIncidents returns up to limit incidents of all channels that are in Resolved state and were resolved at or before the given time
+func (r *IncidentRepositoryBolt) ListResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]repository.ChannelIncident, error) {
+	type resolvedIncident struct {
+		channelID   ref.ChannelID
+		resolvedAt  time.Time
+		openTimelog *Timelog
+		Incident
+	}
+
+	var resolved []resolvedIncident
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		// buckets are not accessed by channelBucket, it would create the missing ones in the writable transaction
+		err := tx.ForEach(func(name []byte, channel *bolt.Bucket) error {
+			incidents := channel.Bucket([]byte(incidentsBucket))
+			if incidents == nil { // not a channel bucket or channel without incidents
+				return nil
+			}
+
+			return incidents.ForEach(func(_, data []byte) error {
+				var storedInc Incident
+				if err := json.Unmarshal(data, &storedInc); err != nil {
+					return err
+				}
+
+				if storedInc.State != incident.StateResolved.String() || storedInc.ResolvedAt == "" {
+					return nil
+				}
+
+				resolvedAt, err := types.DateTime(storedInc.ResolvedAt).ToTime()
+				if err != nil {
+					return err
+				}
+
+				if !resolvedAt.After(resolvedBefore) {
+					resolved = append(resolved, resolvedIncident{channelID: ref.ChannelID(name), resolvedAt: resolvedAt, Incident: storedInc})
+				}
+
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		sort.SliceStable(resolved, func(i, j int) bool {
+			return resolved[i].resolvedAt.Before(resolved[j].resolvedAt)
+		})
+
+		if uint(len(resolved)) > limit {
+			resolved = resolved[:limit]
+		}
+
+		for i := range resolved {
+			if resolved[i].openTimelog, err = loadOpenTimelog(tx, resolved[i].channelID, resolved[i].Incident); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, wrapError(err, "error loading resolved incidents from repository")
+	}
+
+	var list []repository.ChannelIncident
+	for _, ri := range resolved {
+		inc, err := r.convertStoredToDomainIncident(ctx, ri.channelID, ri.Incident, ri.openTimelog)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, repository.ChannelIncident{ChannelID: ri.channelID, Incident: inc})
+	}
+
+	return list, nil
+}
+
+// GetIncidentTimelog returns the incident's timelog with the given ID from the repository
+func (r *IncidentRepositoryBolt) GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
+	var storedTimelog Timelog
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		if ok, err := hasRecord(tx, channelID, incidentsBucket, incID.String()); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		if err := getRecord(tx, channelID, timelogsBucket, timelogID.String(), &storedTimelog); err != nil {
+			return err
+		}
+
+		if storedTimelog.IncidentID != incID.String() {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return timelog.Timelog{}, wrapError(err, "error loading timelog from repository")
+	}
+
+	tmlg, err := r.convertStoredToDomainTimelog(ctx, channelID, storedTimelog)
+	if err != nil {
+		return timelog.Timelog{}, err
+	}
+
+	return *tmlg, nil
+}
+
+// UpdateIncidentTimelog updates the given closed timelog of the incident in the repository
+func (r *IncidentRepositoryBolt) UpdateIncidentTimelog(_ context.Context, channelID ref.ChannelID, incID ref.UUID, tmlg timelog.Timelog) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	err := r.store.update(func(tx *bolt.Tx) error {
+		if ok, err := hasRecord(tx, channelID, incidentsBucket, incID.String()); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		var storedTimelog Timelog
+		if err := getRecord(tx, channelID, timelogsBucket, tmlg.UUID().String(), &storedTimelog); err != nil {
+			return err
+		}
+
+		if storedTimelog.IncidentID != incID.String() {
+			return ErrNotFound
+		}
+
+		storedTimelog.Remote = tmlg.Remote
+		storedTimelog.Start = tmlg.Start.String()
+		storedTimelog.End = tmlg.End.String()
+		storedTimelog.Work = tmlg.Work
+		storedTimelog.Timespans = convertTimespansToStored(tmlg.Timespans)
+		storedTimelog.VisitSummary = tmlg.VisitSummary
+		storedTimelog.Corrections = convertCorrectionsToStored(tmlg.Corrections)
+		storedTimelog.Original = convertOriginalToStored(tmlg.Original)
+		storedTimelog.UpdatedBy = tmlg.CreatedUpdated.UpdatedByID().String()
+		storedTimelog.UpdatedAt = now
+
+		return putRecord(tx, channelID, timelogsBucket, storedTimelog.ID, storedTimelog)
+	})
+	if err != nil {
+		return tmlg.UUID(), wrapError(err, "error updating timelog in repository")
+	}
+
+	return tmlg.UUID(), nil
+}
+
+// ListIncidentTimelogs returns the list of the incident's timelogs from the repository
+func (r *IncidentRepositoryBolt) ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.TimelogList, error) {
+	var storedTimelogs []Timelog
+	var pagination *repository.Pagination
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		var storedInc Incident
+		if err := getRecord(tx, channelID, incidentsBucket, incID.String(), &storedInc); err != nil {
+			return err
+		}
+
+		pagination = repository.NewPagination(len(storedInc.Timelogs), page, itemsPerPage)
+		if pagination.Size == 0 {
+			return nil
+		}
+
+		for _, timelogID := range storedInc.Timelogs[pagination.FirstElementIndex : pagination.LastElementIndex+1] {
+			var storedTimelog Timelog
+			if err := getRecord(tx, channelID, timelogsBucket, timelogID, &storedTimelog); err != nil {
+				return err
+			}
+			storedTimelogs = append(storedTimelogs, storedTimelog)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return repository.TimelogList{}, wrapError(err, "error loading timelogs from repository")
+	}
+
+	var list []timelog.Timelog
+	for _, storedTimelog := range storedTimelogs {
+		tmlg, err := r.convertStoredToDomainTimelog(ctx, channelID, storedTimelog)
+		if err != nil {
+			return repository.TimelogList{}, err
+		}
+		list = append(list, *tmlg)
+	}
+
+	timelogList := repository.TimelogList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return timelogList, nil
+}
+
+// loadOpenTimelog loads incident's open timelog if any
+func loadOpenTimelog(tx *bolt.Tx, channelID ref.ChannelID, storedInc Incident) (*Timelog, error) {
+	for _, timelogID := range storedInc.Timelogs {
+		var storedTimelog Timelog
+		if err := getRecord(tx, channelID, timelogsBucket, timelogID, &storedTimelog); err != nil {
+			return nil, err
+		}
+
+		if storedTimelog.End == "" { // timelog is open
+			return &storedTimelog, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r IncidentRepositoryBolt) convertStoredToDomainIncident(ctx context.Context, channelID ref.ChannelID, storedInc Incident, storedTimelog *Timelog) (incident.Incident, error) {
+	var inc incident.Incident
+	errMsg := "error loading incident from repository (%s)"
+
+	err := inc.SetUUID(ref.UUID(storedInc.ID))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.ID")
+	}
+
+	inc.Number = storedInc.Number
+	inc.ExternalID = storedInc.ExternalID
+	inc.ShortDescription = storedInc.ShortDescription
+	inc.Description = storedInc.Description
+	inc.SetVersion(storedInc.Version)
+
+	if storedInc.FieldEngineerID != "" {
+		feUUID := ref.UUID(storedInc.FieldEngineerID)
+		inc.FieldEngineerID = &feUUID
+	}
+	if storedInc.FieldEngineerAccepted != nil {
+		inc.SetFieldEngineerAccepted(*storedInc.FieldEngineerAccepted)
+	} else {
+		// incident stored before the acceptance was introduced, the assigned field engineer has already accepted it
+		// if the incident left the New state
+		inc.SetFieldEngineerAccepted(storedInc.FieldEngineerID != "" && storedInc.State != incident.StateNew.String())
+	}
+
+	// set Timelogs (UUIDs)
+	var timelogUUIDs []ref.UUID
+	for _, timelogID := range storedInc.Timelogs {
+		timelogUUIDs = append(timelogUUIDs, ref.UUID(timelogID))
+	}
+
+	inc.Timelogs = timelogUUIDs
+
+	if storedTimelog != nil {
+		openTimelog, err := r.convertStoredToDomainTimelog(ctx, channelID, *storedTimelog)
+		if err != nil {
+			return incident.Incident{}, err
+		}
+
+		inc.SetOpenTimelog(openTimelog)
+	}
+
+	state, err := incident.NewStateFromString(storedInc.State)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "state")
+	}
+
+	err = inc.RestoreState(state)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "state")
+	}
+
+	if storedInc.OnHoldReason != "" {
+		reason, err := incident.NewOnHoldReasonFromString(storedInc.OnHoldReason)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "onHoldReason")
+		}
+
+		inc.SetOnHold(&incident.OnHoldInfo{
+			Reason:   reason,
+			RemindAt: types.DateTime(storedInc.RemindAt),
+		})
+	}
+
+	if storedInc.ResolutionCode != "" {
+		code, err := incident.NewResolutionCodeFromString(storedInc.ResolutionCode)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "resolutionCode")
+		}
+
+		inc.SetResolution(&incident.Resolution{
+			Code:       code,
+			Notes:      storedInc.ResolutionNotes,
+			ResolvedAt: types.DateTime(storedInc.ResolvedAt),
+		})
+	}
+
+	err = restoreIncidentPriority(&inc, storedInc.Impact, storedInc.Urgency, storedInc.Priority, storedInc.PriorityOverridden)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "priority")
+	}
+
+	var auditTrail []incident.AuditRecord
+	for _, record := range storedInc.AuditTrail {
+		auditTrail = append(auditTrail, incident.AuditRecord{
+			Action:  incident.AllowedAction(record.Action),
+			ActorID: ref.UUID(record.ActorID),
+			Time:    types.DateTime(record.Time),
+			Note:    record.Note,
+		})
+	}
+	inc.SetAuditTrail(auditTrail)
+
+	incSLA, err := convertStoredToDomainIncidentSLA(storedInc.SLA)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "sla")
+	}
+	inc.SetSLA(incSLA)
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedInc.CreatedBy))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.CreatedBy")
+	}
+
+	err = inc.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedInc.CreatedAt))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedInc.UpdatedBy))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.UpdatedBy")
+	}
+
+	err = inc.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedInc.UpdatedAt))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.UpdatedAt")
+	}
+
+	return inc, nil
+}
+
+func (r IncidentRepositoryBolt) convertStoredToDomainTimelog(ctx context.Context, channelID ref.ChannelID, storedTimelog Timelog) (*timelog.Timelog, error) {
+	errMsg := "error loading timelog from repository (%s)"
+
+	timespans, err := convertStoredToDomainTimespans(storedTimelog.Timespans)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Timespans")
+	}
+
+	corrections, err := convertStoredToDomainCorrections(storedTimelog.Corrections)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Corrections")
+	}
+
+	original, err := convertStoredToDomainOriginal(storedTimelog.Original)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Original")
+	}
+
+	tmlg := &timelog.Timelog{
+		Remote:       storedTimelog.Remote,
+		Start:        types.DateTime(storedTimelog.Start),
+		End:          types.DateTime(storedTimelog.End),
+		Work:         storedTimelog.Work,
+		Timespans:    timespans,
+		VisitSummary: storedTimelog.VisitSummary,
+		Corrections:  corrections,
+		Original:     original,
+	}
+
+	err = tmlg.SetUUID(ref.UUID(storedTimelog.ID))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.ID")
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.CreatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdBy")
+	}
+
+	err = tmlg.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedTimelog.CreatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.UpdatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedBy")
+	}
+
+	err = tmlg.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedTimelog.UpdatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedAt")
+	}
+
+	return tmlg, nil
+}
+
+func convertTimespansToStored(timespans []timelog.Timespan) []Timespan {
+	var storedTimespans []Timespan
+	for _, span := range timespans {
+		storedTimespans = append(storedTimespans, Timespan{
+			Type:  span.Type.String(),
+			Start: span.Start.String(),
+			End:   span.End.String(),
+		})
+	}
+
+	return storedTimespans
+}
+
+func convertStoredToDomainTimespans(storedTimespans []Timespan) ([]timelog.Timespan, error) {
+	var timespans []timelog.Timespan
+	for _, storedSpan := range storedTimespans {
+		spanType, err := timelog.NewTimespanTypeFromString(storedSpan.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		timespans = append(timespans, timelog.Timespan{
+			Type:  spanType,
+			Start: types.DateTime(storedSpan.Start),
+			End:   types.DateTime(storedSpan.End),
+		})
+	}
+
+	return timespans, nil
+}
+
+func convertCorrectionsToStored(corrections []timelog.Correction) []TimelogCorrection {
+	var storedCorrections []TimelogCorrection
+	for _, c := range corrections {
+		storedCorrections = append(storedCorrections, TimelogCorrection{
+			Remote:       c.Remote,
+			Start:        c.Start.String(),
+			End:          c.End.String(),
+			VisitSummary: c.VisitSummary,
+			Reason:       c.Reason,
+			Status:       c.Status.String(),
+			ProposedBy:   c.ProposedBy.String(),
+			ProposedAt:   c.ProposedAt.String(),
+			DecidedBy:    c.DecidedBy.String(),
+			DecidedAt:    c.DecidedAt.String(),
+			DecisionNote: c.DecisionNote,
+		})
+	}
+
+	return storedCorrections
+}
+
+func convertStoredToDomainCorrections(storedCorrections []TimelogCorrection) ([]timelog.Correction, error) {
+	var corrections []timelog.Correction
+	for _, c := range storedCorrections {
+		status, err := timelog.NewCorrectionStatusFromString(c.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		corrections = append(corrections, timelog.Correction{
+			Remote:       c.Remote,
+			Start:        types.DateTime(c.Start),
+			End:          types.DateTime(c.End),
+			VisitSummary: c.VisitSummary,
+			Reason:       c.Reason,
+			Status:       status,
+			ProposedBy:   ref.UUID(c.ProposedBy),
+			ProposedAt:   types.DateTime(c.ProposedAt),
+			DecidedBy:    ref.UUID(c.DecidedBy),
+			DecidedAt:    types.DateTime(c.DecidedAt),
+			DecisionNote: c.DecisionNote,
+		})
+	}
+
+	return corrections, nil
+}
+
+func convertOriginalToStored(original *timelog.OriginalValues) *TimelogOriginal {
+	if original == nil {
+		return nil
+	}
+
+	return &TimelogOriginal{
+		Remote:       original.Remote,
+		Start:        original.Start.String(),
+		End:          original.End.String(),
+		Work:         original.Work,
+		Timespans:    convertTimespansToStored(original.Timespans),
+		VisitSummary: original.VisitSummary,
+	}
+}
+
+func convertStoredToDomainOriginal(storedOriginal *TimelogOriginal) (*timelog.OriginalValues, error) {
+	if storedOriginal == nil {
+		return nil, nil
+	}
+
+	timespans, err := convertStoredToDomainTimespans(storedOriginal.Timespans)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timelog.OriginalValues{
+		Remote:       storedOriginal.Remote,
+		Start:        types.DateTime(storedOriginal.Start),
+		End:          types.DateTime(storedOriginal.End),
+		Work:         storedOriginal.Work,
+		Timespans:    timespans,
+		VisitSummary: storedOriginal.VisitSummary,
+	}, nil
+}
+
+// AddIncidentHistoryEntry adds the given history entry of the incident to the repository
+func (r *IncidentRepositoryBolt) AddIncidentHistoryEntry(_ context.Context, channelID ref.ChannelID, entry history.Entry) (ref.UUID, error) {
+	entryID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	var changes []HistoryChange
+	for _, change := range entry.Changes {
+		changes = append(changes, HistoryChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	storedEntry := HistoryEntry{
+		ID:            entryID.String(),
+		IncidentID:    entry.IncidentID.String(),
+		Action:        entry.Action,
+		ActorID:       entry.ActorID.String(),
+		RequestedByID: entry.RequestedByID.String(),
+		Time:          entry.Time.String(),
+		Changes:       changes,
+	}
+
+	err = r.store.update(func(tx *bolt.Tx) error {
+		if ok, err := hasRecord(tx, channelID, incidentsBucket, storedEntry.IncidentID); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		return putRecord(tx, channelID, historyBucketPrefix+storedEntry.IncidentID, storedEntry.ID, storedEntry)
+	})
+	if err != nil {
+		return ref.UUID(""), wrapError(err, "error adding history entry to repository")
+	}
+
+	return entryID, nil
+}
+
+// ListIncidentHistory returns the list of the incident's history entries from the repository, the oldest entries are listed first
+func (r *IncidentRepositoryBolt) ListIncidentHistory(_ context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.HistoryList, error) {
+	var storedEntries []*HistoryEntry
+	var pagination *repository.Pagination
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		if ok, err := hasRecord(tx, channelID, incidentsBucket, incID.String()); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		bucket := historyBucketPrefix + incID.String()
+
+		total, err := countRecords(tx, channelID, bucket)
+		if err != nil {
+			return err
+		}
+
+		pagination = repository.NewPagination(total, page, itemsPerPage)
+
+		return listRecords(tx, channelID, bucket, pagination.FirstElementIndex, pagination.Size, func() interface{} {
+			storedEntry := &HistoryEntry{}
+			storedEntries = append(storedEntries, storedEntry)
+			return storedEntry
+		})
+	})
+	if err != nil {
+		return repository.HistoryList{}, wrapError(err, "error loading history from repository")
+	}
+
+	var list []history.Entry
+	for _, storedEntry := range storedEntries {
+		entry, err := convertStoredToDomainHistoryEntry(*storedEntry)
+		if err != nil {
+			return repository.HistoryList{}, err
+		}
+		list = append(list, entry)
+	}
+
+	historyList := repository.HistoryList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return historyList, nil
+}
+
+func convertStoredToDomainHistoryEntry(storedEntry HistoryEntry) (history.Entry, error) {
+	var changes []history.Change
+	for _, change := range storedEntry.Changes {
+		changes = append(changes, history.Change{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	entry := history.Entry{
+		IncidentID:    ref.UUID(storedEntry.IncidentID),
+		Action:        storedEntry.Action,
+		ActorID:       ref.UUID(storedEntry.ActorID),
+		RequestedByID: ref.UUID(storedEntry.RequestedByID),
+		Time:          types.DateTime(storedEntry.Time),
+		Changes:       changes,
+	}
+
+	if err := entry.SetUUID(ref.UUID(storedEntry.ID)); err != nil {
+		return history.Entry{}, err
+	}
+
+	return entry, nil
+}
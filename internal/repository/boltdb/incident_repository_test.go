@@ -0,0 +1,78 @@
+package boltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestIncidentRepositoryBolt_LegacyFieldEngineerAccepted(t *testing.T) {
+	store := openTestStore(t)
+	clock := mocks.NewFixedClock()
+	basicUserRepository := NewBasicUserRepositoryBolt(store)
+	fieldEngineerRepository := NewFieldEngineerRepositoryBolt(store, clock, basicUserRepository)
+	repo := NewIncidentRepositoryBolt(store, clock, basicUserRepository, fieldEngineerRepository)
+
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+	}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+	require.NoError(t, basicUser.SetUUID(basicUserID))
+
+	feID := ref.UUID("c4b1d2a0-3b5e-4f4e-9a43-1d2f3e4a5b6c")
+
+	// addLegacyIncident stores the incident as it was stored before the field engineer acceptance was introduced
+	addLegacyIncident := func(state incident.State, fieldEngineerID *ref.UUID) ref.UUID {
+		inc := incident.Incident{Number: "ABC123", FieldEngineerID: fieldEngineerID}
+		require.NoError(t, inc.RestoreState(state))
+		require.NoError(t, inc.CreatedUpdated.SetCreatedBy(basicUser))
+		require.NoError(t, inc.CreatedUpdated.SetUpdatedBy(basicUser))
+		incID, err := repo.AddIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		err = store.update(func(tx *bolt.Tx) error {
+			var storedInc Incident
+			if err := getRecord(tx, channelID, incidentsBucket, incID.String(), &storedInc); err != nil {
+				return err
+			}
+			storedInc.FieldEngineerAccepted = nil
+			return putRecord(tx, channelID, incidentsBucket, storedInc.ID, storedInc)
+		})
+		require.NoError(t, err)
+
+		return incID
+	}
+
+	tests := []struct {
+		name            string
+		state           incident.State
+		fieldEngineerID *ref.UUID
+		accepted        bool
+	}{
+		{name: "assigned incident in progress is accepted", state: incident.StateInProgress, fieldEngineerID: &feID, accepted: true},
+		{name: "assigned new incident is not accepted", state: incident.StateNew, fieldEngineerID: &feID, accepted: false},
+		{name: "unassigned incident is not accepted", state: incident.StateInProgress, accepted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incID := addLegacyIncident(tt.state, tt.fieldEngineerID)
+
+			inc, err := repo.GetIncident(ctx, channelID, incID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.accepted, inc.FieldEngineerAccepted())
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// OutboxMessage stored in bolt database
+type OutboxMessage struct {
+	ID string `json:"id"`
+
+	ChannelID string `json:"channel_id"`
+
+	EventType string `json:"event_type"`
+
+	AggregateID string `json:"aggregate_id"`
+
+	Payload json.RawMessage `json:"payload"`
+
+	OccurredAt string `json:"occurred_at"`
+}
+
+// outboxBucket is a top level bucket shared by all channels, messages are stored under sequence keys
+// (to keep the order they were stored in) and are indexed by ID in 'outbox_ids' bucket
+const outboxBucket = "outbox"
+
+// putOutboxMessages stores messages of the events recorded by the aggregate in the outbox
+func putOutboxMessages(tx *bolt.Tx, rand io.Reader, clock repository.Clock, channelID ref.ChannelID, events []event.Event) error {
+	messages, err := repository.NewOutboxMessages(rand, clock, channelID, events)
+	if err != nil || len(messages) == 0 {
+		return err
+	}
+
+	b, err := tx.CreateBucketIfNotExists([]byte(outboxBucket))
+	if err != nil {
+		return err
+	}
+
+	ids, err := tx.CreateBucketIfNotExists([]byte(outboxBucket + idsBucketSuffix))
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		data, err := json.Marshal(OutboxMessage{
+			ID:          msg.ID.String(),
+			ChannelID:   msg.ChannelID.String(),
+			EventType:   msg.EventType.String(),
+			AggregateID: msg.AggregateID.String(),
+			Payload:     msg.Payload,
+			OccurredAt:  msg.OccurredAt.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+
+		if err := ids.Put([]byte(msg.ID.String()), key); err != nil {
+			return err
+		}
+
+		if err := b.Put(key, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OutboxRepositoryBolt reads the outbox messages stored by the field engineer and incident bolt repositories
+type OutboxRepositoryBolt struct {
+	store *Store
+}
+
+// NewOutboxRepositoryBolt returns new initialized repository
+func NewOutboxRepositoryBolt(store *Store) *OutboxRepositoryBolt {
+	return &OutboxRepositoryBolt{
+		store: store,
+	}
+}
+
+// ListPendingMessages returns up to limit messages that were not delivered yet, in the order they were stored
+func (r *OutboxRepositoryBolt) ListPendingMessages(_ context.Context, limit uint) ([]repository.OutboxMessage, error) {
+	var messages []repository.OutboxMessage
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(outboxBucket))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.First(); k != nil && uint(len(messages)) < limit; k, v = c.Next() {
+			var storedMsg OutboxMessage
+			if err := json.Unmarshal(v, &storedMsg); err != nil {
+				return err
+			}
+
+			messages = append(messages, repository.OutboxMessage{
+				ID:          ref.UUID(storedMsg.ID),
+				ChannelID:   ref.ChannelID(storedMsg.ChannelID),
+				EventType:   event.Type(storedMsg.EventType),
+				AggregateID: ref.UUID(storedMsg.AggregateID),
+				Payload:     storedMsg.Payload,
+				OccurredAt:  types.DateTime(storedMsg.OccurredAt),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, wrapError(err, "error listing outbox messages")
+	}
+
+	return messages, nil
+}
+
+// MarkMessageDelivered removes the delivered message from the pending messages
+func (r *OutboxRepositoryBolt) MarkMessageDelivered(_ context.Context, ID ref.UUID) error {
+	err := r.store.update(func(tx *bolt.Tx) error {
+		ids := tx.Bucket([]byte(outboxBucket + idsBucketSuffix))
+		if ids == nil {
+			return ErrNotFound
+		}
+
+		key := ids.Get([]byte(ID.String()))
+		if key == nil {
+			return ErrNotFound
+		}
+
+		if err := tx.Bucket([]byte(outboxBucket)).Delete(key); err != nil {
+			return err
+		}
+
+		return ids.Delete([]byte(ID.String()))
+	})
+	if err != nil {
+		return wrapError(err, "error marking outbox message as delivered")
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package boltdb
+
+// PriorityMatrix stored in bolt database
+type PriorityMatrix struct {
+	Priorities []PriorityMatrixEntry `json:"priorities"`
+
+	CreatedAt string `json:"created_at"`
+
+	CreatedBy string `json:"created_by"`
+
+	UpdatedAt string `json:"updated_at"`
+
+	UpdatedBy string `json:"updated_by"`
+}
+
+// PriorityMatrixEntry stored in bolt database
+type PriorityMatrixEntry struct {
+	Impact string `json:"impact"`
+
+	Urgency string `json:"urgency"`
+
+	Priority string `json:"priority"`
+}
@@ -0,0 +1,138 @@
+package boltdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// priorityMatrixID is the key of the channel's priority matrix in the priority matrices bucket
+const priorityMatrixID = "matrix"
+
+// PriorityMatrixRepositoryBolt keeps data in bolt database
+type PriorityMatrixRepositoryBolt struct {
+	store               *Store
+	basicUserRepository repository.BasicUserRepository
+	clock               repository.Clock
+}
+
+// NewPriorityMatrixRepositoryBolt returns new initialized repository
+func NewPriorityMatrixRepositoryBolt(store *Store, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *PriorityMatrixRepositoryBolt {
+	return &PriorityMatrixRepositoryBolt{
+		store:               store,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// SetPriorityMatrix stores the priority matrix of the channel, the current matrix (if any) is replaced
+func (r *PriorityMatrixRepositoryBolt) SetPriorityMatrix(_ context.Context, channelID ref.ChannelID, matrix incident.PriorityMatrix) error {
+	now := r.clock.NowFormatted().String()
+
+	storedMatrix := PriorityMatrix{
+		CreatedBy: matrix.CreatedUpdated.CreatedByID().String(),
+		CreatedAt: now,
+		UpdatedBy: matrix.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt: now,
+	}
+
+	for _, impact := range incident.Impacts() {
+		for _, urgency := range incident.Urgencies() {
+			if priority := matrix.PriorityFor(impact, urgency); !priority.IsZero() {
+				storedMatrix.Priorities = append(storedMatrix.Priorities, PriorityMatrixEntry{
+					Impact:   impact.String(),
+					Urgency:  urgency.String(),
+					Priority: priority.String(),
+				})
+			}
+		}
+	}
+
+	err := r.store.update(func(tx *bolt.Tx) error {
+		// replaced matrix keeps the information about its creation
+		var currentMatrix PriorityMatrix
+		err := getRecord(tx, channelID, priorityMatricesBucket, priorityMatrixID, &currentMatrix)
+		if err == nil {
+			storedMatrix.CreatedBy = currentMatrix.CreatedBy
+			storedMatrix.CreatedAt = currentMatrix.CreatedAt
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		return putRecord(tx, channelID, priorityMatricesBucket, priorityMatrixID, storedMatrix)
+	})
+	if err != nil {
+		return wrapError(err, "error storing priority matrix to repository")
+	}
+
+	return nil
+}
+
+// GetPriorityMatrix returns the priority matrix of the channel from the repository
+func (r *PriorityMatrixRepositoryBolt) GetPriorityMatrix(ctx context.Context, channelID ref.ChannelID) (incident.PriorityMatrix, error) {
+	var storedMatrix PriorityMatrix
+	err := r.store.view(func(tx *bolt.Tx) error {
+		return getRecord(tx, channelID, priorityMatricesBucket, priorityMatrixID, &storedMatrix)
+	})
+	if err != nil {
+		return incident.PriorityMatrix{}, wrapError(err, "error loading priority matrix from repository")
+	}
+
+	return r.convertStoredToDomainPriorityMatrix(ctx, channelID, storedMatrix)
+}
+
+func (r *PriorityMatrixRepositoryBolt) convertStoredToDomainPriorityMatrix(ctx context.Context, channelID ref.ChannelID, storedMatrix PriorityMatrix) (incident.PriorityMatrix, error) {
+	errMsg := "error loading priority matrix from repository (%s)"
+
+	matrix := incident.PriorityMatrix{
+		Priorities: make(map[incident.ImpactUrgency]sla.Priority),
+	}
+
+	for _, entry := range storedMatrix.Priorities {
+		impact, err := incident.NewImpactFromString(entry.Impact)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		urgency, err := incident.NewUrgencyFromString(entry.Urgency)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		priority, err := sla.NewPriorityFromString(entry.Priority)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		matrix.Priorities[incident.ImpactUrgency{Impact: impact, Urgency: urgency}] = priority
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedMatrix.CreatedBy))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.CreatedBy")
+	}
+
+	err = matrix.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedMatrix.CreatedAt))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedMatrix.UpdatedBy))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.UpdatedBy")
+	}
+
+	err = matrix.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedMatrix.UpdatedAt))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.UpdatedAt")
+	}
+
+	return matrix, nil
+}
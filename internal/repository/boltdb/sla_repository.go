@@ -0,0 +1,138 @@
+package boltdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// slaDefinitionID is the key of the channel's SLA definition in the SLA definitions bucket
+const slaDefinitionID = "definition"
+
+// SLARepositoryBolt keeps data in bolt database
+type SLARepositoryBolt struct {
+	store               *Store
+	basicUserRepository repository.BasicUserRepository
+	clock               repository.Clock
+}
+
+// NewSLARepositoryBolt returns new initialized repository
+func NewSLARepositoryBolt(store *Store, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *SLARepositoryBolt {
+	return &SLARepositoryBolt{
+		store:               store,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// SetSLADefinition stores the SLA definition of the channel, the current definition (if any) is replaced
+func (r *SLARepositoryBolt) SetSLADefinition(_ context.Context, channelID ref.ChannelID, def sla.Definition) error {
+	now := r.clock.NowFormatted().String()
+
+	storedDef := SLADefinition{
+		DefaultPriority: def.DefaultPriority.String(),
+		Calendar:        convertCalendarToStored(def.Calendar),
+		CreatedBy:       def.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:       now,
+		UpdatedBy:       def.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:       now,
+	}
+
+	for _, priority := range sla.Priorities() {
+		if target, ok := def.Targets[priority]; ok {
+			storedDef.Targets = append(storedDef.Targets, SLATarget{
+				Priority:   priority.String(),
+				Response:   int64(target.Response / time.Second),
+				Resolution: int64(target.Resolution / time.Second),
+			})
+		}
+	}
+
+	err := r.store.update(func(tx *bolt.Tx) error {
+		// replaced definition keeps the information about its creation
+		var currentDef SLADefinition
+		err := getRecord(tx, channelID, slaDefinitionsBucket, slaDefinitionID, &currentDef)
+		if err == nil {
+			storedDef.CreatedBy = currentDef.CreatedBy
+			storedDef.CreatedAt = currentDef.CreatedAt
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		return putRecord(tx, channelID, slaDefinitionsBucket, slaDefinitionID, storedDef)
+	})
+	if err != nil {
+		return wrapError(err, "error storing SLA definition to repository")
+	}
+
+	return nil
+}
+
+// GetSLADefinition returns the SLA definition of the channel from the repository
+func (r *SLARepositoryBolt) GetSLADefinition(ctx context.Context, channelID ref.ChannelID) (sla.Definition, error) {
+	var storedDef SLADefinition
+	err := r.store.view(func(tx *bolt.Tx) error {
+		return getRecord(tx, channelID, slaDefinitionsBucket, slaDefinitionID, &storedDef)
+	})
+	if err != nil {
+		return sla.Definition{}, wrapError(err, "error loading SLA definition from repository")
+	}
+
+	return r.convertStoredToDomainDefinition(ctx, channelID, storedDef)
+}
+
+func (r *SLARepositoryBolt) convertStoredToDomainDefinition(ctx context.Context, channelID ref.ChannelID, storedDef SLADefinition) (sla.Definition, error) {
+	errMsg := "error loading SLA definition from repository (%s)"
+
+	def := sla.Definition{
+		Targets:  make(map[sla.Priority]sla.Target),
+		Calendar: convertStoredToDomainCalendar(storedDef.Calendar),
+	}
+
+	var err error
+	def.DefaultPriority, err = sla.NewPriorityFromString(storedDef.DefaultPriority)
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.DefaultPriority")
+	}
+
+	for _, storedTarget := range storedDef.Targets {
+		priority, err := sla.NewPriorityFromString(storedTarget.Priority)
+		if err != nil {
+			return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.Targets")
+		}
+
+		def.Targets[priority] = sla.Target{
+			Response:   time.Duration(storedTarget.Response) * time.Second,
+			Resolution: time.Duration(storedTarget.Resolution) * time.Second,
+		}
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedDef.CreatedBy))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.CreatedBy")
+	}
+
+	err = def.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedDef.CreatedAt))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedDef.UpdatedBy))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.UpdatedBy")
+	}
+
+	err = def.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedDef.UpdatedAt))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.UpdatedAt")
+	}
+
+	return def, nil
+}
@@ -0,0 +1,389 @@
+package boltdb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNotFound represents the error when object is not found in the repository
+var ErrNotFound = errors.New("record was not found")
+
+// Bucket names. Every channel has its own top level bucket with nested bucket for each kind of record.
+// Listed records are stored under sequence keys (to keep the insertion order) and are indexed by ID in '<name>_ids' bucket.
+const (
+	basicUsersBucket     = "basic_users"
+	fieldEngineersBucket = "field_engineers"
+	timeSessionsBucket   = "time_sessions"
+	incidentsBucket      = "incidents"
+	timelogsBucket       = "timelogs"
+	idsBucketSuffix      = "_ids"
+	// history entries of every incident are stored in their own 'history/<incident ID>' bucket
+	historyBucketPrefix        = "history/"
+	webhookSubscriptionsBucket = "webhook_subscriptions"
+	// deliveries of every webhook subscription are stored in their own 'webhook_deliveries/<subscription ID>' bucket
+	webhookDeliveriesBucketPrefix = "webhook_deliveries/"
+	// every channel has at most one SLA definition stored under slaDefinitionID key
+	slaDefinitionsBucket = "sla_definitions"
+	// every channel has at most one priority matrix stored under priorityMatrixID key
+	priorityMatricesBucket = "priority_matrices"
+)
+
+// Store is a single-file embedded database shared by the bolt repositories. It supports on-disk snapshots and restore.
+type Store struct {
+	mu   sync.RWMutex
+	path string
+	db   *bolt.DB
+}
+
+// Open opens (or creates) the database file at the given path
+func Open(path string) (*Store, error) {
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		path: path,
+		db:   db,
+	}, nil
+}
+
+func openDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not open bolt database '%s'", path)
+	}
+	return db, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.db.Close()
+}
+
+// Snapshot writes consistent copy of the whole database to w. It does not block readers nor writers.
+func (s *Store) Snapshot(w io.Writer) (int64, error) {
+	var n int64
+	err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	if err != nil {
+		return n, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not create database snapshot")
+	}
+
+	return n, nil
+}
+
+// SnapshotToFile writes the snapshot of the database to the file at the given path.
+// The file is written atomically, ie. it is either complete or it does not exist.
+func (s *Store) SnapshotToFile(path string) error {
+	tmpPath := path + ".tmp"
+
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not create snapshot file")
+	}
+
+	if _, err := s.Snapshot(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not write snapshot file")
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not write snapshot file")
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not write snapshot file")
+	}
+
+	return nil
+}
+
+// Restore replaces the content of the database with the snapshot read from r.
+// The snapshot is validated before the current database is replaced, operations in progress are finished first.
+func (s *Store) Restore(r io.Reader) error {
+	tmpPath := filepath.Join(filepath.Dir(s.path), "."+filepath.Base(s.path)+".restore")
+
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not restore database")
+	}
+
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not restore database")
+	}
+
+	// check that the snapshot is a valid database
+	snapshotDB, err := bolt.Open(tmpPath, 0600, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return domain.WrapErrorf(err, domain.ErrorCodeInvalidArgument, "invalid database snapshot")
+	}
+	_ = snapshotDB.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.db.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not restore database")
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		_ = os.Remove(tmpPath)
+		// reopen the original database
+		if s.db, err = openDB(s.path); err != nil {
+			return err
+		}
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not restore database")
+	}
+
+	s.db, err = openDB(s.path)
+	return err
+}
+
+// view runs fn in the read-only transaction
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.db.View(fn)
+}
+
+// update runs fn in the read-write transaction, the transaction is rolled back if fn returns error
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.db.Update(fn)
+}
+
+// channelBucket returns the bucket with the given name belonging to the channel.
+// It returns nil if the bucket does not exist and the transaction is read-only.
+func channelBucket(tx *bolt.Tx, channelID ref.ChannelID, name string) (*bolt.Bucket, error) {
+	if !tx.Writable() {
+		channel := tx.Bucket([]byte(channelID.String()))
+		if channel == nil {
+			return nil, nil
+		}
+		return channel.Bucket([]byte(name)), nil
+	}
+
+	channel, err := tx.CreateBucketIfNotExists([]byte(channelID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return channel.CreateBucketIfNotExists([]byte(name))
+}
+
+// putRecord stores JSON encoded record with the given ID in the bucket of the channel
+func putRecord(tx *bolt.Tx, channelID ref.ChannelID, name string, id string, record interface{}) error {
+	b, err := channelBucket(tx, channelID, name)
+	if err != nil {
+		return err
+	}
+
+	ids, err := channelBucket(tx, channelID, name+idsBucketSuffix)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	key := ids.Get([]byte(id))
+	if key == nil { // new record
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		key = make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+
+		if err := ids.Put([]byte(id), key); err != nil {
+			return err
+		}
+	}
+
+	return b.Put(key, data)
+}
+
+// getRecord loads the record with the given ID from the bucket of the channel, it returns ErrNotFound if it does not exist
+func getRecord(tx *bolt.Tx, channelID ref.ChannelID, name string, id string, record interface{}) error {
+	ids, err := channelBucket(tx, channelID, name+idsBucketSuffix)
+	if err != nil {
+		return err
+	}
+	if ids == nil {
+		return ErrNotFound
+	}
+
+	key := ids.Get([]byte(id))
+	if key == nil {
+		return ErrNotFound
+	}
+
+	b, err := channelBucket(tx, channelID, name)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b.Get(key), record)
+}
+
+// hasRecord returns true if the record with the given ID exists in the bucket of the channel
+func hasRecord(tx *bolt.Tx, channelID ref.ChannelID, name string, id string) (bool, error) {
+	ids, err := channelBucket(tx, channelID, name+idsBucketSuffix)
+	if err != nil || ids == nil {
+		return false, err
+	}
+
+	return ids.Get([]byte(id)) != nil, nil
+}
+
+// deleteRecord deletes the record with the given ID from the bucket of the channel, it returns ErrNotFound if it does not exist
+func deleteRecord(tx *bolt.Tx, channelID ref.ChannelID, name string, id string) error {
+	ids, err := channelBucket(tx, channelID, name+idsBucketSuffix)
+	if err != nil {
+		return err
+	}
+
+	key := ids.Get([]byte(id))
+	if key == nil {
+		return ErrNotFound
+	}
+
+	b, err := channelBucket(tx, channelID, name)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Delete(key); err != nil {
+		return err
+	}
+
+	return ids.Delete([]byte(id))
+}
+
+// deleteBucket deletes the bucket of the channel with all its records, it does nothing if the bucket does not exist
+func deleteBucket(tx *bolt.Tx, channelID ref.ChannelID, name string) error {
+	channel := tx.Bucket([]byte(channelID.String()))
+	if channel == nil {
+		return nil
+	}
+
+	for _, bucket := range []string{name, name + idsBucketSuffix} {
+		if err := channel.DeleteBucket([]byte(bucket)); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachRecord calls fn for each record in the bucket of the channel in the insertion order, it stops if fn returns false
+func forEachRecord(tx *bolt.Tx, channelID ref.ChannelID, name string, fn func(data []byte) (bool, error)) error {
+	return forEachRecordWithSeq(tx, channelID, name, func(_ uint64, data []byte) (bool, error) {
+		return fn(data)
+	})
+}
+
+// forEachRecordWithSeq is like forEachRecord, fn also gets the insertion sequence number of the record
+func forEachRecordWithSeq(tx *bolt.Tx, channelID ref.ChannelID, name string, fn func(seq uint64, data []byte) (bool, error)) error {
+	b, err := channelBucket(tx, channelID, name)
+	if err != nil || b == nil {
+		return err
+	}
+
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		next, err := fn(binary.BigEndian.Uint64(k), v)
+		if err != nil {
+			return err
+		}
+		if !next {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// countRecords returns number of records in the bucket of the channel
+func countRecords(tx *bolt.Tx, channelID ref.ChannelID, name string) (int, error) {
+	ids, err := channelBucket(tx, channelID, name+idsBucketSuffix)
+	if err != nil || ids == nil {
+		return 0, err
+	}
+
+	return ids.Stats().KeyN, nil
+}
+
+// listRecords decodes a page of records from the bucket of the channel, newRecord is called for each of them to get the decoding target
+func listRecords(tx *bolt.Tx, channelID ref.ChannelID, name string, offset, limit int, newRecord func() interface{}) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	i := 0
+	return forEachRecord(tx, channelID, name, func(data []byte) (bool, error) {
+		if i < offset {
+			i++
+			return true, nil
+		}
+
+		if err := json.Unmarshal(data, newRecord()); err != nil {
+			return false, err
+		}
+
+		i++
+		return i < offset+limit, nil
+	})
+}
+
+// wrapError converts ErrNotFound to not found domain error, other errors are wrapped as unknown errors
+func wrapError(err error, msg string) error {
+	if errors.Is(err, ErrNotFound) {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, msg)
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+
+	return domain.WrapErrorf(err, domain.ErrorCodeUnknown, msg)
+}
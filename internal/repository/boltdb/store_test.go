@@ -0,0 +1,88 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SnapshotAndRestore(t *testing.T) {
+	store := openTestStore(t)
+	repo := NewBasicUserRepositoryBolt(store)
+
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	alfredID, err := repo.AddBasicUser(ctx, channelID, user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+	})
+	require.NoError(t, err)
+
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.db")
+	err = store.SnapshotToFile(snapshotPath)
+	require.NoError(t, err)
+
+	// data added after the snapshot
+	janID, err := repo.AddBasicUser(ctx, channelID, user.BasicUser{
+		ExternalUserUUID: "ee824cad-d7a6-4f48-87dc-e8461a9201c4",
+		Name:             "Jan",
+	})
+	require.NoError(t, err)
+
+	snapshot, err := os.Open(snapshotPath)
+	require.NoError(t, err)
+	defer func() { _ = snapshot.Close() }()
+
+	err = store.Restore(snapshot)
+	require.NoError(t, err)
+
+	retUser, err := repo.GetBasicUser(ctx, channelID, alfredID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alfred", retUser.Name)
+
+	_, err = repo.GetBasicUser(ctx, channelID, janID)
+	require.Error(t, err)
+	var domainErr *domain.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNotFound, domainErr.Code())
+
+	// restored database is writable
+	_, err = repo.AddBasicUser(ctx, channelID, user.BasicUser{
+		ExternalUserUUID: "ee824cad-d7a6-4f48-87dc-e8461a9201c4",
+		Name:             "Jan",
+	})
+	require.NoError(t, err)
+}
+
+func TestStore_RestoreInvalidSnapshot(t *testing.T) {
+	store := openTestStore(t)
+	repo := NewBasicUserRepositoryBolt(store)
+
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	alfredID, err := repo.AddBasicUser(ctx, channelID, user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+	})
+	require.NoError(t, err)
+
+	err = store.Restore(bytes.NewBufferString("this is not a database"))
+	require.Error(t, err)
+	var domainErr *domain.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeInvalidArgument, domainErr.Code())
+
+	// original data are untouched
+	_, err = repo.GetBasicUser(ctx, channelID, alfredID)
+	require.NoError(t, err)
+}
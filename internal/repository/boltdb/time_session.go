@@ -0,0 +1,36 @@
+package boltdb
+
+// TimeSession stored in bolt database
+type TimeSession struct {
+	ID string `json:"id"`
+
+	FieldEngineerID string `json:"field_engineer_id"`
+
+	State string `json:"state"`
+
+	StateChangedAt string `json:"state_changed_at"`
+
+	Incidents []IncidentInfo `json:"incidents"`
+
+	Work uint `json:"work"`
+
+	Travel uint `json:"travel"`
+
+	TravelBack uint `json:"travel_back"`
+
+	TravelDistanceInTravelUnits uint `json:"travel_distance_in_travel_units"`
+
+	CreatedAt string `json:"created_at"`
+
+	CreatedBy string `json:"created_by"`
+
+	UpdatedAt string `json:"updated_at"`
+
+	UpdatedBy string `json:"updated_by"`
+}
+
+// IncidentInfo stored in bolt database
+type IncidentInfo struct {
+	ID                 string `json:"id"`
+	HasSupplierProduct bool   `json:"has_supplier_product"`
+}
@@ -0,0 +1,81 @@
+package boltdb
+
+// Timelog stored in bolt database
+type Timelog struct {
+	ID string `json:"id"`
+
+	IncidentID string `json:"incident_id"`
+
+	Remote bool `json:"remote"`
+
+	Start string `json:"start"`
+
+	End string `json:"end"`
+
+	Work uint `json:"work"`
+
+	Timespans []Timespan `json:"timespans"`
+
+	VisitSummary string `json:"visit_summary"`
+
+	Corrections []TimelogCorrection `json:"corrections,omitempty"`
+
+	Original *TimelogOriginal `json:"original,omitempty"`
+
+	CreatedAt string `json:"created_at"`
+
+	CreatedBy string `json:"created_by"`
+
+	UpdatedAt string `json:"updated_at"`
+
+	UpdatedBy string `json:"updated_by"`
+}
+
+// Timespan stored in bolt database
+type Timespan struct {
+	Type string `json:"type"`
+
+	Start string `json:"start"`
+
+	End string `json:"end"`
+}
+
+// TimelogCorrection stored in bolt database
+type TimelogCorrection struct {
+	Remote bool `json:"remote"`
+
+	Start string `json:"start"`
+
+	End string `json:"end"`
+
+	VisitSummary string `json:"visit_summary"`
+
+	Reason string `json:"reason"`
+
+	Status string `json:"status"`
+
+	ProposedBy string `json:"proposed_by"`
+
+	ProposedAt string `json:"proposed_at"`
+
+	DecidedBy string `json:"decided_by"`
+
+	DecidedAt string `json:"decided_at"`
+
+	DecisionNote string `json:"decision_note"`
+}
+
+// TimelogOriginal stored in bolt database
+type TimelogOriginal struct {
+	Remote bool `json:"remote"`
+
+	Start string `json:"start"`
+
+	End string `json:"end"`
+
+	Work uint `json:"work"`
+
+	Timespans []Timespan `json:"timespans"`
+
+	VisitSummary string `json:"visit_summary"`
+}
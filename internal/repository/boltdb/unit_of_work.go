@@ -0,0 +1,73 @@
+package boltdb
+
+import (
+	"context"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// txStore runs the repository operations in the store, or in the unit of work transaction if it is set
+type txStore struct {
+	*Store
+	tx *bolt.Tx
+}
+
+// view runs fn in the unit of work transaction or in the new read-only transaction
+func (s txStore) view(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+
+	return s.Store.view(fn)
+}
+
+// update runs fn in the unit of work transaction or in the new read-write transaction
+func (s txStore) update(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+
+	return s.Store.update(fn)
+}
+
+// UnitOfWorkBolt runs operations atomically in one read-write transaction
+type UnitOfWorkBolt struct {
+	store                   *Store
+	basicUserRepository     *BasicUserRepositoryBolt
+	fieldEngineerRepository *FieldEngineerRepositoryBolt
+	incidentRepository      *IncidentRepositoryBolt
+}
+
+// NewUnitOfWorkBolt returns new unit of work over the given repositories
+func NewUnitOfWorkBolt(store *Store, basicUserRepository *BasicUserRepositoryBolt,
+	fieldEngineerRepository *FieldEngineerRepositoryBolt, incidentRepository *IncidentRepositoryBolt) *UnitOfWorkBolt {
+	return &UnitOfWorkBolt{
+		store:                   store,
+		basicUserRepository:     basicUserRepository,
+		fieldEngineerRepository: fieldEngineerRepository,
+		incidentRepository:      incidentRepository,
+	}
+}
+
+// Do calls fn with the repositories bound to the transaction, the transaction is rolled back if fn returns error
+func (u *UnitOfWorkBolt) Do(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	return u.store.update(func(tx *bolt.Tx) error {
+		basicUserRepository := *u.basicUserRepository
+		basicUserRepository.store = txStore{Store: u.store, tx: tx}
+
+		fieldEngineerRepository := *u.fieldEngineerRepository
+		fieldEngineerRepository.store = txStore{Store: u.store, tx: tx}
+		fieldEngineerRepository.basicUserRepository = &basicUserRepository
+
+		incidentRepository := *u.incidentRepository
+		incidentRepository.store = txStore{Store: u.store, tx: tx}
+		incidentRepository.basicUserRepository = &basicUserRepository
+		incidentRepository.fieldEngineerRepository = &fieldEngineerRepository
+
+		return fn(ctx, repository.Repositories{
+			FieldEngineer: &fieldEngineerRepository,
+			Incident:      &incidentRepository,
+		})
+	})
+}
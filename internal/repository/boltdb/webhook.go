@@ -0,0 +1,54 @@
+package boltdb
+
+// WebhookSubscription stored in bolt database
+type WebhookSubscription struct {
+	ID string `json:"id"`
+
+	URL string `json:"url"`
+
+	Secret string `json:"secret"`
+
+	EventTypes []string `json:"event_types"`
+
+	CreatedAt string `json:"created_at"`
+
+	CreatedBy string `json:"created_by"`
+
+	UpdatedAt string `json:"updated_at"`
+
+	UpdatedBy string `json:"updated_by"`
+}
+
+// WebhookDelivery stored in bolt database
+type WebhookDelivery struct {
+	ID string `json:"id"`
+
+	SubscriptionID string `json:"subscription_id"`
+
+	EventID string `json:"event_id"`
+
+	EventType string `json:"event_type"`
+
+	Payload string `json:"payload"`
+
+	Status string `json:"status"`
+
+	Attempts uint `json:"attempts"`
+
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+
+	LastAttemptAt string `json:"last_attempt_at,omitempty"`
+
+	LastResponseStatus int `json:"last_response_status,omitempty"`
+
+	LastError string `json:"last_error,omitempty"`
+
+	CreatedAt string `json:"created_at"`
+}
+
+// pendingWebhookDelivery is the value stored in the pending deliveries index
+type pendingWebhookDelivery struct {
+	ChannelID string `json:"channel_id"`
+
+	SubscriptionID string `json:"subscription_id"`
+}
@@ -0,0 +1,443 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// pendingWebhookDeliveriesBucket is a top level bucket shared by all channels, it indexes pending deliveries by ID
+// so that the due deliveries can be found without scanning all channels
+const pendingWebhookDeliveriesBucket = "webhook_pending_deliveries"
+
+// WebhookRepositoryBolt keeps data in bolt database
+type WebhookRepositoryBolt struct {
+	store               *Store
+	basicUserRepository repository.BasicUserRepository
+	Rand                io.Reader
+	clock               repository.Clock
+}
+
+// NewWebhookRepositoryBolt returns new initialized repository
+func NewWebhookRepositoryBolt(store *Store, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *WebhookRepositoryBolt {
+	return &WebhookRepositoryBolt{
+		store:               store,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// AddWebhookSubscription adds the given webhook subscription to the repository
+func (r *WebhookRepositoryBolt) AddWebhookSubscription(_ context.Context, channelID ref.ChannelID, sub webhook.Subscription) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	subID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	var eventTypes []string
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	storedSub := WebhookSubscription{
+		ID:         subID.String(),
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		EventTypes: eventTypes,
+		CreatedBy:  sub.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:  now,
+		UpdatedBy:  sub.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:  now,
+	}
+
+	err = r.store.update(func(tx *bolt.Tx) error {
+		return putRecord(tx, channelID, webhookSubscriptionsBucket, storedSub.ID, storedSub)
+	})
+	if err != nil {
+		return ref.UUID(""), wrapError(err, "error adding webhook subscription to repository")
+	}
+
+	return subID, nil
+}
+
+// GetWebhookSubscription returns the webhook subscription with the given ID from the repository
+func (r *WebhookRepositoryBolt) GetWebhookSubscription(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (webhook.Subscription, error) {
+	var storedSub WebhookSubscription
+	err := r.store.view(func(tx *bolt.Tx) error {
+		return getRecord(tx, channelID, webhookSubscriptionsBucket, ID.String(), &storedSub)
+	})
+	if err != nil {
+		return webhook.Subscription{}, wrapError(err, "error loading webhook subscription from repository")
+	}
+
+	return r.convertStoredToDomainSubscription(ctx, channelID, storedSub)
+}
+
+// ListWebhookSubscriptions returns the list of webhook subscriptions from the repository
+func (r *WebhookRepositoryBolt) ListWebhookSubscriptions(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.WebhookSubscriptionList, error) {
+	var storedSubs []*WebhookSubscription
+	var pagination *repository.Pagination
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		total, err := countRecords(tx, channelID, webhookSubscriptionsBucket)
+		if err != nil {
+			return err
+		}
+
+		pagination = repository.NewPagination(total, page, itemsPerPage)
+
+		return listRecords(tx, channelID, webhookSubscriptionsBucket, pagination.FirstElementIndex, pagination.Size, func() interface{} {
+			storedSub := &WebhookSubscription{}
+			storedSubs = append(storedSubs, storedSub)
+			return storedSub
+		})
+	})
+	if err != nil {
+		return repository.WebhookSubscriptionList{}, wrapError(err, "error loading webhook subscriptions from repository")
+	}
+
+	var list []webhook.Subscription
+	for _, storedSub := range storedSubs {
+		sub, err := r.convertStoredToDomainSubscription(ctx, channelID, *storedSub)
+		if err != nil {
+			return repository.WebhookSubscriptionList{}, err
+		}
+		list = append(list, sub)
+	}
+
+	subscriptionList := repository.WebhookSubscriptionList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return subscriptionList, nil
+}
+
+// DeleteWebhookSubscription deletes the webhook subscription with the given ID and all its deliveries from the repository
+func (r *WebhookRepositoryBolt) DeleteWebhookSubscription(_ context.Context, channelID ref.ChannelID, ID ref.UUID) error {
+	err := r.store.update(func(tx *bolt.Tx) error {
+		if err := deleteRecord(tx, channelID, webhookSubscriptionsBucket, ID.String()); err != nil {
+			return err
+		}
+
+		bucket := webhookDeliveriesBucketPrefix + ID.String()
+
+		// remove the subscription's deliveries from the pending deliveries index
+		var deliveryIDs []string
+		err := forEachRecord(tx, channelID, bucket, func(data []byte) (bool, error) {
+			var storedDelivery WebhookDelivery
+			if err := json.Unmarshal(data, &storedDelivery); err != nil {
+				return false, err
+			}
+			deliveryIDs = append(deliveryIDs, storedDelivery.ID)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if pending := tx.Bucket([]byte(pendingWebhookDeliveriesBucket)); pending != nil {
+			for _, deliveryID := range deliveryIDs {
+				if err := pending.Delete([]byte(deliveryID)); err != nil {
+					return err
+				}
+			}
+		}
+
+		return deleteBucket(tx, channelID, bucket)
+	})
+	if err != nil {
+		return wrapError(err, "error deleting webhook subscription from repository")
+	}
+
+	return nil
+}
+
+// AddWebhookDelivery adds the given delivery of the subscription to the repository
+func (r *WebhookRepositoryBolt) AddWebhookDelivery(_ context.Context, channelID ref.ChannelID, delivery webhook.Delivery) (ref.UUID, error) {
+	deliveryID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	if err := delivery.SetUUID(deliveryID); err != nil {
+		return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeUnknown, "error adding webhook delivery to repository")
+	}
+
+	err = r.store.update(func(tx *bolt.Tx) error {
+		if ok, err := hasRecord(tx, channelID, webhookSubscriptionsBucket, delivery.SubscriptionID.String()); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		return putWebhookDelivery(tx, channelID, convertDomainToStoredDelivery(delivery))
+	})
+	if err != nil {
+		return ref.UUID(""), wrapError(err, "error adding webhook delivery to repository")
+	}
+
+	return deliveryID, nil
+}
+
+// UpdateWebhookDelivery updates the given delivery in the repository
+func (r *WebhookRepositoryBolt) UpdateWebhookDelivery(_ context.Context, channelID ref.ChannelID, delivery webhook.Delivery) error {
+	storedDelivery := convertDomainToStoredDelivery(delivery)
+
+	err := r.store.update(func(tx *bolt.Tx) error {
+		bucket := webhookDeliveriesBucketPrefix + storedDelivery.SubscriptionID
+		if ok, err := hasRecord(tx, channelID, bucket, storedDelivery.ID); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		return putWebhookDelivery(tx, channelID, storedDelivery)
+	})
+	if err != nil {
+		return wrapError(err, "error updating webhook delivery in repository")
+	}
+
+	return nil
+}
+
+// putWebhookDelivery stores the delivery and keeps the pending deliveries index up to date
+func putWebhookDelivery(tx *bolt.Tx, channelID ref.ChannelID, storedDelivery WebhookDelivery) error {
+	if err := putRecord(tx, channelID, webhookDeliveriesBucketPrefix+storedDelivery.SubscriptionID, storedDelivery.ID, storedDelivery); err != nil {
+		return err
+	}
+
+	pending, err := tx.CreateBucketIfNotExists([]byte(pendingWebhookDeliveriesBucket))
+	if err != nil {
+		return err
+	}
+
+	if storedDelivery.Status != webhook.DeliveryStatusPending.String() {
+		return pending.Delete([]byte(storedDelivery.ID))
+	}
+
+	data, err := json.Marshal(pendingWebhookDelivery{
+		ChannelID:      channelID.String(),
+		SubscriptionID: storedDelivery.SubscriptionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return pending.Put([]byte(storedDelivery.ID), data)
+}
+
+// ListWebhookDeliveries returns the list of the subscription's deliveries from the repository, the oldest deliveries are listed first
+func (r *WebhookRepositoryBolt) ListWebhookDeliveries(_ context.Context, channelID ref.ChannelID, subscriptionID ref.UUID, page, itemsPerPage uint) (repository.WebhookDeliveryList, error) {
+	var storedDeliveries []*WebhookDelivery
+	var pagination *repository.Pagination
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		if ok, err := hasRecord(tx, channelID, webhookSubscriptionsBucket, subscriptionID.String()); err != nil || !ok {
+			if err == nil {
+				err = ErrNotFound
+			}
+			return err
+		}
+
+		bucket := webhookDeliveriesBucketPrefix + subscriptionID.String()
+
+		total, err := countRecords(tx, channelID, bucket)
+		if err != nil {
+			return err
+		}
+
+		pagination = repository.NewPagination(total, page, itemsPerPage)
+
+		return listRecords(tx, channelID, bucket, pagination.FirstElementIndex, pagination.Size, func() interface{} {
+			storedDelivery := &WebhookDelivery{}
+			storedDeliveries = append(storedDeliveries, storedDelivery)
+			return storedDelivery
+		})
+	})
+	if err != nil {
+		return repository.WebhookDeliveryList{}, wrapError(err, "error loading webhook deliveries from repository")
+	}
+
+	var list []webhook.Delivery
+	for _, storedDelivery := range storedDeliveries {
+		delivery, err := convertStoredToDomainDelivery(*storedDelivery)
+		if err != nil {
+			return repository.WebhookDeliveryList{}, err
+		}
+		list = append(list, delivery)
+	}
+
+	deliveryList := repository.WebhookDeliveryList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return deliveryList, nil
+}
+
+// ListDueWebhookDeliveries returns up to limit pending deliveries of all channels whose next attempt is due at the given time
+func (r *WebhookRepositoryBolt) ListDueWebhookDeliveries(_ context.Context, now time.Time, limit uint) ([]repository.ChannelWebhookDelivery, error) {
+	type dueDelivery struct {
+		channelID     ref.ChannelID
+		nextAttemptAt time.Time
+		WebhookDelivery
+	}
+
+	var due []dueDelivery
+
+	err := r.store.view(func(tx *bolt.Tx) error {
+		pending := tx.Bucket([]byte(pendingWebhookDeliveriesBucket))
+		if pending == nil {
+			return nil
+		}
+
+		return pending.ForEach(func(k, v []byte) error {
+			var index pendingWebhookDelivery
+			if err := json.Unmarshal(v, &index); err != nil {
+				return err
+			}
+
+			channelID := ref.ChannelID(index.ChannelID)
+
+			var storedDelivery WebhookDelivery
+			if err := getRecord(tx, channelID, webhookDeliveriesBucketPrefix+index.SubscriptionID, string(k), &storedDelivery); err != nil {
+				return err
+			}
+
+			nextAttemptAt, err := types.DateTime(storedDelivery.NextAttemptAt).ToTime()
+			if err != nil {
+				return err
+			}
+
+			if !nextAttemptAt.After(now) {
+				due = append(due, dueDelivery{channelID: channelID, nextAttemptAt: nextAttemptAt, WebhookDelivery: storedDelivery})
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, wrapError(err, "error loading due webhook deliveries from repository")
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].nextAttemptAt.Before(due[j].nextAttemptAt)
+	})
+
+	if uint(len(due)) > limit {
+		due = due[:limit]
+	}
+
+	var list []repository.ChannelWebhookDelivery
+	for _, d := range due {
+		delivery, err := convertStoredToDomainDelivery(d.WebhookDelivery)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, repository.ChannelWebhookDelivery{ChannelID: d.channelID, Delivery: delivery})
+	}
+
+	return list, nil
+}
+
+func (r *WebhookRepositoryBolt) convertStoredToDomainSubscription(ctx context.Context, channelID ref.ChannelID, storedSub WebhookSubscription) (webhook.Subscription, error) {
+	var sub webhook.Subscription
+	errMsg := "error loading webhook subscription from repository (%s)"
+
+	if err := sub.SetUUID(ref.UUID(storedSub.ID)); err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.ID")
+	}
+
+	sub.URL = storedSub.URL
+	sub.Secret = storedSub.Secret
+
+	for _, t := range storedSub.EventTypes {
+		eventType, err := event.NewTypeFromString(t)
+		if err != nil {
+			return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.EventTypes")
+		}
+		sub.EventTypes = append(sub.EventTypes, eventType)
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedSub.CreatedBy))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.CreatedBy")
+	}
+
+	err = sub.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedSub.CreatedAt))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedSub.UpdatedBy))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.UpdatedBy")
+	}
+
+	err = sub.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedSub.UpdatedAt))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.UpdatedAt")
+	}
+
+	return sub, nil
+}
+
+func convertDomainToStoredDelivery(delivery webhook.Delivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:                 delivery.UUID().String(),
+		SubscriptionID:     delivery.SubscriptionID.String(),
+		EventID:            delivery.EventID.String(),
+		EventType:          delivery.EventType.String(),
+		Payload:            string(delivery.Payload),
+		Status:             delivery.Status.String(),
+		Attempts:           delivery.Attempts,
+		NextAttemptAt:      delivery.NextAttemptAt.String(),
+		LastAttemptAt:      delivery.LastAttemptAt.String(),
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		CreatedAt:          delivery.CreatedAt.String(),
+	}
+}
+
+func convertStoredToDomainDelivery(storedDelivery WebhookDelivery) (webhook.Delivery, error) {
+	errMsg := "error loading webhook delivery from repository (%s)"
+
+	status, err := webhook.NewDeliveryStatusFromString(storedDelivery.Status)
+	if err != nil {
+		return webhook.Delivery{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDelivery.Status")
+	}
+
+	delivery := webhook.Delivery{
+		SubscriptionID:     ref.UUID(storedDelivery.SubscriptionID),
+		EventID:            ref.UUID(storedDelivery.EventID),
+		EventType:          event.Type(storedDelivery.EventType),
+		Payload:            []byte(storedDelivery.Payload),
+		Status:             status,
+		Attempts:           storedDelivery.Attempts,
+		NextAttemptAt:      types.DateTime(storedDelivery.NextAttemptAt),
+		LastAttemptAt:      types.DateTime(storedDelivery.LastAttemptAt),
+		LastResponseStatus: storedDelivery.LastResponseStatus,
+		LastError:          storedDelivery.LastError,
+		CreatedAt:          types.DateTime(storedDelivery.CreatedAt),
+	}
+
+	if err := delivery.SetUUID(ref.UUID(storedDelivery.ID)); err != nil {
+		return webhook.Delivery{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDelivery.ID")
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// Cursor is a position in the sorted list, it is used by the cursor based pagination
+type Cursor struct {
+	// SortValue is the value of the sort field of the element at the position
+	SortValue string
+
+	// Seq is the insertion sequence number of the element at the position, it orders elements with equal sort values
+	Seq uint64
+}
+
+// CursorPage defines the page requested by the cursor based pagination, zero cursors request the first page
+type CursorPage struct {
+	// After requests elements following the cursor position
+	After *Cursor
+
+	// Before requests elements preceding the cursor position
+	Before *Cursor
+
+	// Limit is the maximum number of elements on the page
+	Limit uint
+}
+
+// PageCursors contains cursors of the pages neighbouring the current page of the cursor based pagination
+type PageCursors struct {
+	// Prev is the position of the first element on the page, the previous page ends before it (nil on the first page)
+	Prev *Cursor
+
+	// Next is the position of the last element on the page, the next page starts after it (nil on the last page)
+	Next *Cursor
+
+	// Last is the position of the element preceding the last page, the last page starts after it (nil if the first page is the last one)
+	Last *Cursor
+
+	// Limit is the maximum number of elements on the page
+	Limit uint
+}
+
+// NewCursorPagination creates new pagination object for the page of the cursor based pagination.
+// Page numbers are derived from the position of the first element on the page.
+func NewCursorPagination(total, startIndex, size int, cursors *PageCursors) *Pagination {
+	limit := int(cursors.Limit)
+	if limit == 0 {
+		limit = 1
+	}
+
+	last := (total + limit - 1) / limit
+	if last == 0 {
+		last = 1
+	}
+
+	page := startIndex/limit + 1
+	if page > last {
+		page = last
+	}
+
+	prev := 0
+	if cursors.Prev != nil {
+		prev = page - 1
+		if prev < 1 {
+			prev = 1
+		}
+	}
+
+	next := 0
+	if cursors.Next != nil {
+		next = page + 1
+		if next > last {
+			next = last
+		}
+	}
+
+	lastIndex := startIndex + size - 1
+	if lastIndex < 0 {
+		lastIndex = 0
+	}
+
+	return &Pagination{
+		Total:             total,
+		Size:              size,
+		Page:              page,
+		Prev:              prev,
+		Next:              next,
+		First:             1,
+		Last:              last,
+		FirstElementIndex: startIndex,
+		LastElementIndex:  lastIndex,
+		Cursors:           cursors,
+	}
+}
+
+// SequencedIncident is an incident with its insertion sequence number
+type SequencedIncident struct {
+	incident.Incident
+	Seq uint64
+}
+
+// SortValue returns the value of the sort field of the incident
+func (s IncidentSort) SortValue(inc incident.Incident) string {
+	switch s.Field {
+	case IncidentSortByCreatedAt:
+		return inc.CreatedUpdated.CreatedAt().String()
+	case IncidentSortByUpdatedAt:
+		return inc.CreatedUpdated.UpdatedAt().String()
+	case IncidentSortByNumber:
+		return inc.Number
+	case IncidentSortByPriority:
+		return strconv.Itoa(PriorityRank(inc.Priority()))
+	default:
+		return ""
+	}
+}
+
+// Compare returns -1 if the position a precedes the position b in the sort order, +1 if it follows it and 0 if they are equal
+func (s IncidentSort) Compare(a, b Cursor) int {
+	c := 0
+	switch s.Field {
+	case IncidentSortByCreatedAt, IncidentSortByUpdatedAt:
+		if dateTimeBefore(types.DateTime(a.SortValue), types.DateTime(b.SortValue)) {
+			c = -1
+		} else if dateTimeBefore(types.DateTime(b.SortValue), types.DateTime(a.SortValue)) {
+			c = 1
+		}
+	case IncidentSortByNumber:
+		c = strings.Compare(a.SortValue, b.SortValue)
+	case IncidentSortByPriority:
+		rankA, _ := strconv.Atoi(a.SortValue)
+		rankB, _ := strconv.Atoi(b.SortValue)
+		switch {
+		case rankA < rankB:
+			c = -1
+		case rankA > rankB:
+			c = 1
+		}
+	}
+
+	if s.Descending {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+
+	switch {
+	case a.Seq < b.Seq:
+		return -1
+	case a.Seq > b.Seq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// PageIncidentsByCursor sorts the incidents and returns the page requested by the cursor.
+// It is used by the repositories that filter and sort the incidents in memory.
+func PageIncidentsByCursor(incidents []SequencedIncident, incidentSort IncidentSort, page CursorPage) IncidentList {
+	cursorOf := func(inc SequencedIncident) Cursor {
+		return Cursor{SortValue: incidentSort.SortValue(inc.Incident), Seq: inc.Seq}
+	}
+
+	sorted := append([]SequencedIncident(nil), incidents...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return incidentSort.Compare(cursorOf(sorted[i]), cursorOf(sorted[j])) < 0
+	})
+
+	limit := int(page.Limit)
+	total := len(sorted)
+
+	start, end := 0, total
+	switch {
+	case page.After != nil:
+		start = sort.Search(total, func(i int) bool {
+			return incidentSort.Compare(cursorOf(sorted[i]), *page.After) > 0
+		})
+		if start+limit < end {
+			end = start + limit
+		}
+	case page.Before != nil:
+		end = sort.Search(total, func(i int) bool {
+			return incidentSort.Compare(cursorOf(sorted[i]), *page.Before) >= 0
+		})
+		if end-limit > 0 {
+			start = end - limit
+		}
+	default:
+		if limit < end {
+			end = limit
+		}
+	}
+
+	cursors := &PageCursors{Limit: page.Limit}
+	if start < end {
+		if start > 0 {
+			prev := cursorOf(sorted[start])
+			cursors.Prev = &prev
+		}
+		if end < total {
+			next := cursorOf(sorted[end-1])
+			cursors.Next = &next
+		}
+	}
+	if total > limit {
+		last := cursorOf(sorted[total-limit-1])
+		cursors.Last = &last
+	}
+
+	var list []incident.Incident
+	for _, inc := range sorted[start:end] {
+		list = append(list, inc.Incident)
+	}
+
+	return IncidentList{
+		Result:     list,
+		Pagination: NewCursorPagination(total, start, end-start, cursors),
+	}
+}
@@ -8,11 +8,17 @@ import (
 	uuidgen "github.com/google/uuid"
 )
 
-// GenerateUUID returns a random UUID
+// GenerateUUID returns a random UUID. If rand is nil, default random source is used.
+// It is safe for concurrent use.
 func GenerateUUID(rand io.Reader) (ref.UUID, error) {
-	uuidgen.SetRand(rand)
+	var uuid uuidgen.UUID
+	var err error
 
-	uuid, err := uuidgen.NewRandom()
+	if rand != nil {
+		uuid, err = uuidgen.NewRandomFromReader(rand)
+	} else {
+		uuid, err = uuidgen.NewRandom()
+	}
 	if err != nil {
 		return "", domain.WrapErrorf(err, domain.ErrorCodeUnknown, "Could not generate UUID")
 	}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// IncidentSortField is the incident field the list of incidents can be sorted by
+type IncidentSortField string
+
+// Incident sort fields
+const (
+	IncidentSortByCreatedAt IncidentSortField = "created_at"
+	IncidentSortByUpdatedAt IncidentSortField = "updated_at"
+	IncidentSortByNumber    IncidentSortField = "number"
+	IncidentSortByPriority  IncidentSortField = "priority"
+)
+
+// IncidentSort defines the order of the listed incidents, zero value keeps the insertion order
+type IncidentSort struct {
+	Field      IncidentSortField
+	Descending bool
+}
+
+// IncidentFilter restricts the listed incidents, zero value matches all incidents.
+// All set conditions must be met.
+type IncidentFilter struct {
+	// States matches incidents in any of the given states
+	States []incident.State
+
+	// Priorities matches incidents with any of the given priorities
+	Priorities []sla.Priority
+
+	// FieldEngineerID matches incidents assigned to the field engineer
+	FieldEngineerID *ref.UUID
+
+	// Number matches incidents with exactly the same number
+	Number string
+
+	// ExternalID matches incidents with exactly the same external ID
+	ExternalID string
+
+	// CreatedFrom, CreatedTo, UpdatedFrom and UpdatedTo are inclusive bounds of the date ranges, zero time means unbounded
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time
+
+	// Text matches incidents containing the text in the short description or in the description (case-insensitive)
+	Text string
+
+	// Sort defines the order of the listed incidents
+	Sort IncidentSort
+}
+
+// Match returns true if the incident meets all conditions of the filter
+func (f IncidentFilter) Match(inc incident.Incident) bool {
+	if len(f.States) > 0 {
+		found := false
+		for _, state := range f.States {
+			if inc.State() == state {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Priorities) > 0 {
+		found := false
+		for _, priority := range f.Priorities {
+			if inc.Priority() == priority {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.FieldEngineerID != nil && (inc.FieldEngineerID == nil || *inc.FieldEngineerID != *f.FieldEngineerID) {
+		return false
+	}
+
+	if f.Number != "" && inc.Number != f.Number {
+		return false
+	}
+
+	if f.ExternalID != "" && inc.ExternalID != f.ExternalID {
+		return false
+	}
+
+	if !inRange(inc.CreatedUpdated.CreatedAt(), f.CreatedFrom, f.CreatedTo) {
+		return false
+	}
+
+	if !inRange(inc.CreatedUpdated.UpdatedAt(), f.UpdatedFrom, f.UpdatedTo) {
+		return false
+	}
+
+	if f.Text != "" {
+		text := strings.ToLower(f.Text)
+		if !strings.Contains(strings.ToLower(inc.ShortDescription), text) && !strings.Contains(strings.ToLower(inc.Description), text) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SortIncidents sorts the incidents in place, incidents with equal sort values keep their original order
+func (s IncidentSort) SortIncidents(incidents []incident.Incident) {
+	var less func(a, b incident.Incident) bool
+
+	switch s.Field {
+	case IncidentSortByCreatedAt:
+		less = func(a, b incident.Incident) bool {
+			return dateTimeBefore(a.CreatedUpdated.CreatedAt(), b.CreatedUpdated.CreatedAt())
+		}
+	case IncidentSortByUpdatedAt:
+		less = func(a, b incident.Incident) bool {
+			return dateTimeBefore(a.CreatedUpdated.UpdatedAt(), b.CreatedUpdated.UpdatedAt())
+		}
+	case IncidentSortByNumber:
+		less = func(a, b incident.Incident) bool {
+			return a.Number < b.Number
+		}
+	case IncidentSortByPriority:
+		less = func(a, b incident.Incident) bool {
+			return PriorityRank(a.Priority()) < PriorityRank(b.Priority())
+		}
+	default:
+		return
+	}
+
+	sort.SliceStable(incidents, func(i, j int) bool {
+		if s.Descending {
+			return less(incidents[j], incidents[i])
+		}
+		return less(incidents[i], incidents[j])
+	})
+}
+
+// PriorityRank returns the position of the priority in the sort order, the most urgent priority has the lowest rank
+// and incidents without priority are sorted after all others
+func PriorityRank(priority sla.Priority) int {
+	for rank, p := range sla.Priorities() {
+		if p == priority {
+			return rank
+		}
+	}
+
+	return len(sla.Priorities())
+}
+
+// inRange returns true if the date time is within the inclusive bounds, zero bound is ignored
+func inRange(dateTime types.DateTime, from, to time.Time) bool {
+	if from.IsZero() && to.IsZero() {
+		return true
+	}
+
+	t, err := dateTime.ToTime()
+	if err != nil {
+		return false
+	}
+
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+
+	if !to.IsZero() && t.After(to) {
+		return false
+	}
+
+	return true
+}
+
+// dateTimeBefore compares date times as time values, unparsable values are compared as strings
+func dateTimeBefore(a, b types.DateTime) bool {
+	ta, errA := a.ToTime()
+	tb, errB := b.ToTime()
+	if errA != nil || errB != nil {
+		return a.String() < b.String()
+	}
+
+	return ta.Before(tb)
+}
@@ -6,10 +6,13 @@ import (
 
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
 )
 
 // Clock provides Now method to enable mocking
@@ -23,11 +26,17 @@ type Clock interface {
 
 // BasicUserRepository provides access to the Basic User repository
 type BasicUserRepository interface {
+	// AddBasicUser adds the given Basic User to the repository
+	AddBasicUser(ctx context.Context, channelID ref.ChannelID, user user.BasicUser) (ref.UUID, error)
+
 	// GetBasicUser returns the Basic User with the given ID from the repository
 	GetBasicUser(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (user.BasicUser, error)
 
 	// GetBasicUserByExternalID returns the Basic User with the given external ID from the repository
 	GetBasicUserByExternalID(ctx context.Context, channelID ref.ChannelID, externalID ref.ExternalUserUUID) (user.BasicUser, error)
+
+	// ListBasicUsers returns the list of Basic Users from the repository
+	ListBasicUsers(ctx context.Context, channelID ref.ChannelID, page, perPage uint) (BasicUserList, error)
 }
 
 // FieldEngineerRepository provides access to the Filed Engineer repository
@@ -40,6 +49,12 @@ type FieldEngineerRepository interface {
 
 	// GetFieldEngineer returns the field engineer with the given ID from the repository
 	GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (fieldengineer.FieldEngineer, error)
+
+	// GetFieldEngineerByBasicUser returns the field engineer belonging to the basic user with the given ID from the repository
+	GetFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error)
+
+	// ListFieldEngineers returns the list of field engineers from the repository
+	ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, page, perPage uint) (FieldEngineerList, error)
 }
 
 // IncidentRepository provides access to the incidents repository
@@ -53,11 +68,99 @@ type IncidentRepository interface {
 	// GetIncident returns the incident with the given ID from the repository
 	GetIncident(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error)
 
-	// ListIncidents returns the list of incidents from the repository
-	ListIncidents(ctx context.Context, channelID ref.ChannelID, page, perPage uint) (IncidentList, error)
+	// ListIncidents returns the list of incidents matching the filter from the repository
+	ListIncidents(ctx context.Context, channelID ref.ChannelID, filter IncidentFilter, page, perPage uint) (IncidentList, error)
+
+	// ListIncidentsByCursor returns the page of incidents matching the filter requested by the cursor from the repository
+	ListIncidentsByCursor(ctx context.Context, channelID ref.ChannelID, filter IncidentFilter, page CursorPage) (IncidentList, error)
+
+	// ListResolvedIncidents returns up to limit incidents of all channels that are in Resolved state and were resolved
+	// at or before the given time, the incidents resolved for the longest time are listed first
+	ListResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]ChannelIncident, error)
 
 	// GetIncidentTimelog returns the incident's timelog with the given ID from the repository
 	GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error)
+
+	// UpdateIncidentTimelog updates the given closed timelog of the incident in the repository
+	UpdateIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, tmlg timelog.Timelog) (ref.UUID, error)
+
+	// ListIncidentTimelogs returns the list of the incident's timelogs from the repository
+	ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, perPage uint) (TimelogList, error)
+
+	// AddIncidentHistoryEntry adds the given history entry of the incident to the repository
+	AddIncidentHistoryEntry(ctx context.Context, channelID ref.ChannelID, entry history.Entry) (ref.UUID, error)
+
+	// ListIncidentHistory returns the list of the incident's history entries from the repository, the oldest entries are listed first
+	ListIncidentHistory(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, perPage uint) (HistoryList, error)
+}
+
+// OutboxRepository provides access to the outbox. Domain events recorded by the aggregates are stored in the outbox
+// by the incident and field engineer repositories in the same transaction as the aggregates.
+type OutboxRepository interface {
+	// ListPendingMessages returns up to limit messages that were not delivered yet, in the order they were stored
+	ListPendingMessages(ctx context.Context, limit uint) ([]OutboxMessage, error)
+
+	// MarkMessageDelivered removes the delivered message from the pending messages
+	MarkMessageDelivered(ctx context.Context, ID ref.UUID) error
+}
+
+// WebhookRepository provides access to the webhook subscriptions and their deliveries
+type WebhookRepository interface {
+	// AddWebhookSubscription adds the given webhook subscription to the repository
+	AddWebhookSubscription(ctx context.Context, channelID ref.ChannelID, sub webhook.Subscription) (ref.UUID, error)
+
+	// GetWebhookSubscription returns the webhook subscription with the given ID from the repository
+	GetWebhookSubscription(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (webhook.Subscription, error)
+
+	// ListWebhookSubscriptions returns the list of webhook subscriptions from the repository
+	ListWebhookSubscriptions(ctx context.Context, channelID ref.ChannelID, page, perPage uint) (WebhookSubscriptionList, error)
+
+	// DeleteWebhookSubscription deletes the webhook subscription with the given ID and all its deliveries from the repository
+	DeleteWebhookSubscription(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) error
+
+	// AddWebhookDelivery adds the given delivery of the subscription to the repository
+	AddWebhookDelivery(ctx context.Context, channelID ref.ChannelID, delivery webhook.Delivery) (ref.UUID, error)
+
+	// UpdateWebhookDelivery updates the given delivery in the repository
+	UpdateWebhookDelivery(ctx context.Context, channelID ref.ChannelID, delivery webhook.Delivery) error
+
+	// ListWebhookDeliveries returns the list of the subscription's deliveries from the repository, the oldest deliveries are listed first
+	ListWebhookDeliveries(ctx context.Context, channelID ref.ChannelID, subscriptionID ref.UUID, page, perPage uint) (WebhookDeliveryList, error)
+
+	// ListDueWebhookDeliveries returns up to limit pending deliveries of all channels whose next attempt is due at the given time,
+	// the deliveries that are due for the longest time are listed first
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint) ([]ChannelWebhookDelivery, error)
+}
+
+// SLARepository provides access to the SLA definitions of the channels
+type SLARepository interface {
+	// SetSLADefinition stores the SLA definition of the channel, the current definition (if any) is replaced
+	SetSLADefinition(ctx context.Context, channelID ref.ChannelID, def sla.Definition) error
+
+	// GetSLADefinition returns the SLA definition of the channel from the repository
+	GetSLADefinition(ctx context.Context, channelID ref.ChannelID) (sla.Definition, error)
+}
+
+// PriorityMatrixRepository provides access to the priority matrices of the channels
+type PriorityMatrixRepository interface {
+	// SetPriorityMatrix stores the priority matrix of the channel, the current matrix (if any) is replaced
+	SetPriorityMatrix(ctx context.Context, channelID ref.ChannelID, matrix incident.PriorityMatrix) error
+
+	// GetPriorityMatrix returns the priority matrix of the channel from the repository
+	GetPriorityMatrix(ctx context.Context, channelID ref.ChannelID) (incident.PriorityMatrix, error)
+}
+
+// UnitOfWork runs operations spanning several aggregates atomically
+type UnitOfWork interface {
+	// Do calls fn with the repositories bound to the unit of work. All changes made through them are committed
+	// if fn returns nil and rolled back otherwise.
+	Do(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
+
+// Repositories is a set of repositories bound to the unit of work
+type Repositories struct {
+	FieldEngineer FieldEngineerRepository
+	Incident      IncidentRepository
 }
 
 // IncidentList is a container with list of results and pagination info
@@ -65,3 +168,51 @@ type IncidentList struct {
 	Result []incident.Incident
 	*Pagination
 }
+
+// TimelogList is a container with list of results and pagination info
+type TimelogList struct {
+	Result []timelog.Timelog
+	*Pagination
+}
+
+// HistoryList is a container with list of results and pagination info
+type HistoryList struct {
+	Result []history.Entry
+	*Pagination
+}
+
+// FieldEngineerList is a container with list of results and pagination info
+type FieldEngineerList struct {
+	Result []fieldengineer.FieldEngineer
+	*Pagination
+}
+
+// BasicUserList is a container with list of results and pagination info
+type BasicUserList struct {
+	Result []user.BasicUser
+	*Pagination
+}
+
+// WebhookSubscriptionList is a container with list of results and pagination info
+type WebhookSubscriptionList struct {
+	Result []webhook.Subscription
+	*Pagination
+}
+
+// WebhookDeliveryList is a container with list of results and pagination info
+type WebhookDeliveryList struct {
+	Result []webhook.Delivery
+	*Pagination
+}
+
+// ChannelIncident is an incident together with the channel it belongs to
+type ChannelIncident struct {
+	ChannelID ref.ChannelID
+	incident.Incident
+}
+
+// ChannelWebhookDelivery is a webhook delivery together with the channel it belongs to
+type ChannelWebhookDelivery struct {
+	ChannelID ref.ChannelID
+	webhook.Delivery
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"io"
+	"sync"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
@@ -10,47 +11,102 @@ import (
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
-// BasicUserRepositoryMemory keeps data in memory
+// BasicUserRepositoryMemory keeps data in memory, data are partitioned by channel.
+// It is safe for concurrent use, the zero value is ready to use.
 type BasicUserRepositoryMemory struct {
-	Rand  io.Reader
-	users []user.BasicUser
+	Rand io.Reader
+
+	mu    sync.RWMutex
+	users map[ref.ChannelID][]user.BasicUser
+	// indexes of users in the users slice by user ID and by external user ID
+	userIndex         map[ref.ChannelID]map[ref.UUID]int
+	externalUserIndex map[ref.ChannelID]map[ref.ExternalUserUUID]int
 }
 
 // AddBasicUser adds the Basic User to the repository
-func (r *BasicUserRepositoryMemory) AddBasicUser(_ context.Context, _ ref.ChannelID, user user.BasicUser) (ref.UUID, error) {
+func (r *BasicUserRepositoryMemory) AddBasicUser(_ context.Context, channelID ref.ChannelID, basicUser user.BasicUser) (ref.UUID, error) {
 	id, err := repository.GenerateUUID(r.Rand)
 	if err != nil {
 		return ref.UUID(""), err
 	}
 
-	err = user.SetUUID(id)
+	err = basicUser.SetUUID(id)
 	if err != nil {
 		return "", domain.WrapErrorf(err, domain.ErrorCodeUnknown, "repo AddBasicUser")
 	}
-	r.users = append(r.users, user)
 
-	return id, nil
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.externalUserIndex[channelID][basicUser.ExternalUserUUID]; ok {
+		return ref.UUID(""), domain.NewErrorf(domain.ErrorCodeInvalidArgument, "basic user with external ID '%s' already exists", basicUser.ExternalUserUUID)
+	}
+
+	r.storeUser(channelID, basicUser)
 
+	return id, nil
 }
 
 // GetBasicUser returns the Basic User with the given ID from the repository
-func (r *BasicUserRepositoryMemory) GetBasicUser(_ context.Context, _ ref.ChannelID, ID ref.UUID) (user.BasicUser, error) {
-	for i := range r.users {
-		if r.users[i].UUID().String() == ID.String() {
-			storedUser := r.users[i]
-			return storedUser, nil
-		}
+func (r *BasicUserRepositoryMemory) GetBasicUser(_ context.Context, channelID ref.ChannelID, ID ref.UUID) (user.BasicUser, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i, ok := r.userIndex[channelID][ID]; ok {
+		return r.users[channelID][i], nil
 	}
+
 	return user.BasicUser{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "repo GetBasicUser")
 }
 
 // GetBasicUserByExternalID returns the Basic User with the given external ID from the repository
-func (r *BasicUserRepositoryMemory) GetBasicUserByExternalID(_ context.Context, _ ref.ChannelID, externalID ref.ExternalUserUUID) (user.BasicUser, error) {
-	for i := range r.users {
-		if r.users[i].ExternalUserUUID == externalID {
-			storedUser := r.users[i]
-			return storedUser, nil
-		}
+func (r *BasicUserRepositoryMemory) GetBasicUserByExternalID(_ context.Context, channelID ref.ChannelID, externalID ref.ExternalUserUUID) (user.BasicUser, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i, ok := r.externalUserIndex[channelID][externalID]; ok {
+		return r.users[channelID][i], nil
 	}
+
 	return user.BasicUser{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "repo GetBasicUserByExternalID")
 }
+
+// ListBasicUsers returns the list of Basic Users from the repository
+func (r *BasicUserRepositoryMemory) ListBasicUsers(_ context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.BasicUserList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := r.users[channelID]
+	total := len(users)
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []user.BasicUser
+	if total > 0 {
+		list = append(list, users[pagination.FirstElementIndex:pagination.LastElementIndex+1]...)
+	}
+
+	basicUserList := repository.BasicUserList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return basicUserList, nil
+}
+
+// storeUser stores the user and updates indexes, write lock must be held
+func (r *BasicUserRepositoryMemory) storeUser(channelID ref.ChannelID, basicUser user.BasicUser) {
+	if r.users == nil {
+		r.users = make(map[ref.ChannelID][]user.BasicUser)
+		r.userIndex = make(map[ref.ChannelID]map[ref.UUID]int)
+		r.externalUserIndex = make(map[ref.ChannelID]map[ref.ExternalUserUUID]int)
+	}
+
+	if r.userIndex[channelID] == nil {
+		r.userIndex[channelID] = make(map[ref.UUID]int)
+		r.externalUserIndex[channelID] = make(map[ref.ExternalUserUUID]int)
+	}
+
+	r.users[channelID] = append(r.users[channelID], basicUser)
+	r.userIndex[channelID][basicUser.UUID()] = len(r.users[channelID]) - 1
+	r.externalUserIndex[channelID][basicUser.ExternalUserUUID] = len(r.users[channelID]) - 1
+}
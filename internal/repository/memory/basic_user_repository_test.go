@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicUserRepositoryMemory_AddingAndListingBasicUsers(t *testing.T) {
+	repo := &BasicUserRepositoryMemory{}
+
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	// empty list
+	emptyList, err := repo.ListBasicUsers(ctx, channelID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, emptyList.Total)
+	assert.Len(t, emptyList.Result, 0)
+
+	basicUser1 := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	basicUser2 := user.BasicUser{
+		ExternalUserUUID: "ee824cad-d7a6-4f48-87dc-e8461a9201c4",
+		Name:             "Jan",
+		Surname:          "Novak",
+	}
+
+	id1, err := repo.AddBasicUser(ctx, channelID, basicUser1)
+	require.NoError(t, err)
+	id2, err := repo.AddBasicUser(ctx, channelID, basicUser2)
+	require.NoError(t, err)
+
+	// user with the same external ID cannot be added twice
+	_, err = repo.AddBasicUser(ctx, channelID, basicUser1)
+	require.EqualError(t, err, "basic user with external ID 'b306a60e-a2a5-463f-a6e1-33e8cb21bc3b' already exists")
+
+	retUser, err := repo.GetBasicUserByExternalID(ctx, channelID, basicUser2.ExternalUserUUID)
+	require.NoError(t, err)
+	assert.Equal(t, id2, retUser.UUID())
+
+	list, err := repo.ListBasicUsers(ctx, channelID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+	assert.Equal(t, 2, list.Size)
+	require.Len(t, list.Result, 2)
+	assert.Equal(t, id1, list.Result[0].UUID())
+	assert.Equal(t, "Alfred", list.Result[0].Name)
+	assert.Equal(t, id2, list.Result[1].UUID())
+
+	// second page
+	list, err = repo.ListBasicUsers(ctx, channelID, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+	assert.Equal(t, 2, list.Page)
+	require.Len(t, list.Result, 1)
+	assert.Equal(t, id2, list.Result[0].UUID())
+}
+
+// newBasicUserRepositoryWithUsers returns new repository containing the given users (with already assigned UUIDs)
+func newBasicUserRepositoryWithUsers(channelID ref.ChannelID, users ...user.BasicUser) *BasicUserRepositoryMemory {
+	repo := &BasicUserRepositoryMemory{}
+	for _, u := range users {
+		repo.storeUser(channelID, u)
+	}
+	return repo
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// Run with -race flag to detect data races
+func TestRepositoriesMemory_ConcurrentAccess(t *testing.T) {
+	channelIDs := []ref.ChannelID{
+		"e27ddcd0-0e1f-4bc5-93df-f6f04155beec",
+		"28b4c1b0-5d2c-4a1f-9c3e-1e6a3f8a2d41",
+	}
+	const workersPerChannel = 8
+	const iterations = 20
+
+	clock := mocks.NewFixedClock()
+	basicUserRepository := &BasicUserRepositoryMemory{}
+	fieldEngineerRepository := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, channelID := range channelIDs {
+		for w := 0; w < workersPerChannel; w++ {
+			wg.Add(1)
+			go func(channelID ref.ChannelID, w int) {
+				defer wg.Done()
+
+				basicUser := user.BasicUser{
+					ExternalUserUUID: ref.ExternalUserUUID(fmt.Sprintf("00000000-0000-4000-8000-%012d", w)),
+					Name:             "Worker",
+					Surname:          fmt.Sprint(w),
+				}
+				basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+				if !assert.NoError(t, err) {
+					return
+				}
+				_ = basicUser.SetUUID(basicUserID)
+
+				fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+				_ = fe.CreatedUpdated.SetCreatedBy(basicUser)
+				_ = fe.CreatedUpdated.SetUpdatedBy(basicUser)
+				feID, err := fieldEngineerRepository.AddFieldEngineer(ctx, channelID, fe)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				for i := 0; i < iterations; i++ {
+					inc := incident.Incident{
+						Number:           fmt.Sprintf("INC-%d-%d", w, i),
+						ShortDescription: "concurrent incident",
+					}
+					_ = inc.RestoreState(incident.StateNew)
+					_ = inc.CreatedUpdated.SetCreatedBy(basicUser)
+					_ = inc.CreatedUpdated.SetUpdatedBy(basicUser)
+
+					incID, err := incidentRepository.AddIncident(ctx, channelID, inc)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					retInc, err := incidentRepository.GetIncident(ctx, channelID, incID)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					retInc.FieldEngineerID = &feID
+					_ = retInc.RestoreState(incident.StateInProgress)
+
+					openTimelog := &timelog.Timelog{}
+					_ = openTimelog.CreatedUpdated.SetCreated(basicUser, clock.NowFormatted())
+					_ = openTimelog.CreatedUpdated.SetUpdated(basicUser, clock.NowFormatted())
+					retInc.SetOpenTimelog(openTimelog)
+
+					_, err = incidentRepository.UpdateIncident(ctx, channelID, retInc)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					updatedInc, err := incidentRepository.GetIncident(ctx, channelID, incID)
+					if !assert.NoError(t, err) || !assert.Len(t, updatedInc.Timelogs, 1) {
+						return
+					}
+
+					_, err = incidentRepository.GetIncidentTimelog(ctx, channelID, incID, updatedInc.Timelogs[0])
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					_, err = incidentRepository.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 10)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					_, err = fieldEngineerRepository.GetFieldEngineer(ctx, channelID, feID)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					_, err = fieldEngineerRepository.ListFieldEngineers(ctx, channelID, 1, 10)
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					_, err = basicUserRepository.ListBasicUsers(ctx, channelID, 1, 10)
+					if !assert.NoError(t, err) {
+						return
+					}
+				}
+			}(channelID, w)
+		}
+	}
+	wg.Wait()
+
+	for _, channelID := range channelIDs {
+		incidents, err := incidentRepository.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, workersPerChannel*iterations, incidents.Total)
+
+		fieldEngineers, err := fieldEngineerRepository.ListFieldEngineers(ctx, channelID, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, workersPerChannel, fieldEngineers.Total)
+
+		basicUsers, err := basicUserRepository.ListBasicUsers(ctx, channelID, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, workersPerChannel, basicUsers.Total)
+	}
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository/repositorytest"
+)
+
+func TestRepositoryMemory_Contract(t *testing.T) {
+	repositorytest.RunContractTests(t, func(t *testing.T, clock repository.Clock) repositorytest.Repositories {
+		basicUserRepository := &BasicUserRepositoryMemory{}
+		fieldEngineerRepository := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+		incidentRepository := NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+
+		return repositorytest.Repositories{
+			BasicUser:      basicUserRepository,
+			FieldEngineer:  fieldEngineerRepository,
+			Incident:       incidentRepository,
+			UnitOfWork:     NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository),
+			Outbox:         NewOutboxRepositoryMemory(fieldEngineerRepository, incidentRepository),
+			Webhook:        NewWebhookRepositoryMemory(clock, basicUserRepository),
+			SLA:            NewSLARepositoryMemory(clock, basicUserRepository),
+			PriorityMatrix: NewPriorityMatrixRepositoryMemory(clock, basicUserRepository),
+		}
+	})
+}
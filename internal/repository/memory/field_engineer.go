@@ -8,6 +8,8 @@ type FieldEngineer struct {
 
 	TimeSessions []string
 
+	Deactivated bool
+
 	CreatedAt string
 
 	CreatedBy string
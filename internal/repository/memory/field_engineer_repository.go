@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"io"
+	"sync"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
@@ -12,28 +13,49 @@ import (
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
-// FieldEngineerRepositoryMemory keeps data in memory
+// FieldEngineerRepositoryMemory keeps data in memory, data are partitioned by channel. It is safe for concurrent use.
 type FieldEngineerRepositoryMemory struct {
 	basicUserRepository repository.BasicUserRepository
 	Rand                io.Reader
 	clock               repository.Clock
-	fieldEngineers      []FieldEngineer
-	timeSessions        map[string]TimeSession
+
+	mu             sync.RWMutex
+	fieldEngineers map[ref.ChannelID][]FieldEngineer
+	// indexes of field engineers in the fieldEngineers slice by field engineer ID and by basic user ID
+	fieldEngineerIndex map[ref.ChannelID]map[string]int
+	basicUserIndex     map[ref.ChannelID]map[string]int
+	timeSessions       map[ref.ChannelID]map[string]TimeSession
+	outbox             map[ref.ChannelID][]OutboxMessage
 }
 
 // NewFieldEngineerRepositoryMemory returns new initialized repository
-func NewFieldEngineerRepositoryMemory(clock repository.Clock, basicUserRepo repository.BasicUserRepository) repository.FieldEngineerRepository {
+func NewFieldEngineerRepositoryMemory(clock repository.Clock, basicUserRepo repository.BasicUserRepository) *FieldEngineerRepositoryMemory {
 	return &FieldEngineerRepositoryMemory{
 		basicUserRepository: basicUserRepo,
 		clock:               clock,
-		timeSessions:        make(map[string]TimeSession),
+		fieldEngineers:      make(map[ref.ChannelID][]FieldEngineer),
+		fieldEngineerIndex:  make(map[ref.ChannelID]map[string]int),
+		basicUserIndex:      make(map[ref.ChannelID]map[string]int),
+		timeSessions:        make(map[ref.ChannelID]map[string]TimeSession),
+		outbox:              make(map[ref.ChannelID][]OutboxMessage),
 	}
 }
 
 // AddFieldEngineer adds the given field engineer to the repository
-func (r *FieldEngineerRepositoryMemory) AddFieldEngineer(_ context.Context, _ ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+func (r *FieldEngineerRepositoryMemory) AddFieldEngineer(ctx context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addFieldEngineer(ctx, channelID, fe)
+}
+
+func (r *FieldEngineerRepositoryMemory) addFieldEngineer(_ context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
 	now := r.clock.NowFormatted().String()
 
+	if _, ok := r.basicUserIndex[channelID][fe.BasicUser.UUID().String()]; ok {
+		return ref.UUID(""), domain.NewErrorf(domain.ErrorCodeInvalidArgument, "field engineer for basic user '%s' already exists", fe.BasicUser.UUID())
+	}
+
 	feID, err := repository.GenerateUUID(r.Rand)
 	if err != nil {
 		return ref.UUID(""), err
@@ -48,16 +70,30 @@ func (r *FieldEngineerRepositoryMemory) AddFieldEngineer(_ context.Context, _ re
 		UpdatedAt:   now,
 	}
 
-	r.fieldEngineers = append(r.fieldEngineers, storedFE)
+	r.storeFieldEngineer(channelID, storedFE)
 
 	return feID, nil
 }
 
 // UpdateFieldEngineer updates the given field engineer in the repository
-func (r *FieldEngineerRepositoryMemory) UpdateFieldEngineer(_ context.Context, _ ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+func (r *FieldEngineerRepositoryMemory) UpdateFieldEngineer(ctx context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateFieldEngineer(ctx, channelID, fe)
+}
+
+func (r *FieldEngineerRepositoryMemory) updateFieldEngineer(_ context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
 	var err error
 	now := r.clock.NowFormatted().String()
 
+	feIndex, ok := r.fieldEngineerIndex[channelID][fe.UUID().String()]
+	if !ok {
+		return fe.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating field engineer in repository")
+	}
+
+	events := fe.Events()
+
 	if fe.HasOpenTimeSession() {
 		openTS := fe.OpenTimeSession()
 		createdAt := openTS.CreatedUpdated.CreatedAt().String()
@@ -74,6 +110,7 @@ func (r *FieldEngineerRepositoryMemory) UpdateFieldEngineer(_ context.Context, _
 			updatedAt = now
 
 			fe.TimeSessions = append(fe.TimeSessions, tSessionID)
+			events = fe.EventsWithOpenTimeSessionID(tSessionID)
 		}
 
 		var incidents []IncidentInfo
@@ -87,6 +124,7 @@ func (r *FieldEngineerRepositoryMemory) UpdateFieldEngineer(_ context.Context, _
 		storedTS := TimeSession{
 			ID:                          tSessionID.String(),
 			State:                       openTS.State().String(),
+			StateChangedAt:              openTS.StateChangedAt().String(),
 			Incidents:                   incidents,
 			Work:                        openTS.Work,
 			Travel:                      openTS.Travel,
@@ -98,7 +136,10 @@ func (r *FieldEngineerRepositoryMemory) UpdateFieldEngineer(_ context.Context, _
 			UpdatedBy:                   openTS.CreatedUpdated.UpdatedByID().String(),
 		}
 
-		r.timeSessions[storedTS.ID] = storedTS
+		if r.timeSessions[channelID] == nil {
+			r.timeSessions[channelID] = make(map[string]TimeSession)
+		}
+		r.timeSessions[channelID][storedTS.ID] = storedTS
 	}
 
 	var tsUUIDs []string
@@ -110,44 +151,107 @@ func (r *FieldEngineerRepositoryMemory) UpdateFieldEngineer(_ context.Context, _
 		ID:           fe.UUID().String(),
 		BasicUserID:  fe.BasicUser.UUID().String(),
 		TimeSessions: tsUUIDs,
+		Deactivated:  fe.IsDeactivated(),
 		CreatedBy:    fe.CreatedUpdated.CreatedByID().String(),
 		CreatedAt:    fe.CreatedUpdated.CreatedAt().String(),
 		UpdatedBy:    fe.CreatedUpdated.UpdatedByID().String(),
 		UpdatedAt:    now,
 	}
 
-	for i := range r.fieldEngineers {
-		if r.fieldEngineers[i].ID == fe.UUID().String() {
-			r.fieldEngineers[i] = storedFE
-			return fe.UUID(), nil
-		}
+	r.fieldEngineers[channelID][feIndex] = storedFE
+
+	if err := storeOutboxMessages(r.outbox, r.Rand, r.clock, channelID, events); err != nil {
+		return fe.UUID(), err
 	}
 
-	return fe.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating field engineer in repository")
+	return fe.UUID(), nil
 }
 
 // GetFieldEngineer returns the field engineer with given ID from the repository
 func (r *FieldEngineerRepositoryMemory) GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
-	var inc fieldengineer.FieldEngineer
-	var err error
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	for i := range r.fieldEngineers {
-		if r.fieldEngineers[i].ID == ID.String() {
-			storedFE := r.fieldEngineers[i]
+	return r.getFieldEngineer(ctx, channelID, ID)
+}
 
-			inc, err = r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE)
-			if err != nil {
-				return fieldengineer.FieldEngineer{}, err
-			}
+func (r *FieldEngineerRepositoryMemory) getFieldEngineer(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	if i, ok := r.fieldEngineerIndex[channelID][ID.String()]; ok {
+		return r.convertStoredToDomainFieldEngineer(ctx, channelID, r.fieldEngineers[channelID][i])
+	}
 
-			return inc, nil
-		}
+	return fieldengineer.FieldEngineer{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading field engineer from repository")
+}
+
+// GetFieldEngineerByBasicUser returns the field engineer belonging to the basic user with the given ID from the repository
+func (r *FieldEngineerRepositoryMemory) GetFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.getFieldEngineerByBasicUser(ctx, channelID, basicUserID)
+}
+
+func (r *FieldEngineerRepositoryMemory) getFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	if i, ok := r.basicUserIndex[channelID][basicUserID.String()]; ok {
+		return r.convertStoredToDomainFieldEngineer(ctx, channelID, r.fieldEngineers[channelID][i])
 	}
 
 	return fieldengineer.FieldEngineer{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading field engineer from repository")
 }
 
-func (r FieldEngineerRepositoryMemory) convertStoredToDomainFieldEngineer(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer) (fieldengineer.FieldEngineer, error) {
+// ListFieldEngineers returns the list of field engineers from the repository
+func (r *FieldEngineerRepositoryMemory) ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.FieldEngineerList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.listFieldEngineers(ctx, channelID, page, itemsPerPage)
+}
+
+func (r *FieldEngineerRepositoryMemory) listFieldEngineers(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.FieldEngineerList, error) {
+	var list []fieldengineer.FieldEngineer
+
+	fieldEngineers := r.fieldEngineers[channelID]
+	total := len(fieldEngineers)
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	firstElementIndex := pagination.FirstElementIndex
+	lastElementIndex := pagination.LastElementIndex
+
+	var perPageList []FieldEngineer
+	if total > 0 {
+		perPageList = fieldEngineers[firstElementIndex : lastElementIndex+1]
+	}
+
+	for _, storedFE := range perPageList {
+		fe, err := r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE)
+		if err != nil {
+			return repository.FieldEngineerList{}, err
+		}
+
+		list = append(list, fe)
+	}
+
+	fieldEngineerList := repository.FieldEngineerList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return fieldEngineerList, nil
+}
+
+// storeFieldEngineer appends new field engineer to the storage and updates indexes, write lock must be held
+func (r *FieldEngineerRepositoryMemory) storeFieldEngineer(channelID ref.ChannelID, storedFE FieldEngineer) {
+	if r.fieldEngineerIndex[channelID] == nil {
+		r.fieldEngineerIndex[channelID] = make(map[string]int)
+		r.basicUserIndex[channelID] = make(map[string]int)
+	}
+
+	r.fieldEngineers[channelID] = append(r.fieldEngineers[channelID], storedFE)
+	r.fieldEngineerIndex[channelID][storedFE.ID] = len(r.fieldEngineers[channelID]) - 1
+	r.basicUserIndex[channelID][storedFE.BasicUserID] = len(r.fieldEngineers[channelID]) - 1
+}
+
+func (r *FieldEngineerRepositoryMemory) convertStoredToDomainFieldEngineer(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer) (fieldengineer.FieldEngineer, error) {
 	var fe fieldengineer.FieldEngineer
 	errMsg := "error loading field engineer from repository (%s)"
 
@@ -171,6 +275,8 @@ func (r FieldEngineerRepositoryMemory) convertStoredToDomainFieldEngineer(ctx co
 
 	fe.TimeSessions = tsUUIDs
 
+	fe.SetDeactivated(storedFE.Deactivated)
+
 	// load and set open time session if any
 	openTS, err := r.loadOpenTimeSession(ctx, channelID, storedFE)
 	if err != nil {
@@ -202,11 +308,11 @@ func (r FieldEngineerRepositoryMemory) convertStoredToDomainFieldEngineer(ctx co
 }
 
 // loadOpenTimeSession loads field engineer's open time session if any
-func (r FieldEngineerRepositoryMemory) loadOpenTimeSession(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer) (*tsession.TimeSession, error) {
+func (r *FieldEngineerRepositoryMemory) loadOpenTimeSession(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer) (*tsession.TimeSession, error) {
 	errMsg := "error loading field engineer from repository (%s)"
 
 	for _, tsID := range storedFE.TimeSessions {
-		storedTS := r.timeSessions[tsID]
+		storedTS := r.timeSessions[channelID][tsID]
 
 		var incidents []tsession.IncidentInfo
 		for _, incInfo := range storedTS.Incidents {
@@ -230,9 +336,14 @@ func (r FieldEngineerRepositoryMemory) loadOpenTimeSession(ctx context.Context,
 				return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.state")
 			}
 
-			if err := openTS.SetState(state); err != nil {
-				return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.SetState")
+			if err := openTS.SetUUID(ref.UUID(tsID)); err != nil {
+				return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.ID")
+			}
+
+			if err := openTS.RestoreState(state); err != nil {
+				return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.RestoreState")
 			}
+			openTS.SetStateChangedAt(types.DateTime(storedTS.StateChangedAt))
 
 			createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTS.CreatedBy))
 			if err != nil {
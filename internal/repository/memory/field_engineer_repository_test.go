@@ -14,6 +14,8 @@ import (
 )
 
 func TestFieldEngineerRepositoryMemory_AddingAndGettingFieldEngineer(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+
 	adminBasicUser := user.BasicUser{
 		ExternalUserUUID: "2d839741-da07-4256-bd53-4030bb0effeb",
 		Name:             "Admin",
@@ -35,12 +37,9 @@ func TestFieldEngineerRepositoryMemory_AddingAndGettingFieldEngineer(t *testing.
 	require.NoError(t, err)
 
 	clock := mocks.NewFixedClock()
-	basicUserRepository := &BasicUserRepositoryMemory{
-		users: []user.BasicUser{adminBasicUser, feBasicUser},
-	}
+	basicUserRepository := newBasicUserRepositoryWithUsers(channelID, adminBasicUser, feBasicUser)
 	repo := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
 
-	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
 	ctx := context.Background()
 
 	fe := fieldengineer.FieldEngineer{BasicUser: feBasicUser}
@@ -67,9 +66,19 @@ func TestFieldEngineerRepositoryMemory_AddingAndGettingFieldEngineer(t *testing.
 	assert.NotEmpty(t, fe.CreatedUpdated.UpdatedByID())
 	assert.Equal(t, fe.CreatedUpdated.UpdatedBy(), retFe.CreatedUpdated.UpdatedBy())
 	assert.Equal(t, clock.NowFormatted(), retFe.CreatedUpdated.UpdatedAt())
+
+	// get by basic user
+	retFe, err = repo.GetFieldEngineerByBasicUser(ctx, channelID, feBasicUser.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, feID, retFe.UUID())
+
+	_, err = repo.GetFieldEngineerByBasicUser(ctx, channelID, adminBasicUser.UUID())
+	require.Error(t, err)
 }
 
 func TestFieldEngineerRepositoryMemory_UpdateFieldEngineer(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+
 	adminBasicUser := user.BasicUser{
 		ExternalUserUUID: "2d839741-da07-4256-bd53-4030bb0effeb",
 		Name:             "Admin",
@@ -91,12 +100,9 @@ func TestFieldEngineerRepositoryMemory_UpdateFieldEngineer(t *testing.T) {
 	require.NoError(t, err)
 
 	clock := mocks.NewFixedClock()
-	basicUserRepository := &BasicUserRepositoryMemory{
-		users: []user.BasicUser{adminBasicUser, feBasicUser},
-	}
+	basicUserRepository := newBasicUserRepositoryWithUsers(channelID, adminBasicUser, feBasicUser)
 	repo := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
 
-	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
 	ctx := context.Background()
 
 	fe := fieldengineer.FieldEngineer{BasicUser: feBasicUser}
@@ -119,7 +125,7 @@ func TestFieldEngineerRepositoryMemory_UpdateFieldEngineer(t *testing.T) {
 		}},
 		Work: 3600,
 	}
-	err = openTS.SetState(tsession.StateWork)
+	err = openTS.RestoreState(tsession.StateWork)
 	require.NoError(t, err)
 	err = openTS.CreatedUpdated.SetCreated(feBasicUser, clock.NowFormatted())
 	require.NoError(t, err)
@@ -138,7 +144,114 @@ func TestFieldEngineerRepositoryMemory_UpdateFieldEngineer(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Len(t, updatedFe.TimeSessions, 1, "time sessions count")
-	assert.Equal(t, retFe.OpenTimeSession(), updatedFe.OpenTimeSession())
+	expectedTS := openTS
+	err = expectedTS.SetUUID(updatedFe.TimeSessions[0])
+	require.NoError(t, err)
+	assert.Equal(t, &expectedTS, updatedFe.OpenTimeSession())
 	assert.Len(t, retFe.OpenTimeSession().Incidents, 1)
 	assert.Equal(t, retFe.OpenTimeSession().Incidents, updatedFe.OpenTimeSession().Incidents)
+
+	// updating the field engineer again must not open another time session
+	_, err = repo.UpdateFieldEngineer(ctx, channelID, updatedFe)
+	require.NoError(t, err)
+
+	updatedFe, err = repo.GetFieldEngineer(ctx, channelID, feID)
+	require.NoError(t, err)
+
+	assert.Len(t, updatedFe.TimeSessions, 1, "time sessions count")
+	assert.Equal(t, expectedTS.UUID(), updatedFe.OpenTimeSession().UUID())
+}
+
+func TestFieldEngineerRepositoryMemory_ListFieldEngineers(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+
+	adminBasicUser := user.BasicUser{
+		ExternalUserUUID: "2d839741-da07-4256-bd53-4030bb0effeb",
+		Name:             "Admin",
+		Surname:          "User",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	err := adminBasicUser.SetUUID("b8d49f19-5e54-44cf-b547-f16bacb69294")
+	require.NoError(t, err)
+
+	feBasicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+	err = feBasicUser.SetUUID("f49d5fd5-8da4-4779-b5ba-32e78aa2c444")
+	require.NoError(t, err)
+
+	clock := mocks.NewFixedClock()
+	basicUserRepository := newBasicUserRepositoryWithUsers(channelID, adminBasicUser, feBasicUser)
+	repo := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+
+	ctx := context.Background()
+
+	// empty list
+	emptyList, err := repo.ListFieldEngineers(ctx, channelID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, emptyList.Total)
+	assert.Len(t, emptyList.Result, 0)
+
+	for _, basicUser := range []user.BasicUser{adminBasicUser, feBasicUser} {
+		fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+		err = fe.CreatedUpdated.SetCreatedBy(adminBasicUser)
+		require.NoError(t, err)
+		err = fe.CreatedUpdated.SetUpdatedBy(adminBasicUser)
+		require.NoError(t, err)
+
+		_, err = repo.AddFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+	}
+
+	// adding another field engineer for the same basic user must fail
+	fe := fieldengineer.FieldEngineer{BasicUser: feBasicUser}
+	err = fe.CreatedUpdated.SetCreatedBy(adminBasicUser)
+	require.NoError(t, err)
+	err = fe.CreatedUpdated.SetUpdatedBy(adminBasicUser)
+	require.NoError(t, err)
+	_, err = repo.AddFieldEngineer(ctx, channelID, fe)
+	require.EqualError(t, err, "field engineer for basic user 'f49d5fd5-8da4-4779-b5ba-32e78aa2c444' already exists")
+
+	list, err := repo.ListFieldEngineers(ctx, channelID, 1, 10)
+	require.NoError(t, err)
+
+	// pagination
+	assert.Equal(t, 2, list.Size)
+	assert.Equal(t, 2, list.Total)
+	assert.Equal(t, 1, list.Page)
+	assert.Equal(t, 1, list.First)
+	assert.Equal(t, 1, list.Last)
+	assert.Equal(t, 0, list.Prev)
+	assert.Equal(t, 0, list.Next)
+
+	require.Len(t, list.Result, 2)
+	assert.Equal(t, adminBasicUser, list.Result[0].BasicUser)
+	assert.Equal(t, feBasicUser, list.Result[1].BasicUser)
+	assert.False(t, list.Result[1].IsDeactivated())
+
+	// deactivation is persisted
+	deactivatedFe := list.Result[1]
+	deactivatedFe.SetDeactivated(true)
+	_, err = repo.UpdateFieldEngineer(ctx, channelID, deactivatedFe)
+	require.NoError(t, err)
+
+	retFe, err := repo.GetFieldEngineer(ctx, channelID, deactivatedFe.UUID())
+	require.NoError(t, err)
+	assert.True(t, retFe.IsDeactivated())
+
+	// second page
+	list, err = repo.ListFieldEngineers(ctx, channelID, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Size)
+	assert.Equal(t, 2, list.Total)
+	assert.Equal(t, 2, list.Page)
+	assert.Equal(t, 1, list.Prev)
+	assert.Equal(t, 0, list.Next)
+	require.Len(t, list.Result, 1)
+	assert.Equal(t, feBasicUser, list.Result[0].BasicUser)
 }
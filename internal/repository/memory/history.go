@@ -0,0 +1,27 @@
+package memory
+
+// HistoryEntry stored in memory storage
+type HistoryEntry struct {
+	ID string
+
+	IncidentID string
+
+	Action string
+
+	ActorID string
+
+	RequestedByID string
+
+	Time string
+
+	Changes []HistoryChange
+}
+
+// HistoryChange stored in memory storage
+type HistoryChange struct {
+	Field string
+
+	Before string
+
+	After string
+}
@@ -1,5 +1,10 @@
 package memory
 
+import (
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+)
+
 // Incident stored in memory storage
 type Incident struct {
 	ID string
@@ -14,8 +19,32 @@ type Incident struct {
 
 	FieldEngineerID string
 
+	FieldEngineerAccepted bool
+
 	State string
 
+	OnHoldReason string
+
+	RemindAt string
+
+	ResolutionCode string
+
+	ResolutionNotes string
+
+	ResolvedAt string
+
+	Impact string
+
+	Urgency string
+
+	Priority string
+
+	PriorityOverridden bool
+
+	AuditTrail []AuditRecord
+
+	SLA *IncidentSLA
+
 	Timelogs []string
 
 	CreatedAt string
@@ -25,4 +54,47 @@ type Incident struct {
 	UpdatedAt string
 
 	UpdatedBy string
+
+	Version uint
+}
+
+// AuditRecord stored in memory storage
+type AuditRecord struct {
+	Action string
+
+	ActorID string
+
+	Time string
+
+	Note string
+}
+
+// restoreIncidentPriority sets the stored impact, urgency and priority to the incident, empty values are restored as zero values
+func restoreIncidentPriority(inc *incident.Incident, impactStr, urgencyStr, priorityStr string, overridden bool) error {
+	var impact incident.Impact
+	var urgency incident.Urgency
+	var priority sla.Priority
+	var err error
+
+	if impactStr != "" {
+		if impact, err = incident.NewImpactFromString(impactStr); err != nil {
+			return err
+		}
+	}
+
+	if urgencyStr != "" {
+		if urgency, err = incident.NewUrgencyFromString(urgencyStr); err != nil {
+			return err
+		}
+	}
+
+	if priorityStr != "" {
+		if priority, err = sla.NewPriorityFromString(priorityStr); err != nil {
+			return err
+		}
+	}
+
+	inc.RestorePriority(impact, urgency, priority, overridden)
+
+	return nil
 }
@@ -3,23 +3,34 @@ package memory
 import (
 	"context"
 	"io"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
 	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 )
 
-// IncidentRepositoryMemory keeps data in memory
+// IncidentRepositoryMemory keeps data in memory, data are partitioned by channel. It is safe for concurrent use.
 type IncidentRepositoryMemory struct {
 	basicUserRepository     repository.BasicUserRepository
 	fieldEngineerRepository repository.FieldEngineerRepository
 	Rand                    io.Reader
 	clock                   repository.Clock
-	incidents               []Incident
-	timelogs                map[string]Timelog
+
+	mu        sync.RWMutex
+	incidents map[ref.ChannelID][]Incident
+	// index of incidents in the incidents slice by incident ID
+	incidentIndex map[ref.ChannelID]map[string]int
+	timelogs      map[ref.ChannelID]map[string]Timelog
+	// history entries by incident ID
+	history map[ref.ChannelID]map[string][]HistoryEntry
+	outbox  map[ref.ChannelID][]OutboxMessage
 }
 
 // NewIncidentRepositoryMemory returns new initialized repository
@@ -28,12 +39,23 @@ func NewIncidentRepositoryMemory(clock repository.Clock, basicUserRepo repositor
 		basicUserRepository:     basicUserRepo,
 		fieldEngineerRepository: fieldEngineerRepository,
 		clock:                   clock,
-		timelogs:                make(map[string]Timelog),
+		incidents:               make(map[ref.ChannelID][]Incident),
+		incidentIndex:           make(map[ref.ChannelID]map[string]int),
+		timelogs:                make(map[ref.ChannelID]map[string]Timelog),
+		history:                 make(map[ref.ChannelID]map[string][]HistoryEntry),
+		outbox:                  make(map[ref.ChannelID][]OutboxMessage),
 	}
 }
 
 // AddIncident adds the given incident to the repository
-func (r *IncidentRepositoryMemory) AddIncident(_ context.Context, _ ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+func (r *IncidentRepositoryMemory) AddIncident(ctx context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addIncident(ctx, channelID, inc)
+}
+
+func (r *IncidentRepositoryMemory) addIncident(_ context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
 	now := r.clock.NowFormatted().String()
 
 	incidentID, err := repository.GenerateUUID(r.Rand)
@@ -47,28 +69,62 @@ func (r *IncidentRepositoryMemory) AddIncident(_ context.Context, _ ref.ChannelI
 	}
 
 	storedInc := Incident{
-		ID:               incidentID.String(),
-		Number:           inc.Number,
-		ExternalID:       inc.ExternalID,
-		ShortDescription: inc.ShortDescription,
-		Description:      inc.Description,
-		FieldEngineerID:  feUUID,
-		State:            inc.State().String(),
-		CreatedBy:        inc.CreatedUpdated.CreatedByID().String(),
-		CreatedAt:        now,
-		UpdatedBy:        inc.CreatedUpdated.UpdatedByID().String(),
-		UpdatedAt:        now,
-	}
-	r.incidents = append(r.incidents, storedInc)
+		ID:                    incidentID.String(),
+		Number:                inc.Number,
+		ExternalID:            inc.ExternalID,
+		ShortDescription:      inc.ShortDescription,
+		Description:           inc.Description,
+		FieldEngineerID:       feUUID,
+		FieldEngineerAccepted: inc.FieldEngineerAccepted(),
+		State:                 inc.State().String(),
+		Impact:                inc.Impact().String(),
+		Urgency:               inc.Urgency().String(),
+		Priority:              inc.Priority().String(),
+		PriorityOverridden:    inc.PriorityOverridden(),
+		SLA:                   convertIncidentSLAToStored(inc.SLA()),
+		CreatedBy:             inc.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:             now,
+		UpdatedBy:             inc.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:             now,
+		Version:               1,
+	}
+
+	if err := storeOutboxMessages(r.outbox, r.Rand, r.clock, channelID, inc.EventsWithIncidentID(incidentID)); err != nil {
+		return ref.UUID(""), err
+	}
+
+	if r.incidentIndex[channelID] == nil {
+		r.incidentIndex[channelID] = make(map[string]int)
+	}
+	r.incidents[channelID] = append(r.incidents[channelID], storedInc)
+	r.incidentIndex[channelID][storedInc.ID] = len(r.incidents[channelID]) - 1
 
 	return incidentID, nil
 }
 
 // UpdateIncident updates the given incident in the repository
-func (r *IncidentRepositoryMemory) UpdateIncident(_ context.Context, _ ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+func (r *IncidentRepositoryMemory) UpdateIncident(ctx context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateIncident(ctx, channelID, inc)
+}
+
+func (r *IncidentRepositoryMemory) updateIncident(_ context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
 	var err error
 	now := r.clock.NowFormatted().String()
 
+	incIndex, ok := r.incidentIndex[channelID][inc.UUID().String()]
+	if !ok {
+		return inc.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating incident in repository")
+	}
+
+	storedVersion := r.incidents[channelID][incIndex].Version
+	if inc.Version() != storedVersion {
+		return inc.UUID(), domain.NewErrorf(domain.ErrorCodeVersionMismatch,
+			"incident '%s' was modified concurrently (version %d, stored version %d)", inc.UUID(), inc.Version(), storedVersion)
+	}
+
 	if inc.HasOpenTimelog() {
 		openTimelog := inc.OpenTimelog()
 		createdAt := openTimelog.CreatedUpdated.CreatedAt().String()
@@ -89,10 +145,12 @@ func (r *IncidentRepositoryMemory) UpdateIncident(_ context.Context, _ ref.Chann
 
 		storedTimelog := Timelog{
 			ID:           timelogID.String(),
+			IncidentID:   inc.UUID().String(),
 			Remote:       openTimelog.Remote,
 			Start:        openTimelog.Start.String(),
 			End:          openTimelog.End.String(),
 			Work:         openTimelog.Work,
+			Timespans:    convertTimespansToStored(openTimelog.Timespans),
 			VisitSummary: openTimelog.VisitSummary,
 			CreatedBy:    openTimelog.CreatedUpdated.CreatedByID().String(),
 			CreatedAt:    createdAt,
@@ -100,7 +158,10 @@ func (r *IncidentRepositoryMemory) UpdateIncident(_ context.Context, _ ref.Chann
 			UpdatedAt:    updatedAt,
 		}
 
-		r.timelogs[storedTimelog.ID] = storedTimelog
+		if r.timelogs[channelID] == nil {
+			r.timelogs[channelID] = make(map[string]Timelog)
+		}
+		r.timelogs[channelID][storedTimelog.ID] = storedTimelog
 	}
 
 	var timelogUUIDs []string
@@ -113,75 +174,112 @@ func (r *IncidentRepositoryMemory) UpdateIncident(_ context.Context, _ ref.Chann
 		feUUID = inc.FieldEngineerID.String()
 	}
 
+	var onHoldReason, remindAt string
+	if onHold := inc.OnHold(); onHold != nil {
+		onHoldReason = onHold.Reason.String()
+		remindAt = onHold.RemindAt.String()
+	}
+
+	var resolutionCode, resolutionNotes, resolvedAt string
+	if resolution := inc.Resolution(); resolution != nil {
+		resolutionCode = resolution.Code.String()
+		resolutionNotes = resolution.Notes
+		resolvedAt = resolution.ResolvedAt.String()
+	}
+
+	var auditTrail []AuditRecord
+	for _, record := range inc.AuditTrail() {
+		auditTrail = append(auditTrail, AuditRecord{
+			Action:  record.Action.String(),
+			ActorID: record.ActorID.String(),
+			Time:    record.Time.String(),
+			Note:    record.Note,
+		})
+	}
+
 	storedInc := Incident{
-		ID:               inc.UUID().String(),
-		Number:           inc.Number,
-		ExternalID:       inc.ExternalID,
-		ShortDescription: inc.ShortDescription,
-		Description:      inc.Description,
-		FieldEngineerID:  feUUID,
-		State:            inc.State().String(),
-		Timelogs:         timelogUUIDs,
-		CreatedBy:        inc.CreatedUpdated.CreatedByID().String(),
-		CreatedAt:        inc.CreatedUpdated.CreatedAt().String(),
-		UpdatedBy:        inc.CreatedUpdated.UpdatedByID().String(),
-		UpdatedAt:        now,
-	}
-
-	for i := range r.incidents {
-		if r.incidents[i].ID == inc.UUID().String() {
-			r.incidents[i] = storedInc
-			return inc.UUID(), nil
-		}
+		ID:                    inc.UUID().String(),
+		Number:                inc.Number,
+		ExternalID:            inc.ExternalID,
+		ShortDescription:      inc.ShortDescription,
+		Description:           inc.Description,
+		FieldEngineerID:       feUUID,
+		FieldEngineerAccepted: inc.FieldEngineerAccepted(),
+		State:                 inc.State().String(),
+		OnHoldReason:          onHoldReason,
+		RemindAt:              remindAt,
+		ResolutionCode:        resolutionCode,
+		ResolutionNotes:       resolutionNotes,
+		ResolvedAt:            resolvedAt,
+		Impact:                inc.Impact().String(),
+		Urgency:               inc.Urgency().String(),
+		Priority:              inc.Priority().String(),
+		PriorityOverridden:    inc.PriorityOverridden(),
+		AuditTrail:            auditTrail,
+		SLA:                   convertIncidentSLAToStored(inc.SLA()),
+		Timelogs:              timelogUUIDs,
+		CreatedBy:             inc.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:             inc.CreatedUpdated.CreatedAt().String(),
+		UpdatedBy:             inc.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:             now,
+		Version:               storedVersion + 1,
 	}
 
-	return inc.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating incident in repository")
+	r.incidents[channelID][incIndex] = storedInc
+
+	if err := storeOutboxMessages(r.outbox, r.Rand, r.clock, channelID, inc.Events()); err != nil {
+		return inc.UUID(), err
+	}
+
+	return inc.UUID(), nil
 }
 
 // GetIncident returns the incident with given ID from the repository
 func (r *IncidentRepositoryMemory) GetIncident(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error) {
-	var inc incident.Incident
-	var err error
-
-	for i := range r.incidents {
-		if r.incidents[i].ID == ID.String() {
-			storedInc := r.incidents[i]
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-			inc, err = r.convertStoredToDomainIncident(ctx, channelID, storedInc)
-			if err != nil {
-				return incident.Incident{}, err
-			}
+	return r.getIncident(ctx, channelID, ID)
+}
 
-			return inc, nil
-		}
+func (r *IncidentRepositoryMemory) getIncident(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error) {
+	if i, ok := r.incidentIndex[channelID][ID.String()]; ok {
+		return r.convertStoredToDomainIncident(ctx, channelID, r.incidents[channelID][i])
 	}
 
 	return incident.Incident{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading incident from repository")
 }
 
-// ListIncidents returns the list of incidents from the repository
-func (r *IncidentRepositoryMemory) ListIncidents(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.IncidentList, error) {
-	var list []incident.Incident
-
-	total := len(r.incidents)
-
-	pagination := repository.NewPagination(total, page, itemsPerPage)
+// ListIncidents returns the list of incidents matching the filter from the repository
+func (r *IncidentRepositoryMemory) ListIncidents(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page, itemsPerPage uint) (repository.IncidentList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	firstElementIndex := pagination.FirstElementIndex
-	lastElementIndex := pagination.LastElementIndex
+	return r.listIncidents(ctx, channelID, filter, page, itemsPerPage)
+}
 
-	var perPageList []Incident
-	if total > 0 {
-		perPageList = r.incidents[firstElementIndex : lastElementIndex+1]
-	}
+func (r *IncidentRepositoryMemory) listIncidents(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page, itemsPerPage uint) (repository.IncidentList, error) {
+	var matched []incident.Incident
 
-	for _, storedInc := range perPageList {
+	for _, storedInc := range r.incidents[channelID] {
 		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc)
 		if err != nil {
 			return repository.IncidentList{}, err
 		}
 
-		list = append(list, inc)
+		if filter.Match(inc) {
+			matched = append(matched, inc)
+		}
+	}
+
+	filter.Sort.SortIncidents(matched)
+
+	total := len(matched)
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []incident.Incident
+	if total > 0 {
+		list = matched[pagination.FirstElementIndex : pagination.LastElementIndex+1]
 	}
 
 	incidentList := repository.IncidentList{
@@ -191,7 +289,86 @@ func (r *IncidentRepositoryMemory) ListIncidents(ctx context.Context, channelID
 	return incidentList, nil
 }
 
-func (r IncidentRepositoryMemory) convertStoredToDomainIncident(ctx context.Context, channelID ref.ChannelID, storedInc Incident) (incident.Incident, error) {
+// ListIncidentsByCursor returns the page of incidents matching the filter requested by the cursor from the repository
+func (r *IncidentRepositoryMemory) ListIncidentsByCursor(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page repository.CursorPage) (repository.IncidentList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.listIncidentsByCursor(ctx, channelID, filter, page)
+}
+
+func (r *IncidentRepositoryMemory) listIncidentsByCursor(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page repository.CursorPage) (repository.IncidentList, error) {
+	var matched []repository.SequencedIncident
+
+	for i, storedInc := range r.incidents[channelID] {
+		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc)
+		if err != nil {
+			return repository.IncidentList{}, err
+		}
+
+		if filter.Match(inc) {
+			matched = append(matched, repository.SequencedIncident{Incident: inc, Seq: uint64(i + 1)})
+		}
+	}
+
+	return repository.PageIncidentsByCursor(matched, filter.Sort, page), nil
+}
+
+// ListResolvedIncidents returns up to limit incidents of all channels that are in Resolved state and were resolved at or before the given time
+func (r *IncidentRepositoryMemory) ListResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]repository.ChannelIncident, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.listResolvedIncidents(ctx, resolvedBefore, limit)
+}
+
+func (r *IncidentRepositoryMemory) listResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]repository.ChannelIncident, error) {
+	type resolvedIncident struct {
+		channelID  ref.ChannelID
+		resolvedAt time.Time
+		Incident
+	}
+
+	var resolved []resolvedIncident
+	for channelID, incidents := range r.incidents {
+		for _, storedInc := range incidents {
+			if storedInc.State != incident.StateResolved.String() || storedInc.ResolvedAt == "" {
+				continue
+			}
+
+			resolvedAt, err := types.DateTime(storedInc.ResolvedAt).ToTime()
+			if err != nil {
+				return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "error loading incidents from repository (resolvedAt)")
+			}
+
+			if !resolvedAt.After(resolvedBefore) {
+				resolved = append(resolved, resolvedIncident{channelID: channelID, resolvedAt: resolvedAt, Incident: storedInc})
+			}
+		}
+	}
+
+	sort.SliceStable(resolved, func(i, j int) bool {
+		return resolved[i].resolvedAt.Before(resolved[j].resolvedAt)
+	})
+
+	if uint(len(resolved)) > limit {
+		resolved = resolved[:limit]
+	}
+
+	var list []repository.ChannelIncident
+	for _, ri := range resolved {
+		inc, err := r.convertStoredToDomainIncident(ctx, ri.channelID, ri.Incident)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, repository.ChannelIncident{ChannelID: ri.channelID, Incident: inc})
+	}
+
+	return list, nil
+}
+
+func (r *IncidentRepositoryMemory) convertStoredToDomainIncident(ctx context.Context, channelID ref.ChannelID, storedInc Incident) (incident.Incident, error) {
 	var inc incident.Incident
 	errMsg := "error loading incident from repository (%s)"
 
@@ -204,11 +381,13 @@ func (r IncidentRepositoryMemory) convertStoredToDomainIncident(ctx context.Cont
 	inc.ExternalID = storedInc.ExternalID
 	inc.ShortDescription = storedInc.ShortDescription
 	inc.Description = storedInc.Description
+	inc.SetVersion(storedInc.Version)
 
 	if storedInc.FieldEngineerID != "" {
 		feUUID := ref.UUID(storedInc.FieldEngineerID)
 		inc.FieldEngineerID = &feUUID
 	}
+	inc.SetFieldEngineerAccepted(storedInc.FieldEngineerAccepted)
 
 	// set Timelogs (UUIDs)
 	var timelogUUIDs []ref.UUID
@@ -220,40 +399,12 @@ func (r IncidentRepositoryMemory) convertStoredToDomainIncident(ctx context.Cont
 
 	// load and set open timelog if any
 	for _, timelogID := range storedInc.Timelogs {
-		storedTimelog := r.timelogs[timelogID]
-
-		if storedTimelog.Work == 0 { // timelog is open
-			openTimelog := &timelog.Timelog{
-				Remote:       storedTimelog.Remote,
-				Start:        types.DateTime(storedTimelog.Start),
-				End:          types.DateTime(storedTimelog.End),
-				Work:         storedTimelog.Work,
-				VisitSummary: storedTimelog.VisitSummary,
-			}
-
-			err = openTimelog.SetUUID(ref.UUID(timelogID))
-			if err != nil {
-				return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "openTimelog.ID")
-			}
-
-			createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.CreatedBy))
-			if err != nil {
-				return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdBy")
-			}
-
-			err = openTimelog.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedTimelog.CreatedAt))
-			if err != nil {
-				return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdAt")
-			}
-
-			updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.UpdatedBy))
-			if err != nil {
-				return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedBy")
-			}
+		storedTimelog := r.timelogs[channelID][timelogID]
 
-			err = openTimelog.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedTimelog.UpdatedAt))
+		if storedTimelog.End == "" { // timelog is open
+			openTimelog, err := r.convertStoredToDomainTimelog(ctx, channelID, storedTimelog)
 			if err != nil {
-				return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedAt")
+				return incident.Incident{}, err
 			}
 
 			inc.SetOpenTimelog(openTimelog)
@@ -266,11 +417,58 @@ func (r IncidentRepositoryMemory) convertStoredToDomainIncident(ctx context.Cont
 		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "state")
 	}
 
-	err = inc.SetState(state)
+	err = inc.RestoreState(state)
 	if err != nil {
 		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "state")
 	}
 
+	if storedInc.OnHoldReason != "" {
+		reason, err := incident.NewOnHoldReasonFromString(storedInc.OnHoldReason)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "onHoldReason")
+		}
+
+		inc.SetOnHold(&incident.OnHoldInfo{
+			Reason:   reason,
+			RemindAt: types.DateTime(storedInc.RemindAt),
+		})
+	}
+
+	if storedInc.ResolutionCode != "" {
+		code, err := incident.NewResolutionCodeFromString(storedInc.ResolutionCode)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "resolutionCode")
+		}
+
+		inc.SetResolution(&incident.Resolution{
+			Code:       code,
+			Notes:      storedInc.ResolutionNotes,
+			ResolvedAt: types.DateTime(storedInc.ResolvedAt),
+		})
+	}
+
+	err = restoreIncidentPriority(&inc, storedInc.Impact, storedInc.Urgency, storedInc.Priority, storedInc.PriorityOverridden)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "priority")
+	}
+
+	var auditTrail []incident.AuditRecord
+	for _, record := range storedInc.AuditTrail {
+		auditTrail = append(auditTrail, incident.AuditRecord{
+			Action:  incident.AllowedAction(record.Action),
+			ActorID: ref.UUID(record.ActorID),
+			Time:    types.DateTime(record.Time),
+			Note:    record.Note,
+		})
+	}
+	inc.SetAuditTrail(auditTrail)
+
+	incSLA, err := convertStoredToDomainIncidentSLA(storedInc.SLA)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "sla")
+	}
+	inc.SetSLA(incSLA)
+
 	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedInc.CreatedBy))
 	if err != nil {
 		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.CreatedBy")
@@ -295,26 +493,375 @@ func (r IncidentRepositoryMemory) convertStoredToDomainIncident(ctx context.Cont
 }
 
 // GetIncidentTimelog returns the incident's timelog with the given ID from the repository
-func (r IncidentRepositoryMemory) GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
-	var tmlg timelog.Timelog
+func (r *IncidentRepositoryMemory) GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.getIncidentTimelog(ctx, channelID, incID, timelogID)
+}
+
+func (r *IncidentRepositoryMemory) getIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
+	if _, ok := r.incidentIndex[channelID][incID.String()]; !ok {
+		return timelog.Timelog{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading incident from repository")
+	}
+
+	storedTimelog, ok := r.timelogs[channelID][timelogID.String()]
+	if !ok || storedTimelog.IncidentID != incID.String() {
+		return timelog.Timelog{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading timelog from repository")
+	}
+
+	tmlg, err := r.convertStoredToDomainTimelog(ctx, channelID, storedTimelog)
+	if err != nil {
+		return timelog.Timelog{}, err
+	}
+
+	return *tmlg, nil
+}
+
+// UpdateIncidentTimelog updates the given closed timelog of the incident in the repository
+func (r *IncidentRepositoryMemory) UpdateIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, tmlg timelog.Timelog) (ref.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateIncidentTimelog(ctx, channelID, incID, tmlg)
+}
+
+func (r *IncidentRepositoryMemory) updateIncidentTimelog(_ context.Context, channelID ref.ChannelID, incID ref.UUID, tmlg timelog.Timelog) (ref.UUID, error) {
+	if _, ok := r.incidentIndex[channelID][incID.String()]; !ok {
+		return tmlg.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating timelog in repository")
+	}
+
+	storedTimelog, ok := r.timelogs[channelID][tmlg.UUID().String()]
+	if !ok || storedTimelog.IncidentID != incID.String() {
+		return tmlg.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating timelog in repository")
+	}
+
+	storedTimelog.Remote = tmlg.Remote
+	storedTimelog.Start = tmlg.Start.String()
+	storedTimelog.End = tmlg.End.String()
+	storedTimelog.Work = tmlg.Work
+	storedTimelog.Timespans = convertTimespansToStored(tmlg.Timespans)
+	storedTimelog.VisitSummary = tmlg.VisitSummary
+	storedTimelog.Corrections = convertCorrectionsToStored(tmlg.Corrections)
+	storedTimelog.Original = convertOriginalToStored(tmlg.Original)
+	storedTimelog.UpdatedBy = tmlg.CreatedUpdated.UpdatedByID().String()
+	storedTimelog.UpdatedAt = r.clock.NowFormatted().String()
+
+	r.timelogs[channelID][storedTimelog.ID] = storedTimelog
+
+	return tmlg.UUID(), nil
+}
+
+// ListIncidentTimelogs returns the list of the incident's timelogs from the repository
+func (r *IncidentRepositoryMemory) ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.TimelogList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.listIncidentTimelogs(ctx, channelID, incID, page, itemsPerPage)
+}
+
+func (r *IncidentRepositoryMemory) listIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.TimelogList, error) {
+	incIndex, ok := r.incidentIndex[channelID][incID.String()]
+	if !ok {
+		return repository.TimelogList{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading incident from repository")
+	}
+
+	timelogIDs := r.incidents[channelID][incIndex].Timelogs
+
+	total := len(timelogIDs)
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []timelog.Timelog
+	if pagination.Size > 0 {
+		for _, timelogID := range timelogIDs[pagination.FirstElementIndex : pagination.LastElementIndex+1] {
+			tmlg, err := r.convertStoredToDomainTimelog(ctx, channelID, r.timelogs[channelID][timelogID])
+			if err != nil {
+				return repository.TimelogList{}, err
+			}
+			list = append(list, *tmlg)
+		}
+	}
+
+	timelogList := repository.TimelogList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return timelogList, nil
+}
+
+func (r *IncidentRepositoryMemory) convertStoredToDomainTimelog(ctx context.Context, channelID ref.ChannelID, storedTimelog Timelog) (*timelog.Timelog, error) {
+	errMsg := "error loading timelog from repository (%s)"
 
-	_, err := r.GetIncident(ctx, channelID, incID)
+	timespans, err := convertStoredToDomainTimespans(storedTimelog.Timespans)
 	if err != nil {
-		return tmlg, err
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Timespans")
 	}
 
-	for _, storedTimelog := range r.timelogs {
-		if storedTimelog.ID == timelogID.String() {
-			tmlg = timelog.Timelog{
-				Remote:       storedTimelog.Remote,
-				Start:        types.DateTime(storedTimelog.Start),
-				End:          types.DateTime(storedTimelog.End),
-				Work:         storedTimelog.Work,
-				VisitSummary: storedTimelog.VisitSummary,
+	corrections, err := convertStoredToDomainCorrections(storedTimelog.Corrections)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Corrections")
+	}
+
+	original, err := convertStoredToDomainOriginal(storedTimelog.Original)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Original")
+	}
+
+	tmlg := &timelog.Timelog{
+		Remote:       storedTimelog.Remote,
+		Start:        types.DateTime(storedTimelog.Start),
+		End:          types.DateTime(storedTimelog.End),
+		Work:         storedTimelog.Work,
+		Timespans:    timespans,
+		VisitSummary: storedTimelog.VisitSummary,
+		Corrections:  corrections,
+		Original:     original,
+	}
+
+	err = tmlg.SetUUID(ref.UUID(storedTimelog.ID))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.ID")
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.CreatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdBy")
+	}
+
+	err = tmlg.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedTimelog.CreatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.UpdatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedBy")
+	}
+
+	err = tmlg.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedTimelog.UpdatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedAt")
+	}
+
+	return tmlg, nil
+}
+
+func convertTimespansToStored(timespans []timelog.Timespan) []Timespan {
+	var storedTimespans []Timespan
+	for _, span := range timespans {
+		storedTimespans = append(storedTimespans, Timespan{
+			Type:  span.Type.String(),
+			Start: span.Start.String(),
+			End:   span.End.String(),
+		})
+	}
+
+	return storedTimespans
+}
+
+func convertStoredToDomainTimespans(storedTimespans []Timespan) ([]timelog.Timespan, error) {
+	var timespans []timelog.Timespan
+	for _, storedSpan := range storedTimespans {
+		spanType, err := timelog.NewTimespanTypeFromString(storedSpan.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		timespans = append(timespans, timelog.Timespan{
+			Type:  spanType,
+			Start: types.DateTime(storedSpan.Start),
+			End:   types.DateTime(storedSpan.End),
+		})
+	}
+
+	return timespans, nil
+}
+
+func convertCorrectionsToStored(corrections []timelog.Correction) []TimelogCorrection {
+	var storedCorrections []TimelogCorrection
+	for _, c := range corrections {
+		storedCorrections = append(storedCorrections, TimelogCorrection{
+			Remote:       c.Remote,
+			Start:        c.Start.String(),
+			End:          c.End.String(),
+			VisitSummary: c.VisitSummary,
+			Reason:       c.Reason,
+			Status:       c.Status.String(),
+			ProposedBy:   c.ProposedBy.String(),
+			ProposedAt:   c.ProposedAt.String(),
+			DecidedBy:    c.DecidedBy.String(),
+			DecidedAt:    c.DecidedAt.String(),
+			DecisionNote: c.DecisionNote,
+		})
+	}
+
+	return storedCorrections
+}
+
+func convertStoredToDomainCorrections(storedCorrections []TimelogCorrection) ([]timelog.Correction, error) {
+	var corrections []timelog.Correction
+	for _, c := range storedCorrections {
+		status, err := timelog.NewCorrectionStatusFromString(c.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		corrections = append(corrections, timelog.Correction{
+			Remote:       c.Remote,
+			Start:        types.DateTime(c.Start),
+			End:          types.DateTime(c.End),
+			VisitSummary: c.VisitSummary,
+			Reason:       c.Reason,
+			Status:       status,
+			ProposedBy:   ref.UUID(c.ProposedBy),
+			ProposedAt:   types.DateTime(c.ProposedAt),
+			DecidedBy:    ref.UUID(c.DecidedBy),
+			DecidedAt:    types.DateTime(c.DecidedAt),
+			DecisionNote: c.DecisionNote,
+		})
+	}
+
+	return corrections, nil
+}
+
+func convertOriginalToStored(original *timelog.OriginalValues) *TimelogOriginal {
+	if original == nil {
+		return nil
+	}
+
+	return &TimelogOriginal{
+		Remote:       original.Remote,
+		Start:        original.Start.String(),
+		End:          original.End.String(),
+		Work:         original.Work,
+		Timespans:    convertTimespansToStored(original.Timespans),
+		VisitSummary: original.VisitSummary,
+	}
+}
+
+func convertStoredToDomainOriginal(storedOriginal *TimelogOriginal) (*timelog.OriginalValues, error) {
+	if storedOriginal == nil {
+		return nil, nil
+	}
+
+	timespans, err := convertStoredToDomainTimespans(storedOriginal.Timespans)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timelog.OriginalValues{
+		Remote:       storedOriginal.Remote,
+		Start:        types.DateTime(storedOriginal.Start),
+		End:          types.DateTime(storedOriginal.End),
+		Work:         storedOriginal.Work,
+		Timespans:    timespans,
+		VisitSummary: storedOriginal.VisitSummary,
+	}, nil
+}
+
+// AddIncidentHistoryEntry adds the given history entry of the incident to the repository
+func (r *IncidentRepositoryMemory) AddIncidentHistoryEntry(ctx context.Context, channelID ref.ChannelID, entry history.Entry) (ref.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addIncidentHistoryEntry(ctx, channelID, entry)
+}
+
+func (r *IncidentRepositoryMemory) addIncidentHistoryEntry(_ context.Context, channelID ref.ChannelID, entry history.Entry) (ref.UUID, error) {
+	if _, ok := r.incidentIndex[channelID][entry.IncidentID.String()]; !ok {
+		return ref.UUID(""), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error adding history entry to repository")
+	}
+
+	entryID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	var changes []HistoryChange
+	for _, change := range entry.Changes {
+		changes = append(changes, HistoryChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	storedEntry := HistoryEntry{
+		ID:            entryID.String(),
+		IncidentID:    entry.IncidentID.String(),
+		Action:        entry.Action,
+		ActorID:       entry.ActorID.String(),
+		RequestedByID: entry.RequestedByID.String(),
+		Time:          entry.Time.String(),
+		Changes:       changes,
+	}
+
+	if r.history[channelID] == nil {
+		r.history[channelID] = make(map[string][]HistoryEntry)
+	}
+	r.history[channelID][storedEntry.IncidentID] = append(r.history[channelID][storedEntry.IncidentID], storedEntry)
+
+	return entryID, nil
+}
+
+// ListIncidentHistory returns the list of the incident's history entries from the repository, the oldest entries are listed first
+func (r *IncidentRepositoryMemory) ListIncidentHistory(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.HistoryList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.listIncidentHistory(ctx, channelID, incID, page, itemsPerPage)
+}
+
+func (r *IncidentRepositoryMemory) listIncidentHistory(_ context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.HistoryList, error) {
+	if _, ok := r.incidentIndex[channelID][incID.String()]; !ok {
+		return repository.HistoryList{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading incident from repository")
+	}
+
+	entries := r.history[channelID][incID.String()]
+
+	total := len(entries)
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []history.Entry
+	if pagination.Size > 0 {
+		for _, storedEntry := range entries[pagination.FirstElementIndex : pagination.LastElementIndex+1] {
+			entry, err := convertStoredToDomainHistoryEntry(storedEntry)
+			if err != nil {
+				return repository.HistoryList{}, err
 			}
-			return tmlg, nil
+			list = append(list, entry)
 		}
 	}
 
-	return tmlg, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading ticket from repository")
+	historyList := repository.HistoryList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return historyList, nil
+}
+
+func convertStoredToDomainHistoryEntry(storedEntry HistoryEntry) (history.Entry, error) {
+	var changes []history.Change
+	for _, change := range storedEntry.Changes {
+		changes = append(changes, history.Change{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	entry := history.Entry{
+		IncidentID:    ref.UUID(storedEntry.IncidentID),
+		Action:        storedEntry.Action,
+		ActorID:       ref.UUID(storedEntry.ActorID),
+		RequestedByID: ref.UUID(storedEntry.RequestedByID),
+		Time:          types.DateTime(storedEntry.Time),
+		Changes:       changes,
+	}
+
+	if err := entry.SetUUID(ref.UUID(storedEntry.ID)); err != nil {
+		return history.Entry{}, err
+	}
+
+	return entry, nil
 }
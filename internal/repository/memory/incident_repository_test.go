@@ -10,11 +10,14 @@ import (
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
 	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
 	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestIncidentRepositoryMemory_AddingAndGettingIncident(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+
 	basicUser := user.BasicUser{
 		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
 		Name:             "Alfred",
@@ -26,14 +29,11 @@ func TestIncidentRepositoryMemory_AddingAndGettingIncident(t *testing.T) {
 	require.NoError(t, err)
 
 	clock := mocks.NewFixedClock()
-	basicUserRepository := &BasicUserRepositoryMemory{
-		users: []user.BasicUser{basicUser},
-	}
+	basicUserRepository := newBasicUserRepositoryWithUsers(channelID, basicUser)
 	fieldEngineerRepository := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
 
 	repo := NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
 
-	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
 	ctx := context.Background()
 
 	inc1 := incident.Incident{
@@ -42,7 +42,7 @@ func TestIncidentRepositoryMemory_AddingAndGettingIncident(t *testing.T) {
 		ShortDescription: "some short description",
 		Description:      "some description",
 	}
-	err = inc1.SetState(incident.StateNew)
+	err = inc1.RestoreState(incident.StateNew)
 	require.NoError(t, err)
 	err = inc1.CreatedUpdated.SetCreatedBy(basicUser)
 	require.NoError(t, err)
@@ -74,6 +74,8 @@ func TestIncidentRepositoryMemory_AddingAndGettingIncident(t *testing.T) {
 }
 
 func TestIncidentRepositoryMemory_UpdateIncident(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+
 	basicUser := user.BasicUser{
 		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
 		Name:             "Alfred",
@@ -94,9 +96,7 @@ func TestIncidentRepositoryMemory_UpdateIncident(t *testing.T) {
 	err = basicUser2.SetUUID("00271cb4-3716-4203-9124-1d2f515ae0b2")
 	require.NoError(t, err)
 
-	basicUserRepository := &BasicUserRepositoryMemory{
-		users: []user.BasicUser{basicUser, basicUser2},
-	}
+	basicUserRepository := newBasicUserRepositoryWithUsers(channelID, basicUser, basicUser2)
 
 	fieldEngineerUUID := ref.UUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
 	fieldEngineer := fieldengineer.FieldEngineer{
@@ -118,15 +118,12 @@ func TestIncidentRepositoryMemory_UpdateIncident(t *testing.T) {
 		UpdatedBy:   basicUser.UUID().String(),
 	}
 
-	fieldEngineerRepository := &FieldEngineerRepositoryMemory{
-		basicUserRepository: basicUserRepository,
-		fieldEngineers:      []FieldEngineer{storedFieldEngineer},
-	}
-
 	clock := mocks.NewFixedClock()
+	fieldEngineerRepository := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	fieldEngineerRepository.storeFieldEngineer(channelID, storedFieldEngineer)
+
 	repo := NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
 
-	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
 	ctx := context.Background()
 
 	inc1 := incident.Incident{
@@ -135,7 +132,7 @@ func TestIncidentRepositoryMemory_UpdateIncident(t *testing.T) {
 		ShortDescription: "some short description",
 		Description:      "some description",
 	}
-	err = inc1.SetState(incident.StateNew)
+	err = inc1.RestoreState(incident.StateNew)
 	require.NoError(t, err)
 	err = inc1.CreatedUpdated.SetCreatedBy(basicUser)
 	require.NoError(t, err)
@@ -151,7 +148,7 @@ func TestIncidentRepositoryMemory_UpdateIncident(t *testing.T) {
 	changedDescription := "some changed description"
 	retInc.Description = changedDescription
 	retInc.FieldEngineerID = &fieldEngineerUUID
-	err = retInc.SetState(incident.StateInProgress)
+	err = retInc.RestoreState(incident.StateInProgress)
 	require.NoError(t, err)
 
 	// set open timelog
@@ -185,6 +182,8 @@ func TestIncidentRepositoryMemory_UpdateIncident(t *testing.T) {
 }
 
 func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+
 	basicUser := user.BasicUser{
 		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
 		Name:             "Alfred",
@@ -205,19 +204,15 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 	require.NoError(t, err)
 
 	clock := mocks.NewFixedClock()
-	basicUserRepository := &BasicUserRepositoryMemory{
-		users: []user.BasicUser{basicUser, basicUser2},
-	}
+	basicUserRepository := newBasicUserRepositoryWithUsers(channelID, basicUser, basicUser2)
 	fieldEngineerRepository := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
 
 	repo := NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
 
-	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
-
 	ctx := context.Background()
 
 	// empty list
-	emptyList, err := repo.ListIncidents(ctx, channelID, 1, 10)
+	emptyList, err := repo.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 10)
 	require.NoError(t, err)
 
 	// pagination
@@ -236,7 +231,7 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 		ShortDescription: "some short description",
 		Description:      "some description",
 	}
-	err = inc1.SetState(incident.StateNew)
+	err = inc1.RestoreState(incident.StateNew)
 	require.NoError(t, err)
 	err = inc1.CreatedUpdated.SetCreatedBy(basicUser)
 	require.NoError(t, err)
@@ -249,7 +244,7 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 		ShortDescription: "some short description 2",
 		Description:      "some description 2",
 	}
-	err = inc2.SetState(incident.StateCancelled)
+	err = inc2.RestoreState(incident.StateCancelled)
 	require.NoError(t, err)
 	err = inc2.CreatedUpdated.SetCreatedBy(basicUser)
 	require.NoError(t, err)
@@ -263,7 +258,7 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 	require.NoError(t, err)
 
 	// first page
-	incidentsList, err := repo.ListIncidents(ctx, channelID, 1, 10)
+	incidentsList, err := repo.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 10)
 	require.NoError(t, err)
 
 	// pagination
@@ -305,7 +300,7 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 	}
 
 	// second page out of range
-	incidentsList, err = repo.ListIncidents(ctx, channelID, 2, 10)
+	incidentsList, err = repo.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 2, 10)
 	require.NoError(t, err)
 
 	list = incidentsList.Result
@@ -321,7 +316,7 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 	assert.Equal(t, 0, incidentsList.Next)
 
 	// first page with small number per page
-	incidentsList, err = repo.ListIncidents(ctx, channelID, 1, 1)
+	incidentsList, err = repo.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 1)
 	require.NoError(t, err)
 
 	// pagination
@@ -337,7 +332,7 @@ func TestIncidentRepositoryMemory_ListIncidents(t *testing.T) {
 	assert.Len(t, list, 1)
 
 	// second page with small number per page
-	incidentsList, err = repo.ListIncidents(ctx, channelID, 2, 1)
+	incidentsList, err = repo.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 2, 1)
 	require.NoError(t, err)
 
 	// pagination
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync/atomic"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// OutboxMessage stored in memory storage
+type OutboxMessage struct {
+	// Seq orders the messages stored by all memory repositories
+	Seq uint64
+
+	ID string
+
+	EventType string
+
+	AggregateID string
+
+	Payload string
+
+	OccurredAt string
+}
+
+// outboxSeq is the sequence number of the last stored outbox message
+var outboxSeq uint64
+
+// storeOutboxMessages appends messages of the events recorded by the aggregate to the outbox of the channel
+func storeOutboxMessages(outbox map[ref.ChannelID][]OutboxMessage, rand io.Reader, clock repository.Clock, channelID ref.ChannelID, events []event.Event) error {
+	messages, err := repository.NewOutboxMessages(rand, clock, channelID, events)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		outbox[channelID] = append(outbox[channelID], OutboxMessage{
+			Seq:         atomic.AddUint64(&outboxSeq, 1),
+			ID:          msg.ID.String(),
+			EventType:   msg.EventType.String(),
+			AggregateID: msg.AggregateID.String(),
+			Payload:     string(msg.Payload),
+			OccurredAt:  msg.OccurredAt.String(),
+		})
+	}
+
+	return nil
+}
+
+// OutboxRepositoryMemory reads the outbox messages stored by the field engineer and incident memory repositories
+type OutboxRepositoryMemory struct {
+	fieldEngineerRepository *FieldEngineerRepositoryMemory
+	incidentRepository      *IncidentRepositoryMemory
+}
+
+// NewOutboxRepositoryMemory returns new outbox repository over the given repositories
+func NewOutboxRepositoryMemory(fieldEngineerRepository *FieldEngineerRepositoryMemory, incidentRepository *IncidentRepositoryMemory) *OutboxRepositoryMemory {
+	return &OutboxRepositoryMemory{
+		fieldEngineerRepository: fieldEngineerRepository,
+		incidentRepository:      incidentRepository,
+	}
+}
+
+type channelOutboxMessage struct {
+	channelID ref.ChannelID
+	OutboxMessage
+}
+
+// ListPendingMessages returns up to limit messages that were not delivered yet, in the order they were stored
+func (r *OutboxRepositoryMemory) ListPendingMessages(_ context.Context, limit uint) ([]repository.OutboxMessage, error) {
+	var pending []channelOutboxMessage
+
+	r.incidentRepository.mu.RLock()
+	pending = appendOutboxMessages(pending, r.incidentRepository.outbox)
+	r.incidentRepository.mu.RUnlock()
+
+	r.fieldEngineerRepository.mu.RLock()
+	pending = appendOutboxMessages(pending, r.fieldEngineerRepository.outbox)
+	r.fieldEngineerRepository.mu.RUnlock()
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Seq < pending[j].Seq
+	})
+
+	if uint(len(pending)) > limit {
+		pending = pending[:limit]
+	}
+
+	var messages []repository.OutboxMessage
+	for _, msg := range pending {
+		messages = append(messages, repository.OutboxMessage{
+			ID:          ref.UUID(msg.ID),
+			ChannelID:   msg.channelID,
+			EventType:   event.Type(msg.EventType),
+			AggregateID: ref.UUID(msg.AggregateID),
+			Payload:     []byte(msg.Payload),
+			OccurredAt:  types.DateTime(msg.OccurredAt),
+		})
+	}
+
+	return messages, nil
+}
+
+func appendOutboxMessages(pending []channelOutboxMessage, outbox map[ref.ChannelID][]OutboxMessage) []channelOutboxMessage {
+	for channelID, messages := range outbox {
+		for _, msg := range messages {
+			pending = append(pending, channelOutboxMessage{channelID: channelID, OutboxMessage: msg})
+		}
+	}
+	return pending
+}
+
+// MarkMessageDelivered removes the delivered message from the pending messages
+func (r *OutboxRepositoryMemory) MarkMessageDelivered(_ context.Context, ID ref.UUID) error {
+	r.incidentRepository.mu.Lock()
+	removed := removeOutboxMessage(r.incidentRepository.outbox, ID)
+	r.incidentRepository.mu.Unlock()
+
+	if !removed {
+		r.fieldEngineerRepository.mu.Lock()
+		removed = removeOutboxMessage(r.fieldEngineerRepository.outbox, ID)
+		r.fieldEngineerRepository.mu.Unlock()
+	}
+
+	if !removed {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error marking outbox message '%s' as delivered", ID)
+	}
+
+	return nil
+}
+
+func removeOutboxMessage(outbox map[ref.ChannelID][]OutboxMessage, ID ref.UUID) bool {
+	for channelID, messages := range outbox {
+		for i, msg := range messages {
+			if msg.ID == ID.String() {
+				outbox[channelID] = append(messages[:i:i], messages[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package memory
+
+// PriorityMatrix stored in memory storage
+type PriorityMatrix struct {
+	Priorities []PriorityMatrixEntry
+
+	CreatedAt string
+
+	CreatedBy string
+
+	UpdatedAt string
+
+	UpdatedBy string
+}
+
+// PriorityMatrixEntry stored in memory storage
+type PriorityMatrixEntry struct {
+	Impact string
+
+	Urgency string
+
+	Priority string
+}
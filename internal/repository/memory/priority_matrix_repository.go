@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// PriorityMatrixRepositoryMemory keeps data in memory, every channel has at most one priority matrix. It is safe for concurrent use.
+type PriorityMatrixRepositoryMemory struct {
+	basicUserRepository repository.BasicUserRepository
+	clock               repository.Clock
+
+	mu       sync.RWMutex
+	matrices map[ref.ChannelID]PriorityMatrix
+}
+
+// NewPriorityMatrixRepositoryMemory returns new initialized repository
+func NewPriorityMatrixRepositoryMemory(clock repository.Clock, basicUserRepo repository.BasicUserRepository) *PriorityMatrixRepositoryMemory {
+	return &PriorityMatrixRepositoryMemory{
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+		matrices:            make(map[ref.ChannelID]PriorityMatrix),
+	}
+}
+
+// SetPriorityMatrix stores the priority matrix of the channel, the current matrix (if any) is replaced
+func (r *PriorityMatrixRepositoryMemory) SetPriorityMatrix(_ context.Context, channelID ref.ChannelID, matrix incident.PriorityMatrix) error {
+	now := r.clock.NowFormatted().String()
+
+	storedMatrix := PriorityMatrix{
+		CreatedBy: matrix.CreatedUpdated.CreatedByID().String(),
+		CreatedAt: now,
+		UpdatedBy: matrix.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt: now,
+	}
+
+	for _, impact := range incident.Impacts() {
+		for _, urgency := range incident.Urgencies() {
+			if priority := matrix.PriorityFor(impact, urgency); !priority.IsZero() {
+				storedMatrix.Priorities = append(storedMatrix.Priorities, PriorityMatrixEntry{
+					Impact:   impact.String(),
+					Urgency:  urgency.String(),
+					Priority: priority.String(),
+				})
+			}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// replaced matrix keeps the information about its creation
+	if currentMatrix, ok := r.matrices[channelID]; ok {
+		storedMatrix.CreatedBy = currentMatrix.CreatedBy
+		storedMatrix.CreatedAt = currentMatrix.CreatedAt
+	}
+
+	r.matrices[channelID] = storedMatrix
+
+	return nil
+}
+
+// GetPriorityMatrix returns the priority matrix of the channel from the repository
+func (r *PriorityMatrixRepositoryMemory) GetPriorityMatrix(ctx context.Context, channelID ref.ChannelID) (incident.PriorityMatrix, error) {
+	r.mu.RLock()
+	storedMatrix, ok := r.matrices[channelID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading priority matrix from repository")
+	}
+
+	return r.convertStoredToDomainPriorityMatrix(ctx, channelID, storedMatrix)
+}
+
+func (r *PriorityMatrixRepositoryMemory) convertStoredToDomainPriorityMatrix(ctx context.Context, channelID ref.ChannelID, storedMatrix PriorityMatrix) (incident.PriorityMatrix, error) {
+	errMsg := "error loading priority matrix from repository (%s)"
+
+	matrix := incident.PriorityMatrix{
+		Priorities: make(map[incident.ImpactUrgency]sla.Priority),
+	}
+
+	for _, entry := range storedMatrix.Priorities {
+		impact, err := incident.NewImpactFromString(entry.Impact)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		urgency, err := incident.NewUrgencyFromString(entry.Urgency)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		priority, err := sla.NewPriorityFromString(entry.Priority)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		matrix.Priorities[incident.ImpactUrgency{Impact: impact, Urgency: urgency}] = priority
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedMatrix.CreatedBy))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.CreatedBy")
+	}
+
+	err = matrix.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedMatrix.CreatedAt))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedMatrix.UpdatedBy))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.UpdatedBy")
+	}
+
+	err = matrix.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedMatrix.UpdatedAt))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.UpdatedAt")
+	}
+
+	return matrix, nil
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// SLARepositoryMemory keeps data in memory, every channel has at most one SLA definition. It is safe for concurrent use.
+type SLARepositoryMemory struct {
+	basicUserRepository repository.BasicUserRepository
+	clock               repository.Clock
+
+	mu          sync.RWMutex
+	definitions map[ref.ChannelID]SLADefinition
+}
+
+// NewSLARepositoryMemory returns new initialized repository
+func NewSLARepositoryMemory(clock repository.Clock, basicUserRepo repository.BasicUserRepository) *SLARepositoryMemory {
+	return &SLARepositoryMemory{
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+		definitions:         make(map[ref.ChannelID]SLADefinition),
+	}
+}
+
+// SetSLADefinition stores the SLA definition of the channel, the current definition (if any) is replaced
+func (r *SLARepositoryMemory) SetSLADefinition(_ context.Context, channelID ref.ChannelID, def sla.Definition) error {
+	now := r.clock.NowFormatted().String()
+
+	storedDef := SLADefinition{
+		DefaultPriority: def.DefaultPriority.String(),
+		Calendar:        convertCalendarToStored(def.Calendar),
+		CreatedBy:       def.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:       now,
+		UpdatedBy:       def.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:       now,
+	}
+
+	for _, priority := range sla.Priorities() {
+		if target, ok := def.Targets[priority]; ok {
+			storedDef.Targets = append(storedDef.Targets, SLATarget{
+				Priority:   priority.String(),
+				Response:   int64(target.Response / time.Second),
+				Resolution: int64(target.Resolution / time.Second),
+			})
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// replaced definition keeps the information about its creation
+	if currentDef, ok := r.definitions[channelID]; ok {
+		storedDef.CreatedBy = currentDef.CreatedBy
+		storedDef.CreatedAt = currentDef.CreatedAt
+	}
+
+	r.definitions[channelID] = storedDef
+
+	return nil
+}
+
+// GetSLADefinition returns the SLA definition of the channel from the repository
+func (r *SLARepositoryMemory) GetSLADefinition(ctx context.Context, channelID ref.ChannelID) (sla.Definition, error) {
+	r.mu.RLock()
+	storedDef, ok := r.definitions[channelID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return sla.Definition{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading SLA definition from repository")
+	}
+
+	return r.convertStoredToDomainDefinition(ctx, channelID, storedDef)
+}
+
+func (r *SLARepositoryMemory) convertStoredToDomainDefinition(ctx context.Context, channelID ref.ChannelID, storedDef SLADefinition) (sla.Definition, error) {
+	errMsg := "error loading SLA definition from repository (%s)"
+
+	def := sla.Definition{
+		Targets:  make(map[sla.Priority]sla.Target),
+		Calendar: convertStoredToDomainCalendar(storedDef.Calendar),
+	}
+
+	var err error
+	def.DefaultPriority, err = sla.NewPriorityFromString(storedDef.DefaultPriority)
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.DefaultPriority")
+	}
+
+	for _, storedTarget := range storedDef.Targets {
+		priority, err := sla.NewPriorityFromString(storedTarget.Priority)
+		if err != nil {
+			return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.Targets")
+		}
+
+		def.Targets[priority] = sla.Target{
+			Response:   time.Duration(storedTarget.Response) * time.Second,
+			Resolution: time.Duration(storedTarget.Resolution) * time.Second,
+		}
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedDef.CreatedBy))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.CreatedBy")
+	}
+
+	err = def.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedDef.CreatedAt))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedDef.UpdatedBy))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.UpdatedBy")
+	}
+
+	err = def.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedDef.UpdatedAt))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.UpdatedAt")
+	}
+
+	return def, nil
+}
@@ -6,6 +6,8 @@ type TimeSession struct {
 
 	State string
 
+	StateChangedAt string
+
 	Incidents []IncidentInfo
 
 	Work uint
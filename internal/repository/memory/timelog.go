@@ -4,6 +4,8 @@ package memory
 type Timelog struct {
 	ID string
 
+	IncidentID string
+
 	Remote bool
 
 	Start string
@@ -12,8 +14,14 @@ type Timelog struct {
 
 	Work uint
 
+	Timespans []Timespan
+
 	VisitSummary string
 
+	Corrections []TimelogCorrection
+
+	Original *TimelogOriginal
+
 	CreatedAt string
 
 	CreatedBy string
@@ -22,3 +30,52 @@ type Timelog struct {
 
 	UpdatedBy string
 }
+
+// Timespan stored in memory storage
+type Timespan struct {
+	Type string
+
+	Start string
+
+	End string
+}
+
+// TimelogCorrection stored in memory storage
+type TimelogCorrection struct {
+	Remote bool
+
+	Start string
+
+	End string
+
+	VisitSummary string
+
+	Reason string
+
+	Status string
+
+	ProposedBy string
+
+	ProposedAt string
+
+	DecidedBy string
+
+	DecidedAt string
+
+	DecisionNote string
+}
+
+// TimelogOriginal stored in memory storage
+type TimelogOriginal struct {
+	Remote bool
+
+	Start string
+
+	End string
+
+	Work uint
+
+	Timespans []Timespan
+
+	VisitSummary string
+}
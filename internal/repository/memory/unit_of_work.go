@@ -0,0 +1,304 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// UnitOfWorkMemory runs operations atomically over the memory repositories.
+// Repositories are locked for the whole unit of work, the previous values of the changed records are recorded
+// and restored on rollback.
+type UnitOfWorkMemory struct {
+	fieldEngineerRepository *FieldEngineerRepositoryMemory
+	incidentRepository      *IncidentRepositoryMemory
+}
+
+// NewUnitOfWorkMemory returns new unit of work over the given repositories
+func NewUnitOfWorkMemory(fieldEngineerRepository *FieldEngineerRepositoryMemory, incidentRepository *IncidentRepositoryMemory) *UnitOfWorkMemory {
+	return &UnitOfWorkMemory{
+		fieldEngineerRepository: fieldEngineerRepository,
+		incidentRepository:      incidentRepository,
+	}
+}
+
+// Do calls fn with the repositories bound to the unit of work, changes are rolled back if fn returns error
+func (u *UnitOfWorkMemory) Do(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	u.incidentRepository.mu.Lock()
+	defer u.incidentRepository.mu.Unlock()
+
+	u.fieldEngineerRepository.mu.Lock()
+	defer u.fieldEngineerRepository.mu.Unlock()
+
+	undo := &undoLog{}
+	feTx := &fieldEngineerRepositoryMemoryTx{repo: u.fieldEngineerRepository, undo: undo}
+	incTx := &incidentRepositoryMemoryTx{repo: u.incidentRepository, undo: undo}
+
+	if err := fn(ctx, repository.Repositories{FieldEngineer: feTx, Incident: incTx}); err != nil {
+		undo.rollback()
+		return err
+	}
+
+	return nil
+}
+
+// undoLog records functions restoring the previous values of the records changed in the unit of work
+type undoLog struct {
+	entries []func()
+}
+
+// add records the function restoring the previous value of the changed record
+func (u *undoLog) add(fn func()) {
+	u.entries = append(u.entries, fn)
+}
+
+// rollback restores the previous values of the changed records in the reverse order of the changes
+func (u *undoLog) rollback() {
+	for i := len(u.entries) - 1; i >= 0; i-- {
+		u.entries[i]()
+	}
+	u.entries = nil
+}
+
+// fieldEngineerRepositoryMemoryTx accesses the locked field engineer repository within the unit of work
+type fieldEngineerRepositoryMemoryTx struct {
+	repo *FieldEngineerRepositoryMemory
+	undo *undoLog
+}
+
+func (r *fieldEngineerRepositoryMemoryTx) AddFieldEngineer(ctx context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	fieldEngineers, existed := r.repo.fieldEngineers[channelID]
+
+	feID, err := r.repo.addFieldEngineer(ctx, channelID, fe)
+	if err != nil {
+		return feID, err
+	}
+
+	basicUserID := fe.BasicUser.UUID().String()
+	r.undo.add(func() {
+		delete(r.repo.fieldEngineerIndex[channelID], feID.String())
+		delete(r.repo.basicUserIndex[channelID], basicUserID)
+		if existed {
+			r.repo.fieldEngineers[channelID] = fieldEngineers
+		} else {
+			delete(r.repo.fieldEngineers, channelID)
+		}
+	})
+
+	return feID, nil
+}
+
+func (r *fieldEngineerRepositoryMemoryTx) UpdateFieldEngineer(ctx context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	feIndex, ok := r.repo.fieldEngineerIndex[channelID][fe.UUID().String()]
+	if !ok {
+		return r.repo.updateFieldEngineer(ctx, channelID, fe)
+	}
+
+	storedFE := r.repo.fieldEngineers[channelID][feIndex]
+	r.undo.add(func() {
+		r.repo.fieldEngineers[channelID][feIndex] = storedFE
+	})
+
+	if openTS := fe.OpenTimeSession(); openTS != nil && !openTS.UUID().IsZero() {
+		r.saveTimeSession(channelID, openTS.UUID().String())
+	}
+	r.saveOutbox(channelID)
+
+	feID, err := r.repo.updateFieldEngineer(ctx, channelID, fe)
+
+	// newly opened time session got its ID in the repository
+	for _, tsID := range newIDs(storedFE.TimeSessions, r.repo.fieldEngineers[channelID][feIndex].TimeSessions) {
+		tsID := tsID
+		r.undo.add(func() {
+			delete(r.repo.timeSessions[channelID], tsID)
+		})
+	}
+
+	return feID, err
+}
+
+func (r *fieldEngineerRepositoryMemoryTx) GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	return r.repo.getFieldEngineer(ctx, channelID, ID)
+}
+
+func (r *fieldEngineerRepositoryMemoryTx) GetFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	return r.repo.getFieldEngineerByBasicUser(ctx, channelID, basicUserID)
+}
+
+func (r *fieldEngineerRepositoryMemoryTx) ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.FieldEngineerList, error) {
+	return r.repo.listFieldEngineers(ctx, channelID, page, itemsPerPage)
+}
+
+// saveTimeSession records the previous value of the time session
+func (r *fieldEngineerRepositoryMemoryTx) saveTimeSession(channelID ref.ChannelID, tsID string) {
+	storedTS, existed := r.repo.timeSessions[channelID][tsID]
+	r.undo.add(func() {
+		if existed {
+			r.repo.timeSessions[channelID][tsID] = storedTS
+		} else {
+			delete(r.repo.timeSessions[channelID], tsID)
+		}
+	})
+}
+
+// saveOutbox records the previous length of the outbox of the channel, messages are only appended within the unit of work
+func (r *fieldEngineerRepositoryMemoryTx) saveOutbox(channelID ref.ChannelID) {
+	messages, existed := r.repo.outbox[channelID]
+	r.undo.add(func() {
+		restoreOutbox(r.repo.outbox, channelID, messages, existed)
+	})
+}
+
+// incidentRepositoryMemoryTx accesses the locked incident repository within the unit of work
+type incidentRepositoryMemoryTx struct {
+	repo *IncidentRepositoryMemory
+	undo *undoLog
+}
+
+func (r *incidentRepositoryMemoryTx) AddIncident(ctx context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	incidents, existed := r.repo.incidents[channelID]
+	r.saveOutbox(channelID)
+
+	incID, err := r.repo.addIncident(ctx, channelID, inc)
+	if err != nil {
+		return incID, err
+	}
+
+	r.undo.add(func() {
+		delete(r.repo.incidentIndex[channelID], incID.String())
+		if existed {
+			r.repo.incidents[channelID] = incidents
+		} else {
+			delete(r.repo.incidents, channelID)
+		}
+	})
+
+	return incID, nil
+}
+
+func (r *incidentRepositoryMemoryTx) UpdateIncident(ctx context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	incIndex, ok := r.repo.incidentIndex[channelID][inc.UUID().String()]
+	if !ok {
+		return r.repo.updateIncident(ctx, channelID, inc)
+	}
+
+	storedInc := r.repo.incidents[channelID][incIndex]
+	r.undo.add(func() {
+		r.repo.incidents[channelID][incIndex] = storedInc
+	})
+
+	if openTimelog := inc.OpenTimelog(); openTimelog != nil && !openTimelog.UUID().IsZero() {
+		r.saveTimelog(channelID, openTimelog.UUID().String())
+	}
+	r.saveOutbox(channelID)
+
+	incID, err := r.repo.updateIncident(ctx, channelID, inc)
+
+	// newly opened timelog got its ID in the repository
+	for _, timelogID := range newIDs(storedInc.Timelogs, r.repo.incidents[channelID][incIndex].Timelogs) {
+		timelogID := timelogID
+		r.undo.add(func() {
+			delete(r.repo.timelogs[channelID], timelogID)
+		})
+	}
+
+	return incID, err
+}
+
+func (r *incidentRepositoryMemoryTx) GetIncident(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error) {
+	return r.repo.getIncident(ctx, channelID, ID)
+}
+
+func (r *incidentRepositoryMemoryTx) ListIncidents(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page, itemsPerPage uint) (repository.IncidentList, error) {
+	return r.repo.listIncidents(ctx, channelID, filter, page, itemsPerPage)
+}
+
+func (r *incidentRepositoryMemoryTx) ListIncidentsByCursor(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page repository.CursorPage) (repository.IncidentList, error) {
+	return r.repo.listIncidentsByCursor(ctx, channelID, filter, page)
+}
+
+func (r *incidentRepositoryMemoryTx) ListResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]repository.ChannelIncident, error) {
+	return r.repo.listResolvedIncidents(ctx, resolvedBefore, limit)
+}
+
+func (r *incidentRepositoryMemoryTx) GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
+	return r.repo.getIncidentTimelog(ctx, channelID, incID, timelogID)
+}
+
+func (r *incidentRepositoryMemoryTx) UpdateIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, tmlg timelog.Timelog) (ref.UUID, error) {
+	r.saveTimelog(channelID, tmlg.UUID().String())
+	return r.repo.updateIncidentTimelog(ctx, channelID, incID, tmlg)
+}
+
+func (r *incidentRepositoryMemoryTx) ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.TimelogList, error) {
+	return r.repo.listIncidentTimelogs(ctx, channelID, incID, page, itemsPerPage)
+}
+
+func (r *incidentRepositoryMemoryTx) AddIncidentHistoryEntry(ctx context.Context, channelID ref.ChannelID, entry history.Entry) (ref.UUID, error) {
+	incID := entry.IncidentID.String()
+	entries, existed := r.repo.history[channelID][incID]
+	r.undo.add(func() {
+		if existed {
+			r.repo.history[channelID][incID] = entries
+		} else {
+			delete(r.repo.history[channelID], incID)
+		}
+	})
+
+	return r.repo.addIncidentHistoryEntry(ctx, channelID, entry)
+}
+
+func (r *incidentRepositoryMemoryTx) ListIncidentHistory(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.HistoryList, error) {
+	return r.repo.listIncidentHistory(ctx, channelID, incID, page, itemsPerPage)
+}
+
+// saveTimelog records the previous value of the timelog
+func (r *incidentRepositoryMemoryTx) saveTimelog(channelID ref.ChannelID, timelogID string) {
+	storedTimelog, existed := r.repo.timelogs[channelID][timelogID]
+	r.undo.add(func() {
+		if existed {
+			r.repo.timelogs[channelID][timelogID] = storedTimelog
+		} else {
+			delete(r.repo.timelogs[channelID], timelogID)
+		}
+	})
+}
+
+// saveOutbox records the previous length of the outbox of the channel, messages are only appended within the unit of work
+func (r *incidentRepositoryMemoryTx) saveOutbox(channelID ref.ChannelID) {
+	messages, existed := r.repo.outbox[channelID]
+	r.undo.add(func() {
+		restoreOutbox(r.repo.outbox, channelID, messages, existed)
+	})
+}
+
+// restoreOutbox restores the previous slice of the outbox messages of the channel
+func restoreOutbox(outbox map[ref.ChannelID][]OutboxMessage, channelID ref.ChannelID, previous []OutboxMessage, existed bool) {
+	if existed {
+		outbox[channelID] = previous
+	} else {
+		delete(outbox, channelID)
+	}
+}
+
+// newIDs returns the IDs that are in the after list but not in the before list
+func newIDs(before, after []string) []string {
+	known := make(map[string]struct{}, len(before))
+	for _, id := range before {
+		known[id] = struct{}{}
+	}
+
+	var added []string
+	for _, id := range after {
+		if _, ok := known[id]; !ok {
+			added = append(added, id)
+		}
+	}
+	return added
+}
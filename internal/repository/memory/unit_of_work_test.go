@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWorkMemory_RollbackRestoresOnlyChangedRecords(t *testing.T) {
+	channelID := ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	ctx := context.Background()
+
+	clock := mocks.NewFixedClock()
+	basicUserRepository := &BasicUserRepositoryMemory{}
+	fieldEngineerRepository := NewFieldEngineerRepositoryMemory(clock, basicUserRepository)
+	incidentRepository := NewIncidentRepositoryMemory(clock, basicUserRepository, fieldEngineerRepository)
+	unitOfWork := NewUnitOfWorkMemory(fieldEngineerRepository, incidentRepository)
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b",
+		Name:             "Alfred",
+		Surname:          "Koletschko",
+	}
+	basicUserID, err := basicUserRepository.AddBasicUser(ctx, channelID, basicUser)
+	require.NoError(t, err)
+	require.NoError(t, basicUser.SetUUID(basicUserID))
+
+	newIncident := func(number string) incident.Incident {
+		inc := incident.Incident{Number: number}
+		require.NoError(t, inc.RestoreState(incident.StateNew))
+		require.NoError(t, inc.CreatedUpdated.SetCreatedBy(basicUser))
+		require.NoError(t, inc.CreatedUpdated.SetUpdatedBy(basicUser))
+		return inc
+	}
+
+	incID, err := incidentRepository.AddIncident(ctx, channelID, newIncident("ABC1"))
+	require.NoError(t, err)
+	otherIncID, err := incidentRepository.AddIncident(ctx, channelID, newIncident("ABC2"))
+	require.NoError(t, err)
+
+	storedIncidents := append([]Incident(nil), incidentRepository.incidents[channelID]...)
+
+	errFailed := errors.New("operation failed")
+	err = unitOfWork.Do(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		inc.Description = "changed"
+		openTimelog := &timelog.Timelog{Remote: true}
+		require.NoError(t, openTimelog.CreatedUpdated.SetCreatedBy(basicUser))
+		require.NoError(t, openTimelog.CreatedUpdated.SetUpdatedBy(basicUser))
+		inc.SetOpenTimelog(openTimelog)
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		_, err = repos.Incident.AddIncidentHistoryEntry(ctx, channelID, history.Entry{IncidentID: incID, Action: history.ActionUpdateIncident})
+		require.NoError(t, err)
+
+		_, err = repos.Incident.AddIncident(ctx, channelID, newIncident("ABC3"))
+		require.NoError(t, err)
+
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+
+	assert.Equal(t, storedIncidents, incidentRepository.incidents[channelID])
+	assert.Equal(t, map[string]int{incID.String(): 0, otherIncID.String(): 1}, incidentRepository.incidentIndex[channelID])
+	assert.Empty(t, incidentRepository.timelogs[channelID], "timelog opened in the unit of work is removed")
+	assert.Empty(t, incidentRepository.history[channelID], "history entry added in the unit of work is removed")
+	assert.Empty(t, incidentRepository.outbox[channelID])
+}
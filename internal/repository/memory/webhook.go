@@ -0,0 +1,47 @@
+package memory
+
+// WebhookSubscription stored in memory storage
+type WebhookSubscription struct {
+	ID string
+
+	URL string
+
+	Secret string
+
+	EventTypes []string
+
+	CreatedAt string
+
+	CreatedBy string
+
+	UpdatedAt string
+
+	UpdatedBy string
+}
+
+// WebhookDelivery stored in memory storage
+type WebhookDelivery struct {
+	ID string
+
+	SubscriptionID string
+
+	EventID string
+
+	EventType string
+
+	Payload string
+
+	Status string
+
+	Attempts uint
+
+	NextAttemptAt string
+
+	LastAttemptAt string
+
+	LastResponseStatus int
+
+	LastError string
+
+	CreatedAt string
+}
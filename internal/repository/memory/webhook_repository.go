@@ -0,0 +1,367 @@
+package memory
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// WebhookRepositoryMemory keeps data in memory, data are partitioned by channel. It is safe for concurrent use.
+// Subscriptions and deliveries can be deleted, so they are looked up by scanning the channel data instead of using indexes.
+type WebhookRepositoryMemory struct {
+	basicUserRepository repository.BasicUserRepository
+	Rand                io.Reader
+	clock               repository.Clock
+
+	mu            sync.RWMutex
+	subscriptions map[ref.ChannelID][]WebhookSubscription
+	deliveries    map[ref.ChannelID][]WebhookDelivery
+}
+
+// NewWebhookRepositoryMemory returns new initialized repository
+func NewWebhookRepositoryMemory(clock repository.Clock, basicUserRepo repository.BasicUserRepository) *WebhookRepositoryMemory {
+	return &WebhookRepositoryMemory{
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+		subscriptions:       make(map[ref.ChannelID][]WebhookSubscription),
+		deliveries:          make(map[ref.ChannelID][]WebhookDelivery),
+	}
+}
+
+// AddWebhookSubscription adds the given webhook subscription to the repository
+func (r *WebhookRepositoryMemory) AddWebhookSubscription(_ context.Context, channelID ref.ChannelID, sub webhook.Subscription) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	subID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	var eventTypes []string
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	storedSub := WebhookSubscription{
+		ID:         subID.String(),
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		EventTypes: eventTypes,
+		CreatedBy:  sub.CreatedUpdated.CreatedByID().String(),
+		CreatedAt:  now,
+		UpdatedBy:  sub.CreatedUpdated.UpdatedByID().String(),
+		UpdatedAt:  now,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions[channelID] = append(r.subscriptions[channelID], storedSub)
+
+	return subID, nil
+}
+
+// GetWebhookSubscription returns the webhook subscription with the given ID from the repository
+func (r *WebhookRepositoryMemory) GetWebhookSubscription(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (webhook.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.subscriptionIndex(channelID, ID)
+	if !ok {
+		return webhook.Subscription{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading webhook subscription from repository")
+	}
+
+	return r.convertStoredToDomainSubscription(ctx, channelID, r.subscriptions[channelID][i])
+}
+
+// ListWebhookSubscriptions returns the list of webhook subscriptions from the repository
+func (r *WebhookRepositoryMemory) ListWebhookSubscriptions(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.WebhookSubscriptionList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriptions := r.subscriptions[channelID]
+	total := len(subscriptions)
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var perPageList []WebhookSubscription
+	if total > 0 {
+		perPageList = subscriptions[pagination.FirstElementIndex : pagination.LastElementIndex+1]
+	}
+
+	var list []webhook.Subscription
+	for _, storedSub := range perPageList {
+		sub, err := r.convertStoredToDomainSubscription(ctx, channelID, storedSub)
+		if err != nil {
+			return repository.WebhookSubscriptionList{}, err
+		}
+
+		list = append(list, sub)
+	}
+
+	subscriptionList := repository.WebhookSubscriptionList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return subscriptionList, nil
+}
+
+// DeleteWebhookSubscription deletes the webhook subscription with the given ID and all its deliveries from the repository
+func (r *WebhookRepositoryMemory) DeleteWebhookSubscription(_ context.Context, channelID ref.ChannelID, ID ref.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.subscriptionIndex(channelID, ID)
+	if !ok {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error deleting webhook subscription from repository")
+	}
+
+	subscriptions := r.subscriptions[channelID]
+	r.subscriptions[channelID] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+
+	var deliveries []WebhookDelivery
+	for _, storedDelivery := range r.deliveries[channelID] {
+		if storedDelivery.SubscriptionID != ID.String() {
+			deliveries = append(deliveries, storedDelivery)
+		}
+	}
+	r.deliveries[channelID] = deliveries
+
+	return nil
+}
+
+// AddWebhookDelivery adds the given delivery of the subscription to the repository
+func (r *WebhookRepositoryMemory) AddWebhookDelivery(_ context.Context, channelID ref.ChannelID, delivery webhook.Delivery) (ref.UUID, error) {
+	deliveryID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptionIndex(channelID, delivery.SubscriptionID); !ok {
+		return ref.UUID(""), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error adding webhook delivery to repository (subscription)")
+	}
+
+	if err := delivery.SetUUID(deliveryID); err != nil {
+		return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeUnknown, "error adding webhook delivery to repository")
+	}
+
+	r.deliveries[channelID] = append(r.deliveries[channelID], convertDomainToStoredDelivery(delivery))
+
+	return deliveryID, nil
+}
+
+// UpdateWebhookDelivery updates the given delivery in the repository
+func (r *WebhookRepositoryMemory) UpdateWebhookDelivery(_ context.Context, channelID ref.ChannelID, delivery webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, storedDelivery := range r.deliveries[channelID] {
+		if storedDelivery.ID == delivery.UUID().String() {
+			r.deliveries[channelID][i] = convertDomainToStoredDelivery(delivery)
+			return nil
+		}
+	}
+
+	return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating webhook delivery in repository")
+}
+
+// ListWebhookDeliveries returns the list of the subscription's deliveries from the repository, the oldest deliveries are listed first
+func (r *WebhookRepositoryMemory) ListWebhookDeliveries(_ context.Context, channelID ref.ChannelID, subscriptionID ref.UUID, page, itemsPerPage uint) (repository.WebhookDeliveryList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.subscriptionIndex(channelID, subscriptionID); !ok {
+		return repository.WebhookDeliveryList{}, domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error loading webhook deliveries from repository (subscription)")
+	}
+
+	var deliveries []WebhookDelivery
+	for _, storedDelivery := range r.deliveries[channelID] {
+		if storedDelivery.SubscriptionID == subscriptionID.String() {
+			deliveries = append(deliveries, storedDelivery)
+		}
+	}
+
+	total := len(deliveries)
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var perPageList []WebhookDelivery
+	if total > 0 {
+		perPageList = deliveries[pagination.FirstElementIndex : pagination.LastElementIndex+1]
+	}
+
+	var list []webhook.Delivery
+	for _, storedDelivery := range perPageList {
+		delivery, err := convertStoredToDomainDelivery(storedDelivery)
+		if err != nil {
+			return repository.WebhookDeliveryList{}, err
+		}
+
+		list = append(list, delivery)
+	}
+
+	deliveryList := repository.WebhookDeliveryList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return deliveryList, nil
+}
+
+// ListDueWebhookDeliveries returns up to limit pending deliveries of all channels whose next attempt is due at the given time
+func (r *WebhookRepositoryMemory) ListDueWebhookDeliveries(_ context.Context, now time.Time, limit uint) ([]repository.ChannelWebhookDelivery, error) {
+	type dueDelivery struct {
+		channelID     ref.ChannelID
+		nextAttemptAt time.Time
+		WebhookDelivery
+	}
+
+	r.mu.RLock()
+	var due []dueDelivery
+	for channelID, deliveries := range r.deliveries {
+		for _, storedDelivery := range deliveries {
+			if storedDelivery.Status != webhook.DeliveryStatusPending.String() {
+				continue
+			}
+
+			nextAttemptAt, err := types.DateTime(storedDelivery.NextAttemptAt).ToTime()
+			if err != nil {
+				r.mu.RUnlock()
+				return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "error loading webhook deliveries from repository (nextAttemptAt)")
+			}
+
+			if !nextAttemptAt.After(now) {
+				due = append(due, dueDelivery{channelID: channelID, nextAttemptAt: nextAttemptAt, WebhookDelivery: storedDelivery})
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].nextAttemptAt.Before(due[j].nextAttemptAt)
+	})
+
+	if uint(len(due)) > limit {
+		due = due[:limit]
+	}
+
+	var list []repository.ChannelWebhookDelivery
+	for _, d := range due {
+		delivery, err := convertStoredToDomainDelivery(d.WebhookDelivery)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, repository.ChannelWebhookDelivery{ChannelID: d.channelID, Delivery: delivery})
+	}
+
+	return list, nil
+}
+
+// subscriptionIndex returns index of the subscription in the subscriptions slice, read lock must be held
+func (r *WebhookRepositoryMemory) subscriptionIndex(channelID ref.ChannelID, ID ref.UUID) (int, bool) {
+	for i, storedSub := range r.subscriptions[channelID] {
+		if storedSub.ID == ID.String() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (r *WebhookRepositoryMemory) convertStoredToDomainSubscription(ctx context.Context, channelID ref.ChannelID, storedSub WebhookSubscription) (webhook.Subscription, error) {
+	var sub webhook.Subscription
+	errMsg := "error loading webhook subscription from repository (%s)"
+
+	if err := sub.SetUUID(ref.UUID(storedSub.ID)); err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.ID")
+	}
+
+	sub.URL = storedSub.URL
+	sub.Secret = storedSub.Secret
+
+	for _, t := range storedSub.EventTypes {
+		eventType, err := event.NewTypeFromString(t)
+		if err != nil {
+			return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.EventTypes")
+		}
+		sub.EventTypes = append(sub.EventTypes, eventType)
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedSub.CreatedBy))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.CreatedBy")
+	}
+
+	err = sub.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedSub.CreatedAt))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedSub.UpdatedBy))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.UpdatedBy")
+	}
+
+	err = sub.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedSub.UpdatedAt))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.UpdatedAt")
+	}
+
+	return sub, nil
+}
+
+func convertDomainToStoredDelivery(delivery webhook.Delivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:                 delivery.UUID().String(),
+		SubscriptionID:     delivery.SubscriptionID.String(),
+		EventID:            delivery.EventID.String(),
+		EventType:          delivery.EventType.String(),
+		Payload:            string(delivery.Payload),
+		Status:             delivery.Status.String(),
+		Attempts:           delivery.Attempts,
+		NextAttemptAt:      delivery.NextAttemptAt.String(),
+		LastAttemptAt:      delivery.LastAttemptAt.String(),
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		CreatedAt:          delivery.CreatedAt.String(),
+	}
+}
+
+func convertStoredToDomainDelivery(storedDelivery WebhookDelivery) (webhook.Delivery, error) {
+	errMsg := "error loading webhook delivery from repository (%s)"
+
+	status, err := webhook.NewDeliveryStatusFromString(storedDelivery.Status)
+	if err != nil {
+		return webhook.Delivery{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDelivery.Status")
+	}
+
+	delivery := webhook.Delivery{
+		SubscriptionID:     ref.UUID(storedDelivery.SubscriptionID),
+		EventID:            ref.UUID(storedDelivery.EventID),
+		EventType:          event.Type(storedDelivery.EventType),
+		Payload:            []byte(storedDelivery.Payload),
+		Status:             status,
+		Attempts:           storedDelivery.Attempts,
+		NextAttemptAt:      types.DateTime(storedDelivery.NextAttemptAt),
+		LastAttemptAt:      types.DateTime(storedDelivery.LastAttemptAt),
+		LastResponseStatus: storedDelivery.LastResponseStatus,
+		LastError:          storedDelivery.LastError,
+		CreatedAt:          types.DateTime(storedDelivery.CreatedAt),
+	}
+
+	if err := delivery.SetUUID(ref.UUID(storedDelivery.ID)); err != nil {
+		return webhook.Delivery{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDelivery.ID")
+	}
+
+	return delivery, nil
+}
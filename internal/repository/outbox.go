@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// OutboxMessage is a domain event stored in the outbox until it is delivered
+type OutboxMessage struct {
+	ID ref.UUID
+
+	ChannelID ref.ChannelID
+
+	EventType event.Type
+
+	// ID of the aggregate that recorded the event
+	AggregateID ref.UUID
+
+	// JSON encoded event
+	Payload []byte
+
+	// Time when the event was stored
+	OccurredAt types.DateTime
+}
+
+// NewOutboxMessages returns outbox messages of the events recorded by the aggregate
+func NewOutboxMessages(rand io.Reader, clock Clock, channelID ref.ChannelID, events []event.Event) ([]OutboxMessage, error) {
+	var messages []OutboxMessage
+	for _, ev := range events {
+		id, err := GenerateUUID(rand)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not encode '%s' event", ev.EventType())
+		}
+
+		messages = append(messages, OutboxMessage{
+			ID:          id,
+			ChannelID:   channelID,
+			EventType:   ev.EventType(),
+			AggregateID: ev.AggregateID(),
+			Payload:     payload,
+			OccurredAt:  clock.NowFormatted(),
+		})
+	}
+
+	return messages, nil
+}
@@ -11,6 +11,9 @@ type Pagination struct {
 	Last              int
 	FirstElementIndex int
 	LastElementIndex  int
+
+	// Cursors are set only if the page was requested by the cursor based pagination
+	Cursors *PageCursors
 }
 
 // NewPagination creates new initialized pagination object
@@ -176,3 +176,72 @@ func TestNewPagination(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCursorPagination(t *testing.T) {
+	type args struct {
+		total      int
+		startIndex int
+		size       int
+		cursors    *PageCursors
+	}
+
+	cursor := &Cursor{SortValue: "INC1", Seq: 1}
+
+	tests := []struct {
+		name string
+		args args
+		want *Pagination
+	}{
+		{
+			"empty collection",
+			args{0, 0, 0, &PageCursors{Limit: 10}},
+			&Pagination{
+				Total:   0,
+				Size:    0,
+				Page:    1,
+				First:   1,
+				Last:    1,
+				Cursors: &PageCursors{Limit: 10},
+			},
+		},
+		{
+			"page in the middle",
+			args{25, 10, 10, &PageCursors{Prev: cursor, Next: cursor, Last: cursor, Limit: 10}},
+			&Pagination{
+				Total:             25,
+				Size:              10,
+				Page:              2,
+				Prev:              1,
+				Next:              3,
+				First:             1,
+				Last:              3,
+				FirstElementIndex: 10,
+				LastElementIndex:  19,
+				Cursors:           &PageCursors{Prev: cursor, Next: cursor, Last: cursor, Limit: 10},
+			},
+		},
+		{
+			"page not aligned to page numbers",
+			args{25, 3, 10, &PageCursors{Prev: cursor, Next: cursor, Last: cursor, Limit: 10}},
+			&Pagination{
+				Total:             25,
+				Size:              10,
+				Page:              1,
+				Prev:              1,
+				Next:              2,
+				First:             1,
+				Last:              3,
+				FirstElementIndex: 3,
+				LastElementIndex:  12,
+				Cursors:           &PageCursors{Prev: cursor, Next: cursor, Last: cursor, Limit: 10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCursorPagination(tt.args.total, tt.args.startIndex, tt.args.size, tt.args.cursors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCursorPagination() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+const basicUserColumns = `id, external_user_uuid, name, surname, org_display_name, org_name`
+
+// BasicUserRepositoryPostgres keeps data in PostgreSQL database
+type BasicUserRepositoryPostgres struct {
+	db   querier
+	Rand io.Reader
+}
+
+// NewBasicUserRepositoryPostgres returns new initialized repository
+func NewBasicUserRepositoryPostgres(db *sql.DB) *BasicUserRepositoryPostgres {
+	return &BasicUserRepositoryPostgres{
+		db: db,
+	}
+}
+
+// AddBasicUser adds the Basic User to the repository
+func (r *BasicUserRepositoryPostgres) AddBasicUser(ctx context.Context, channelID ref.ChannelID, user user.BasicUser) (ref.UUID, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM basic_users WHERE channel_id = $1 AND external_user_uuid = $2)`,
+		channelID.String(), user.ExternalUserUUID.String(),
+	).Scan(&exists)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, "repo AddBasicUser")
+	}
+
+	if exists {
+		return ref.UUID(""), domain.NewErrorf(domain.ErrorCodeInvalidArgument, "basic user with external ID '%s' already exists", user.ExternalUserUUID)
+	}
+
+	id, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO basic_users (channel_id, `+basicUserColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		channelID.String(), id.String(), user.ExternalUserUUID.String(), user.Name, user.Surname, user.OrgDisplayName, user.OrgName,
+	)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, "repo AddBasicUser")
+	}
+
+	return id, nil
+}
+
+// GetBasicUser returns the Basic User with the given ID from the repository
+func (r *BasicUserRepositoryPostgres) GetBasicUser(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (user.BasicUser, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+basicUserColumns+` FROM basic_users WHERE channel_id = $1 AND id = $2`,
+		channelID.String(), ID.String(),
+	)
+
+	basicUser, err := scanBasicUser(row)
+	if err != nil {
+		return user.BasicUser{}, wrapQueryError(err, "repo GetBasicUser")
+	}
+
+	return basicUser, nil
+}
+
+// GetBasicUserByExternalID returns the Basic User with the given external ID from the repository
+func (r *BasicUserRepositoryPostgres) GetBasicUserByExternalID(ctx context.Context, channelID ref.ChannelID, externalID ref.ExternalUserUUID) (user.BasicUser, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+basicUserColumns+` FROM basic_users WHERE channel_id = $1 AND external_user_uuid = $2`,
+		channelID.String(), externalID.String(),
+	)
+
+	basicUser, err := scanBasicUser(row)
+	if err != nil {
+		return user.BasicUser{}, wrapQueryError(err, "repo GetBasicUserByExternalID")
+	}
+
+	return basicUser, nil
+}
+
+// ListBasicUsers returns the list of Basic Users from the repository
+func (r *BasicUserRepositoryPostgres) ListBasicUsers(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.BasicUserList, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM basic_users WHERE channel_id = $1`, channelID.String()).Scan(&total)
+	if err != nil {
+		return repository.BasicUserList{}, wrapQueryError(err, "repo ListBasicUsers")
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []user.BasicUser
+	if pagination.Size > 0 {
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+basicUserColumns+` FROM basic_users WHERE channel_id = $1 ORDER BY seq LIMIT $2 OFFSET $3`,
+			channelID.String(), pagination.Size, pagination.FirstElementIndex,
+		)
+		if err != nil {
+			return repository.BasicUserList{}, wrapQueryError(err, "repo ListBasicUsers")
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			basicUser, err := scanBasicUser(rows)
+			if err != nil {
+				return repository.BasicUserList{}, wrapQueryError(err, "repo ListBasicUsers")
+			}
+			list = append(list, basicUser)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.BasicUserList{}, wrapQueryError(err, "repo ListBasicUsers")
+		}
+	}
+
+	basicUserList := repository.BasicUserList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return basicUserList, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBasicUser(row rowScanner) (user.BasicUser, error) {
+	var basicUser user.BasicUser
+	var id, externalID string
+
+	err := row.Scan(&id, &externalID, &basicUser.Name, &basicUser.Surname, &basicUser.OrgDisplayName, &basicUser.OrgName)
+	if err != nil {
+		return user.BasicUser{}, err
+	}
+
+	basicUser.ExternalUserUUID = ref.ExternalUserUUID(externalID)
+	if err := basicUser.SetUUID(ref.UUID(id)); err != nil {
+		return user.BasicUser{}, err
+	}
+
+	return basicUser, nil
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+)
+
+// ErrNotFound represents the error when object is not found in the repository
+var ErrNotFound = errors.New("record was not found")
+
+// wrapQueryError converts sql.ErrNoRows to not found domain error, other errors are wrapped as unknown errors
+func wrapQueryError(err error, msg string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, msg)
+	}
+	return domain.WrapErrorf(err, domain.ErrorCodeUnknown, msg)
+}
+
+// withTx runs fn in the transaction, the transaction is rolled back if fn returns error
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not begin transaction")
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not commit transaction")
+	}
+
+	return nil
+}
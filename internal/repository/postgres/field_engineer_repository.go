@@ -0,0 +1,460 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+const fieldEngineerColumns = `id, basic_user_id, deactivated, created_by, created_at, updated_by, updated_at`
+
+const timeSessionColumns = `id, state, state_changed_at, incidents, work, travel, travel_back, travel_distance_in_travel_units,
+	created_by, created_at, updated_by, updated_at`
+
+// FieldEngineerRepositoryPostgres keeps data in PostgreSQL database
+type FieldEngineerRepositoryPostgres struct {
+	db                  querier
+	basicUserRepository repository.BasicUserRepository
+	Rand                io.Reader
+	clock               repository.Clock
+}
+
+// NewFieldEngineerRepositoryPostgres returns new initialized repository
+func NewFieldEngineerRepositoryPostgres(db *sql.DB, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *FieldEngineerRepositoryPostgres {
+	return &FieldEngineerRepositoryPostgres{
+		db:                  db,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// FieldEngineer is a field engineer row stored in the database
+type FieldEngineer struct {
+	ID          string
+	BasicUserID string
+	Deactivated bool
+	CreatedBy   string
+	CreatedAt   string
+	UpdatedBy   string
+	UpdatedAt   string
+}
+
+// TimeSession is a time session row stored in the database
+type TimeSession struct {
+	ID                          string
+	State                       string
+	StateChangedAt              string
+	Incidents                   []IncidentInfo
+	Work                        uint
+	Travel                      uint
+	TravelBack                  uint
+	TravelDistanceInTravelUnits uint
+	CreatedBy                   string
+	CreatedAt                   string
+	UpdatedBy                   string
+	UpdatedAt                   string
+}
+
+// IncidentInfo is stored in the time session as JSON
+type IncidentInfo struct {
+	ID                 string `json:"id"`
+	HasSupplierProduct bool   `json:"has_supplier_product"`
+}
+
+// AddFieldEngineer adds the given field engineer to the repository
+func (r *FieldEngineerRepositoryPostgres) AddFieldEngineer(ctx context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM field_engineers WHERE channel_id = $1 AND basic_user_id = $2)`,
+		channelID.String(), fe.BasicUser.UUID().String(),
+	).Scan(&exists)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, "error adding field engineer to repository")
+	}
+
+	if exists {
+		return ref.UUID(""), domain.NewErrorf(domain.ErrorCodeInvalidArgument, "field engineer for basic user '%s' already exists", fe.BasicUser.UUID())
+	}
+
+	feID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO field_engineers (channel_id, `+fieldEngineerColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		channelID.String(), feID.String(), fe.BasicUser.UUID().String(), false,
+		fe.CreatedUpdated.CreatedByID().String(), now, fe.CreatedUpdated.UpdatedByID().String(), now,
+	)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, "error adding field engineer to repository")
+	}
+
+	return feID, nil
+}
+
+// UpdateFieldEngineer updates the given field engineer together with its open time session in one transaction
+func (r *FieldEngineerRepositoryPostgres) UpdateFieldEngineer(ctx context.Context, channelID ref.ChannelID, fe fieldengineer.FieldEngineer) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE field_engineers SET basic_user_id = $3, deactivated = $4, updated_by = $5, updated_at = $6
+			WHERE channel_id = $1 AND id = $2`,
+			channelID.String(), fe.UUID().String(), fe.BasicUser.UUID().String(), fe.IsDeactivated(),
+			fe.CreatedUpdated.UpdatedByID().String(), now,
+		)
+		if err != nil {
+			return wrapQueryError(err, "error updating field engineer in repository")
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, "error updating field engineer in repository")
+		}
+
+		events := fe.Events()
+
+		if fe.HasOpenTimeSession() {
+			tSessionID, isNew, err := r.saveOpenTimeSession(ctx, tx, channelID, fe.UUID(), fe.OpenTimeSession(), now)
+			if err != nil {
+				return err
+			}
+
+			if isNew {
+				events = fe.EventsWithOpenTimeSessionID(tSessionID)
+			}
+		}
+
+		return insertOutboxMessages(ctx, tx, r.Rand, r.clock, channelID, events)
+	})
+	if err != nil {
+		return fe.UUID(), err
+	}
+
+	return fe.UUID(), nil
+}
+
+// saveOpenTimeSession inserts newly opened time session or updates the existing one, returns ID of the time session and true if it was newly opened
+func (r *FieldEngineerRepositoryPostgres) saveOpenTimeSession(ctx context.Context, tx *sql.Tx, channelID ref.ChannelID, feID ref.UUID, openTS *tsession.TimeSession, now string) (ref.UUID, bool, error) {
+	var err error
+	errMsg := "error updating field engineer in repository"
+
+	createdAt := openTS.CreatedUpdated.CreatedAt().String()
+	updatedAt := openTS.CreatedUpdated.UpdatedAt().String()
+
+	tSessionID := openTS.UUID()
+	isNew := tSessionID.IsZero()
+	if isNew { // newly opened => set new UUID
+		tSessionID, err = repository.GenerateUUID(r.Rand)
+		if err != nil {
+			return tSessionID, false, err
+		}
+
+		createdAt = now
+		updatedAt = now
+	}
+
+	incidents := []IncidentInfo{}
+	for _, incInfo := range openTS.Incidents {
+		incidents = append(incidents, IncidentInfo{
+			ID:                 incInfo.IncidentID.String(),
+			HasSupplierProduct: incInfo.HasSupplierProduct,
+		})
+	}
+
+	incidentsJSON, err := json.Marshal(incidents)
+	if err != nil {
+		return tSessionID, false, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO time_sessions (channel_id, field_engineer_id, `+timeSessionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (channel_id, id) DO UPDATE SET
+			state = EXCLUDED.state,
+			state_changed_at = EXCLUDED.state_changed_at,
+			incidents = EXCLUDED.incidents,
+			work = EXCLUDED.work,
+			travel = EXCLUDED.travel,
+			travel_back = EXCLUDED.travel_back,
+			travel_distance_in_travel_units = EXCLUDED.travel_distance_in_travel_units,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		WHERE time_sessions.field_engineer_id = EXCLUDED.field_engineer_id`,
+		channelID.String(), feID.String(), tSessionID.String(),
+		openTS.State().String(), openTS.StateChangedAt().String(), string(incidentsJSON),
+		openTS.Work, openTS.Travel, openTS.TravelBack, openTS.TravelDistanceInTravelUnits,
+		openTS.CreatedUpdated.CreatedByID().String(), createdAt, openTS.CreatedUpdated.UpdatedByID().String(), updatedAt,
+	)
+	if err != nil {
+		return tSessionID, false, wrapQueryError(err, errMsg)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return tSessionID, false, domain.NewErrorf(domain.ErrorCodeInvalidArgument, "time session '%s' does not belong to field engineer '%s'", tSessionID, feID)
+	}
+
+	return tSessionID, isNew, nil
+}
+
+// GetFieldEngineer returns the field engineer with given ID from the repository
+func (r *FieldEngineerRepositoryPostgres) GetFieldEngineer(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+fieldEngineerColumns+` FROM field_engineers WHERE channel_id = $1 AND id = $2`,
+		channelID.String(), ID.String(),
+	)
+
+	storedFE, err := scanFieldEngineer(row)
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, wrapQueryError(err, "error loading field engineer from repository")
+	}
+
+	return r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE)
+}
+
+// GetFieldEngineerByBasicUser returns the field engineer belonging to the basic user with the given ID from the repository
+func (r *FieldEngineerRepositoryPostgres) GetFieldEngineerByBasicUser(ctx context.Context, channelID ref.ChannelID, basicUserID ref.UUID) (fieldengineer.FieldEngineer, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+fieldEngineerColumns+` FROM field_engineers WHERE channel_id = $1 AND basic_user_id = $2`,
+		channelID.String(), basicUserID.String(),
+	)
+
+	storedFE, err := scanFieldEngineer(row)
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, wrapQueryError(err, "error loading field engineer from repository")
+	}
+
+	return r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE)
+}
+
+// ListFieldEngineers returns the list of field engineers from the repository
+func (r *FieldEngineerRepositoryPostgres) ListFieldEngineers(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.FieldEngineerList, error) {
+	errMsg := "error loading field engineers from repository"
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM field_engineers WHERE channel_id = $1`, channelID.String()).Scan(&total)
+	if err != nil {
+		return repository.FieldEngineerList{}, wrapQueryError(err, errMsg)
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var storedFEs []FieldEngineer
+	if pagination.Size > 0 {
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+fieldEngineerColumns+` FROM field_engineers WHERE channel_id = $1 ORDER BY seq LIMIT $2 OFFSET $3`,
+			channelID.String(), pagination.Size, pagination.FirstElementIndex,
+		)
+		if err != nil {
+			return repository.FieldEngineerList{}, wrapQueryError(err, errMsg)
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			storedFE, err := scanFieldEngineer(rows)
+			if err != nil {
+				return repository.FieldEngineerList{}, wrapQueryError(err, errMsg)
+			}
+			storedFEs = append(storedFEs, storedFE)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.FieldEngineerList{}, wrapQueryError(err, errMsg)
+		}
+		_ = rows.Close()
+	}
+
+	var list []fieldengineer.FieldEngineer
+	for _, storedFE := range storedFEs {
+		fe, err := r.convertStoredToDomainFieldEngineer(ctx, channelID, storedFE)
+		if err != nil {
+			return repository.FieldEngineerList{}, err
+		}
+
+		list = append(list, fe)
+	}
+
+	fieldEngineerList := repository.FieldEngineerList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return fieldEngineerList, nil
+}
+
+func scanFieldEngineer(row rowScanner) (FieldEngineer, error) {
+	var storedFE FieldEngineer
+	err := row.Scan(&storedFE.ID, &storedFE.BasicUserID, &storedFE.Deactivated,
+		&storedFE.CreatedBy, &storedFE.CreatedAt, &storedFE.UpdatedBy, &storedFE.UpdatedAt)
+	return storedFE, err
+}
+
+func scanTimeSession(row rowScanner) (TimeSession, error) {
+	var storedTS TimeSession
+	var incidentsJSON []byte
+
+	err := row.Scan(&storedTS.ID, &storedTS.State, &storedTS.StateChangedAt, &incidentsJSON,
+		&storedTS.Work, &storedTS.Travel, &storedTS.TravelBack, &storedTS.TravelDistanceInTravelUnits,
+		&storedTS.CreatedBy, &storedTS.CreatedAt, &storedTS.UpdatedBy, &storedTS.UpdatedAt)
+	if err != nil {
+		return TimeSession{}, err
+	}
+
+	if err := json.Unmarshal(incidentsJSON, &storedTS.Incidents); err != nil {
+		return TimeSession{}, err
+	}
+
+	return storedTS, nil
+}
+
+func (r FieldEngineerRepositoryPostgres) convertStoredToDomainFieldEngineer(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer) (fieldengineer.FieldEngineer, error) {
+	var fe fieldengineer.FieldEngineer
+	errMsg := "error loading field engineer from repository (%s)"
+
+	err := fe.SetUUID(ref.UUID(storedFE.ID))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.ID")
+	}
+
+	basicUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedFE.BasicUserID))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.BasicUser")
+	}
+
+	fe.BasicUser = basicUser
+
+	// set Time sessions (UUIDs)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id FROM time_sessions WHERE channel_id = $1 AND field_engineer_id = $2 ORDER BY seq`,
+		channelID.String(), storedFE.ID,
+	)
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "timeSessions")
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var tsID string
+		if err := rows.Scan(&tsID); err != nil {
+			return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "timeSessions")
+		}
+		fe.TimeSessions = append(fe.TimeSessions, ref.UUID(tsID))
+	}
+
+	if err := rows.Err(); err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "timeSessions")
+	}
+	_ = rows.Close()
+
+	fe.SetDeactivated(storedFE.Deactivated)
+
+	// load and set open time session if any
+	openTS, err := r.loadOpenTimeSession(ctx, channelID, storedFE)
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, err
+	}
+	fe.SetOpenTimeSession(openTS)
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedFE.CreatedBy))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.CreatedBy")
+	}
+
+	err = fe.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedFE.CreatedAt))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedFE.UpdatedBy))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.UpdatedBy")
+	}
+
+	err = fe.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedFE.UpdatedAt))
+	if err != nil {
+		return fieldengineer.FieldEngineer{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedFE.UpdatedAt")
+	}
+
+	return fe, nil
+}
+
+// loadOpenTimeSession loads field engineer's open time session if any
+func (r FieldEngineerRepositoryPostgres) loadOpenTimeSession(ctx context.Context, channelID ref.ChannelID, storedFE FieldEngineer) (*tsession.TimeSession, error) {
+	errMsg := "error loading field engineer from repository (%s)"
+
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+timeSessionColumns+` FROM time_sessions
+		WHERE channel_id = $1 AND field_engineer_id = $2 AND state <> $3
+		ORDER BY seq LIMIT 1`,
+		channelID.String(), storedFE.ID, tsession.StateClosed.String(),
+	)
+
+	storedTS, err := scanTimeSession(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession")
+	}
+
+	var incidents []tsession.IncidentInfo
+	for _, incInfo := range storedTS.Incidents {
+		incidents = append(incidents, tsession.IncidentInfo{
+			IncidentID:         ref.UUID(incInfo.ID),
+			HasSupplierProduct: incInfo.HasSupplierProduct,
+		})
+	}
+
+	openTS := &tsession.TimeSession{
+		Incidents:                   incidents,
+		Work:                        storedTS.Work,
+		Travel:                      storedTS.Travel,
+		TravelBack:                  storedTS.TravelBack,
+		TravelDistanceInTravelUnits: storedTS.TravelDistanceInTravelUnits,
+	}
+
+	state, err := tsession.NewStateFromString(storedTS.State)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.state")
+	}
+
+	if err := openTS.SetUUID(ref.UUID(storedTS.ID)); err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.ID")
+	}
+
+	if err := openTS.RestoreState(state); err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.RestoreState")
+	}
+	openTS.SetStateChangedAt(types.DateTime(storedTS.StateChangedAt))
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTS.CreatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.createdBy")
+	}
+
+	err = openTS.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedTS.CreatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.createdAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTS.UpdatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.UpdatedBy")
+	}
+
+	err = openTS.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedTS.UpdatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimeSession.UpdatedAt")
+	}
+
+	return openTS, nil
+}
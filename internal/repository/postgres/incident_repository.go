@@ -0,0 +1,1426 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+const incidentColumns = `id, number, external_id, short_description, description, field_engineer_id, field_engineer_accepted, state,
+	on_hold_reason, remind_at, resolution_code, resolution_notes, resolved_at, impact, urgency, priority, priority_overridden,
+	audit_trail, sla, created_by, created_at, updated_by, updated_at, version`
+
+const timelogColumns = `id, remote, start, "end", work, timespans, visit_summary, corrections, original,
+	created_by, created_at, updated_by, updated_at`
+
+const historyColumns = `id, incident_id, action, actor_id, requested_by_id, time, changes`
+
+// IncidentRepositoryPostgres keeps data in PostgreSQL database
+type IncidentRepositoryPostgres struct {
+	db                      querier
+	basicUserRepository     repository.BasicUserRepository
+	fieldEngineerRepository repository.FieldEngineerRepository
+	Rand                    io.Reader
+	clock                   repository.Clock
+}
+
+// NewIncidentRepositoryPostgres returns new initialized repository
+func NewIncidentRepositoryPostgres(db *sql.DB, clock repository.Clock, basicUserRepo repository.BasicUserRepository, fieldEngineerRepository repository.FieldEngineerRepository) *IncidentRepositoryPostgres {
+	return &IncidentRepositoryPostgres{
+		db:                      db,
+		basicUserRepository:     basicUserRepo,
+		fieldEngineerRepository: fieldEngineerRepository,
+		clock:                   clock,
+	}
+}
+
+// Incident is an incident row stored in the database
+type Incident struct {
+	ID                    string
+	Number                string
+	ExternalID            string
+	ShortDescription      string
+	Description           string
+	FieldEngineerID       sql.NullString
+	FieldEngineerAccepted bool
+	State                 string
+	OnHoldReason          string
+	RemindAt              string
+	ResolutionCode        string
+	ResolutionNotes       string
+	ResolvedAt            string
+	Impact                string
+	Urgency               string
+	Priority              string
+	PriorityOverridden    bool
+	AuditTrail            []AuditRecord
+	SLA                   *IncidentSLA
+	CreatedBy             string
+	CreatedAt             string
+	UpdatedBy             string
+	UpdatedAt             string
+	Version               uint
+}
+
+// AuditRecord is stored in the incident as JSON
+type AuditRecord struct {
+	Action  string `json:"action"`
+	ActorID string `json:"actor_id"`
+	Time    string `json:"time"`
+	Note    string `json:"note"`
+}
+
+// Timelog is a timelog row stored in the database
+type Timelog struct {
+	ID           string
+	Remote       bool
+	Start        string
+	End          string
+	Work         uint
+	Timespans    []Timespan
+	VisitSummary string
+	Corrections  []TimelogCorrection
+	Original     *TimelogOriginal
+	CreatedBy    string
+	CreatedAt    string
+	UpdatedBy    string
+	UpdatedAt    string
+}
+
+// Timespan is stored in the timelog as JSON
+type Timespan struct {
+	Type  string `json:"type"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// TimelogCorrection is stored in the timelog as JSON
+type TimelogCorrection struct {
+	Remote       bool   `json:"remote"`
+	Start        string `json:"start"`
+	End          string `json:"end"`
+	VisitSummary string `json:"visit_summary"`
+	Reason       string `json:"reason"`
+	Status       string `json:"status"`
+	ProposedBy   string `json:"proposed_by"`
+	ProposedAt   string `json:"proposed_at"`
+	DecidedBy    string `json:"decided_by"`
+	DecidedAt    string `json:"decided_at"`
+	DecisionNote string `json:"decision_note"`
+}
+
+// TimelogOriginal is stored in the timelog as JSON
+type TimelogOriginal struct {
+	Remote       bool       `json:"remote"`
+	Start        string     `json:"start"`
+	End          string     `json:"end"`
+	Work         uint       `json:"work"`
+	Timespans    []Timespan `json:"timespans"`
+	VisitSummary string     `json:"visit_summary"`
+}
+
+// HistoryEntry is an incident history row stored in the database
+type HistoryEntry struct {
+	ID            string
+	IncidentID    string
+	Action        string
+	ActorID       sql.NullString
+	RequestedByID sql.NullString
+	Time          string
+	Changes       []HistoryChange
+}
+
+// HistoryChange is stored in the history entry as JSON
+type HistoryChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// AddIncident adds the given incident to the repository
+func (r *IncidentRepositoryPostgres) AddIncident(ctx context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+
+	incidentID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	slaJSON, err := marshalIncidentSLA(inc)
+	if err != nil {
+		return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeUnknown, "error adding incident to repository")
+	}
+
+	err = inTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO incidents (channel_id, id, number, external_id, short_description, description, field_engineer_id,
+				field_engineer_accepted, state, impact, urgency, priority, priority_overridden, sla, created_by, created_at, updated_by, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+			channelID.String(), incidentID.String(), inc.Number, inc.ExternalID, inc.ShortDescription, inc.Description,
+			nullableUUID(inc.FieldEngineerID), inc.FieldEngineerAccepted(), inc.State().String(),
+			inc.Impact().String(), inc.Urgency().String(), inc.Priority().String(), inc.PriorityOverridden(), slaJSON,
+			inc.CreatedUpdated.CreatedByID().String(), now, inc.CreatedUpdated.UpdatedByID().String(), now,
+		)
+		if err != nil {
+			return wrapQueryError(err, "error adding incident to repository")
+		}
+
+		return insertOutboxMessages(ctx, tx, r.Rand, r.clock, channelID, inc.EventsWithIncidentID(incidentID))
+	})
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	return incidentID, nil
+}
+
+// UpdateIncident updates the given incident together with its open timelog in one transaction
+func (r *IncidentRepositoryPostgres) UpdateIncident(ctx context.Context, channelID ref.ChannelID, inc incident.Incident) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+	errMsg := "error updating incident in repository"
+
+	var onHoldReason, remindAt string
+	if onHold := inc.OnHold(); onHold != nil {
+		onHoldReason = onHold.Reason.String()
+		remindAt = onHold.RemindAt.String()
+	}
+
+	var resolutionCode, resolutionNotes, resolvedAt string
+	if resolution := inc.Resolution(); resolution != nil {
+		resolutionCode = resolution.Code.String()
+		resolutionNotes = resolution.Notes
+		resolvedAt = resolution.ResolvedAt.String()
+	}
+
+	auditTrail := []AuditRecord{}
+	for _, record := range inc.AuditTrail() {
+		auditTrail = append(auditTrail, AuditRecord{
+			Action:  record.Action.String(),
+			ActorID: record.ActorID.String(),
+			Time:    record.Time.String(),
+			Note:    record.Note,
+		})
+	}
+
+	auditTrailJSON, err := json.Marshal(auditTrail)
+	if err != nil {
+		return inc.UUID(), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	slaJSON, err := marshalIncidentSLA(inc)
+	if err != nil {
+		return inc.UUID(), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	err = inTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE incidents SET
+				number = $3, external_id = $4, short_description = $5, description = $6, field_engineer_id = $7,
+				field_engineer_accepted = $8, state = $9,
+				on_hold_reason = $10, remind_at = $11, resolution_code = $12, resolution_notes = $13, resolved_at = $14,
+				impact = $15, urgency = $16, priority = $17, priority_overridden = $18,
+				audit_trail = $19, sla = $20, updated_by = $21, updated_at = $22, version = version + 1
+			WHERE channel_id = $1 AND id = $2 AND version = $23`,
+			channelID.String(), inc.UUID().String(),
+			inc.Number, inc.ExternalID, inc.ShortDescription, inc.Description, nullableUUID(inc.FieldEngineerID),
+			inc.FieldEngineerAccepted(), inc.State().String(),
+			onHoldReason, remindAt, resolutionCode, resolutionNotes, resolvedAt,
+			inc.Impact().String(), inc.Urgency().String(), inc.Priority().String(), inc.PriorityOverridden(),
+			string(auditTrailJSON), slaJSON, inc.CreatedUpdated.UpdatedByID().String(), now, inc.Version(),
+		)
+		if err != nil {
+			return wrapQueryError(err, errMsg)
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			// distinguish missing incident from the concurrent modification
+			var storedVersion uint
+			err := tx.QueryRowContext(ctx, `SELECT version FROM incidents WHERE channel_id = $1 AND id = $2`,
+				channelID.String(), inc.UUID().String()).Scan(&storedVersion)
+			if err != nil {
+				return wrapQueryError(err, errMsg)
+			}
+
+			return domain.NewErrorf(domain.ErrorCodeVersionMismatch,
+				"incident '%s' was modified concurrently (version %d, stored version %d)", inc.UUID(), inc.Version(), storedVersion)
+		}
+
+		if inc.HasOpenTimelog() {
+			if err := r.saveOpenTimelog(ctx, tx, channelID, inc.UUID(), inc.OpenTimelog(), now); err != nil {
+				return err
+			}
+		}
+
+		return insertOutboxMessages(ctx, tx, r.Rand, r.clock, channelID, inc.Events())
+	})
+	if err != nil {
+		return inc.UUID(), err
+	}
+
+	return inc.UUID(), nil
+}
+
+// saveOpenTimelog inserts newly opened timelog or updates the existing one
+func (r *IncidentRepositoryPostgres) saveOpenTimelog(ctx context.Context, tx *sql.Tx, channelID ref.ChannelID, incID ref.UUID, openTimelog *timelog.Timelog, now string) error {
+	var err error
+	errMsg := "error updating incident in repository"
+
+	createdAt := openTimelog.CreatedUpdated.CreatedAt().String()
+	updatedAt := openTimelog.CreatedUpdated.UpdatedAt().String()
+
+	timelogID := openTimelog.UUID()
+	if timelogID.IsZero() { // newly opened => set new UUID
+		timelogID, err = repository.GenerateUUID(r.Rand)
+		if err != nil {
+			return err
+		}
+
+		createdAt = now
+		updatedAt = now
+	}
+
+	timespansJSON, err := json.Marshal(convertTimespansToStored(openTimelog.Timespans))
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO timelogs (channel_id, incident_id, `+timelogColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, '[]', NULL, $10, $11, $12, $13)
+		ON CONFLICT (channel_id, id) DO UPDATE SET
+			remote = EXCLUDED.remote,
+			start = EXCLUDED.start,
+			"end" = EXCLUDED."end",
+			work = EXCLUDED.work,
+			timespans = EXCLUDED.timespans,
+			visit_summary = EXCLUDED.visit_summary,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		WHERE timelogs.incident_id = EXCLUDED.incident_id`,
+		channelID.String(), incID.String(), timelogID.String(),
+		openTimelog.Remote, openTimelog.Start.String(), openTimelog.End.String(), openTimelog.Work,
+		string(timespansJSON), openTimelog.VisitSummary,
+		openTimelog.CreatedUpdated.CreatedByID().String(), createdAt, openTimelog.CreatedUpdated.UpdatedByID().String(), updatedAt,
+	)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return domain.NewErrorf(domain.ErrorCodeInvalidArgument, "timelog '%s' does not belong to incident '%s'", timelogID, incID)
+	}
+
+	return nil
+}
+
+// GetIncident returns the incident with given ID from the repository
+func (r *IncidentRepositoryPostgres) GetIncident(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (incident.Incident, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+incidentColumns+` FROM incidents WHERE channel_id = $1 AND id = $2`,
+		channelID.String(), ID.String(),
+	)
+
+	storedInc, err := scanIncident(row)
+	if err != nil {
+		return incident.Incident{}, wrapQueryError(err, "error loading incident from repository")
+	}
+
+	return r.convertStoredToDomainIncident(ctx, channelID, storedInc)
+}
+
+// ListIncidents returns the list of incidents matching the filter from the repository
+func (r *IncidentRepositoryPostgres) ListIncidents(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page, itemsPerPage uint) (repository.IncidentList, error) {
+	errMsg := "error loading incidents from repository"
+
+	where, args := incidentFilterConditions(channelID, filter)
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM incidents WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return repository.IncidentList{}, wrapQueryError(err, errMsg)
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var storedIncidents []Incident
+	if pagination.Size > 0 {
+		args = append(args, pagination.Size, pagination.FirstElementIndex)
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+incidentColumns+` FROM incidents WHERE `+where+` ORDER BY `+incidentOrderBy(filter.Sort, false)+
+				fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args)),
+			args...,
+		)
+		if err != nil {
+			return repository.IncidentList{}, wrapQueryError(err, errMsg)
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			storedInc, err := scanIncident(rows)
+			if err != nil {
+				return repository.IncidentList{}, wrapQueryError(err, errMsg)
+			}
+			storedIncidents = append(storedIncidents, storedInc)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.IncidentList{}, wrapQueryError(err, errMsg)
+		}
+		_ = rows.Close()
+	}
+
+	var list []incident.Incident
+	for _, storedInc := range storedIncidents {
+		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc)
+		if err != nil {
+			return repository.IncidentList{}, err
+		}
+
+		list = append(list, inc)
+	}
+
+	incidentList := repository.IncidentList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return incidentList, nil
+}
+
+// ListResolvedIncidents returns up to limit incidents of all channels that are in Resolved state and were resolved at or before the given time
+func (r *IncidentRepositoryPostgres) ListResolvedIncidents(ctx context.Context, resolvedBefore time.Time, limit uint) ([]repository.ChannelIncident, error) {
+	errMsg := "error loading resolved incidents from repository"
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT channel_id, `+incidentColumns+` FROM incidents
+		WHERE state = $1 AND NULLIF(resolved_at, '')::timestamptz <= $2
+		ORDER BY NULLIF(resolved_at, '')::timestamptz, seq LIMIT $3`,
+		incident.StateResolved.String(), resolvedBefore, limit,
+	)
+	if err != nil {
+		return nil, wrapQueryError(err, errMsg)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var channelIDs []string
+	var storedIncidents []Incident
+	for rows.Next() {
+		var channelID string
+		storedInc, err := scanIncident(rows, &channelID)
+		if err != nil {
+			return nil, wrapQueryError(err, errMsg)
+		}
+
+		channelIDs = append(channelIDs, channelID)
+		storedIncidents = append(storedIncidents, storedInc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapQueryError(err, errMsg)
+	}
+	_ = rows.Close()
+
+	var list []repository.ChannelIncident
+	for i, storedInc := range storedIncidents {
+		channelID := ref.ChannelID(channelIDs[i])
+		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, repository.ChannelIncident{ChannelID: channelID, Incident: inc})
+	}
+
+	return list, nil
+}
+
+// ListIncidentsByCursor returns the page of incidents matching the filter requested by the cursor from the repository
+func (r *IncidentRepositoryPostgres) ListIncidentsByCursor(ctx context.Context, channelID ref.ChannelID, filter repository.IncidentFilter, page repository.CursorPage) (repository.IncidentList, error) {
+	errMsg := "error loading incidents from repository"
+
+	where, args := incidentFilterConditions(channelID, filter)
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM incidents WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return repository.IncidentList{}, wrapQueryError(err, errMsg)
+	}
+
+	pageWhere, pageArgs := where, args
+	reverse := false
+	switch {
+	case page.After != nil:
+		pageWhere, pageArgs = incidentCursorCondition(where, args, filter.Sort, *page.After, false)
+	case page.Before != nil:
+		pageWhere, pageArgs = incidentCursorCondition(where, args, filter.Sort, *page.Before, true)
+		reverse = true
+	}
+
+	storedIncidents, seqs, err := r.queryIncidentsWithSeq(ctx, pageWhere, pageArgs, incidentOrderBy(filter.Sort, reverse), int(page.Limit), 0)
+	if err != nil {
+		return repository.IncidentList{}, wrapQueryError(err, errMsg)
+	}
+
+	if reverse {
+		for i, j := 0, len(storedIncidents)-1; i < j; i, j = i+1, j-1 {
+			storedIncidents[i], storedIncidents[j] = storedIncidents[j], storedIncidents[i]
+			seqs[i], seqs[j] = seqs[j], seqs[i]
+		}
+	}
+
+	size := len(storedIncidents)
+	cursors := &repository.PageCursors{Limit: page.Limit}
+
+	startIndex := 0
+	if size > 0 {
+		first := incidentCursor(filter.Sort, storedIncidents[0], seqs[0])
+		precedingWhere, precedingArgs := incidentCursorCondition(where, args, filter.Sort, *first, true)
+		err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM incidents WHERE `+precedingWhere, precedingArgs...).Scan(&startIndex)
+		if err != nil {
+			return repository.IncidentList{}, wrapQueryError(err, errMsg)
+		}
+
+		if startIndex > 0 {
+			cursors.Prev = first
+		}
+		if startIndex+size < total {
+			cursors.Next = incidentCursor(filter.Sort, storedIncidents[size-1], seqs[size-1])
+		}
+	} else if page.After != nil {
+		startIndex = total
+	}
+
+	if total > int(page.Limit) {
+		lastPageStored, lastPageSeqs, err := r.queryIncidentsWithSeq(ctx, where, args, incidentOrderBy(filter.Sort, true), 1, int(page.Limit))
+		if err != nil {
+			return repository.IncidentList{}, wrapQueryError(err, errMsg)
+		}
+		if len(lastPageStored) > 0 {
+			cursors.Last = incidentCursor(filter.Sort, lastPageStored[0], lastPageSeqs[0])
+		}
+	}
+
+	var list []incident.Incident
+	for _, storedInc := range storedIncidents {
+		inc, err := r.convertStoredToDomainIncident(ctx, channelID, storedInc)
+		if err != nil {
+			return repository.IncidentList{}, err
+		}
+
+		list = append(list, inc)
+	}
+
+	incidentList := repository.IncidentList{
+		Result:     list,
+		Pagination: repository.NewCursorPagination(total, startIndex, size, cursors),
+	}
+	return incidentList, nil
+}
+
+// queryIncidentsWithSeq loads the incidents together with their insertion sequence numbers
+func (r *IncidentRepositoryPostgres) queryIncidentsWithSeq(ctx context.Context, where string, args []interface{}, orderBy string, limit, offset int) ([]Incident, []int64, error) {
+	args = append(append([]interface{}(nil), args...), limit, offset)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT seq, `+incidentColumns+` FROM incidents WHERE `+where+` ORDER BY `+orderBy+
+			fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var storedIncidents []Incident
+	var seqs []int64
+	for rows.Next() {
+		var seq int64
+		storedInc, err := scanIncident(seqRowScanner{rowScanner: rows, seq: &seq})
+		if err != nil {
+			return nil, nil, err
+		}
+		storedIncidents = append(storedIncidents, storedInc)
+		seqs = append(seqs, seq)
+	}
+
+	return storedIncidents, seqs, rows.Err()
+}
+
+// seqRowScanner scans the leading seq column and passes the rest of the row to the wrapped scanner destinations
+type seqRowScanner struct {
+	rowScanner
+	seq *int64
+}
+
+func (s seqRowScanner) Scan(dest ...interface{}) error {
+	return s.rowScanner.Scan(append([]interface{}{s.seq}, dest...)...)
+}
+
+// incidentFilterConditions returns the WHERE conditions and their arguments matching the filter
+func incidentFilterConditions(channelID ref.ChannelID, filter repository.IncidentFilter) (string, []interface{}) {
+	args := []interface{}{channelID.String()}
+	conditions := []string{"channel_id = $1"}
+
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(filter.States) > 0 {
+		var placeholders []string
+		for _, state := range filter.States {
+			placeholders = append(placeholders, arg(state.String()))
+		}
+		conditions = append(conditions, "state IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if len(filter.Priorities) > 0 {
+		var placeholders []string
+		for _, priority := range filter.Priorities {
+			placeholders = append(placeholders, arg(priority.String()))
+		}
+		conditions = append(conditions, "priority IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.FieldEngineerID != nil {
+		conditions = append(conditions, "field_engineer_id = "+arg(filter.FieldEngineerID.String()))
+	}
+
+	if filter.Number != "" {
+		conditions = append(conditions, "number = "+arg(filter.Number))
+	}
+
+	if filter.ExternalID != "" {
+		conditions = append(conditions, "external_id = "+arg(filter.ExternalID))
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at::timestamptz >= "+arg(filter.CreatedFrom))
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at::timestamptz <= "+arg(filter.CreatedTo))
+	}
+
+	if !filter.UpdatedFrom.IsZero() {
+		conditions = append(conditions, "updated_at::timestamptz >= "+arg(filter.UpdatedFrom))
+	}
+
+	if !filter.UpdatedTo.IsZero() {
+		conditions = append(conditions, "updated_at::timestamptz <= "+arg(filter.UpdatedTo))
+	}
+
+	if filter.Text != "" {
+		text := arg("%" + escapeLike(filter.Text) + "%")
+		conditions = append(conditions, "(short_description ILIKE "+text+" OR description ILIKE "+text+")")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// incidentOrderBy returns the ORDER BY clause, incidents with equal sort values are kept in the insertion order.
+// If reverse is true, the order is reversed.
+func incidentOrderBy(sort repository.IncidentSort, reverse bool) string {
+	direction := "ASC"
+	if sort.Descending != reverse {
+		direction = "DESC"
+	}
+
+	seqOrder := "seq"
+	if reverse {
+		seqOrder = "seq DESC"
+	}
+
+	expr := incidentSortExpression(sort.Field)
+	if expr == "" {
+		return seqOrder
+	}
+
+	return expr + " " + direction + ", " + seqOrder
+}
+
+// incidentSortExpression returns the SQL expression the incidents are sorted by, it is empty for the insertion order
+func incidentSortExpression(field repository.IncidentSortField) string {
+	switch field {
+	case repository.IncidentSortByCreatedAt:
+		return "created_at::timestamptz"
+	case repository.IncidentSortByUpdatedAt:
+		return "updated_at::timestamptz"
+	case repository.IncidentSortByNumber:
+		return `number COLLATE "C"`
+	case repository.IncidentSortByPriority:
+		return priorityRankExpression()
+	default:
+		return ""
+	}
+}
+
+// incidentCursorCondition appends the condition matching incidents following the cursor position in the sort order
+// (or preceding it if reverse is true) to the WHERE conditions and returns them with their arguments
+func incidentCursorCondition(where string, args []interface{}, sort repository.IncidentSort, cursor repository.Cursor, reverse bool) (string, []interface{}) {
+	args = append(append([]interface{}(nil), args...), int64(cursor.Seq))
+	seqArg := fmt.Sprintf("$%d", len(args))
+
+	seqOp := ">"
+	if reverse {
+		seqOp = "<"
+	}
+
+	expr := incidentSortExpression(sort.Field)
+	if expr == "" {
+		return where + " AND seq " + seqOp + " " + seqArg, args
+	}
+
+	args = append(args, cursor.SortValue)
+	value := fmt.Sprintf("$%d", len(args))
+	switch sort.Field {
+	case repository.IncidentSortByCreatedAt, repository.IncidentSortByUpdatedAt:
+		value += "::timestamptz"
+	case repository.IncidentSortByPriority:
+		value += "::int"
+	}
+
+	op := ">"
+	if sort.Descending != reverse {
+		op = "<"
+	}
+
+	return fmt.Sprintf("%s AND (%s %s %s OR (%s = %s AND seq %s %s))", where, expr, op, value, expr, value, seqOp, seqArg), args
+}
+
+// incidentCursor returns the position of the stored incident in the sort order
+func incidentCursor(sort repository.IncidentSort, storedInc Incident, seq int64) *repository.Cursor {
+	cursor := &repository.Cursor{Seq: uint64(seq)}
+
+	switch sort.Field {
+	case repository.IncidentSortByCreatedAt:
+		cursor.SortValue = storedInc.CreatedAt
+	case repository.IncidentSortByUpdatedAt:
+		cursor.SortValue = storedInc.UpdatedAt
+	case repository.IncidentSortByNumber:
+		cursor.SortValue = storedInc.Number
+	case repository.IncidentSortByPriority:
+		priority, _ := sla.NewPriorityFromString(storedInc.Priority)
+		cursor.SortValue = strconv.Itoa(repository.PriorityRank(priority))
+	}
+
+	return cursor
+}
+
+// priorityRankExpression returns the SQL expression evaluated to the rank of the incident's priority (see repository.PriorityRank)
+func priorityRankExpression() string {
+	expr := "CASE priority"
+	for _, priority := range sla.Priorities() {
+		expr += fmt.Sprintf(" WHEN '%s' THEN %d", priority, repository.PriorityRank(priority))
+	}
+
+	return expr + fmt.Sprintf(" ELSE %d END", repository.PriorityRank(sla.Priority{}))
+}
+
+// escapeLike escapes the special characters of the LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// GetIncidentTimelog returns the incident's timelog with the given ID from the repository
+func (r IncidentRepositoryPostgres) GetIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, timelogID ref.UUID) (timelog.Timelog, error) {
+	_, err := r.GetIncident(ctx, channelID, incID)
+	if err != nil {
+		return timelog.Timelog{}, err
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+timelogColumns+` FROM timelogs WHERE channel_id = $1 AND incident_id = $2 AND id = $3`,
+		channelID.String(), incID.String(), timelogID.String(),
+	)
+
+	storedTimelog, err := scanTimelog(row)
+	if err != nil {
+		return timelog.Timelog{}, wrapQueryError(err, "error loading timelog from repository")
+	}
+
+	tmlg, err := r.convertStoredToDomainTimelog(ctx, channelID, storedTimelog)
+	if err != nil {
+		return timelog.Timelog{}, err
+	}
+
+	return *tmlg, nil
+}
+
+// UpdateIncidentTimelog updates the given closed timelog of the incident in the repository
+func (r IncidentRepositoryPostgres) UpdateIncidentTimelog(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, tmlg timelog.Timelog) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+	errMsg := "error updating timelog in repository"
+
+	timespansJSON, err := json.Marshal(convertTimespansToStored(tmlg.Timespans))
+	if err != nil {
+		return tmlg.UUID(), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	correctionsJSON, err := json.Marshal(convertCorrectionsToStored(tmlg.Corrections))
+	if err != nil {
+		return tmlg.UUID(), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	var originalJSON []byte
+	if original := convertOriginalToStored(tmlg.Original); original != nil {
+		originalJSON, err = json.Marshal(original)
+		if err != nil {
+			return tmlg.UUID(), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+		}
+	}
+
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE timelogs SET remote = $4, start = $5, "end" = $6, work = $7, timespans = $8, visit_summary = $9,
+			corrections = $10, original = $11, updated_by = $12, updated_at = $13
+		WHERE channel_id = $1 AND incident_id = $2 AND id = $3`,
+		channelID.String(), incID.String(), tmlg.UUID().String(),
+		tmlg.Remote, tmlg.Start.String(), tmlg.End.String(), tmlg.Work, string(timespansJSON), tmlg.VisitSummary,
+		string(correctionsJSON), nullableJSON(originalJSON), tmlg.CreatedUpdated.UpdatedByID().String(), now,
+	)
+	if err != nil {
+		return tmlg.UUID(), wrapQueryError(err, errMsg)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return tmlg.UUID(), domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, errMsg)
+	}
+
+	return tmlg.UUID(), nil
+}
+
+// ListIncidentTimelogs returns the list of the incident's timelogs from the repository
+func (r IncidentRepositoryPostgres) ListIncidentTimelogs(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.TimelogList, error) {
+	errMsg := "error loading timelogs from repository"
+
+	_, err := r.GetIncident(ctx, channelID, incID)
+	if err != nil {
+		return repository.TimelogList{}, err
+	}
+
+	var total int
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM timelogs WHERE channel_id = $1 AND incident_id = $2`,
+		channelID.String(), incID.String(),
+	).Scan(&total)
+	if err != nil {
+		return repository.TimelogList{}, wrapQueryError(err, errMsg)
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var storedTimelogs []Timelog
+	if pagination.Size > 0 {
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+timelogColumns+` FROM timelogs WHERE channel_id = $1 AND incident_id = $2 ORDER BY seq LIMIT $3 OFFSET $4`,
+			channelID.String(), incID.String(), pagination.Size, pagination.FirstElementIndex,
+		)
+		if err != nil {
+			return repository.TimelogList{}, wrapQueryError(err, errMsg)
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			storedTimelog, err := scanTimelog(rows)
+			if err != nil {
+				return repository.TimelogList{}, wrapQueryError(err, errMsg)
+			}
+			storedTimelogs = append(storedTimelogs, storedTimelog)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.TimelogList{}, wrapQueryError(err, errMsg)
+		}
+		_ = rows.Close()
+	}
+
+	var list []timelog.Timelog
+	for _, storedTimelog := range storedTimelogs {
+		tmlg, err := r.convertStoredToDomainTimelog(ctx, channelID, storedTimelog)
+		if err != nil {
+			return repository.TimelogList{}, err
+		}
+		list = append(list, *tmlg)
+	}
+
+	timelogList := repository.TimelogList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return timelogList, nil
+}
+
+func scanIncident(row rowScanner, extra ...interface{}) (Incident, error) {
+	var storedInc Incident
+	var auditTrailJSON, slaJSON []byte
+
+	dest := append(extra, &storedInc.ID, &storedInc.Number, &storedInc.ExternalID, &storedInc.ShortDescription, &storedInc.Description,
+		&storedInc.FieldEngineerID, &storedInc.FieldEngineerAccepted, &storedInc.State,
+		&storedInc.OnHoldReason, &storedInc.RemindAt, &storedInc.ResolutionCode, &storedInc.ResolutionNotes, &storedInc.ResolvedAt,
+		&storedInc.Impact, &storedInc.Urgency, &storedInc.Priority, &storedInc.PriorityOverridden,
+		&auditTrailJSON, &slaJSON, &storedInc.CreatedBy, &storedInc.CreatedAt, &storedInc.UpdatedBy, &storedInc.UpdatedAt, &storedInc.Version)
+	if err := row.Scan(dest...); err != nil {
+		return Incident{}, err
+	}
+
+	if err := json.Unmarshal(auditTrailJSON, &storedInc.AuditTrail); err != nil {
+		return Incident{}, err
+	}
+
+	if slaJSON != nil {
+		if err := json.Unmarshal(slaJSON, &storedInc.SLA); err != nil {
+			return Incident{}, err
+		}
+	}
+
+	return storedInc, nil
+}
+
+// restoreIncidentPriority sets the stored impact, urgency and priority to the incident, empty values are restored as zero values
+func restoreIncidentPriority(inc *incident.Incident, impactStr, urgencyStr, priorityStr string, overridden bool) error {
+	var impact incident.Impact
+	var urgency incident.Urgency
+	var priority sla.Priority
+	var err error
+
+	if impactStr != "" {
+		if impact, err = incident.NewImpactFromString(impactStr); err != nil {
+			return err
+		}
+	}
+
+	if urgencyStr != "" {
+		if urgency, err = incident.NewUrgencyFromString(urgencyStr); err != nil {
+			return err
+		}
+	}
+
+	if priorityStr != "" {
+		if priority, err = sla.NewPriorityFromString(priorityStr); err != nil {
+			return err
+		}
+	}
+
+	inc.RestorePriority(impact, urgency, priority, overridden)
+
+	return nil
+}
+
+// marshalIncidentSLA returns JSON encoded SLA of the incident, or nil if the incident does not have SLA
+func marshalIncidentSLA(inc incident.Incident) (interface{}, error) {
+	storedSLA := convertIncidentSLAToStored(inc.SLA())
+	if storedSLA == nil {
+		return nil, nil
+	}
+
+	slaJSON, err := json.Marshal(storedSLA)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(slaJSON), nil
+}
+
+func scanTimelog(row rowScanner) (Timelog, error) {
+	var storedTimelog Timelog
+	var timespansJSON []byte
+
+	var correctionsJSON, originalJSON []byte
+
+	err := row.Scan(&storedTimelog.ID, &storedTimelog.Remote, &storedTimelog.Start, &storedTimelog.End, &storedTimelog.Work,
+		&timespansJSON, &storedTimelog.VisitSummary, &correctionsJSON, &originalJSON,
+		&storedTimelog.CreatedBy, &storedTimelog.CreatedAt, &storedTimelog.UpdatedBy, &storedTimelog.UpdatedAt)
+	if err != nil {
+		return Timelog{}, err
+	}
+
+	if err := json.Unmarshal(timespansJSON, &storedTimelog.Timespans); err != nil {
+		return Timelog{}, err
+	}
+
+	if err := json.Unmarshal(correctionsJSON, &storedTimelog.Corrections); err != nil {
+		return Timelog{}, err
+	}
+
+	if originalJSON != nil {
+		if err := json.Unmarshal(originalJSON, &storedTimelog.Original); err != nil {
+			return Timelog{}, err
+		}
+	}
+
+	return storedTimelog, nil
+}
+
+func (r IncidentRepositoryPostgres) convertStoredToDomainIncident(ctx context.Context, channelID ref.ChannelID, storedInc Incident) (incident.Incident, error) {
+	var inc incident.Incident
+	errMsg := "error loading incident from repository (%s)"
+
+	err := inc.SetUUID(ref.UUID(storedInc.ID))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.ID")
+	}
+
+	inc.Number = storedInc.Number
+	inc.ExternalID = storedInc.ExternalID
+	inc.ShortDescription = storedInc.ShortDescription
+	inc.Description = storedInc.Description
+	inc.SetVersion(storedInc.Version)
+
+	if storedInc.FieldEngineerID.Valid {
+		feUUID := ref.UUID(storedInc.FieldEngineerID.String)
+		inc.FieldEngineerID = &feUUID
+	}
+	inc.SetFieldEngineerAccepted(storedInc.FieldEngineerAccepted)
+
+	// set Timelogs (UUIDs) and open timelog if any
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+timelogColumns+` FROM timelogs WHERE channel_id = $1 AND incident_id = $2 ORDER BY seq`,
+		channelID.String(), storedInc.ID,
+	)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "timelogs")
+	}
+	defer func() { _ = rows.Close() }()
+
+	var openStoredTimelog *Timelog
+	for rows.Next() {
+		storedTimelog, err := scanTimelog(rows)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "timelogs")
+		}
+
+		inc.Timelogs = append(inc.Timelogs, ref.UUID(storedTimelog.ID))
+
+		if storedTimelog.End == "" && openStoredTimelog == nil { // timelog is open
+			openStoredTimelog = &storedTimelog
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "timelogs")
+	}
+	_ = rows.Close()
+
+	if openStoredTimelog != nil {
+		openTimelog, err := r.convertStoredToDomainTimelog(ctx, channelID, *openStoredTimelog)
+		if err != nil {
+			return incident.Incident{}, err
+		}
+
+		inc.SetOpenTimelog(openTimelog)
+	}
+
+	state, err := incident.NewStateFromString(storedInc.State)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "state")
+	}
+
+	err = inc.RestoreState(state)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "state")
+	}
+
+	if storedInc.OnHoldReason != "" {
+		reason, err := incident.NewOnHoldReasonFromString(storedInc.OnHoldReason)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "onHoldReason")
+		}
+
+		inc.SetOnHold(&incident.OnHoldInfo{
+			Reason:   reason,
+			RemindAt: types.DateTime(storedInc.RemindAt),
+		})
+	}
+
+	if storedInc.ResolutionCode != "" {
+		code, err := incident.NewResolutionCodeFromString(storedInc.ResolutionCode)
+		if err != nil {
+			return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "resolutionCode")
+		}
+
+		inc.SetResolution(&incident.Resolution{
+			Code:       code,
+			Notes:      storedInc.ResolutionNotes,
+			ResolvedAt: types.DateTime(storedInc.ResolvedAt),
+		})
+	}
+
+	err = restoreIncidentPriority(&inc, storedInc.Impact, storedInc.Urgency, storedInc.Priority, storedInc.PriorityOverridden)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "priority")
+	}
+
+	var auditTrail []incident.AuditRecord
+	for _, record := range storedInc.AuditTrail {
+		auditTrail = append(auditTrail, incident.AuditRecord{
+			Action:  incident.AllowedAction(record.Action),
+			ActorID: ref.UUID(record.ActorID),
+			Time:    types.DateTime(record.Time),
+			Note:    record.Note,
+		})
+	}
+	inc.SetAuditTrail(auditTrail)
+
+	incSLA, err := convertStoredToDomainIncidentSLA(storedInc.SLA)
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "sla")
+	}
+	inc.SetSLA(incSLA)
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedInc.CreatedBy))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.CreatedBy")
+	}
+
+	err = inc.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedInc.CreatedAt))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedInc.UpdatedBy))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.UpdatedBy")
+	}
+
+	err = inc.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedInc.UpdatedAt))
+	if err != nil {
+		return incident.Incident{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedInc.UpdatedAt")
+	}
+
+	return inc, nil
+}
+
+func (r IncidentRepositoryPostgres) convertStoredToDomainTimelog(ctx context.Context, channelID ref.ChannelID, storedTimelog Timelog) (*timelog.Timelog, error) {
+	errMsg := "error loading timelog from repository (%s)"
+
+	timespans, err := convertStoredToDomainTimespans(storedTimelog.Timespans)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Timespans")
+	}
+
+	corrections, err := convertStoredToDomainCorrections(storedTimelog.Corrections)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Corrections")
+	}
+
+	original, err := convertStoredToDomainOriginal(storedTimelog.Original)
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.Original")
+	}
+
+	tmlg := &timelog.Timelog{
+		Remote:       storedTimelog.Remote,
+		Start:        types.DateTime(storedTimelog.Start),
+		End:          types.DateTime(storedTimelog.End),
+		Work:         storedTimelog.Work,
+		Timespans:    timespans,
+		VisitSummary: storedTimelog.VisitSummary,
+		Corrections:  corrections,
+		Original:     original,
+	}
+
+	err = tmlg.SetUUID(ref.UUID(storedTimelog.ID))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.ID")
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.CreatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdBy")
+	}
+
+	err = tmlg.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedTimelog.CreatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.createdAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedTimelog.UpdatedBy))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedBy")
+	}
+
+	err = tmlg.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedTimelog.UpdatedAt))
+	if err != nil {
+		return nil, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedTimelog.UpdatedAt")
+	}
+
+	return tmlg, nil
+}
+
+func nullableUUID(id *ref.UUID) sql.NullString {
+	if id == nil || id.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: id.String(), Valid: true}
+}
+
+func nullableJSON(data []byte) sql.NullString {
+	if data == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+func convertTimespansToStored(timespans []timelog.Timespan) []Timespan {
+	storedTimespans := []Timespan{}
+	for _, span := range timespans {
+		storedTimespans = append(storedTimespans, Timespan{
+			Type:  span.Type.String(),
+			Start: span.Start.String(),
+			End:   span.End.String(),
+		})
+	}
+
+	return storedTimespans
+}
+
+func convertStoredToDomainTimespans(storedTimespans []Timespan) ([]timelog.Timespan, error) {
+	var timespans []timelog.Timespan
+	for _, storedSpan := range storedTimespans {
+		spanType, err := timelog.NewTimespanTypeFromString(storedSpan.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		timespans = append(timespans, timelog.Timespan{
+			Type:  spanType,
+			Start: types.DateTime(storedSpan.Start),
+			End:   types.DateTime(storedSpan.End),
+		})
+	}
+
+	return timespans, nil
+}
+
+func convertCorrectionsToStored(corrections []timelog.Correction) []TimelogCorrection {
+	storedCorrections := []TimelogCorrection{}
+	for _, c := range corrections {
+		storedCorrections = append(storedCorrections, TimelogCorrection{
+			Remote:       c.Remote,
+			Start:        c.Start.String(),
+			End:          c.End.String(),
+			VisitSummary: c.VisitSummary,
+			Reason:       c.Reason,
+			Status:       c.Status.String(),
+			ProposedBy:   c.ProposedBy.String(),
+			ProposedAt:   c.ProposedAt.String(),
+			DecidedBy:    c.DecidedBy.String(),
+			DecidedAt:    c.DecidedAt.String(),
+			DecisionNote: c.DecisionNote,
+		})
+	}
+
+	return storedCorrections
+}
+
+func convertStoredToDomainCorrections(storedCorrections []TimelogCorrection) ([]timelog.Correction, error) {
+	var corrections []timelog.Correction
+	for _, c := range storedCorrections {
+		status, err := timelog.NewCorrectionStatusFromString(c.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		corrections = append(corrections, timelog.Correction{
+			Remote:       c.Remote,
+			Start:        types.DateTime(c.Start),
+			End:          types.DateTime(c.End),
+			VisitSummary: c.VisitSummary,
+			Reason:       c.Reason,
+			Status:       status,
+			ProposedBy:   ref.UUID(c.ProposedBy),
+			ProposedAt:   types.DateTime(c.ProposedAt),
+			DecidedBy:    ref.UUID(c.DecidedBy),
+			DecidedAt:    types.DateTime(c.DecidedAt),
+			DecisionNote: c.DecisionNote,
+		})
+	}
+
+	return corrections, nil
+}
+
+func convertOriginalToStored(original *timelog.OriginalValues) *TimelogOriginal {
+	if original == nil {
+		return nil
+	}
+
+	return &TimelogOriginal{
+		Remote:       original.Remote,
+		Start:        original.Start.String(),
+		End:          original.End.String(),
+		Work:         original.Work,
+		Timespans:    convertTimespansToStored(original.Timespans),
+		VisitSummary: original.VisitSummary,
+	}
+}
+
+func convertStoredToDomainOriginal(storedOriginal *TimelogOriginal) (*timelog.OriginalValues, error) {
+	if storedOriginal == nil {
+		return nil, nil
+	}
+
+	timespans, err := convertStoredToDomainTimespans(storedOriginal.Timespans)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timelog.OriginalValues{
+		Remote:       storedOriginal.Remote,
+		Start:        types.DateTime(storedOriginal.Start),
+		End:          types.DateTime(storedOriginal.End),
+		Work:         storedOriginal.Work,
+		Timespans:    timespans,
+		VisitSummary: storedOriginal.VisitSummary,
+	}, nil
+}
+
+// AddIncidentHistoryEntry adds the given history entry of the incident to the repository
+func (r IncidentRepositoryPostgres) AddIncidentHistoryEntry(ctx context.Context, channelID ref.ChannelID, entry history.Entry) (ref.UUID, error) {
+	errMsg := "error adding history entry to repository"
+
+	_, err := r.GetIncident(ctx, channelID, entry.IncidentID)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	entryID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	changes := []HistoryChange{}
+	for _, change := range entry.Changes {
+		changes = append(changes, HistoryChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO incident_history (channel_id, `+historyColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		channelID.String(), entryID.String(), entry.IncidentID.String(), entry.Action,
+		nullableUUID(&entry.ActorID), nullableUUID(&entry.RequestedByID), entry.Time.String(), string(changesJSON),
+	)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, errMsg)
+	}
+
+	return entryID, nil
+}
+
+// ListIncidentHistory returns the list of the incident's history entries from the repository, the oldest entries are listed first
+func (r IncidentRepositoryPostgres) ListIncidentHistory(ctx context.Context, channelID ref.ChannelID, incID ref.UUID, page, itemsPerPage uint) (repository.HistoryList, error) {
+	errMsg := "error loading history from repository"
+
+	_, err := r.GetIncident(ctx, channelID, incID)
+	if err != nil {
+		return repository.HistoryList{}, err
+	}
+
+	var total int
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM incident_history WHERE channel_id = $1 AND incident_id = $2`,
+		channelID.String(), incID.String(),
+	).Scan(&total)
+	if err != nil {
+		return repository.HistoryList{}, wrapQueryError(err, errMsg)
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []history.Entry
+	if pagination.Size > 0 {
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+historyColumns+` FROM incident_history WHERE channel_id = $1 AND incident_id = $2 ORDER BY seq LIMIT $3 OFFSET $4`,
+			channelID.String(), incID.String(), pagination.Size, pagination.FirstElementIndex,
+		)
+		if err != nil {
+			return repository.HistoryList{}, wrapQueryError(err, errMsg)
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			storedEntry, err := scanHistoryEntry(rows)
+			if err != nil {
+				return repository.HistoryList{}, wrapQueryError(err, errMsg)
+			}
+
+			entry, err := convertStoredToDomainHistoryEntry(storedEntry)
+			if err != nil {
+				return repository.HistoryList{}, err
+			}
+			list = append(list, entry)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.HistoryList{}, wrapQueryError(err, errMsg)
+		}
+	}
+
+	historyList := repository.HistoryList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return historyList, nil
+}
+
+func scanHistoryEntry(row rowScanner) (HistoryEntry, error) {
+	var storedEntry HistoryEntry
+	var changesJSON []byte
+
+	err := row.Scan(&storedEntry.ID, &storedEntry.IncidentID, &storedEntry.Action, &storedEntry.ActorID, &storedEntry.RequestedByID,
+		&storedEntry.Time, &changesJSON)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+
+	if err := json.Unmarshal(changesJSON, &storedEntry.Changes); err != nil {
+		return HistoryEntry{}, err
+	}
+
+	return storedEntry, nil
+}
+
+func convertStoredToDomainHistoryEntry(storedEntry HistoryEntry) (history.Entry, error) {
+	var changes []history.Change
+	for _, change := range storedEntry.Changes {
+		changes = append(changes, history.Change{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	entry := history.Entry{
+		IncidentID:    ref.UUID(storedEntry.IncidentID),
+		Action:        storedEntry.Action,
+		ActorID:       ref.UUID(storedEntry.ActorID.String),
+		RequestedByID: ref.UUID(storedEntry.RequestedByID.String),
+		Time:          types.DateTime(storedEntry.Time),
+		Changes:       changes,
+	}
+
+	if err := entry.SetUUID(ref.UUID(storedEntry.ID)); err != nil {
+		return history.Entry{}, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate applies all embedded SQL migrations which were not applied to the database yet.
+// Applied migrations are recorded in the schema_migrations table, each migration runs in its own transaction.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not create schema_migrations table")
+	}
+
+	names, err := fs.Glob(migrationFiles, "migrations/*.up.sql")
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not list migrations")
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".up.sql")
+
+		if err := applyMigration(ctx, db, version, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, version, name string) error {
+	script, err := migrationFiles.ReadFile(name)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not read migration %s", version)
+	}
+
+	return withTx(ctx, db, func(tx *sql.Tx) error {
+		// serialize concurrently starting instances
+		if _, err := tx.ExecContext(ctx, `LOCK TABLE schema_migrations IN EXCLUSIVE MODE`); err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not lock schema_migrations table")
+		}
+
+		var applied bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied)
+		if err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not check migration %s", version)
+		}
+
+		if applied {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not apply migration %s", version)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			return domain.WrapErrorf(err, domain.ErrorCodeUnknown, "could not record migration %s", version)
+		}
+
+		return nil
+	})
+}
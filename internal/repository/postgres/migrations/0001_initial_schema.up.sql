@@ -0,0 +1,95 @@
+-- All tables are scoped by channel, every query must filter by channel_id.
+-- Timestamps are stored as RFC3339 formatted strings (types.DateTime).
+
+CREATE TABLE basic_users (
+    seq                BIGSERIAL,
+    channel_id         TEXT NOT NULL,
+    id                 UUID NOT NULL,
+    external_user_uuid TEXT NOT NULL,
+    name               TEXT NOT NULL DEFAULT '',
+    surname            TEXT NOT NULL DEFAULT '',
+    org_display_name   TEXT NOT NULL DEFAULT '',
+    org_name           TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (channel_id, id),
+    UNIQUE (channel_id, external_user_uuid)
+);
+
+CREATE TABLE field_engineers (
+    seq           BIGSERIAL,
+    channel_id    TEXT NOT NULL,
+    id            UUID NOT NULL,
+    basic_user_id UUID NOT NULL,
+    deactivated   BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by    UUID NOT NULL,
+    created_at    TEXT NOT NULL,
+    updated_by    UUID NOT NULL,
+    updated_at    TEXT NOT NULL,
+    PRIMARY KEY (channel_id, id),
+    UNIQUE (channel_id, basic_user_id)
+);
+
+CREATE TABLE time_sessions (
+    seq                             BIGSERIAL,
+    channel_id                      TEXT NOT NULL,
+    id                              UUID NOT NULL,
+    field_engineer_id               UUID NOT NULL,
+    state                           TEXT NOT NULL,
+    state_changed_at                TEXT NOT NULL DEFAULT '',
+    incidents                       JSONB NOT NULL DEFAULT '[]',
+    work                            BIGINT NOT NULL DEFAULT 0,
+    travel                          BIGINT NOT NULL DEFAULT 0,
+    travel_back                     BIGINT NOT NULL DEFAULT 0,
+    travel_distance_in_travel_units BIGINT NOT NULL DEFAULT 0,
+    created_by                      UUID NOT NULL,
+    created_at                      TEXT NOT NULL,
+    updated_by                      UUID NOT NULL,
+    updated_at                      TEXT NOT NULL,
+    PRIMARY KEY (channel_id, id),
+    FOREIGN KEY (channel_id, field_engineer_id) REFERENCES field_engineers (channel_id, id)
+);
+
+CREATE INDEX time_sessions_field_engineer_idx ON time_sessions (channel_id, field_engineer_id);
+
+CREATE TABLE incidents (
+    seq               BIGSERIAL,
+    channel_id        TEXT NOT NULL,
+    id                UUID NOT NULL,
+    number            TEXT NOT NULL DEFAULT '',
+    external_id       TEXT NOT NULL DEFAULT '',
+    short_description TEXT NOT NULL DEFAULT '',
+    description       TEXT NOT NULL DEFAULT '',
+    field_engineer_id UUID,
+    state             TEXT NOT NULL,
+    on_hold_reason    TEXT NOT NULL DEFAULT '',
+    remind_at         TEXT NOT NULL DEFAULT '',
+    resolution_code   TEXT NOT NULL DEFAULT '',
+    resolution_notes  TEXT NOT NULL DEFAULT '',
+    resolved_at       TEXT NOT NULL DEFAULT '',
+    audit_trail       JSONB NOT NULL DEFAULT '[]',
+    created_by        UUID NOT NULL,
+    created_at        TEXT NOT NULL,
+    updated_by        UUID NOT NULL,
+    updated_at        TEXT NOT NULL,
+    PRIMARY KEY (channel_id, id)
+);
+
+CREATE TABLE timelogs (
+    seq           BIGSERIAL,
+    channel_id    TEXT NOT NULL,
+    id            UUID NOT NULL,
+    incident_id   UUID NOT NULL,
+    remote        BOOLEAN NOT NULL DEFAULT FALSE,
+    start         TEXT NOT NULL DEFAULT '',
+    "end"         TEXT NOT NULL DEFAULT '',
+    work          BIGINT NOT NULL DEFAULT 0,
+    timespans     JSONB NOT NULL DEFAULT '[]',
+    visit_summary TEXT NOT NULL DEFAULT '',
+    created_by    UUID NOT NULL,
+    created_at    TEXT NOT NULL,
+    updated_by    UUID NOT NULL,
+    updated_at    TEXT NOT NULL,
+    PRIMARY KEY (channel_id, id),
+    FOREIGN KEY (channel_id, incident_id) REFERENCES incidents (channel_id, id)
+);
+
+CREATE INDEX timelogs_incident_idx ON timelogs (channel_id, incident_id);
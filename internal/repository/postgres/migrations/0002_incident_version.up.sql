@@ -0,0 +1,2 @@
+-- version of the incident used for optimistic locking, it is incremented on each update
+ALTER TABLE incidents ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
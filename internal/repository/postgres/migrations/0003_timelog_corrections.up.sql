@@ -0,0 +1,3 @@
+-- corrections of the closed timelog proposed by the field engineer and the values of the timelog before the first approved correction
+ALTER TABLE timelogs ADD COLUMN corrections JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE timelogs ADD COLUMN original JSONB;
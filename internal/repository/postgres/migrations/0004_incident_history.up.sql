@@ -0,0 +1,16 @@
+-- immutable history of the incident changes, actor_id is NULL for the changes made automatically by the system
+CREATE TABLE incident_history (
+    seq             BIGSERIAL,
+    channel_id      TEXT NOT NULL,
+    id              UUID NOT NULL,
+    incident_id     UUID NOT NULL,
+    action          TEXT NOT NULL,
+    actor_id        UUID,
+    requested_by_id UUID,
+    time            TEXT NOT NULL,
+    changes         JSONB NOT NULL DEFAULT '[]',
+    PRIMARY KEY (channel_id, id),
+    FOREIGN KEY (channel_id, incident_id) REFERENCES incidents (channel_id, id)
+);
+
+CREATE INDEX incident_history_incident_idx ON incident_history (channel_id, incident_id, seq);
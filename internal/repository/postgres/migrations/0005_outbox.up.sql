@@ -0,0 +1,13 @@
+-- domain events waiting for delivery, they are inserted in the same transaction as the aggregate that recorded them
+CREATE TABLE outbox (
+    seq          BIGSERIAL,
+    id           UUID PRIMARY KEY,
+    channel_id   TEXT NOT NULL,
+    event_type   TEXT NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload      JSONB NOT NULL,
+    occurred_at  TEXT NOT NULL,
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (seq) WHERE delivered_at IS NULL;
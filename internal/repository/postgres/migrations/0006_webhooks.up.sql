@@ -0,0 +1,37 @@
+-- webhook subscriptions of the channels, event_types is empty if all events are sent to the URL
+CREATE TABLE webhook_subscriptions (
+    seq         BIGSERIAL,
+    channel_id  TEXT NOT NULL,
+    id          UUID NOT NULL,
+    url         TEXT NOT NULL,
+    secret      TEXT NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    created_by  UUID NOT NULL,
+    created_at  TEXT NOT NULL,
+    updated_by  UUID NOT NULL,
+    updated_at  TEXT NOT NULL,
+    PRIMARY KEY (channel_id, id)
+);
+
+-- deliveries of the events to the subscribed URLs, they are deleted together with the subscription
+CREATE TABLE webhook_deliveries (
+    seq                  BIGSERIAL,
+    channel_id           TEXT NOT NULL,
+    id                   UUID NOT NULL,
+    subscription_id      UUID NOT NULL,
+    event_id             UUID NOT NULL,
+    event_type           TEXT NOT NULL,
+    payload              TEXT NOT NULL,
+    status               TEXT NOT NULL,
+    attempts             INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at      TEXT NOT NULL DEFAULT '',
+    last_attempt_at      TEXT NOT NULL DEFAULT '',
+    last_response_status INTEGER NOT NULL DEFAULT 0,
+    last_error           TEXT NOT NULL DEFAULT '',
+    created_at           TEXT NOT NULL,
+    PRIMARY KEY (channel_id, id),
+    FOREIGN KEY (channel_id, subscription_id) REFERENCES webhook_subscriptions (channel_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_subscription_idx ON webhook_deliveries (channel_id, subscription_id, seq);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (seq) WHERE status = 'Pending';
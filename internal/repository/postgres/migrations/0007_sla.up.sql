@@ -0,0 +1,15 @@
+-- SLA timers of the incident, NULL if the channel had no SLA definition when the incident was created
+ALTER TABLE incidents ADD COLUMN sla JSONB;
+
+-- SLA definition of the channel, there is at most one definition per channel
+CREATE TABLE sla_definitions (
+    channel_id       TEXT NOT NULL,
+    targets          JSONB NOT NULL DEFAULT '[]',
+    default_priority TEXT NOT NULL,
+    calendar         JSONB NOT NULL,
+    created_by       UUID NOT NULL,
+    created_at       TEXT NOT NULL,
+    updated_by       UUID NOT NULL,
+    updated_at       TEXT NOT NULL,
+    PRIMARY KEY (channel_id)
+);
@@ -0,0 +1,18 @@
+-- Impact, urgency and priority of the incident, empty for the incidents created before they were introduced
+ALTER TABLE incidents ADD COLUMN impact TEXT NOT NULL DEFAULT '';
+ALTER TABLE incidents ADD COLUMN urgency TEXT NOT NULL DEFAULT '';
+ALTER TABLE incidents ADD COLUMN priority TEXT NOT NULL DEFAULT '';
+ALTER TABLE incidents ADD COLUMN priority_overridden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX incidents_priority_idx ON incidents (channel_id, priority);
+
+-- Priority matrix of the channel, there is at most one matrix per channel
+CREATE TABLE priority_matrices (
+    channel_id TEXT NOT NULL,
+    priorities JSONB NOT NULL DEFAULT '[]',
+    created_by UUID NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_by UUID NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (channel_id)
+);
@@ -0,0 +1,5 @@
+-- Acceptance of the incident by the assigned field engineer
+ALTER TABLE incidents ADD COLUMN field_engineer_accepted BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- field engineers of the incidents they already started working on do not have to accept them
+UPDATE incidents SET field_engineer_accepted = TRUE WHERE field_engineer_id IS NOT NULL AND state <> 'new';
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// insertOutboxMessages inserts messages of the events recorded by the aggregate to the outbox
+func insertOutboxMessages(ctx context.Context, tx *sql.Tx, rand io.Reader, clock repository.Clock, channelID ref.ChannelID, events []event.Event) error {
+	messages, err := repository.NewOutboxMessages(rand, clock, channelID, events)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO outbox (id, channel_id, event_type, aggregate_id, payload, occurred_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			msg.ID.String(), msg.ChannelID.String(), msg.EventType.String(), msg.AggregateID.String(), string(msg.Payload), msg.OccurredAt.String(),
+		)
+		if err != nil {
+			return wrapQueryError(err, "error storing outbox message")
+		}
+	}
+
+	return nil
+}
+
+// OutboxRepositoryPostgres reads the outbox messages stored by the field engineer and incident PostgreSQL repositories
+type OutboxRepositoryPostgres struct {
+	db querier
+}
+
+// NewOutboxRepositoryPostgres returns new initialized repository
+func NewOutboxRepositoryPostgres(db *sql.DB) *OutboxRepositoryPostgres {
+	return &OutboxRepositoryPostgres{
+		db: db,
+	}
+}
+
+// ListPendingMessages returns up to limit messages that were not delivered yet, in the order they were stored
+func (r *OutboxRepositoryPostgres) ListPendingMessages(ctx context.Context, limit uint) ([]repository.OutboxMessage, error) {
+	errMsg := "error listing outbox messages"
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, channel_id, event_type, aggregate_id, payload, occurred_at FROM outbox
+		WHERE delivered_at IS NULL ORDER BY seq LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, wrapQueryError(err, errMsg)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []repository.OutboxMessage
+	for rows.Next() {
+		var id, channelID, eventType, aggregateID, payload, occurredAt string
+		if err := rows.Scan(&id, &channelID, &eventType, &aggregateID, &payload, &occurredAt); err != nil {
+			return nil, wrapQueryError(err, errMsg)
+		}
+
+		messages = append(messages, repository.OutboxMessage{
+			ID:          ref.UUID(id),
+			ChannelID:   ref.ChannelID(channelID),
+			EventType:   event.Type(eventType),
+			AggregateID: ref.UUID(aggregateID),
+			Payload:     []byte(payload),
+			OccurredAt:  types.DateTime(occurredAt),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapQueryError(err, errMsg)
+	}
+
+	return messages, nil
+}
+
+// MarkMessageDelivered removes the delivered message from the pending messages
+func (r *OutboxRepositoryPostgres) MarkMessageDelivered(ctx context.Context, ID ref.UUID) error {
+	errMsg := "error marking outbox message as delivered"
+
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE outbox SET delivered_at = now() WHERE id = $1 AND delivered_at IS NULL`,
+		ID.String(),
+	)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, errMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package postgres
+
+// PriorityMatrix is a priority matrix row stored in the database
+type PriorityMatrix struct {
+	Priorities []PriorityMatrixEntry
+
+	CreatedAt string
+
+	CreatedBy string
+
+	UpdatedAt string
+
+	UpdatedBy string
+}
+
+// PriorityMatrixEntry is stored as JSON
+type PriorityMatrixEntry struct {
+	Impact string `json:"impact"`
+
+	Urgency string `json:"urgency"`
+
+	Priority string `json:"priority"`
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+const priorityMatrixColumns = `priorities, created_by, created_at, updated_by, updated_at`
+
+// PriorityMatrixRepositoryPostgres keeps data in PostgreSQL database
+type PriorityMatrixRepositoryPostgres struct {
+	db                  querier
+	basicUserRepository repository.BasicUserRepository
+	clock               repository.Clock
+}
+
+// NewPriorityMatrixRepositoryPostgres returns new initialized repository
+func NewPriorityMatrixRepositoryPostgres(db *sql.DB, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *PriorityMatrixRepositoryPostgres {
+	return &PriorityMatrixRepositoryPostgres{
+		db:                  db,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// SetPriorityMatrix stores the priority matrix of the channel, the current matrix (if any) is replaced
+func (r *PriorityMatrixRepositoryPostgres) SetPriorityMatrix(ctx context.Context, channelID ref.ChannelID, matrix incident.PriorityMatrix) error {
+	now := r.clock.NowFormatted().String()
+	errMsg := "error storing priority matrix to repository"
+
+	priorities := []PriorityMatrixEntry{}
+	for _, impact := range incident.Impacts() {
+		for _, urgency := range incident.Urgencies() {
+			if priority := matrix.PriorityFor(impact, urgency); !priority.IsZero() {
+				priorities = append(priorities, PriorityMatrixEntry{
+					Impact:   impact.String(),
+					Urgency:  urgency.String(),
+					Priority: priority.String(),
+				})
+			}
+		}
+	}
+
+	prioritiesJSON, err := json.Marshal(priorities)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	// replaced matrix keeps the information about its creation
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO priority_matrices (channel_id, `+priorityMatrixColumns+`) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (channel_id) DO UPDATE SET
+			priorities = EXCLUDED.priorities,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`,
+		channelID.String(), string(prioritiesJSON),
+		matrix.CreatedUpdated.CreatedByID().String(), now, matrix.CreatedUpdated.UpdatedByID().String(), now,
+	)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	return nil
+}
+
+// GetPriorityMatrix returns the priority matrix of the channel from the repository
+func (r *PriorityMatrixRepositoryPostgres) GetPriorityMatrix(ctx context.Context, channelID ref.ChannelID) (incident.PriorityMatrix, error) {
+	errMsg := "error loading priority matrix from repository"
+
+	var storedMatrix PriorityMatrix
+	var prioritiesJSON []byte
+
+	err := r.db.QueryRowContext(ctx,
+		`SELECT `+priorityMatrixColumns+` FROM priority_matrices WHERE channel_id = $1`, channelID.String(),
+	).Scan(&prioritiesJSON, &storedMatrix.CreatedBy, &storedMatrix.CreatedAt, &storedMatrix.UpdatedBy, &storedMatrix.UpdatedAt)
+	if err != nil {
+		return incident.PriorityMatrix{}, wrapQueryError(err, errMsg)
+	}
+
+	if err := json.Unmarshal(prioritiesJSON, &storedMatrix.Priorities); err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	return r.convertStoredToDomainPriorityMatrix(ctx, channelID, storedMatrix)
+}
+
+func (r *PriorityMatrixRepositoryPostgres) convertStoredToDomainPriorityMatrix(ctx context.Context, channelID ref.ChannelID, storedMatrix PriorityMatrix) (incident.PriorityMatrix, error) {
+	errMsg := "error loading priority matrix from repository (%s)"
+
+	matrix := incident.PriorityMatrix{
+		Priorities: make(map[incident.ImpactUrgency]sla.Priority),
+	}
+
+	for _, entry := range storedMatrix.Priorities {
+		impact, err := incident.NewImpactFromString(entry.Impact)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		urgency, err := incident.NewUrgencyFromString(entry.Urgency)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		priority, err := sla.NewPriorityFromString(entry.Priority)
+		if err != nil {
+			return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.Priorities")
+		}
+
+		matrix.Priorities[incident.ImpactUrgency{Impact: impact, Urgency: urgency}] = priority
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedMatrix.CreatedBy))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.CreatedBy")
+	}
+
+	err = matrix.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedMatrix.CreatedAt))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedMatrix.UpdatedBy))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.UpdatedBy")
+	}
+
+	err = matrix.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedMatrix.UpdatedAt))
+	if err != nil {
+		return incident.PriorityMatrix{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedMatrix.UpdatedAt")
+	}
+
+	return matrix, nil
+}
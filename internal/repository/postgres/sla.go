@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+)
+
+// SLADefinition is an SLA definition row stored in the database
+type SLADefinition struct {
+	Targets []SLATarget
+
+	DefaultPriority string
+
+	Calendar SLACalendar
+
+	CreatedAt string
+
+	CreatedBy string
+
+	UpdatedAt string
+
+	UpdatedBy string
+}
+
+// SLATarget is stored as JSON, times are in seconds
+type SLATarget struct {
+	Priority string `json:"priority"`
+
+	Response int64 `json:"response"`
+
+	Resolution int64 `json:"resolution"`
+}
+
+// SLACalendar is stored as JSON
+type SLACalendar struct {
+	Location string `json:"location"`
+
+	WorkingHours []SLAWorkingHours `json:"working_hours"`
+
+	Holidays []string `json:"holidays"`
+}
+
+// SLAWorkingHours is stored as JSON
+type SLAWorkingHours struct {
+	Weekday int `json:"weekday"`
+
+	Start string `json:"start"`
+
+	End string `json:"end"`
+}
+
+// IncidentSLA is stored as JSON
+type IncidentSLA struct {
+	Priority string `json:"priority"`
+
+	Calendar SLACalendar `json:"calendar"`
+
+	Response SLATimer `json:"response"`
+
+	Resolution SLATimer `json:"resolution"`
+}
+
+// SLATimer is stored as JSON, times are in seconds
+type SLATimer struct {
+	Target int64 `json:"target"`
+
+	Elapsed int64 `json:"elapsed"`
+
+	RunningSince string `json:"running_since"`
+
+	StoppedAt string `json:"stopped_at"`
+}
+
+func convertCalendarToStored(cal sla.Calendar) SLACalendar {
+	storedCal := SLACalendar{
+		Location: cal.Location,
+		Holidays: append([]string(nil), cal.Holidays...),
+	}
+
+	for _, wh := range cal.WorkingHours {
+		storedCal.WorkingHours = append(storedCal.WorkingHours, SLAWorkingHours{
+			Weekday: int(wh.Weekday),
+			Start:   wh.Start,
+			End:     wh.End,
+		})
+	}
+
+	return storedCal
+}
+
+func convertStoredToDomainCalendar(storedCal SLACalendar) sla.Calendar {
+	cal := sla.Calendar{
+		Location: storedCal.Location,
+		Holidays: append([]string(nil), storedCal.Holidays...),
+	}
+
+	for _, wh := range storedCal.WorkingHours {
+		cal.WorkingHours = append(cal.WorkingHours, sla.WorkingHours{
+			Weekday: time.Weekday(wh.Weekday),
+			Start:   wh.Start,
+			End:     wh.End,
+		})
+	}
+
+	return cal
+}
+
+func convertIncidentSLAToStored(s *sla.SLA) *IncidentSLA {
+	if s == nil {
+		return nil
+	}
+
+	return &IncidentSLA{
+		Priority:   s.Priority.String(),
+		Calendar:   convertCalendarToStored(s.Calendar),
+		Response:   convertTimerToStored(s.Response),
+		Resolution: convertTimerToStored(s.Resolution),
+	}
+}
+
+func convertStoredToDomainIncidentSLA(storedSLA *IncidentSLA) (*sla.SLA, error) {
+	if storedSLA == nil {
+		return nil, nil
+	}
+
+	priority, err := sla.NewPriorityFromString(storedSLA.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sla.SLA{
+		Priority:   priority,
+		Calendar:   convertStoredToDomainCalendar(storedSLA.Calendar),
+		Response:   convertStoredToDomainTimer(storedSLA.Response),
+		Resolution: convertStoredToDomainTimer(storedSLA.Resolution),
+	}, nil
+}
+
+func convertTimerToStored(t sla.Timer) SLATimer {
+	return SLATimer{
+		Target:       int64(t.Target / time.Second),
+		Elapsed:      int64(t.Elapsed / time.Second),
+		RunningSince: t.RunningSince.String(),
+		StoppedAt:    t.StoppedAt.String(),
+	}
+}
+
+func convertStoredToDomainTimer(storedTimer SLATimer) sla.Timer {
+	return sla.Timer{
+		Target:       time.Duration(storedTimer.Target) * time.Second,
+		Elapsed:      time.Duration(storedTimer.Elapsed) * time.Second,
+		RunningSince: types.DateTime(storedTimer.RunningSince),
+		StoppedAt:    types.DateTime(storedTimer.StoppedAt),
+	}
+}
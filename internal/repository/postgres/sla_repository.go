@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+const slaDefinitionColumns = `targets, default_priority, calendar, created_by, created_at, updated_by, updated_at`
+
+// SLARepositoryPostgres keeps data in PostgreSQL database
+type SLARepositoryPostgres struct {
+	db                  querier
+	basicUserRepository repository.BasicUserRepository
+	clock               repository.Clock
+}
+
+// NewSLARepositoryPostgres returns new initialized repository
+func NewSLARepositoryPostgres(db *sql.DB, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *SLARepositoryPostgres {
+	return &SLARepositoryPostgres{
+		db:                  db,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// SetSLADefinition stores the SLA definition of the channel, the current definition (if any) is replaced
+func (r *SLARepositoryPostgres) SetSLADefinition(ctx context.Context, channelID ref.ChannelID, def sla.Definition) error {
+	now := r.clock.NowFormatted().String()
+	errMsg := "error storing SLA definition to repository"
+
+	targets := []SLATarget{}
+	for _, priority := range sla.Priorities() {
+		if target, ok := def.Targets[priority]; ok {
+			targets = append(targets, SLATarget{
+				Priority:   priority.String(),
+				Response:   int64(target.Response / time.Second),
+				Resolution: int64(target.Resolution / time.Second),
+			})
+		}
+	}
+
+	targetsJSON, err := json.Marshal(targets)
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	calendarJSON, err := json.Marshal(convertCalendarToStored(def.Calendar))
+	if err != nil {
+		return domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	// replaced definition keeps the information about its creation
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO sla_definitions (channel_id, `+slaDefinitionColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (channel_id) DO UPDATE SET
+			targets = EXCLUDED.targets,
+			default_priority = EXCLUDED.default_priority,
+			calendar = EXCLUDED.calendar,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`,
+		channelID.String(), string(targetsJSON), def.DefaultPriority.String(), string(calendarJSON),
+		def.CreatedUpdated.CreatedByID().String(), now, def.CreatedUpdated.UpdatedByID().String(), now,
+	)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	return nil
+}
+
+// GetSLADefinition returns the SLA definition of the channel from the repository
+func (r *SLARepositoryPostgres) GetSLADefinition(ctx context.Context, channelID ref.ChannelID) (sla.Definition, error) {
+	errMsg := "error loading SLA definition from repository"
+
+	var storedDef SLADefinition
+	var targetsJSON, calendarJSON []byte
+
+	err := r.db.QueryRowContext(ctx,
+		`SELECT `+slaDefinitionColumns+` FROM sla_definitions WHERE channel_id = $1`, channelID.String(),
+	).Scan(&targetsJSON, &storedDef.DefaultPriority, &calendarJSON,
+		&storedDef.CreatedBy, &storedDef.CreatedAt, &storedDef.UpdatedBy, &storedDef.UpdatedAt)
+	if err != nil {
+		return sla.Definition{}, wrapQueryError(err, errMsg)
+	}
+
+	if err := json.Unmarshal(targetsJSON, &storedDef.Targets); err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	if err := json.Unmarshal(calendarJSON, &storedDef.Calendar); err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	return r.convertStoredToDomainDefinition(ctx, channelID, storedDef)
+}
+
+func (r *SLARepositoryPostgres) convertStoredToDomainDefinition(ctx context.Context, channelID ref.ChannelID, storedDef SLADefinition) (sla.Definition, error) {
+	errMsg := "error loading SLA definition from repository (%s)"
+
+	def := sla.Definition{
+		Targets:  make(map[sla.Priority]sla.Target),
+		Calendar: convertStoredToDomainCalendar(storedDef.Calendar),
+	}
+
+	var err error
+	def.DefaultPriority, err = sla.NewPriorityFromString(storedDef.DefaultPriority)
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.DefaultPriority")
+	}
+
+	for _, storedTarget := range storedDef.Targets {
+		priority, err := sla.NewPriorityFromString(storedTarget.Priority)
+		if err != nil {
+			return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.Targets")
+		}
+
+		def.Targets[priority] = sla.Target{
+			Response:   time.Duration(storedTarget.Response) * time.Second,
+			Resolution: time.Duration(storedTarget.Resolution) * time.Second,
+		}
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedDef.CreatedBy))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.CreatedBy")
+	}
+
+	err = def.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedDef.CreatedAt))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedDef.UpdatedBy))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.UpdatedBy")
+	}
+
+	err = def.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedDef.UpdatedAt))
+	if err != nil {
+		return sla.Definition{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedDef.UpdatedAt")
+	}
+
+	return def, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+// querier is implemented by both *sql.DB and *sql.Tx, so the repositories can run within the unit of work
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in the transaction, the running transaction is joined if q is already a transaction
+func inTx(ctx context.Context, q querier, fn func(tx *sql.Tx) error) error {
+	switch db := q.(type) {
+	case *sql.Tx:
+		return fn(db)
+	case *sql.DB:
+		return withTx(ctx, db, fn)
+	default:
+		return domain.NewErrorf(domain.ErrorCodeUnknown, "could not begin transaction (unsupported querier %T)", q)
+	}
+}
+
+// UnitOfWorkPostgres runs operations atomically in one database transaction
+type UnitOfWorkPostgres struct {
+	db                      *sql.DB
+	basicUserRepository     *BasicUserRepositoryPostgres
+	fieldEngineerRepository *FieldEngineerRepositoryPostgres
+	incidentRepository      *IncidentRepositoryPostgres
+}
+
+// NewUnitOfWorkPostgres returns new unit of work over the given repositories
+func NewUnitOfWorkPostgres(db *sql.DB, basicUserRepository *BasicUserRepositoryPostgres,
+	fieldEngineerRepository *FieldEngineerRepositoryPostgres, incidentRepository *IncidentRepositoryPostgres) *UnitOfWorkPostgres {
+	return &UnitOfWorkPostgres{
+		db:                      db,
+		basicUserRepository:     basicUserRepository,
+		fieldEngineerRepository: fieldEngineerRepository,
+		incidentRepository:      incidentRepository,
+	}
+}
+
+// Do calls fn with the repositories bound to the transaction, the transaction is rolled back if fn returns error
+func (u *UnitOfWorkPostgres) Do(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	return withTx(ctx, u.db, func(tx *sql.Tx) error {
+		basicUserRepository := *u.basicUserRepository
+		basicUserRepository.db = tx
+
+		fieldEngineerRepository := *u.fieldEngineerRepository
+		fieldEngineerRepository.db = tx
+		fieldEngineerRepository.basicUserRepository = &basicUserRepository
+
+		incidentRepository := *u.incidentRepository
+		incidentRepository.db = tx
+		incidentRepository.basicUserRepository = &basicUserRepository
+		incidentRepository.fieldEngineerRepository = &fieldEngineerRepository
+
+		return fn(ctx, repository.Repositories{
+			FieldEngineer: &fieldEngineerRepository,
+			Incident:      &incidentRepository,
+		})
+	})
+}
@@ -0,0 +1,423 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+)
+
+const webhookSubscriptionColumns = `id, url, secret, event_types, created_by, created_at, updated_by, updated_at`
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_attempt_at,
+	last_response_status, last_error, created_at`
+
+// WebhookRepositoryPostgres keeps data in PostgreSQL database
+type WebhookRepositoryPostgres struct {
+	db                  querier
+	basicUserRepository repository.BasicUserRepository
+	Rand                io.Reader
+	clock               repository.Clock
+}
+
+// NewWebhookRepositoryPostgres returns new initialized repository
+func NewWebhookRepositoryPostgres(db *sql.DB, clock repository.Clock, basicUserRepo repository.BasicUserRepository) *WebhookRepositoryPostgres {
+	return &WebhookRepositoryPostgres{
+		db:                  db,
+		basicUserRepository: basicUserRepo,
+		clock:               clock,
+	}
+}
+
+// WebhookSubscription is a webhook subscription row stored in the database
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedBy  string
+	CreatedAt  string
+	UpdatedBy  string
+	UpdatedAt  string
+}
+
+// AddWebhookSubscription adds the given webhook subscription to the repository
+func (r *WebhookRepositoryPostgres) AddWebhookSubscription(ctx context.Context, channelID ref.ChannelID, sub webhook.Subscription) (ref.UUID, error) {
+	now := r.clock.NowFormatted().String()
+	errMsg := "error adding webhook subscription to repository"
+
+	subID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	eventTypes := []string{}
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	eventTypesJSON, err := json.Marshal(eventTypes)
+	if err != nil {
+		return ref.UUID(""), domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg)
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO webhook_subscriptions (channel_id, `+webhookSubscriptionColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		channelID.String(), subID.String(), sub.URL, sub.Secret, string(eventTypesJSON),
+		sub.CreatedUpdated.CreatedByID().String(), now, sub.CreatedUpdated.UpdatedByID().String(), now,
+	)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, errMsg)
+	}
+
+	return subID, nil
+}
+
+// GetWebhookSubscription returns the webhook subscription with the given ID from the repository
+func (r *WebhookRepositoryPostgres) GetWebhookSubscription(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) (webhook.Subscription, error) {
+	errMsg := "error loading webhook subscription from repository"
+
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE channel_id = $1 AND id = $2`,
+		channelID.String(), ID.String(),
+	)
+
+	storedSub, err := scanWebhookSubscription(row)
+	if err != nil {
+		return webhook.Subscription{}, wrapQueryError(err, errMsg)
+	}
+
+	return r.convertStoredToDomainSubscription(ctx, channelID, storedSub)
+}
+
+// ListWebhookSubscriptions returns the list of webhook subscriptions from the repository
+func (r *WebhookRepositoryPostgres) ListWebhookSubscriptions(ctx context.Context, channelID ref.ChannelID, page, itemsPerPage uint) (repository.WebhookSubscriptionList, error) {
+	errMsg := "error loading webhook subscriptions from repository"
+
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM webhook_subscriptions WHERE channel_id = $1`,
+		channelID.String(),
+	).Scan(&total)
+	if err != nil {
+		return repository.WebhookSubscriptionList{}, wrapQueryError(err, errMsg)
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var storedSubs []WebhookSubscription
+	if pagination.Size > 0 {
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE channel_id = $1 ORDER BY seq LIMIT $2 OFFSET $3`,
+			channelID.String(), pagination.Size, pagination.FirstElementIndex,
+		)
+		if err != nil {
+			return repository.WebhookSubscriptionList{}, wrapQueryError(err, errMsg)
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			storedSub, err := scanWebhookSubscription(rows)
+			if err != nil {
+				return repository.WebhookSubscriptionList{}, wrapQueryError(err, errMsg)
+			}
+			storedSubs = append(storedSubs, storedSub)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.WebhookSubscriptionList{}, wrapQueryError(err, errMsg)
+		}
+	}
+
+	// basic users are loaded after all rows are read, so that no other query runs while the rows are open
+	var list []webhook.Subscription
+	for _, storedSub := range storedSubs {
+		sub, err := r.convertStoredToDomainSubscription(ctx, channelID, storedSub)
+		if err != nil {
+			return repository.WebhookSubscriptionList{}, err
+		}
+		list = append(list, sub)
+	}
+
+	subscriptionList := repository.WebhookSubscriptionList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return subscriptionList, nil
+}
+
+// DeleteWebhookSubscription deletes the webhook subscription with the given ID and all its deliveries from the repository
+func (r *WebhookRepositoryPostgres) DeleteWebhookSubscription(ctx context.Context, channelID ref.ChannelID, ID ref.UUID) error {
+	errMsg := "error deleting webhook subscription from repository"
+
+	// deliveries are deleted by the foreign key constraint
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM webhook_subscriptions WHERE channel_id = $1 AND id = $2`,
+		channelID.String(), ID.String(),
+	)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, errMsg)
+	}
+
+	return nil
+}
+
+// AddWebhookDelivery adds the given delivery of the subscription to the repository
+func (r *WebhookRepositoryPostgres) AddWebhookDelivery(ctx context.Context, channelID ref.ChannelID, delivery webhook.Delivery) (ref.UUID, error) {
+	errMsg := "error adding webhook delivery to repository"
+
+	if err := r.assertSubscriptionExists(ctx, channelID, delivery.SubscriptionID, errMsg); err != nil {
+		return ref.UUID(""), err
+	}
+
+	deliveryID, err := repository.GenerateUUID(r.Rand)
+	if err != nil {
+		return ref.UUID(""), err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (channel_id, `+webhookDeliveryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		channelID.String(), deliveryID.String(), delivery.SubscriptionID.String(), delivery.EventID.String(), delivery.EventType.String(),
+		string(delivery.Payload), delivery.Status.String(), delivery.Attempts, delivery.NextAttemptAt.String(), delivery.LastAttemptAt.String(),
+		delivery.LastResponseStatus, delivery.LastError, delivery.CreatedAt.String(),
+	)
+	if err != nil {
+		return ref.UUID(""), wrapQueryError(err, errMsg)
+	}
+
+	return deliveryID, nil
+}
+
+// UpdateWebhookDelivery updates the given delivery in the repository
+func (r *WebhookRepositoryPostgres) UpdateWebhookDelivery(ctx context.Context, channelID ref.ChannelID, delivery webhook.Delivery) error {
+	errMsg := "error updating webhook delivery in repository"
+
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET status = $3, attempts = $4, next_attempt_at = $5, last_attempt_at = $6,
+			last_response_status = $7, last_error = $8
+		WHERE channel_id = $1 AND id = $2`,
+		channelID.String(), delivery.UUID().String(), delivery.Status.String(), delivery.Attempts, delivery.NextAttemptAt.String(),
+		delivery.LastAttemptAt.String(), delivery.LastResponseStatus, delivery.LastError,
+	)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, errMsg)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries returns the list of the subscription's deliveries from the repository, the oldest deliveries are listed first
+func (r *WebhookRepositoryPostgres) ListWebhookDeliveries(ctx context.Context, channelID ref.ChannelID, subscriptionID ref.UUID, page, itemsPerPage uint) (repository.WebhookDeliveryList, error) {
+	errMsg := "error loading webhook deliveries from repository"
+
+	if err := r.assertSubscriptionExists(ctx, channelID, subscriptionID, errMsg); err != nil {
+		return repository.WebhookDeliveryList{}, err
+	}
+
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM webhook_deliveries WHERE channel_id = $1 AND subscription_id = $2`,
+		channelID.String(), subscriptionID.String(),
+	).Scan(&total)
+	if err != nil {
+		return repository.WebhookDeliveryList{}, wrapQueryError(err, errMsg)
+	}
+
+	pagination := repository.NewPagination(total, page, itemsPerPage)
+
+	var list []webhook.Delivery
+	if pagination.Size > 0 {
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE channel_id = $1 AND subscription_id = $2
+			ORDER BY seq LIMIT $3 OFFSET $4`,
+			channelID.String(), subscriptionID.String(), pagination.Size, pagination.FirstElementIndex,
+		)
+		if err != nil {
+			return repository.WebhookDeliveryList{}, wrapQueryError(err, errMsg)
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			delivery, err := scanWebhookDelivery(rows)
+			if err != nil {
+				return repository.WebhookDeliveryList{}, wrapQueryError(err, errMsg)
+			}
+			list = append(list, delivery)
+		}
+
+		if err := rows.Err(); err != nil {
+			return repository.WebhookDeliveryList{}, wrapQueryError(err, errMsg)
+		}
+	}
+
+	deliveryList := repository.WebhookDeliveryList{
+		Result:     list,
+		Pagination: pagination,
+	}
+	return deliveryList, nil
+}
+
+// ListDueWebhookDeliveries returns up to limit pending deliveries of all channels whose next attempt is due at the given time
+func (r *WebhookRepositoryPostgres) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint) ([]repository.ChannelWebhookDelivery, error) {
+	errMsg := "error loading due webhook deliveries from repository"
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT channel_id, `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE status = $1 AND NULLIF(next_attempt_at, '')::timestamptz <= $2
+		ORDER BY NULLIF(next_attempt_at, '')::timestamptz, seq LIMIT $3`,
+		webhook.DeliveryStatusPending.String(), now, limit,
+	)
+	if err != nil {
+		return nil, wrapQueryError(err, errMsg)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var list []repository.ChannelWebhookDelivery
+	for rows.Next() {
+		var channelID string
+		delivery, err := scanWebhookDelivery(rows, &channelID)
+		if err != nil {
+			return nil, wrapQueryError(err, errMsg)
+		}
+
+		list = append(list, repository.ChannelWebhookDelivery{ChannelID: ref.ChannelID(channelID), Delivery: delivery})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapQueryError(err, errMsg)
+	}
+
+	return list, nil
+}
+
+// assertSubscriptionExists returns not found error if the subscription does not exist
+func (r *WebhookRepositoryPostgres) assertSubscriptionExists(ctx context.Context, channelID ref.ChannelID, subscriptionID ref.UUID, errMsg string) error {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM webhook_subscriptions WHERE channel_id = $1 AND id = $2)`,
+		channelID.String(), subscriptionID.String(),
+	).Scan(&exists)
+	if err != nil {
+		return wrapQueryError(err, errMsg)
+	}
+
+	if !exists {
+		return domain.WrapErrorf(ErrNotFound, domain.ErrorCodeNotFound, errMsg+" (subscription)")
+	}
+
+	return nil
+}
+
+func scanWebhookSubscription(row rowScanner) (WebhookSubscription, error) {
+	var storedSub WebhookSubscription
+	var eventTypesJSON []byte
+
+	err := row.Scan(&storedSub.ID, &storedSub.URL, &storedSub.Secret, &eventTypesJSON,
+		&storedSub.CreatedBy, &storedSub.CreatedAt, &storedSub.UpdatedBy, &storedSub.UpdatedAt)
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+
+	if err := json.Unmarshal(eventTypesJSON, &storedSub.EventTypes); err != nil {
+		return WebhookSubscription{}, err
+	}
+
+	return storedSub, nil
+}
+
+func (r *WebhookRepositoryPostgres) convertStoredToDomainSubscription(ctx context.Context, channelID ref.ChannelID, storedSub WebhookSubscription) (webhook.Subscription, error) {
+	var sub webhook.Subscription
+	errMsg := "error loading webhook subscription from repository (%s)"
+
+	if err := sub.SetUUID(ref.UUID(storedSub.ID)); err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.ID")
+	}
+
+	sub.URL = storedSub.URL
+	sub.Secret = storedSub.Secret
+
+	for _, t := range storedSub.EventTypes {
+		eventType, err := event.NewTypeFromString(t)
+		if err != nil {
+			return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.EventTypes")
+		}
+		sub.EventTypes = append(sub.EventTypes, eventType)
+	}
+
+	createdByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedSub.CreatedBy))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.CreatedBy")
+	}
+
+	err = sub.CreatedUpdated.SetCreated(createdByUser, types.DateTime(storedSub.CreatedAt))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.CreatedAt")
+	}
+
+	updatedByUser, err := r.basicUserRepository.GetBasicUser(ctx, channelID, ref.UUID(storedSub.UpdatedBy))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.UpdatedBy")
+	}
+
+	err = sub.CreatedUpdated.SetUpdated(updatedByUser, types.DateTime(storedSub.UpdatedAt))
+	if err != nil {
+		return webhook.Subscription{}, domain.WrapErrorf(err, domain.ErrorCodeUnknown, errMsg, "storedSub.UpdatedAt")
+	}
+
+	return sub, nil
+}
+
+// scanWebhookDelivery scans the delivery row, extra destinations are scanned from the columns preceding the delivery columns
+func scanWebhookDelivery(row rowScanner, extra ...interface{}) (webhook.Delivery, error) {
+	var id, subscriptionID, eventID, eventType, payload, status, nextAttemptAt, lastAttemptAt, lastError, createdAt string
+	var attempts uint
+	var lastResponseStatus int
+
+	dest := append(extra, &id, &subscriptionID, &eventID, &eventType, &payload, &status, &attempts, &nextAttemptAt, &lastAttemptAt,
+		&lastResponseStatus, &lastError, &createdAt)
+	if err := row.Scan(dest...); err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	deliveryStatus, err := webhook.NewDeliveryStatusFromString(status)
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	delivery := webhook.Delivery{
+		SubscriptionID:     ref.UUID(subscriptionID),
+		EventID:            ref.UUID(eventID),
+		EventType:          event.Type(eventType),
+		Payload:            []byte(payload),
+		Status:             deliveryStatus,
+		Attempts:           attempts,
+		NextAttemptAt:      types.DateTime(nextAttemptAt),
+		LastAttemptAt:      types.DateTime(lastAttemptAt),
+		LastResponseStatus: lastResponseStatus,
+		LastError:          lastError,
+		CreatedAt:          types.DateTime(createdAt),
+	}
+
+	if err := delivery.SetUUID(ref.UUID(id)); err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return delivery, nil
+}
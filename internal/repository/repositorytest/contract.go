@@ -0,0 +1,1878 @@
+// Package repositorytest contains driver-independent contract tests that every repository implementation must pass
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/event"
+	fieldengineer "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer"
+	tsession "github.com/crywolf/itsm-ticket-management-service/internal/domain/field_engineer/time_session"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/history"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/incident/timelog"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/ref"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/sla"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/types"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/user/actor"
+	"github.com/crywolf/itsm-ticket-management-service/internal/domain/webhook"
+	"github.com/crywolf/itsm-ticket-management-service/internal/mocks"
+	"github.com/crywolf/itsm-ticket-management-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories is a set of repositories sharing the same storage
+type Repositories struct {
+	BasicUser      repository.BasicUserRepository
+	FieldEngineer  repository.FieldEngineerRepository
+	Incident       repository.IncidentRepository
+	UnitOfWork     repository.UnitOfWork
+	Outbox         repository.OutboxRepository
+	Webhook        repository.WebhookRepository
+	SLA            repository.SLARepository
+	PriorityMatrix repository.PriorityMatrixRepository
+}
+
+// Factory returns new empty repositories which use the given clock
+type Factory func(t *testing.T, clock repository.Clock) Repositories
+
+const (
+	channelID      = ref.ChannelID("e27ddcd0-0e1f-4bc5-93df-f6f04155beec")
+	otherChannelID = ref.ChannelID("a5c2ed5e-0b8e-4d3a-9c4b-7a1f4e55d0c2")
+)
+
+// RunContractTests runs the repository contract test suite against repositories returned by newRepositories
+func RunContractTests(t *testing.T, newRepositories Factory) {
+	t.Run("BasicUserRepository", func(t *testing.T) {
+		testBasicUserRepository(t, newRepositories)
+	})
+
+	t.Run("FieldEngineerRepository", func(t *testing.T) {
+		testFieldEngineerRepository(t, newRepositories)
+	})
+
+	t.Run("IncidentRepository", func(t *testing.T) {
+		testIncidentRepository(t, newRepositories)
+	})
+
+	t.Run("ChannelIsolation", func(t *testing.T) {
+		testChannelIsolation(t, newRepositories)
+	})
+
+	t.Run("UnitOfWork", func(t *testing.T) {
+		testUnitOfWork(t, newRepositories)
+	})
+
+	t.Run("Outbox", func(t *testing.T) {
+		testOutbox(t, newRepositories)
+	})
+
+	t.Run("WebhookRepository", func(t *testing.T) {
+		testWebhookRepository(t, newRepositories)
+	})
+
+	t.Run("SLARepository", func(t *testing.T) {
+		testSLARepository(t, newRepositories)
+	})
+
+	t.Run("PriorityMatrixRepository", func(t *testing.T) {
+		testPriorityMatrixRepository(t, newRepositories)
+	})
+}
+
+func testBasicUserRepository(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	t.Run("add, get and list basic users", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		alfred := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		jan := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		addBasicUser(t, repos, "0e1b1f14-92e6-4fa4-a0a9-8d0e3b0c3a11", "Karel")
+
+		retUser, err := repos.BasicUser.GetBasicUser(ctx, channelID, alfred.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, alfred, retUser)
+
+		retUser, err = repos.BasicUser.GetBasicUserByExternalID(ctx, channelID, jan.ExternalUserUUID)
+		require.NoError(t, err)
+		assert.Equal(t, jan, retUser)
+
+		list, err := repos.BasicUser.ListBasicUsers(ctx, channelID, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 2)
+		assert.Equal(t, 3, list.Total)
+		assert.Equal(t, alfred, list.Result[0])
+		assert.Equal(t, jan, list.Result[1])
+
+		list, err = repos.BasicUser.ListBasicUsers(ctx, channelID, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, "Karel", list.Result[0].Name)
+	})
+
+	t.Run("duplicate external ID is rejected", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		alfred := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		_, err := repos.BasicUser.AddBasicUser(ctx, channelID, user.BasicUser{ExternalUserUUID: alfred.ExternalUserUUID})
+		assertErrorCode(t, domain.ErrorCodeInvalidArgument, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		_, err := repos.BasicUser.GetBasicUser(ctx, channelID, "00271cb4-3716-4203-9124-1d2f515ae0b2")
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		_, err = repos.BasicUser.GetBasicUserByExternalID(ctx, channelID, "00271cb4-3716-4203-9124-1d2f515ae0b2")
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		list, err := repos.BasicUser.ListBasicUsers(ctx, channelID, 1, 10)
+		require.NoError(t, err)
+		assert.Empty(t, list.Result)
+		assert.Equal(t, 0, list.Total)
+	})
+}
+
+func testFieldEngineerRepository(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	t.Run("add and get field engineer", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+
+		feID := addFieldEngineer(t, repos, engineer, admin)
+
+		retFE, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+
+		assert.Equal(t, feID, retFE.UUID())
+		assert.Equal(t, engineer, retFE.BasicUser)
+		assert.False(t, retFE.IsDeactivated())
+		assert.False(t, retFE.HasOpenTimeSession())
+		assert.Empty(t, retFE.TimeSessions)
+		assert.Equal(t, admin, retFE.CreatedUpdated.CreatedBy())
+		assert.Equal(t, clock.NowFormatted(), retFE.CreatedUpdated.CreatedAt())
+		assert.Equal(t, admin, retFE.CreatedUpdated.UpdatedBy())
+		assert.Equal(t, clock.NowFormatted(), retFE.CreatedUpdated.UpdatedAt())
+
+		retFE, err = repos.FieldEngineer.GetFieldEngineerByBasicUser(ctx, channelID, engineer.UUID())
+		require.NoError(t, err)
+		assert.Equal(t, feID, retFE.UUID())
+
+		_, err = repos.FieldEngineer.GetFieldEngineerByBasicUser(ctx, channelID, admin.UUID())
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		_, err = repos.FieldEngineer.GetFieldEngineer(ctx, channelID, "1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("duplicate field engineer for the same basic user is rejected", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		addFieldEngineer(t, repos, engineer, admin)
+
+		fe := fieldengineer.FieldEngineer{BasicUser: engineer}
+		require.NoError(t, fe.CreatedUpdated.SetCreatedBy(admin))
+		require.NoError(t, fe.CreatedUpdated.SetUpdatedBy(admin))
+
+		_, err := repos.FieldEngineer.AddFieldEngineer(ctx, channelID, fe)
+		assertErrorCode(t, domain.ErrorCodeInvalidArgument, err)
+	})
+
+	t.Run("update field engineer with open time session", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		feID := addFieldEngineer(t, repos, engineer, admin)
+
+		fe, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+
+		openTS := &tsession.TimeSession{
+			Incidents: []tsession.IncidentInfo{
+				{IncidentID: "0ac5ebce-17e7-4edc-9552-fefe16e127fb", HasSupplierProduct: true},
+			},
+			Work: 120,
+		}
+		require.NoError(t, openTS.RestoreState(tsession.StateWork))
+		openTS.SetStateChangedAt(clock.NowFormatted())
+		require.NoError(t, openTS.CreatedUpdated.SetCreatedBy(engineer))
+		require.NoError(t, openTS.CreatedUpdated.SetUpdatedBy(engineer))
+		fe.SetOpenTimeSession(openTS)
+
+		clock.AddTime(time.Minute)
+		_, err = repos.FieldEngineer.UpdateFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+
+		updatedFE, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+
+		require.True(t, updatedFE.HasOpenTimeSession())
+		assert.Len(t, updatedFE.TimeSessions, 1)
+		retTS := updatedFE.OpenTimeSession()
+		assert.Equal(t, updatedFE.TimeSessions[0], retTS.UUID())
+		assert.Equal(t, tsession.StateWork, retTS.State())
+		assert.Equal(t, openTS.StateChangedAt(), retTS.StateChangedAt())
+		assert.Equal(t, openTS.Incidents, retTS.Incidents)
+		assert.Equal(t, uint(120), retTS.Work)
+		assert.Equal(t, engineer, retTS.CreatedUpdated.CreatedBy())
+		assert.Equal(t, clock.NowFormatted(), retTS.CreatedUpdated.CreatedAt())
+		assert.Equal(t, clock.NowFormatted(), updatedFE.CreatedUpdated.UpdatedAt())
+
+		// close the time session and deactivate the field engineer
+		require.NoError(t, retTS.RestoreState(tsession.StateClosed))
+		retTS.TravelDistanceInTravelUnits = 42
+		updatedFE.SetDeactivated(true)
+
+		_, err = repos.FieldEngineer.UpdateFieldEngineer(ctx, channelID, updatedFE)
+		require.NoError(t, err)
+
+		closedFE, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+
+		assert.False(t, closedFE.HasOpenTimeSession())
+		assert.Equal(t, updatedFE.TimeSessions, closedFE.TimeSessions)
+		assert.True(t, closedFE.IsDeactivated())
+	})
+
+	t.Run("update of not existing field engineer fails", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		fe := fieldengineer.FieldEngineer{BasicUser: admin}
+		require.NoError(t, fe.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4"))
+		require.NoError(t, fe.CreatedUpdated.SetCreatedBy(admin))
+		require.NoError(t, fe.CreatedUpdated.SetUpdatedBy(admin))
+
+		_, err := repos.FieldEngineer.UpdateFieldEngineer(ctx, channelID, fe)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("list field engineers", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer1 := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		engineer2 := addBasicUser(t, repos, "0e1b1f14-92e6-4fa4-a0a9-8d0e3b0c3a11", "Karel")
+
+		feID1 := addFieldEngineer(t, repos, engineer1, admin)
+		feID2 := addFieldEngineer(t, repos, engineer2, admin)
+
+		list, err := repos.FieldEngineer.ListFieldEngineers(ctx, channelID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 2)
+		assert.Equal(t, 2, list.Total)
+		assert.Equal(t, feID1, list.Result[0].UUID())
+		assert.Equal(t, feID2, list.Result[1].UUID())
+
+		list, err = repos.FieldEngineer.ListFieldEngineers(ctx, channelID, 2, 1)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, feID2, list.Result[0].UUID())
+	})
+}
+
+func testIncidentRepository(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	t.Run("add and get incident", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		inc := newIncident(t, creator, "ABC123")
+		incID, err := repos.Incident.AddIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		retInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		assert.Equal(t, incID, retInc.UUID())
+		assert.Equal(t, inc.Number, retInc.Number)
+		assert.Equal(t, inc.ExternalID, retInc.ExternalID)
+		assert.Equal(t, inc.ShortDescription, retInc.ShortDescription)
+		assert.Equal(t, inc.Description, retInc.Description)
+		assert.Equal(t, incident.StateNew, retInc.State())
+		assert.Nil(t, retInc.FieldEngineerID)
+		assert.Nil(t, retInc.OnHold())
+		assert.Nil(t, retInc.Resolution())
+		assert.Empty(t, retInc.AuditTrail())
+		assert.Empty(t, retInc.Timelogs)
+		assert.False(t, retInc.HasOpenTimelog())
+		assert.Equal(t, creator, retInc.CreatedUpdated.CreatedBy())
+		assert.Equal(t, clock.NowFormatted(), retInc.CreatedUpdated.CreatedAt())
+		assert.Equal(t, creator, retInc.CreatedUpdated.UpdatedBy())
+		assert.Equal(t, clock.NowFormatted(), retInc.CreatedUpdated.UpdatedAt())
+
+		_, err = repos.Incident.GetIncident(ctx, channelID, "1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("update incident with open timelog", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		feID := addFieldEngineer(t, repos, engineer, creator)
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC123"))
+		require.NoError(t, err)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		inc.Description = "some changed description"
+		inc.FieldEngineerID = &feID
+		inc.SetFieldEngineerAccepted(true)
+		require.NoError(t, inc.RestoreState(incident.StateInProgress))
+		require.NoError(t, inc.CreatedUpdated.SetUpdatedBy(engineer))
+
+		openTimelog := &timelog.Timelog{
+			Remote: true,
+			Start:  clock.NowFormatted(),
+			Timespans: []timelog.Timespan{
+				{Type: timelog.TimespanTypeWork, Start: clock.NowFormatted()},
+			},
+		}
+		require.NoError(t, openTimelog.CreatedUpdated.SetCreatedBy(engineer))
+		require.NoError(t, openTimelog.CreatedUpdated.SetUpdatedBy(engineer))
+		inc.SetOpenTimelog(openTimelog)
+
+		clock.AddTime(time.Minute)
+		retIncID, err := repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+		assert.Equal(t, incID, retIncID)
+
+		updatedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		assert.Equal(t, "some changed description", updatedInc.Description)
+		require.NotNil(t, updatedInc.FieldEngineerID)
+		assert.Equal(t, feID, *updatedInc.FieldEngineerID)
+		assert.True(t, updatedInc.FieldEngineerAccepted())
+		assert.Equal(t, incident.StateInProgress, updatedInc.State())
+		assert.Equal(t, engineer, updatedInc.CreatedUpdated.UpdatedBy())
+		assert.Equal(t, clock.NowFormatted(), updatedInc.CreatedUpdated.UpdatedAt())
+		assert.Equal(t, inc.CreatedUpdated.CreatedAt(), updatedInc.CreatedUpdated.CreatedAt())
+
+		require.Len(t, updatedInc.Timelogs, 1, "timelogs count")
+		require.True(t, updatedInc.HasOpenTimelog())
+		retTimelog := updatedInc.OpenTimelog()
+		assert.Equal(t, updatedInc.Timelogs[0], retTimelog.UUID())
+		assert.True(t, retTimelog.Remote)
+		assert.Equal(t, openTimelog.Start, retTimelog.Start)
+		assert.Equal(t, openTimelog.Timespans, retTimelog.Timespans)
+		assert.Equal(t, engineer, retTimelog.CreatedUpdated.CreatedBy())
+		assert.Equal(t, clock.NowFormatted(), retTimelog.CreatedUpdated.CreatedAt())
+
+		// close the timelog
+		clock.AddTime(time.Hour)
+		require.NoError(t, retTimelog.Close(clock, "some visit summary"))
+
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, updatedInc)
+		require.NoError(t, err)
+
+		closedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		assert.False(t, closedInc.HasOpenTimelog())
+		assert.Equal(t, updatedInc.Timelogs, closedInc.Timelogs)
+
+		closedTimelog, err := repos.Incident.GetIncidentTimelog(ctx, channelID, incID, closedInc.Timelogs[0])
+		require.NoError(t, err)
+		assert.Equal(t, closedInc.Timelogs[0], closedTimelog.UUID())
+		assert.Equal(t, clock.NowFormatted(), closedTimelog.End)
+		assert.Equal(t, "some visit summary", closedTimelog.VisitSummary)
+		assert.Equal(t, uint((time.Hour+time.Minute)/time.Second), closedTimelog.Work)
+
+		_, err = repos.Incident.GetIncidentTimelog(ctx, channelID, incID, "1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("list incident timelogs", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+
+		// opens new timelog in the incident, the previous timelog is closed
+		startTimelog := func(incID ref.UUID) {
+			inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+			require.NoError(t, err)
+
+			if inc.HasOpenTimelog() {
+				require.NoError(t, inc.OpenTimelog().Close(clock, "visit summary"))
+				_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+				require.NoError(t, err)
+
+				inc, err = repos.Incident.GetIncident(ctx, channelID, incID)
+				require.NoError(t, err)
+			}
+
+			openTimelog := &timelog.Timelog{Start: clock.NowFormatted()}
+			require.NoError(t, openTimelog.CreatedUpdated.SetCreatedBy(engineer))
+			require.NoError(t, openTimelog.CreatedUpdated.SetUpdatedBy(engineer))
+			inc.SetOpenTimelog(openTimelog)
+
+			_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+			require.NoError(t, err)
+			clock.AddTime(time.Hour)
+		}
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC123"))
+		require.NoError(t, err)
+		otherIncID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "DEF456"))
+		require.NoError(t, err)
+
+		startTimelog(incID)
+		startTimelog(otherIncID)
+		startTimelog(incID)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		require.Len(t, inc.Timelogs, 2)
+
+		otherInc, err := repos.Incident.GetIncident(ctx, channelID, otherIncID)
+		require.NoError(t, err)
+		require.Len(t, otherInc.Timelogs, 1)
+
+		list, err := repos.Incident.ListIncidentTimelogs(ctx, channelID, incID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+		require.Len(t, list.Result, 2)
+		assert.Equal(t, inc.Timelogs[0], list.Result[0].UUID())
+		assert.Equal(t, "visit summary", list.Result[0].VisitSummary)
+		assert.False(t, list.Result[0].End.IsZero())
+		assert.Equal(t, inc.Timelogs[1], list.Result[1].UUID())
+		assert.True(t, list.Result[1].End.IsZero())
+		for _, tmlg := range list.Result {
+			assert.Equal(t, engineer, tmlg.CreatedUpdated.CreatedBy())
+			assert.Equal(t, engineer, tmlg.CreatedUpdated.UpdatedBy())
+			assert.False(t, tmlg.CreatedUpdated.CreatedAt().IsZero())
+		}
+
+		list, err = repos.Incident.ListIncidentTimelogs(ctx, channelID, incID, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, inc.Timelogs[1], list.Result[0].UUID())
+
+		retTimelog, err := repos.Incident.GetIncidentTimelog(ctx, channelID, incID, inc.Timelogs[0])
+		require.NoError(t, err)
+		assert.Equal(t, inc.Timelogs[0], retTimelog.UUID())
+		assert.Equal(t, engineer, retTimelog.CreatedUpdated.CreatedBy())
+		assert.False(t, retTimelog.CreatedUpdated.CreatedAt().IsZero())
+
+		// timelog of another incident is not found
+		_, err = repos.Incident.GetIncidentTimelog(ctx, channelID, incID, otherInc.Timelogs[0])
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		_, err = repos.Incident.ListIncidentTimelogs(ctx, channelID, "1adb8393-cff0-489c-a82f-3fe5d15708d4", 1, 10)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("update incident timelog with corrections", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC123"))
+		require.NoError(t, err)
+		otherIncID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "DEF456"))
+		require.NoError(t, err)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		openTimelog := &timelog.Timelog{Start: clock.NowFormatted()}
+		require.NoError(t, openTimelog.CreatedUpdated.SetCreatedBy(engineer))
+		require.NoError(t, openTimelog.CreatedUpdated.SetUpdatedBy(engineer))
+		require.NoError(t, openTimelog.StartTimespan(clock, timelog.TimespanTypeWork))
+		clock.AddTime(5 * time.Hour)
+		require.NoError(t, openTimelog.Close(clock, "visit summary"))
+		inc.SetOpenTimelog(openTimelog)
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		inc, err = repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		require.Len(t, inc.Timelogs, 1)
+
+		tmlg, err := repos.Incident.GetIncidentTimelog(ctx, channelID, incID, inc.Timelogs[0])
+		require.NoError(t, err)
+		assert.Empty(t, tmlg.Corrections)
+		assert.Nil(t, tmlg.Original)
+
+		original := timelog.OriginalValues{
+			Remote:       tmlg.Remote,
+			Start:        tmlg.Start,
+			End:          tmlg.End,
+			Work:         tmlg.Work,
+			Timespans:    tmlg.Timespans,
+			VisitSummary: tmlg.VisitSummary,
+		}
+		tmlg.Original = &original
+		tmlg.Remote = true
+		tmlg.End = "2021-04-01T13:34:56+02:00"
+		tmlg.Work = 3600
+		tmlg.Timespans = []timelog.Timespan{{Type: timelog.TimespanTypeWork, Start: tmlg.Start, End: tmlg.End}}
+		tmlg.VisitSummary = "corrected visit summary"
+		tmlg.Corrections = []timelog.Correction{
+			{
+				Remote:       true,
+				Start:        tmlg.Start,
+				End:          tmlg.End,
+				VisitSummary: "corrected visit summary",
+				Reason:       "forgot to stop working",
+				Status:       timelog.CorrectionStatusApproved,
+				ProposedBy:   engineer.UUID(),
+				ProposedAt:   clock.NowFormatted(),
+				DecidedBy:    creator.UUID(),
+				DecidedAt:    clock.NowFormatted(),
+				DecisionNote: "ok",
+			},
+			{
+				Start:      tmlg.Start,
+				End:        tmlg.End,
+				Reason:     "visit summary is missing",
+				Status:     timelog.CorrectionStatusProposed,
+				ProposedBy: engineer.UUID(),
+				ProposedAt: clock.NowFormatted(),
+			},
+		}
+		require.NoError(t, tmlg.CreatedUpdated.SetUpdatedBy(creator))
+
+		_, err = repos.Incident.UpdateIncidentTimelog(ctx, channelID, incID, tmlg)
+		require.NoError(t, err)
+
+		retTimelog, err := repos.Incident.GetIncidentTimelog(ctx, channelID, incID, tmlg.UUID())
+		require.NoError(t, err)
+		assert.True(t, retTimelog.Remote)
+		assert.Equal(t, tmlg.End, retTimelog.End)
+		assert.Equal(t, uint(3600), retTimelog.Work)
+		assert.Equal(t, tmlg.Timespans, retTimelog.Timespans)
+		assert.Equal(t, "corrected visit summary", retTimelog.VisitSummary)
+		assert.Equal(t, tmlg.Corrections, retTimelog.Corrections)
+		require.NotNil(t, retTimelog.Original)
+		assert.Equal(t, original, *retTimelog.Original)
+		assert.Equal(t, engineer, retTimelog.CreatedUpdated.CreatedBy())
+		assert.Equal(t, creator, retTimelog.CreatedUpdated.UpdatedBy())
+
+		// timelog of another incident cannot be updated
+		_, err = repos.Incident.UpdateIncidentTimelog(ctx, channelID, otherIncID, tmlg)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		// not existing timelog cannot be updated
+		var missingTimelog timelog.Timelog
+		require.NoError(t, missingTimelog.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4"))
+		require.NoError(t, missingTimelog.CreatedUpdated.SetUpdatedBy(creator))
+		_, err = repos.Incident.UpdateIncidentTimelog(ctx, channelID, incID, missingTimelog)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("update incident with on hold, resolution and audit trail", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC123"))
+		require.NoError(t, err)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		onHold := &incident.OnHoldInfo{
+			Reason:   incident.OnHoldReasonAwaitingCaller,
+			RemindAt: clock.NowFormatted(),
+		}
+		resolution := &incident.Resolution{
+			Code:       incident.ResolutionCodeSolvedWorkaround,
+			Notes:      "some notes",
+			ResolvedAt: clock.NowFormatted(),
+		}
+		auditTrail := []incident.AuditRecord{
+			{Action: incident.ActionResolve, ActorID: creator.UUID(), Time: clock.NowFormatted()},
+			{Action: incident.ActionReopen, ActorID: creator.UUID(), Time: clock.NowFormatted(), Note: "not fixed"},
+		}
+		inc.SetOnHold(onHold)
+		inc.SetResolution(resolution)
+		inc.SetAuditTrail(auditTrail)
+
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		updatedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		assert.Equal(t, onHold, updatedInc.OnHold())
+		assert.Equal(t, resolution, updatedInc.Resolution())
+		assert.Equal(t, auditTrail, updatedInc.AuditTrail())
+	})
+
+	t.Run("add and update incident with SLA", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		newInc := newIncident(t, creator, "ABC123")
+		require.NoError(t, newInc.StartSLA(clock, newSLADefinition()))
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newInc)
+		require.NoError(t, err)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		require.NotNil(t, inc.SLA())
+		assert.Equal(t, newInc.SLA(), inc.SLA())
+
+		clock.AddTime(30 * time.Minute)
+		require.NoError(t, inc.SLA().Update(clock.Now(), sla.TimerStateStopped, sla.TimerStatePaused))
+
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		updatedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, inc.SLA(), updatedInc.SLA())
+		assert.Equal(t, sla.TimerStateStopped, updatedInc.SLA().Response.State())
+		assert.Equal(t, sla.TimerStatePaused, updatedInc.SLA().Resolution.State())
+
+		incWithoutSLA, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC124"))
+		require.NoError(t, err)
+
+		inc, err = repos.Incident.GetIncident(ctx, channelID, incWithoutSLA)
+		require.NoError(t, err)
+		assert.Nil(t, inc.SLA())
+	})
+
+	t.Run("add and update incident with priority", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		newInc := newIncident(t, creator, "ABC123")
+		require.NoError(t, newInc.Prioritize(incident.DefaultPriorityMatrix(), incident.ImpactHigh, incident.UrgencyMedium))
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newInc)
+		require.NoError(t, err)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, incident.ImpactHigh, inc.Impact())
+		assert.Equal(t, incident.UrgencyMedium, inc.Urgency())
+		assert.Equal(t, sla.PriorityHigh, inc.Priority())
+		assert.False(t, inc.PriorityOverridden())
+
+		require.NoError(t, inc.OverridePriority(actor.Actor{BasicUser: creator}, clock, sla.PriorityCritical, "VIP caller"))
+
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		updatedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, sla.PriorityCritical, updatedInc.Priority())
+		assert.True(t, updatedInc.PriorityOverridden())
+		assert.Equal(t, inc.AuditTrail(), updatedInc.AuditTrail())
+
+		incWithoutPriority, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC124"))
+		require.NoError(t, err)
+
+		inc, err = repos.Incident.GetIncident(ctx, channelID, incWithoutPriority)
+		require.NoError(t, err)
+		assert.True(t, inc.Impact().IsZero())
+		assert.True(t, inc.Urgency().IsZero())
+		assert.True(t, inc.Priority().IsZero())
+	})
+
+	t.Run("add and list incident history", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		dispatcher := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC123"))
+		require.NoError(t, err)
+		otherIncID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "DEF456"))
+		require.NoError(t, err)
+
+		entries := []history.Entry{
+			{
+				IncidentID: incID,
+				Action:     history.ActionCreateIncident,
+				ActorID:    creator.UUID(),
+				Time:       clock.NowFormatted(),
+				Changes:    []history.Change{{Field: "number", After: "ABC123"}},
+			},
+			{
+				IncidentID:    incID,
+				Action:        history.ActionUpdateIncident,
+				ActorID:       creator.UUID(),
+				RequestedByID: dispatcher.UUID(),
+				Time:          clock.NowFormatted(),
+				Changes: []history.Change{
+					{Field: "description", Before: "some description", After: "new description"},
+					{Field: "short_description", Before: "some short description", After: "new short description"},
+				},
+			},
+			{
+				IncidentID: incID,
+				Action:     history.ActionAutoClose,
+				Time:       clock.NowFormatted(),
+				Changes:    []history.Change{{Field: "state", Before: "resolved", After: "closed"}},
+			},
+		}
+
+		var entryIDs []ref.UUID
+		for _, entry := range entries {
+			entryID, err := repos.Incident.AddIncidentHistoryEntry(ctx, channelID, entry)
+			require.NoError(t, err)
+			assert.False(t, entryID.IsZero())
+			entryIDs = append(entryIDs, entryID)
+		}
+
+		_, err = repos.Incident.AddIncidentHistoryEntry(ctx, channelID, history.Entry{
+			IncidentID: otherIncID,
+			Action:     history.ActionCreateIncident,
+			ActorID:    creator.UUID(),
+			Time:       clock.NowFormatted(),
+		})
+		require.NoError(t, err)
+
+		list, err := repos.Incident.ListIncidentHistory(ctx, channelID, incID, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 3, list.Total)
+		assert.Equal(t, 2, list.Size)
+		assert.Equal(t, 2, list.Next)
+		require.Len(t, list.Result, 2)
+
+		for i, entry := range list.Result {
+			expected := entries[i]
+			require.NoError(t, expected.SetUUID(entryIDs[i]))
+			assert.Equal(t, expected, entry)
+		}
+
+		list, err = repos.Incident.ListIncidentHistory(ctx, channelID, incID, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, entryIDs[2], list.Result[0].UUID())
+		assert.True(t, list.Result[0].ActorID.IsZero())
+		assert.True(t, list.Result[0].RequestedByID.IsZero())
+
+		list, err = repos.Incident.ListIncidentHistory(ctx, channelID, otherIncID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total)
+
+		missingIncID := ref.UUID("1adb8393-cff0-489c-a82f-3fe5d15708d4")
+		_, err = repos.Incident.AddIncidentHistoryEntry(ctx, channelID, history.Entry{
+			IncidentID: missingIncID,
+			Action:     history.ActionUpdateIncident,
+			ActorID:    creator.UUID(),
+			Time:       clock.NowFormatted(),
+		})
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		_, err = repos.Incident.ListIncidentHistory(ctx, channelID, missingIncID, 1, 10)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("update of concurrently modified incident fails", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "ABC123"))
+		require.NoError(t, err)
+
+		inc1, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		inc2, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), inc1.Version())
+
+		inc1.Description = "first update"
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc1)
+		require.NoError(t, err)
+
+		// inc2 was loaded before the first update
+		inc2.Description = "second update"
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc2)
+		assertErrorCode(t, domain.ErrorCodeVersionMismatch, err)
+
+		updatedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), updatedInc.Version())
+		assert.Equal(t, "first update", updatedInc.Description)
+	})
+
+	t.Run("update of not existing incident fails", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		inc := newIncident(t, creator, "ABC123")
+		require.NoError(t, inc.SetUUID("1adb8393-cff0-489c-a82f-3fe5d15708d4"))
+
+		_, err := repos.Incident.UpdateIncident(ctx, channelID, inc)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("list incidents", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		var incIDs []ref.UUID
+		for _, number := range []string{"ABC1", "ABC2", "ABC3"} {
+			incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, number))
+			require.NoError(t, err)
+			incIDs = append(incIDs, incID)
+		}
+
+		list, err := repos.Incident.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 2)
+		assert.Equal(t, 3, list.Total)
+		assert.Equal(t, 2, list.Next)
+		assert.Equal(t, incIDs[0], list.Result[0].UUID())
+		assert.Equal(t, "ABC1", list.Result[0].Number)
+		assert.Equal(t, incIDs[1], list.Result[1].UUID())
+
+		list, err = repos.Incident.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, incIDs[2], list.Result[0].UUID())
+	})
+
+	t.Run("list resolved incidents of all channels", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		otherCreator := addBasicUserToChannel(t, repos, otherChannelID, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		// addResolved adds the incident to the channel, it is resolved the given time ago unless the time is zero
+		addResolved := func(channelID ref.ChannelID, creator user.BasicUser, number string, ago time.Duration) ref.UUID {
+			incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, number))
+			require.NoError(t, err)
+
+			if ago == 0 {
+				return incID
+			}
+
+			inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+			require.NoError(t, err)
+
+			require.NoError(t, inc.RestoreState(incident.StateResolved))
+			inc.SetResolution(&incident.Resolution{
+				Code:       incident.ResolutionCodeSolvedPermanently,
+				Notes:      "some notes",
+				ResolvedAt: types.DateTime(clock.Now().Add(-ago).Format(time.RFC3339)),
+			})
+
+			_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+			require.NoError(t, err)
+			return incID
+		}
+
+		addResolved(channelID, creator, "ABC1", 0)
+		resolved2h := addResolved(channelID, creator, "ABC2", 2*time.Hour)
+		addResolved(channelID, creator, "ABC3", time.Minute)
+		resolved3h := addResolved(otherChannelID, otherCreator, "XYZ1", 3*time.Hour)
+
+		list, err := repos.Incident.ListResolvedIncidents(ctx, clock.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, otherChannelID, list[0].ChannelID)
+		assert.Equal(t, resolved3h, list[0].UUID())
+		assert.Equal(t, incident.StateResolved, list[0].State())
+		assert.Equal(t, channelID, list[1].ChannelID)
+		assert.Equal(t, resolved2h, list[1].UUID())
+		assert.Equal(t, "ABC2", list[1].Number)
+
+		list, err = repos.Incident.ListResolvedIncidents(ctx, clock.Now().Add(-time.Hour), 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, resolved3h, list[0].UUID())
+	})
+
+	t.Run("list incidents with filter and sort", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		feID := addFieldEngineer(t, repos, engineer, creator)
+
+		start := clock.Now().Truncate(time.Second) // stored timestamps have second precision
+
+		incA := newIncident(t, creator, "INC3")
+		incA.ShortDescription = "Printer on fire"
+		require.NoError(t, incA.Prioritize(incident.DefaultPriorityMatrix(), incident.ImpactHigh, incident.UrgencyHigh))
+		incAID, err := repos.Incident.AddIncident(ctx, channelID, incA)
+		require.NoError(t, err)
+
+		clock.AddTime(time.Hour)
+		incB := newIncident(t, creator, "INC1")
+		incB.ShortDescription = "Network outage"
+		incB.ExternalID = "EXT-2"
+		incB.FieldEngineerID = &feID
+		require.NoError(t, incB.Prioritize(incident.DefaultPriorityMatrix(), incident.ImpactLow, incident.UrgencyLow))
+		incBID, err := repos.Incident.AddIncident(ctx, channelID, incB)
+		require.NoError(t, err)
+
+		clock.AddTime(time.Hour)
+		incC := newIncident(t, creator, "INC2")
+		incC.Description = "Replace PRINTER toner"
+		require.NoError(t, incC.Prioritize(incident.DefaultPriorityMatrix(), incident.ImpactMedium, incident.UrgencyMedium))
+		incCID, err := repos.Incident.AddIncident(ctx, channelID, incC)
+		require.NoError(t, err)
+
+		clock.AddTime(time.Hour)
+		storedB, err := repos.Incident.GetIncident(ctx, channelID, incBID)
+		require.NoError(t, err)
+		require.NoError(t, storedB.RestoreState(incident.StateInProgress))
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, storedB)
+		require.NoError(t, err)
+
+		tests := []struct {
+			name     string
+			filter   repository.IncidentFilter
+			expected []ref.UUID
+		}{
+			{"no filter", repository.IncidentFilter{}, []ref.UUID{incAID, incBID, incCID}},
+			{"state", repository.IncidentFilter{States: []incident.State{incident.StateInProgress}}, []ref.UUID{incBID}},
+			{"multiple states", repository.IncidentFilter{States: []incident.State{incident.StateNew, incident.StateInProgress}}, []ref.UUID{incAID, incBID, incCID}},
+			{"priority", repository.IncidentFilter{Priorities: []sla.Priority{sla.PriorityCritical}}, []ref.UUID{incAID}},
+			{"multiple priorities", repository.IncidentFilter{Priorities: []sla.Priority{sla.PriorityLow, sla.PriorityModerate}}, []ref.UUID{incBID, incCID}},
+			{"field engineer", repository.IncidentFilter{FieldEngineerID: &feID}, []ref.UUID{incBID}},
+			{"number", repository.IncidentFilter{Number: "INC2"}, []ref.UUID{incCID}},
+			{"external ID", repository.IncidentFilter{ExternalID: "EXT-2"}, []ref.UUID{incBID}},
+			{"text", repository.IncidentFilter{Text: "printer"}, []ref.UUID{incAID, incCID}},
+			{"text with special characters", repository.IncidentFilter{Text: "%"}, nil},
+			{"created from", repository.IncidentFilter{CreatedFrom: start.Add(time.Hour)}, []ref.UUID{incBID, incCID}},
+			{"created to", repository.IncidentFilter{CreatedTo: start.Add(time.Hour)}, []ref.UUID{incAID, incBID}},
+			{"updated from", repository.IncidentFilter{UpdatedFrom: start.Add(3 * time.Hour)}, []ref.UUID{incBID}},
+			{"combined conditions", repository.IncidentFilter{States: []incident.State{incident.StateNew}, Text: "printer", CreatedFrom: start.Add(time.Minute)}, []ref.UUID{incCID}},
+			{"sort by number", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByNumber}}, []ref.UUID{incBID, incCID, incAID}},
+			{"sort by number descending", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByNumber, Descending: true}}, []ref.UUID{incAID, incCID, incBID}},
+			{"sort by created at descending", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByCreatedAt, Descending: true}}, []ref.UUID{incCID, incBID, incAID}},
+			{"sort by updated at descending", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByUpdatedAt, Descending: true}}, []ref.UUID{incBID, incCID, incAID}},
+			{"sort by priority", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByPriority}}, []ref.UUID{incAID, incCID, incBID}},
+			{"sort by priority descending", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByPriority, Descending: true}}, []ref.UUID{incBID, incCID, incAID}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				list, err := repos.Incident.ListIncidents(ctx, channelID, tt.filter, 1, 10)
+				require.NoError(t, err)
+				assert.Equal(t, len(tt.expected), list.Total)
+
+				var incIDs []ref.UUID
+				for _, inc := range list.Result {
+					incIDs = append(incIDs, inc.UUID())
+				}
+				assert.Equal(t, tt.expected, incIDs)
+			})
+		}
+
+		// pagination counts only matching incidents
+		list, err := repos.Incident.ListIncidents(ctx, channelID, repository.IncidentFilter{Text: "printer"}, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+		assert.Equal(t, 2, list.Last)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, incCID, list.Result[0].UUID())
+	})
+
+	t.Run("list incidents by cursor", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		creator := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		// pairs of incidents are created at the same time, so the sort values are equal
+		impacts := []incident.Impact{incident.ImpactHigh, incident.ImpactLow, incident.ImpactHigh, incident.ImpactMedium, incident.ImpactLow}
+		var incIDs []ref.UUID
+		for i, number := range []string{"INC5", "INC4", "INC3", "INC2", "INC1"} {
+			if i%2 == 0 {
+				clock.AddTime(time.Hour)
+			}
+			inc := newIncident(t, creator, number)
+			require.NoError(t, inc.Prioritize(incident.DefaultPriorityMatrix(), impacts[i], incident.UrgencyHigh))
+			incID, err := repos.Incident.AddIncident(ctx, channelID, inc)
+			require.NoError(t, err)
+			incIDs = append(incIDs, incID)
+		}
+
+		ids := func(list repository.IncidentList) []ref.UUID {
+			var listIDs []ref.UUID
+			for _, inc := range list.Result {
+				listIDs = append(listIDs, inc.UUID())
+			}
+			return listIDs
+		}
+
+		tests := []struct {
+			name     string
+			filter   repository.IncidentFilter
+			expected []ref.UUID
+		}{
+			{"insertion order", repository.IncidentFilter{}, incIDs},
+			{"sort by number", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByNumber}},
+				[]ref.UUID{incIDs[4], incIDs[3], incIDs[2], incIDs[1], incIDs[0]}},
+			{"sort by created at descending", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByCreatedAt, Descending: true}},
+				[]ref.UUID{incIDs[4], incIDs[2], incIDs[3], incIDs[0], incIDs[1]}},
+			{"sort by priority", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByPriority}},
+				[]ref.UUID{incIDs[0], incIDs[2], incIDs[3], incIDs[1], incIDs[4]}},
+			{"sort by priority descending", repository.IncidentFilter{Sort: repository.IncidentSort{Field: repository.IncidentSortByPriority, Descending: true}},
+				[]ref.UUID{incIDs[1], incIDs[4], incIDs[3], incIDs[0], incIDs[2]}},
+			{"filtered", repository.IncidentFilter{Number: "INC3"}, []ref.UUID{incIDs[2]}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// forward through the next cursors
+				var forward []ref.UUID
+				page := repository.CursorPage{Limit: 2}
+				for i := 0; ; i++ {
+					require.Less(t, i, 5, "too many pages")
+
+					list, err := repos.Incident.ListIncidentsByCursor(ctx, channelID, tt.filter, page)
+					require.NoError(t, err)
+					require.NotNil(t, list.Cursors)
+					assert.Equal(t, len(tt.expected), list.Total)
+					assert.Equal(t, i+1, list.Page)
+					assert.Equal(t, i > 0, list.Cursors.Prev != nil, "previous page cursor")
+
+					forward = append(forward, ids(list)...)
+					if list.Cursors.Next == nil {
+						break
+					}
+					page = repository.CursorPage{After: list.Cursors.Next, Limit: 2}
+				}
+				assert.Equal(t, tt.expected, forward)
+
+				// backward from the last page through the previous cursors
+				list, err := repos.Incident.ListIncidentsByCursor(ctx, channelID, tt.filter, repository.CursorPage{Limit: 2})
+				require.NoError(t, err)
+				if list.Cursors.Last != nil {
+					list, err = repos.Incident.ListIncidentsByCursor(ctx, channelID, tt.filter, repository.CursorPage{After: list.Cursors.Last, Limit: 2})
+					require.NoError(t, err)
+				}
+				assert.Nil(t, list.Cursors.Next, "last page must not have the next page")
+
+				backward := ids(list)
+				for i := 0; list.Cursors.Prev != nil; i++ {
+					require.Less(t, i, 5, "too many pages")
+
+					list, err = repos.Incident.ListIncidentsByCursor(ctx, channelID, tt.filter, repository.CursorPage{Before: list.Cursors.Prev, Limit: 2})
+					require.NoError(t, err)
+					backward = append(ids(list), backward...)
+				}
+				assert.Equal(t, tt.expected, backward)
+			})
+		}
+
+		// incidents added during the listing do not shift the pages
+		list, err := repos.Incident.ListIncidentsByCursor(ctx, channelID, repository.IncidentFilter{}, repository.CursorPage{Limit: 2})
+		require.NoError(t, err)
+		require.NotNil(t, list.Cursors.Next)
+
+		clock.AddTime(time.Hour)
+		incID, err := repos.Incident.AddIncident(ctx, channelID, newIncident(t, creator, "INC6"))
+		require.NoError(t, err)
+
+		list, err = repos.Incident.ListIncidentsByCursor(ctx, channelID, repository.IncidentFilter{}, repository.CursorPage{After: list.Cursors.Next, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []ref.UUID{incIDs[2], incIDs[3], incIDs[4], incID}, ids(list))
+		assert.Equal(t, 6, list.Total)
+	})
+}
+
+func testChannelIsolation(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	// both channels contain the same users (with the same external IDs), field engineers and incidents
+	type channelData struct {
+		channelID ref.ChannelID
+		user      user.BasicUser
+		engineer  user.BasicUser
+		feID      ref.UUID
+		incID     ref.UUID
+	}
+
+	clock := mocks.NewFixedClock()
+	repos := newRepositories(t, clock)
+
+	var channels []channelData
+	for _, chID := range []ref.ChannelID{channelID, otherChannelID} {
+		data := channelData{channelID: chID}
+		data.user = addBasicUserToChannel(t, repos, chID, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		data.engineer = addBasicUserToChannel(t, repos, chID, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		data.feID = addFieldEngineerToChannel(t, repos, chID, data.engineer, data.user)
+
+		inc := newIncident(t, data.user, "ABC123")
+		incID, err := repos.Incident.AddIncident(ctx, chID, inc)
+		require.NoError(t, err)
+		data.incID = incID
+
+		// open timelog in the incident
+		inc, err = repos.Incident.GetIncident(ctx, chID, incID)
+		require.NoError(t, err)
+		openTimelog := &timelog.Timelog{Start: clock.NowFormatted()}
+		require.NoError(t, openTimelog.CreatedUpdated.SetCreatedBy(data.engineer))
+		require.NoError(t, openTimelog.CreatedUpdated.SetUpdatedBy(data.engineer))
+		inc.SetOpenTimelog(openTimelog)
+		_, err = repos.Incident.UpdateIncident(ctx, chID, inc)
+		require.NoError(t, err)
+
+		channels = append(channels, data)
+	}
+
+	for i, data := range channels {
+		other := channels[1-i]
+
+		t.Run(string(data.channelID)+" sees only its own data", func(t *testing.T) {
+			basicUsers, err := repos.BasicUser.ListBasicUsers(ctx, data.channelID, 1, 10)
+			require.NoError(t, err)
+			assert.Equal(t, 2, basicUsers.Total)
+			assert.ElementsMatch(t, []user.BasicUser{data.user, data.engineer}, basicUsers.Result)
+
+			retUser, err := repos.BasicUser.GetBasicUserByExternalID(ctx, data.channelID, data.user.ExternalUserUUID)
+			require.NoError(t, err)
+			assert.Equal(t, data.user, retUser)
+
+			fieldEngineers, err := repos.FieldEngineer.ListFieldEngineers(ctx, data.channelID, 1, 10)
+			require.NoError(t, err)
+			require.Len(t, fieldEngineers.Result, 1)
+			assert.Equal(t, data.feID, fieldEngineers.Result[0].UUID())
+
+			retFE, err := repos.FieldEngineer.GetFieldEngineerByBasicUser(ctx, data.channelID, data.engineer.UUID())
+			require.NoError(t, err)
+			assert.Equal(t, data.feID, retFE.UUID())
+
+			incidents, err := repos.Incident.ListIncidents(ctx, data.channelID, repository.IncidentFilter{}, 1, 10)
+			require.NoError(t, err)
+			require.Len(t, incidents.Result, 1)
+			assert.Equal(t, data.incID, incidents.Result[0].UUID())
+			assert.Equal(t, data.user, incidents.Result[0].CreatedUpdated.CreatedBy())
+			require.Len(t, incidents.Result[0].Timelogs, 1)
+		})
+
+		t.Run(string(data.channelID)+" cannot read data of the other channel", func(t *testing.T) {
+			_, err := repos.BasicUser.GetBasicUser(ctx, data.channelID, other.user.UUID())
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+			_, err = repos.FieldEngineer.GetFieldEngineer(ctx, data.channelID, other.feID)
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+			_, err = repos.FieldEngineer.GetFieldEngineerByBasicUser(ctx, data.channelID, other.engineer.UUID())
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+			_, err = repos.Incident.GetIncident(ctx, data.channelID, other.incID)
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+			otherInc, err := repos.Incident.GetIncident(ctx, other.channelID, other.incID)
+			require.NoError(t, err)
+
+			_, err = repos.Incident.GetIncidentTimelog(ctx, data.channelID, other.incID, otherInc.Timelogs[0])
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+		})
+
+		t.Run(string(data.channelID)+" cannot update data of the other channel", func(t *testing.T) {
+			otherInc, err := repos.Incident.GetIncident(ctx, other.channelID, other.incID)
+			require.NoError(t, err)
+
+			otherInc.Description = "changed from the wrong channel"
+			_, err = repos.Incident.UpdateIncident(ctx, data.channelID, otherInc)
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+			otherFE, err := repos.FieldEngineer.GetFieldEngineer(ctx, other.channelID, other.feID)
+			require.NoError(t, err)
+
+			otherFE.SetDeactivated(true)
+			_, err = repos.FieldEngineer.UpdateFieldEngineer(ctx, data.channelID, otherFE)
+			assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+			// data in the other channel are untouched
+			retInc, err := repos.Incident.GetIncident(ctx, other.channelID, other.incID)
+			require.NoError(t, err)
+			assert.Equal(t, "some description", retInc.Description)
+
+			retFE, err := repos.FieldEngineer.GetFieldEngineer(ctx, other.channelID, other.feID)
+			require.NoError(t, err)
+			assert.False(t, retFE.IsDeactivated())
+		})
+	}
+}
+
+func testUnitOfWork(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	// setup adds the incident assigned to the field engineer
+	setup := func(t *testing.T) (Repositories, user.BasicUser, ref.UUID, ref.UUID) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		feID := addFieldEngineer(t, repos, engineer, admin)
+
+		inc := newIncident(t, admin, "ABC123")
+		inc.FieldEngineerID = &feID
+		incID, err := repos.Incident.AddIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		return repos, engineer, incID, feID
+	}
+
+	// update changes both the incident and the field engineer using the repositories bound to the unit of work
+	update := func(t *testing.T, repos repository.Repositories, engineer user.BasicUser, incID, feID ref.UUID) {
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		inc.Description = "updated in unit of work"
+		openTimelog := &timelog.Timelog{Remote: true}
+		require.NoError(t, openTimelog.CreatedUpdated.SetCreatedBy(engineer))
+		require.NoError(t, openTimelog.CreatedUpdated.SetUpdatedBy(engineer))
+		inc.SetOpenTimelog(openTimelog)
+
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		_, err = repos.Incident.AddIncident(ctx, channelID, newIncident(t, engineer, "ABC124"))
+		require.NoError(t, err)
+
+		fe, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+
+		fe.SetDeactivated(true)
+		_, err = repos.FieldEngineer.UpdateFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+
+		// changes are visible within the unit of work
+		updatedInc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, "updated in unit of work", updatedInc.Description)
+		assert.True(t, updatedInc.HasOpenTimelog())
+
+		updatedFE, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+		assert.True(t, updatedFE.IsDeactivated())
+	}
+
+	t.Run("changes are committed together", func(t *testing.T) {
+		repos, engineer, incID, feID := setup(t)
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos repository.Repositories) error {
+			update(t, txRepos, engineer, incID, feID)
+			return nil
+		})
+		require.NoError(t, err)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, "updated in unit of work", inc.Description)
+		assert.Equal(t, uint(2), inc.Version())
+		assert.Len(t, inc.Timelogs, 1)
+		assert.True(t, inc.HasOpenTimelog())
+
+		list, err := repos.Incident.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+
+		fe, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+		assert.True(t, fe.IsDeactivated())
+	})
+
+	t.Run("changes are rolled back together", func(t *testing.T) {
+		repos, engineer, incID, feID := setup(t)
+		errFailed := errors.New("operation failed")
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos repository.Repositories) error {
+			update(t, txRepos, engineer, incID, feID)
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+		assert.Equal(t, "some description", inc.Description)
+		assert.Equal(t, uint(1), inc.Version())
+		assert.Empty(t, inc.Timelogs)
+		assert.False(t, inc.HasOpenTimelog())
+
+		list, err := repos.Incident.ListIncidents(ctx, channelID, repository.IncidentFilter{}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total)
+
+		fe, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+		assert.False(t, fe.IsDeactivated())
+
+		// repositories are usable after the rollback
+		inc.Description = "updated after rollback"
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+	})
+}
+
+func testOutbox(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	// setup adds the incident assigned to and accepted by the field engineer
+	setup := func(t *testing.T) (Repositories, actor.Actor, ref.UUID, ref.UUID) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		feID := addFieldEngineer(t, repos, engineer, admin)
+
+		inc := newIncident(t, admin, "ABC123")
+		inc.FieldEngineerID = &feID
+		inc.SetFieldEngineerAccepted(true)
+		incID, err := repos.Incident.AddIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		engineerActor := actor.Actor{BasicUser: engineer}
+		engineerActor.SetFieldEngineerID(&feID)
+
+		return repos, engineerActor, incID, feID
+	}
+
+	// startWorking starts working on the incident, both the incident and the field engineer record the event
+	startWorking := func(t *testing.T, repos repository.Repositories, engineerActor actor.Actor, incID, feID ref.UUID) {
+		clock := mocks.NewFixedClock()
+
+		inc, err := repos.Incident.GetIncident(ctx, channelID, incID)
+		require.NoError(t, err)
+
+		require.NoError(t, inc.StartWorking(engineerActor, clock, true))
+		_, err = repos.Incident.UpdateIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		fe, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+
+		require.NoError(t, fe.StartWorking(engineerActor, clock, inc))
+		_, err = repos.FieldEngineer.UpdateFieldEngineer(ctx, channelID, fe)
+		require.NoError(t, err)
+	}
+
+	t.Run("events are stored in order and removed when delivered", func(t *testing.T) {
+		repos, engineerActor, incID, feID := setup(t)
+
+		messages, err := repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+
+		startWorking(t, repository.Repositories{FieldEngineer: repos.FieldEngineer, Incident: repos.Incident}, engineerActor, incID, feID)
+
+		messages, err = repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 3)
+
+		assert.Equal(t, event.TypeIncidentStateChanged, messages[0].EventType)
+		assert.Equal(t, incID, messages[0].AggregateID)
+
+		assert.False(t, messages[1].ID.IsZero())
+		assert.Equal(t, channelID, messages[1].ChannelID)
+		assert.Equal(t, event.TypeIncidentWorkStarted, messages[1].EventType)
+		assert.Equal(t, incID, messages[1].AggregateID)
+		assert.Equal(t, mocks.NewFixedClock().NowFormatted(), messages[1].OccurredAt)
+
+		var workStarted event.IncidentWorkStarted
+		require.NoError(t, json.Unmarshal(messages[1].Payload, &workStarted))
+		assert.Equal(t, event.IncidentWorkStarted{
+			IncidentID:      incID,
+			FieldEngineerID: feID,
+			ActorID:         engineerActor.BasicUser.UUID(),
+			Remote:          true,
+		}, workStarted)
+
+		assert.Equal(t, event.TypeTimeSessionStateChanged, messages[2].EventType)
+		assert.Equal(t, feID, messages[2].AggregateID)
+
+		fe, err := repos.FieldEngineer.GetFieldEngineer(ctx, channelID, feID)
+		require.NoError(t, err)
+		require.True(t, fe.HasOpenTimeSession())
+
+		var tSessionChanged event.TimeSessionStateChanged
+		require.NoError(t, json.Unmarshal(messages[2].Payload, &tSessionChanged))
+		assert.Equal(t, fe.OpenTimeSession().UUID(), tSessionChanged.TimeSessionID, "ID of the newly opened time session")
+		assert.Equal(t, incID, tSessionChanged.IncidentID)
+
+		limited, err := repos.Outbox.ListPendingMessages(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, messages[0].ID, limited[0].ID)
+
+		require.NoError(t, repos.Outbox.MarkMessageDelivered(ctx, messages[0].ID))
+
+		pending, err := repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, messages[1].ID, pending[0].ID)
+
+		err = repos.Outbox.MarkMessageDelivered(ctx, messages[0].ID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("events recorded before the incident is added are stored with its ID", func(t *testing.T) {
+		repos := newRepositories(t, mocks.NewFixedClock())
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		engineer := addBasicUser(t, repos, "ee824cad-d7a6-4f48-87dc-e8461a9201c4", "Jan")
+		feID := addFieldEngineer(t, repos, engineer, admin)
+
+		inc := newIncident(t, admin, "ABC123")
+		inc.AssignFieldEngineer(actor.Actor{BasicUser: admin}, feID)
+
+		errFailed := errors.New("operation failed")
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos repository.Repositories) error {
+			_, err := txRepos.Incident.AddIncident(ctx, channelID, inc)
+			require.NoError(t, err)
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		messages, err := repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, messages, "events are discarded together with the incident")
+
+		incID, err := repos.Incident.AddIncident(ctx, channelID, inc)
+		require.NoError(t, err)
+
+		messages, err = repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, event.TypeIncidentFieldEngineerAssigned, messages[0].EventType)
+		assert.Equal(t, incID, messages[0].AggregateID)
+
+		var assigned event.IncidentFieldEngineerAssigned
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &assigned))
+		assert.Equal(t, event.IncidentFieldEngineerAssigned{
+			IncidentID:      incID,
+			FieldEngineerID: feID,
+			ActorID:         admin.UUID(),
+		}, assigned)
+	})
+
+	t.Run("events are discarded when the unit of work is rolled back", func(t *testing.T) {
+		repos, engineerActor, incID, feID := setup(t)
+		errFailed := errors.New("operation failed")
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos repository.Repositories) error {
+			startWorking(t, txRepos, engineerActor, incID, feID)
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		messages, err := repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+
+		err = repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos repository.Repositories) error {
+			startWorking(t, txRepos, engineerActor, incID, feID)
+			return nil
+		})
+		require.NoError(t, err)
+
+		messages, err = repos.Outbox.ListPendingMessages(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, messages, 3)
+	})
+}
+
+func testWebhookRepository(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	// setup adds the subscription created by the admin
+	setup := func(t *testing.T, clock *mocks.FixedClock) (Repositories, user.BasicUser, ref.UUID) {
+		repos := newRepositories(t, clock)
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+
+		sub := webhook.Subscription{
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []event.Type{event.TypeIncidentStateChanged, event.TypeIncidentFieldEngineerAssigned},
+		}
+		require.NoError(t, sub.CreatedUpdated.SetCreatedBy(admin))
+		require.NoError(t, sub.CreatedUpdated.SetUpdatedBy(admin))
+
+		subID, err := repos.Webhook.AddWebhookSubscription(ctx, channelID, sub)
+		require.NoError(t, err)
+
+		return repos, admin, subID
+	}
+
+	newDelivery := func(t *testing.T, clock *mocks.FixedClock, subID ref.UUID, eventID ref.UUID) webhook.Delivery {
+		delivery, err := webhook.NewDelivery(clock, subID, webhook.Payload{
+			ID:          eventID,
+			Type:        event.TypeIncidentStateChanged,
+			ChannelID:   channelID,
+			AggregateID: "7e0d38d1-e5f5-4211-b2aa-3b142e4da80e",
+			OccurredAt:  clock.NowFormatted(),
+			Data:        json.RawMessage(`{}`),
+		})
+		require.NoError(t, err)
+		return delivery
+	}
+
+	t.Run("add, get, list and delete subscriptions", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos, admin, subID := setup(t, clock)
+
+		sub, err := repos.Webhook.GetWebhookSubscription(ctx, channelID, subID)
+		require.NoError(t, err)
+		assert.Equal(t, subID, sub.UUID())
+		assert.Equal(t, "https://example.com/hooks", sub.URL)
+		assert.Equal(t, "0123456789abcdef", sub.Secret)
+		assert.Equal(t, []event.Type{event.TypeIncidentStateChanged, event.TypeIncidentFieldEngineerAssigned}, sub.EventTypes)
+		assert.Equal(t, admin.UUID(), sub.CreatedUpdated.CreatedByID())
+		assert.Equal(t, clock.NowFormatted(), sub.CreatedUpdated.CreatedAt())
+
+		allEvents := webhook.Subscription{URL: "http://localhost:8000/hooks", Secret: "fedcba9876543210"}
+		require.NoError(t, allEvents.CreatedUpdated.SetCreatedBy(admin))
+		require.NoError(t, allEvents.CreatedUpdated.SetUpdatedBy(admin))
+		allEventsID, err := repos.Webhook.AddWebhookSubscription(ctx, channelID, allEvents)
+		require.NoError(t, err)
+
+		list, err := repos.Webhook.ListWebhookSubscriptions(ctx, channelID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 2)
+		assert.Equal(t, 2, list.Total)
+		assert.Equal(t, subID, list.Result[0].UUID())
+		assert.Equal(t, allEventsID, list.Result[1].UUID())
+		assert.Empty(t, list.Result[1].EventTypes)
+
+		require.NoError(t, repos.Webhook.DeleteWebhookSubscription(ctx, channelID, subID))
+
+		_, err = repos.Webhook.GetWebhookSubscription(ctx, channelID, subID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		err = repos.Webhook.DeleteWebhookSubscription(ctx, channelID, subID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		list, err = repos.Webhook.ListWebhookSubscriptions(ctx, channelID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, allEventsID, list.Result[0].UUID())
+
+		_, err = repos.Webhook.GetWebhookSubscription(ctx, otherChannelID, allEventsID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("add, update and list deliveries", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos, _, subID := setup(t, clock)
+
+		delivery := newDelivery(t, clock, subID, "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e")
+		deliveryID, err := repos.Webhook.AddWebhookDelivery(ctx, channelID, delivery)
+		require.NoError(t, err)
+
+		_, err = repos.Webhook.AddWebhookDelivery(ctx, channelID, newDelivery(t, clock, "cb2fe2a7-ab9f-4f6d-9fd6-c7c209403cf0", "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e"))
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		list, err := repos.Webhook.ListWebhookDeliveries(ctx, channelID, subID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		stored := list.Result[0]
+		assert.Equal(t, deliveryID, stored.UUID())
+		assert.Equal(t, subID, stored.SubscriptionID)
+		assert.Equal(t, ref.UUID("b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e"), stored.EventID)
+		assert.Equal(t, event.TypeIncidentStateChanged, stored.EventType)
+		assert.JSONEq(t, string(delivery.Payload), string(stored.Payload))
+		assert.Equal(t, webhook.DeliveryStatusPending, stored.Status)
+		assert.Equal(t, clock.NowFormatted(), stored.NextAttemptAt)
+
+		policy := webhook.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Hour}
+		stored.RecordFailure(clock, 500, "unexpected response status 500", policy)
+		require.NoError(t, repos.Webhook.UpdateWebhookDelivery(ctx, channelID, stored))
+
+		list, err = repos.Webhook.ListWebhookDeliveries(ctx, channelID, subID, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, list.Result, 1)
+		assert.Equal(t, stored, list.Result[0])
+
+		_, err = repos.Webhook.ListWebhookDeliveries(ctx, otherChannelID, subID, 1, 10)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		require.NoError(t, repos.Webhook.DeleteWebhookSubscription(ctx, channelID, subID))
+
+		_, err = repos.Webhook.ListWebhookDeliveries(ctx, channelID, subID, 1, 10)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		err = repos.Webhook.UpdateWebhookDelivery(ctx, channelID, stored)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+
+	t.Run("list due deliveries", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos, _, subID := setup(t, clock)
+		policy := webhook.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute, MaxBackoff: time.Hour}
+
+		first := newDelivery(t, clock, subID, "b6a4b6b1-36e8-4a0b-a5fd-1a3e5b6e8f4e")
+		firstID, err := repos.Webhook.AddWebhookDelivery(ctx, channelID, first)
+		require.NoError(t, err)
+		require.NoError(t, first.SetUUID(firstID))
+
+		clock.AddTime(10 * time.Second)
+		second := newDelivery(t, clock, subID, "0f2b53c4-2c1f-4c57-9e62-2a7e28e4d5d1")
+		secondID, err := repos.Webhook.AddWebhookDelivery(ctx, channelID, second)
+		require.NoError(t, err)
+		require.NoError(t, second.SetUUID(secondID))
+
+		due, err := repos.Webhook.ListDueWebhookDeliveries(ctx, clock.Now(), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		assert.Equal(t, channelID, due[0].ChannelID)
+		assert.Equal(t, firstID, due[0].UUID())
+		assert.Equal(t, secondID, due[1].UUID())
+
+		limited, err := repos.Webhook.ListDueWebhookDeliveries(ctx, clock.Now(), 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, firstID, limited[0].UUID())
+
+		// the first delivery failed and is retried in a minute, the second one was delivered
+		first.RecordFailure(clock, 0, "connection refused", policy)
+		require.NoError(t, repos.Webhook.UpdateWebhookDelivery(ctx, channelID, first))
+		second.RecordSuccess(clock, 200)
+		require.NoError(t, repos.Webhook.UpdateWebhookDelivery(ctx, channelID, second))
+
+		due, err = repos.Webhook.ListDueWebhookDeliveries(ctx, clock.Now(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+
+		clock.AddTime(time.Minute)
+		due, err = repos.Webhook.ListDueWebhookDeliveries(ctx, clock.Now(), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, firstID, due[0].UUID())
+		assert.Equal(t, uint(1), due[0].Attempts)
+
+		// dead-lettered delivery is not attempted again
+		first.RecordFailure(clock, 0, "connection refused", policy)
+		require.Equal(t, webhook.DeliveryStatusDeadLettered, first.Status)
+		require.NoError(t, repos.Webhook.UpdateWebhookDelivery(ctx, channelID, first))
+
+		due, err = repos.Webhook.ListDueWebhookDeliveries(ctx, clock.Now().Add(24*time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+}
+
+func testSLARepository(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	t.Run("set, get and replace SLA definition", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		otherAdmin := addBasicUser(t, repos, "2af4f493-0bd5-4513-b440-6cbb465feadb", "Alice")
+
+		_, err := repos.SLA.GetSLADefinition(ctx, channelID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		def := newSLADefinition()
+		require.NoError(t, def.CreatedUpdated.SetCreatedBy(admin))
+		require.NoError(t, def.CreatedUpdated.SetUpdatedBy(admin))
+		require.NoError(t, repos.SLA.SetSLADefinition(ctx, channelID, def))
+
+		stored, err := repos.SLA.GetSLADefinition(ctx, channelID)
+		require.NoError(t, err)
+		assert.Equal(t, def.Targets, stored.Targets)
+		assert.Equal(t, def.DefaultPriority, stored.DefaultPriority)
+		assert.Equal(t, def.Calendar, stored.Calendar)
+		assert.Equal(t, admin.UUID(), stored.CreatedUpdated.CreatedByID())
+		assert.Equal(t, clock.NowFormatted(), stored.CreatedUpdated.CreatedAt())
+
+		createdAt := clock.NowFormatted()
+		clock.AddTime(time.Hour)
+
+		replacement := sla.Definition{
+			Targets: map[sla.Priority]sla.Target{
+				sla.PriorityLow: {Response: 8 * time.Hour, Resolution: 40 * time.Hour},
+			},
+			DefaultPriority: sla.PriorityLow,
+		}
+		require.NoError(t, replacement.CreatedUpdated.SetCreatedBy(otherAdmin))
+		require.NoError(t, replacement.CreatedUpdated.SetUpdatedBy(otherAdmin))
+		require.NoError(t, repos.SLA.SetSLADefinition(ctx, channelID, replacement))
+
+		stored, err = repos.SLA.GetSLADefinition(ctx, channelID)
+		require.NoError(t, err)
+		assert.Equal(t, replacement.Targets, stored.Targets)
+		assert.Equal(t, sla.PriorityLow, stored.DefaultPriority)
+		assert.Empty(t, stored.Calendar.WorkingHours)
+		assert.Equal(t, admin.UUID(), stored.CreatedUpdated.CreatedByID())
+		assert.Equal(t, createdAt, stored.CreatedUpdated.CreatedAt())
+		assert.Equal(t, otherAdmin.UUID(), stored.CreatedUpdated.UpdatedByID())
+		assert.Equal(t, clock.NowFormatted(), stored.CreatedUpdated.UpdatedAt())
+
+		_, err = repos.SLA.GetSLADefinition(ctx, otherChannelID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+}
+
+func testPriorityMatrixRepository(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	t.Run("set, get and replace priority matrix", func(t *testing.T) {
+		clock := mocks.NewFixedClock()
+		repos := newRepositories(t, clock)
+
+		admin := addBasicUser(t, repos, "b306a60e-a2a5-463f-a6e1-33e8cb21bc3b", "Alfred")
+		otherAdmin := addBasicUser(t, repos, "2af4f493-0bd5-4513-b440-6cbb465feadb", "Alice")
+
+		_, err := repos.PriorityMatrix.GetPriorityMatrix(ctx, channelID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+
+		matrix := incident.DefaultPriorityMatrix()
+		require.NoError(t, matrix.CreatedUpdated.SetCreatedBy(admin))
+		require.NoError(t, matrix.CreatedUpdated.SetUpdatedBy(admin))
+		require.NoError(t, repos.PriorityMatrix.SetPriorityMatrix(ctx, channelID, matrix))
+
+		stored, err := repos.PriorityMatrix.GetPriorityMatrix(ctx, channelID)
+		require.NoError(t, err)
+		assert.Equal(t, matrix.Priorities, stored.Priorities)
+		assert.Equal(t, admin.UUID(), stored.CreatedUpdated.CreatedByID())
+		assert.Equal(t, clock.NowFormatted(), stored.CreatedUpdated.CreatedAt())
+
+		createdAt := clock.NowFormatted()
+		clock.AddTime(time.Hour)
+
+		replacement := incident.DefaultPriorityMatrix()
+		replacement.Priorities[incident.ImpactUrgency{Impact: incident.ImpactLow, Urgency: incident.UrgencyLow}] = sla.PriorityModerate
+		require.NoError(t, replacement.CreatedUpdated.SetCreatedBy(otherAdmin))
+		require.NoError(t, replacement.CreatedUpdated.SetUpdatedBy(otherAdmin))
+		require.NoError(t, repos.PriorityMatrix.SetPriorityMatrix(ctx, channelID, replacement))
+
+		stored, err = repos.PriorityMatrix.GetPriorityMatrix(ctx, channelID)
+		require.NoError(t, err)
+		assert.Equal(t, replacement.Priorities, stored.Priorities)
+		assert.Equal(t, sla.PriorityModerate, stored.PriorityFor(incident.ImpactLow, incident.UrgencyLow))
+		assert.Equal(t, admin.UUID(), stored.CreatedUpdated.CreatedByID())
+		assert.Equal(t, createdAt, stored.CreatedUpdated.CreatedAt())
+		assert.Equal(t, otherAdmin.UUID(), stored.CreatedUpdated.UpdatedByID())
+		assert.Equal(t, clock.NowFormatted(), stored.CreatedUpdated.UpdatedAt())
+
+		_, err = repos.PriorityMatrix.GetPriorityMatrix(ctx, otherChannelID)
+		assertErrorCode(t, domain.ErrorCodeNotFound, err)
+	})
+}
+
+func addBasicUser(t *testing.T, repos Repositories, externalID ref.ExternalUserUUID, name string) user.BasicUser {
+	t.Helper()
+
+	return addBasicUserToChannel(t, repos, channelID, externalID, name)
+}
+
+func addBasicUserToChannel(t *testing.T, repos Repositories, channelID ref.ChannelID, externalID ref.ExternalUserUUID, name string) user.BasicUser {
+	t.Helper()
+
+	basicUser := user.BasicUser{
+		ExternalUserUUID: externalID,
+		Name:             name,
+		Surname:          "Koletschko",
+		OrgDisplayName:   "KompiTech",
+		OrgName:          "a897a407-e41b-4b14-924a-39f5d5a8038f.kompitech.com",
+	}
+
+	id, err := repos.BasicUser.AddBasicUser(context.Background(), channelID, basicUser)
+	require.NoError(t, err)
+	require.NoError(t, basicUser.SetUUID(id))
+
+	return basicUser
+}
+
+func addFieldEngineer(t *testing.T, repos Repositories, basicUser, creator user.BasicUser) ref.UUID {
+	t.Helper()
+
+	return addFieldEngineerToChannel(t, repos, channelID, basicUser, creator)
+}
+
+func addFieldEngineerToChannel(t *testing.T, repos Repositories, channelID ref.ChannelID, basicUser, creator user.BasicUser) ref.UUID {
+	t.Helper()
+
+	fe := fieldengineer.FieldEngineer{BasicUser: basicUser}
+	require.NoError(t, fe.CreatedUpdated.SetCreatedBy(creator))
+	require.NoError(t, fe.CreatedUpdated.SetUpdatedBy(creator))
+
+	id, err := repos.FieldEngineer.AddFieldEngineer(context.Background(), channelID, fe)
+	require.NoError(t, err)
+
+	return id
+}
+
+func newIncident(t *testing.T, creator user.BasicUser, number string) incident.Incident {
+	t.Helper()
+
+	inc := incident.Incident{
+		Number:           number,
+		ExternalID:       "some external ID",
+		ShortDescription: "some short description",
+		Description:      "some description",
+	}
+	require.NoError(t, inc.RestoreState(incident.StateNew))
+	require.NoError(t, inc.CreatedUpdated.SetCreatedBy(creator))
+	require.NoError(t, inc.CreatedUpdated.SetUpdatedBy(creator))
+
+	return inc
+}
+
+func newSLADefinition() sla.Definition {
+	return sla.Definition{
+		Targets: map[sla.Priority]sla.Target{
+			sla.PriorityCritical: {Response: 15 * time.Minute, Resolution: 4 * time.Hour},
+			sla.PriorityModerate: {Response: time.Hour, Resolution: 16 * time.Hour},
+		},
+		DefaultPriority: sla.PriorityModerate,
+		Calendar: sla.Calendar{
+			Location: "Europe/Prague",
+			WorkingHours: []sla.WorkingHours{
+				{Weekday: time.Monday, Start: "08:00", End: "16:30"},
+				{Weekday: time.Thursday, Start: "08:00", End: "24:00"},
+			},
+			Holidays: []string{"2021-04-05"},
+		},
+	}
+}
+
+func assertErrorCode(t *testing.T, expected domain.ErrorCode, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+
+	var domainErr *domain.Error
+	require.True(t, errors.As(err, &domainErr), "domain error expected, got %v", err)
+	assert.Equal(t, expected, domainErr.Code())
+}